@@ -0,0 +1,263 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+	"github.com/gnodet/mvx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose common environment problems",
+	Long: `Check the environment mvx runs in and report what is needed to triage problems.
+
+The report covers:
+  - the operating system and architecture, and the platform tools are installed for
+  - the mvx home directory and the free disk space
+  - the MVX_* and proxy environment variables in effect
+  - the reachability of the servers tools are downloaded from
+  - in a project, the resolved version and install status of each configured tool,
+    and the system tools on PATH shadowing the mvx-managed ones
+
+The command exits with a non-zero status when a blocking problem is found, e.g. an
+mvx home that is not writable, no server reachable, or a version that can't be resolved.
+Attach its output when reporting an issue.
+
+Examples:
+  mvx doctor`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runDoctor(); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+const (
+	// doctorMinFreeSpace is the free disk space under which installing tools is likely to fail
+	doctorMinFreeSpace = 1 << 30
+	// doctorHostTimeout is how long to wait for each server to answer
+	doctorHostTimeout = 10 * time.Second
+)
+
+// runDoctor runs the environment checks, reports their results, and fails if any found a
+// blocking problem
+func runDoctor() error {
+	problems := 0
+
+	printInfo("🖥️  System")
+	printInfo("  mvx version: %s", version)
+	printInfo("  OS/Arch:     %s/%s", runtime.GOOS, runtime.GOARCH)
+	printInfo("")
+
+	printInfo("📁 mvx home")
+	problems += reportMvxHome()
+	printInfo("")
+
+	printInfo("⚙️  Environment overrides")
+	overrides := doctorEnvOverrides(os.Environ())
+	if len(overrides) == 0 {
+		printInfo("  None")
+	}
+	for _, override := range overrides {
+		printInfo("  %s", override)
+	}
+	printInfo("")
+
+	// Only report the missing tools, checking them must not install them
+	disableAutoInstall()
+	manager, err := tools.NewManager()
+	if err != nil {
+		printInfo("  ❌ %v", err)
+		return fmt.Errorf("found %d blocking problem(s)", problems+1)
+	}
+	if manager.IsForeignPlatform() {
+		printInfo("  Tools are installed for %s", manager.GetPlatform())
+		printInfo("")
+	}
+
+	printInfo("🌐 Network")
+	problems += reportNetwork(manager)
+	printInfo("")
+
+	if projectRoot, ok := hookEnvProjectRoot(); ok {
+		printInfo("🔧 Project tools (%s)", projectRoot)
+		problems += reportProjectTools(manager, projectRoot)
+	} else {
+		printInfo("🔧 Project tools")
+		printInfo("  Not in an mvx project")
+	}
+	printInfo("")
+
+	if problems > 0 {
+		return fmt.Errorf("found %d blocking problem(s)", problems)
+	}
+	printSuccess("✅ No blocking problem found")
+	return nil
+}
+
+// reportMvxHome reports the location of the mvx home and the disk space available to it, and
+// returns the number of blocking problems found
+func reportMvxHome() int {
+	mvxHome, err := config.GetMvxHome()
+	if err != nil {
+		printInfo("  ❌ %v", err)
+		return 1
+	}
+	printInfo("  Location:    %s", mvxHome)
+
+	// The home may not exist yet, the disk space is the one of its closest existing parent
+	dir := mvxHome
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	free, err := util.FreeDiskSpace(dir)
+	if err != nil {
+		printInfo("  ⚠️  Failed to determine the free disk space: %v", err)
+		return 0
+	}
+	if free < doctorMinFreeSpace {
+		printInfo("  ❌ Free space: %s, tools need up to several hundred MB each", formatPackageSize(int64(free)))
+		return 1
+	}
+	printInfo("  Free space:  %s", formatPackageSize(int64(free)))
+	return 0
+}
+
+// doctorEnvOverrides returns the MVX_* and proxy variables set in environ, sorted
+func doctorEnvOverrides(environ []string) []string {
+	var overrides []string
+	for _, entry := range environ {
+		name, _, _ := strings.Cut(entry, "=")
+		switch strings.ToUpper(name) {
+		case "HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY":
+			overrides = append(overrides, entry)
+			continue
+		}
+		if strings.HasPrefix(name, "MVX_") {
+			overrides = append(overrides, entry)
+		}
+	}
+	sort.Strings(overrides)
+	return overrides
+}
+
+// reportNetwork reports the reachability of the servers tools are downloaded from, and returns
+// the number of blocking problems found: none is reachable while online
+func reportNetwork(manager *tools.Manager) int {
+	if tools.IsOffline() {
+		printInfo("  Offline mode, servers not checked")
+		return 0
+	}
+
+	reachable := 0
+	for _, check := range manager.CheckHosts(tools.DiagnosticHosts, doctorHostTimeout) {
+		switch {
+		case !check.Reachable():
+			printInfo("  ⚠️  %s: %s unreachable: %v", check.Host.Name, check.URL, check.Err)
+		case check.StatusCode >= 500:
+			reachable++
+			printInfo("  ⚠️  %s: HTTP %d in %v", check.Host.Name, check.StatusCode, check.Latency.Round(time.Millisecond))
+		default:
+			reachable++
+			printInfo("  ✅ %s: HTTP %d in %v", check.Host.Name, check.StatusCode, check.Latency.Round(time.Millisecond))
+		}
+	}
+	if reachable == 0 {
+		printInfo("  ❌ No server reachable: check your network, proxy (HTTPS_PROXY) and certificates (%s)", tools.EnvCACert)
+		return 1
+	}
+	return 0
+}
+
+// reportProjectTools reports the resolved version and install status of each tool of the
+// project, and returns the number of blocking problems found
+func reportProjectTools(manager *tools.Manager, projectRoot string) int {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		printInfo("  ❌ Failed to load configuration: %v", err)
+		return 1
+	}
+
+	toolNames := make([]string, 0, len(cfg.Tools))
+	for toolName := range cfg.Tools {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	problems := 0
+	missing := 0
+	for _, toolName := range toolNames {
+		toolConfig := cfg.Tools[toolName]
+		tool, err := manager.GetTool(toolName)
+		if err != nil {
+			printInfo("  ❌ %s: %v", toolName, err)
+			problems++
+			continue
+		}
+		resolved, err := manager.ResolveVersion(toolName, toolConfig)
+		if err != nil {
+			printInfo("  ❌ %s %s: %v", toolName, toolConfig.Version, err)
+			problems++
+			continue
+		}
+		resolvedConfig := toolConfig
+		resolvedConfig.Version = resolved
+		display := toolConfig.Version
+		if canonical := manager.CanonicalVersion(toolName, resolved); canonical != display {
+			display += " -> " + canonical
+		}
+		if tool.IsInstalled(resolved, resolvedConfig) {
+			printInfo("  ✅ %s %s (installed)", toolName, display)
+		} else {
+			printInfo("  ⚠️  %s %s is not installed", toolName, display)
+			missing++
+		}
+	}
+	if len(toolNames) == 0 {
+		printInfo("  No tools configured")
+	}
+	if missing > 0 {
+		printInfo("  Run 'mvx setup' to install the missing tools")
+	}
+
+	if reportPathShadowing(manager, cfg) == 0 {
+		printInfo("  ✅ No system tool shadows an mvx-managed tool")
+	}
+	return problems
+}
+
+// reportPathShadowing warns about system tools found on PATH before the mvx-managed ones
+// and returns how many were found
+func reportPathShadowing(manager *tools.Manager, cfg *config.Config) int {
+	shadowed := manager.CheckPathShadowing(cfg)
+	for _, s := range shadowed {
+		printWarning("%s on PATH resolves to %s, not the mvx-managed %s in %s", s.Binary, s.SystemPath, s.Tool, s.ManagedDir)
+	}
+	if len(shadowed) > 0 {
+		printWarning("Outside of mvx these system tools win: run commands through mvx (e.g. 'mvx shell'), or load the environment with 'eval \"$(mvx env --shell auto)\"'")
+	}
+	return len(shadowed)
+}
@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDoctorEnvOverrides(t *testing.T) {
+	environ := []string{
+		"PATH=/usr/bin",
+		"MVX_VERBOSE=true",
+		"https_proxy=http://proxy:3128",
+		"HOME=/home/user",
+		"MVX_JAVA_VERSION=21",
+		"MAVEN_OPTS=-Xmx1g",
+	}
+	expected := []string{"MVX_JAVA_VERSION=21", "MVX_VERBOSE=true", "https_proxy=http://proxy:3128"}
+	if got := doctorEnvOverrides(environ); !reflect.DeepEqual(got, expected) {
+		t.Errorf("doctorEnvOverrides() = %q, want %q", got, expected)
+	}
+}
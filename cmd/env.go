@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/config"
@@ -13,31 +16,42 @@ import (
 )
 
 var (
-	envShell string
+	envShell      string
+	envExportFile string
+	envJSON       bool
 )
 
 // envCmd represents the env command
 var envCmd = &cobra.Command{
 	Use:   "env",
-	Short: "Output environment variables for shell integration",
-	Long: `Output environment variables in shell-specific format for integration
-with shell activation hooks.
+	Short: "Output the environment variables mvx sets",
+	Long: `Output the environment variables mvx sets for the project: the tool variables
+(JAVA_HOME, MAVEN_HOME, ...), the PATH entries of the tools and the configured
+environment. Only the variables mvx adds or changes are shown, as KEY=value lines,
+or as JSON with --json.
 
-This command is primarily used by 'mvx activate' to set up the environment
-when entering directories with .mvx configuration.
-
-The command outputs shell-specific export statements that can be evaluated
-to set up PATH and other environment variables.
+With --shell, the variables are output as shell-specific statements that can be
+evaluated to set up the environment; 'mvx activate' uses them when entering
+directories with .mvx configuration. --shell auto detects the current shell.
 
 Examples:
+  # Show what mvx sets
+  mvx env
+
   # Bash/Zsh
   eval "$(mvx env --shell bash)"
-  
+
   # Fish
   mvx env --shell fish | source
   
   # PowerShell
-  Invoke-Expression (mvx env --shell powershell | Out-String)`,
+  Invoke-Expression (mvx env --shell powershell | Out-String)
+
+  # JSON for tooling
+  mvx env --json
+
+  # dotenv file for docker-compose or CI steps
+  mvx env --export-file .env.mvx`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := outputEnvironment(); err != nil {
@@ -48,7 +62,9 @@ Examples:
 }
 
 func init() {
-	envCmd.Flags().StringVar(&envShell, "shell", detectShell(), "shell type (bash, zsh, fish, powershell)")
+	envCmd.Flags().StringVar(&envShell, "shell", "", "output statements for this shell (bash, zsh, fish, powershell, or auto to detect it)")
+	envCmd.Flags().StringVar(&envExportFile, "export-file", "", "write the tool environment as KEY=VALUE lines to this dotenv file instead")
+	envCmd.Flags().BoolVar(&envJSON, "json", false, "output the variables as a JSON object")
 }
 
 // detectShell attempts to detect the current shell
@@ -104,45 +120,85 @@ func outputEnvironment() error {
 		return fmt.Errorf("failed to create tool manager: %w", err)
 	}
 
-	// Get environment variables (includes PATH with tool directories)
-	env, err := manager.SetupEnvironment(cfg)
+	// Only output what mvx computes, not the inherited environment
+	changes, err := manager.EnvironmentChanges(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to setup environment: %w", err)
 	}
 
-	// Extract PATH from environment
-	pathDirs := []string{}
-	if pathValue, exists := env["PATH"]; exists && pathValue != "" {
-		pathDirs = strings.Split(pathValue, string(os.PathListSeparator))
+	if envExportFile != "" {
+		if err := writeDotenvFile(envExportFile, changes); err != nil {
+			return err
+		}
+		printSuccess("✅ Wrote %d environment variables to %s", len(changes), envExportFile)
+		return nil
+	}
+
+	if envJSON {
+		data, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode environment: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	// Shells prepend the tool directories to their PATH
+	var pathDirs []string
+	if pathValue, exists := changes["PATH"]; exists {
+		pathDirs = pathAdditions(pathValue, os.Getenv("PATH"))
 	}
 
 	// Output environment in shell-specific format
-	switch envShell {
+	shellType := envShell
+	if shellType == "auto" {
+		shellType = detectShell()
+	}
+	switch shellType {
+	case "":
+		fmt.Print(formatDotenv(changes))
+		return nil
 	case "bash", "zsh":
-		return outputBashEnv(pathDirs, env)
+		return outputBashEnv(pathDirs, changes)
 	case "fish":
-		return outputFishEnv(pathDirs, env)
+		return outputFishEnv(pathDirs, changes)
 	case "powershell":
-		return outputPowerShellEnv(pathDirs, env)
+		return outputPowerShellEnv(pathDirs, changes)
 	default:
 		return fmt.Errorf("unsupported shell: %s", envShell)
 	}
 }
 
+// pathAdditions returns the directories of path that are not in currentPath, in order
+func pathAdditions(path, currentPath string) []string {
+	current := make(map[string]bool)
+	for _, dir := range filepath.SplitList(currentPath) {
+		current[dir] = true
+	}
+	var additions []string
+	for _, dir := range filepath.SplitList(path) {
+		if dir != "" && !current[dir] {
+			additions = append(additions, dir)
+			current[dir] = true
+		}
+	}
+	return additions
+}
+
 // outputBashEnv outputs environment in bash/zsh format
 func outputBashEnv(pathDirs []string, env map[string]string) error {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+
 	// Export PATH
 	if len(pathDirs) > 0 {
 		pathStr := strings.Join(pathDirs, string(os.PathListSeparator))
-		fmt.Printf("export PATH=\"%s:$PATH\"\n", pathStr)
+		fmt.Printf("export PATH=\"%s:$PATH\"\n", quote.Replace(pathStr))
 	}
 
 	// Export other environment variables
-	for key, value := range env {
+	for _, key := range sortedEnvKeys(env) {
 		if key != "PATH" {
-			// Escape quotes in value
-			escapedValue := strings.ReplaceAll(value, `"`, `\"`)
-			fmt.Printf("export %s=\"%s\"\n", key, escapedValue)
+			fmt.Printf("export %s=\"%s\"\n", key, quote.Replace(env[key]))
 		}
 	}
 
@@ -151,19 +207,21 @@ func outputBashEnv(pathDirs []string, env map[string]string) error {
 
 // outputFishEnv outputs environment in fish format
 func outputFishEnv(pathDirs []string, env map[string]string) error {
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`)
+
 	// Set PATH
 	if len(pathDirs) > 0 {
-		for _, dir := range pathDirs {
-			fmt.Printf("set -gx PATH \"%s\" $PATH\n", dir)
+		quoted := make([]string, len(pathDirs))
+		for i, dir := range pathDirs {
+			quoted[i] = `"` + quote.Replace(dir) + `"`
 		}
+		fmt.Printf("set -gx PATH %s $PATH\n", strings.Join(quoted, " "))
 	}
 
 	// Set other environment variables
-	for key, value := range env {
+	for _, key := range sortedEnvKeys(env) {
 		if key != "PATH" {
-			// Escape quotes in value
-			escapedValue := strings.ReplaceAll(value, `"`, `\"`)
-			fmt.Printf("set -gx %s \"%s\"\n", key, escapedValue)
+			fmt.Printf("set -gx %s \"%s\"\n", key, quote.Replace(env[key]))
 		}
 	}
 
@@ -172,20 +230,76 @@ func outputFishEnv(pathDirs []string, env map[string]string) error {
 
 // outputPowerShellEnv outputs environment in PowerShell format
 func outputPowerShellEnv(pathDirs []string, env map[string]string) error {
+	quote := strings.NewReplacer("`", "``", `"`, "`\"", "$", "`$")
+
 	// Set PATH
 	if len(pathDirs) > 0 {
-		pathStr := strings.Join(pathDirs, string(os.PathListSeparator))
-		fmt.Printf("$env:PATH = \"%s;$env:PATH\"\n", pathStr)
+		separator := string(os.PathListSeparator)
+		fmt.Printf("$env:PATH = \"%s%s$env:PATH\"\n", quote.Replace(strings.Join(pathDirs, separator)), separator)
 	}
 
 	// Set other environment variables
-	for key, value := range env {
+	for _, key := range sortedEnvKeys(env) {
 		if key != "PATH" {
-			// Escape quotes in value
-			escapedValue := strings.ReplaceAll(value, `"`, `\"`+"`"+`"`)
-			fmt.Printf("$env:%s = \"%s\"\n", key, escapedValue)
+			fmt.Printf("$env:%s = \"%s\"\n", key, quote.Replace(env[key]))
 		}
 	}
 
 	return nil
 }
+
+// dotenvBareValue matches values that need no quoting in dotenv files
+var dotenvBareValue = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+// formatDotenv formats variables as sorted KEY=VALUE lines
+func formatDotenv(env map[string]string) string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&b, "%s=%s\n", key, quoteDotenvValue(env[key]))
+	}
+	return b.String()
+}
+
+// quoteDotenvValue quotes a value when needed. Single quotes keep the value literal (no
+// variable interpolation); values containing single quotes or newlines are double-quoted
+// with backslash escapes.
+func quoteDotenvValue(value string) string {
+	if dotenvBareValue.MatchString(value) {
+		return value
+	}
+	if !strings.ContainsAny(value, "'\n") {
+		return "'" + value + "'"
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
+
+// writeDotenvFile atomically replaces path with the given variables in dotenv format
+func writeDotenvFile(path string, env map[string]string) error {
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(temp.Name()) // No-op once renamed
+
+	if _, err := temp.WriteString(formatDotenv(env)); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(temp.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move file to %s: %w", path, err)
+	}
+	return nil
+}
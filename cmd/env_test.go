@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
 	"runtime"
 	"strings"
 	"testing"
@@ -336,3 +338,93 @@ func TestEnvCommandWithShellFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestPathAdditions(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	current := strings.Join([]string{"/usr/local/bin", "/usr/bin"}, sep)
+	path := strings.Join([]string{"/tools/java/bin", "/tools/maven/bin", "/usr/local/bin", "/tools/java/bin", "/usr/bin"}, sep)
+
+	expected := []string{"/tools/java/bin", "/tools/maven/bin"}
+	if additions := pathAdditions(path, current); !reflect.DeepEqual(additions, expected) {
+		t.Errorf("pathAdditions() = %v, want %v", additions, expected)
+	}
+	if additions := pathAdditions(current, current); len(additions) != 0 {
+		t.Errorf("pathAdditions() of an unchanged PATH = %v, want none", additions)
+	}
+}
+
+func TestOutputBashEnvQuoting(t *testing.T) {
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := outputBashEnv(nil, map[string]string{
+		"MAVEN_OPTS": `-Dmsg="hi" -Dhome=$HOME`,
+		"CMD":        "echo `id`",
+		"PATH":       "/ignored",
+	})
+
+	w.Close()
+	os.Stdout = oldStdout
+	output, _ := io.ReadAll(r)
+
+	if err != nil {
+		t.Fatalf("outputBashEnv() error = %v", err)
+	}
+	expected := "export CMD=\"echo \\`id\\`\"\nexport MAVEN_OPTS=\"-Dmsg=\\\"hi\\\" -Dhome=\\$HOME\"\n"
+	if string(output) != expected {
+		t.Errorf("outputBashEnv() =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+func TestQuoteDotenvValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"/opt/java/21", "/opt/java/21"},
+		{"", ""},
+		{"/Users/me/Library/Java Home", "'/Users/me/Library/Java Home'"},
+		{"-Xmx2g -Dfoo=$HOME", "'-Xmx2g -Dfoo=$HOME'"},
+		{`it's "quoted"`, `"it's \"quoted\""`},
+		{"line1\nline2", `"line1\nline2"`},
+		{`C:\it's`, `"C:\\it's"`},
+	}
+
+	for _, tt := range tests {
+		if got := quoteDotenvValue(tt.value); got != tt.expected {
+			t.Errorf("quoteDotenvValue(%q) = %s, want %s", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestWriteDotenvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env.mvx")
+	if err := os.WriteFile(path, []byte("STALE=1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	env := map[string]string{
+		"PATH":      "/tools/java/bin:/usr/bin",
+		"JAVA_HOME": "/tools/java",
+		"GREETING":  "hello world",
+	}
+	if err := writeDotenvFile(path, env); err != nil {
+		t.Fatalf("writeDotenvFile() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "GREETING='hello world'\nJAVA_HOME=/tools/java\nPATH=/tools/java/bin:/usr/bin\n"
+	if string(content) != expected {
+		t.Errorf("dotenv file =\n%s\nwant\n%s", content, expected)
+	}
+
+	// No temporary files are left behind
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("Expected only the dotenv file, got %d entries", len(entries))
+	}
+}
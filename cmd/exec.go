@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+// execCmd runs any executable with the project's tools on PATH
+var execCmd = &cobra.Command{
+	Use:   "exec [--] <command> [args...]",
+	Short: "Run an arbitrary command in the mvx environment",
+	Long: `Run any executable with the project's tools on PATH and their environment
+variables (JAVA_HOME, GOROOT, ...) set, without defining a custom command.
+
+The command runs in the current directory, with the terminal's stdin, stdout and
+stderr, and mvx exits with its exit code. Use '--' before the command when its
+arguments start with a dash that mvx should not interpret.
+
+Examples:
+  mvx exec java -version              # Run the project's Java
+  mvx exec -- ./gradlew build         # Run a script with the project's JDK
+  mvx exec env                        # Show the environment tools run with
+  mvx exec --print-env -- mvn -v      # Print the computed environment to stderr, then run`,
+
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		exitCode, err := execInEnvironment(args)
+		if err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+		os.Exit(exitCode)
+	},
+}
+
+var execPrintEnv bool
+
+func init() {
+	execCmd.Flags().BoolVar(&execPrintEnv, "print-env", false, "print the computed environment to stderr before executing")
+	// Everything after the command name belongs to the command, e.g. 'mvx exec java -version'
+	execCmd.Flags().SetInterspersed(false)
+	rootCmd.AddCommand(execCmd)
+}
+
+// execInEnvironment runs args in the project environment and returns the command's exit code
+func execInEnvironment(args []string) (int, error) {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return 0, fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create tool manager: %w", err)
+	}
+
+	envMap, err := manager.SetupProjectEnvironment(cfg, projectRoot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to setup environment: %w", err)
+	}
+
+	executable, err := lookPathIn(args[0], envMap["PATH"])
+	if err != nil {
+		return 0, err
+	}
+
+	env := make([]string, 0, len(envMap))
+	for key, value := range envMap {
+		env = append(env, key+"="+value)
+	}
+	if execPrintEnv {
+		printEnvironment(os.Stderr, env)
+	}
+
+	printVerbose("Executing %s", strings.Join(append([]string{executable}, args[1:]...), " "))
+	c := exec.Command(executable, args[1:]...)
+	c.Env = env
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitCode(), nil
+		}
+		return 0, fmt.Errorf("failed to run %s: %w", args[0], err)
+	}
+	return 0, nil
+}
+
+// lookPathIn finds an executable in the given PATH rather than in mvx's own one, so that
+// the project's tools take precedence over installations found on the system
+func lookPathIn(name, path string) (string, error) {
+	originalPath := os.Getenv("PATH")
+	os.Setenv("PATH", path)
+	defer os.Setenv("PATH", originalPath)
+
+	executable, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("command not found: %s", name)
+	}
+	return executable, nil
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+func TestExecInEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	t.Setenv("HOME", t.TempDir())
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	projectDir := t.TempDir()
+	mvxDir := filepath.Join(projectDir, ".mvx")
+	if err := os.MkdirAll(mvxDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := `{ project: { name: "exec" }, environment: { EXEC_TEST: "from-config" } }`
+	if err := os.WriteFile(filepath.Join(mvxDir, "config.json5"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(projectDir)
+
+	exitCode, err := execInEnvironment([]string{"sh", "-c", `test "$EXEC_TEST" = from-config && exit 3`})
+	if err != nil {
+		t.Fatalf("execInEnvironment() error = %v", err)
+	}
+	if exitCode != 3 {
+		t.Errorf("execInEnvironment() exit code = %d, want 3", exitCode)
+	}
+
+	if _, err := execInEnvironment([]string{"mvx-exec-test-missing-command"}); err == nil {
+		t.Errorf("execInEnvironment() expected an error for a missing command")
+	}
+}
+
+func TestExecPrintEnv(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	t.Setenv("HOME", t.TempDir())
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	projectDir := t.TempDir()
+	mvxDir := filepath.Join(projectDir, ".mvx")
+	if err := os.MkdirAll(mvxDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := `{ project: { name: "exec" }, environment: { EXEC_TEST: "from-config" } }`
+	if err := os.WriteFile(filepath.Join(mvxDir, "config.json5"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(projectDir)
+
+	execPrintEnv = true
+	defer func() { execPrintEnv = false }()
+
+	// Capture stderr
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	captured := make(chan string)
+	go func() {
+		output, _ := io.ReadAll(r)
+		captured <- string(output)
+	}()
+
+	_, err := execInEnvironment([]string{"true"})
+
+	w.Close()
+	os.Stderr = oldStderr
+	output := <-captured
+
+	if err != nil {
+		t.Fatalf("execInEnvironment() error = %v", err)
+	}
+	if !strings.Contains(output, "EXEC_TEST=from-config\n") {
+		t.Errorf("stderr = %q, want it to contain EXEC_TEST=from-config", output)
+	}
+	if !strings.Contains(output, "PATH=") {
+		t.Errorf("stderr = %q, want it to contain PATH", output)
+	}
+}
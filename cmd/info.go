@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/config"
@@ -21,7 +23,8 @@ script, requirements, and environment variables.
 Examples:
   mvx info build             # Show info about build command
   mvx info test              # Show info about test command
-  mvx info                   # Show project information`,
+  mvx info                   # Show project information
+  mvx info --json            # Show project information as JSON`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
@@ -41,10 +44,84 @@ Examples:
 	},
 }
 
+var infoJSON bool
+
 func init() {
+	infoCmd.Flags().BoolVar(&infoJSON, "json", false, "output project information as JSON")
+
 	rootCmd.AddCommand(infoCmd)
 }
 
+// projectInfo holds the project overview shown by 'mvx info'
+type projectInfo struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Root        string            `json:"root"`
+	Tools       []projectToolInfo `json:"tools"`
+	Commands    []projectCmdInfo  `json:"commands"`
+}
+
+// projectToolInfo describes a configured tool and its resolved version
+type projectToolInfo struct {
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	ResolvedVersion string `json:"resolved_version,omitempty"`
+	Distribution    string `json:"distribution,omitempty"`
+}
+
+// projectCmdInfo describes a command defined in the configuration
+type projectCmdInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// collectProjectInfo gathers project metadata, tools with resolved versions, and commands
+func collectProjectInfo(cfg *config.Config, projectRoot string, manager *tools.Manager) projectInfo {
+	info := projectInfo{
+		Name:        cfg.Project.Name,
+		Description: cfg.Project.Description,
+		Root:        projectRoot,
+		Tools:       []projectToolInfo{},
+		Commands:    []projectCmdInfo{},
+	}
+
+	toolNames := make([]string, 0, len(cfg.Tools))
+	for toolName := range cfg.Tools {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	for _, toolName := range toolNames {
+		toolConfig := cfg.Tools[toolName]
+		toolInfo := projectToolInfo{
+			Name:         toolName,
+			Version:      manager.CanonicalVersion(toolName, toolConfig.Version),
+			Distribution: toolConfig.Distribution,
+		}
+		if resolved, err := manager.ResolveVersion(toolName, toolConfig); err == nil {
+			toolInfo.ResolvedVersion = manager.CanonicalVersion(toolName, resolved)
+		} else {
+			printVerbose("Failed to resolve %s %s: %v", toolName, toolConfig.Version, err)
+		}
+		info.Tools = append(info.Tools, toolInfo)
+	}
+
+	commandNames := make([]string, 0, len(cfg.Commands))
+	for commandName := range cfg.Commands {
+		commandNames = append(commandNames, commandName)
+	}
+	sort.Strings(commandNames)
+
+	for _, commandName := range commandNames {
+		info.Commands = append(info.Commands, projectCmdInfo{
+			Name:        commandName,
+			Description: cfg.Commands[commandName].Description,
+		})
+	}
+
+	return info
+}
+
 // showProjectInfo displays general project information
 func showProjectInfo() error {
 	projectRoot, err := findProjectRoot()
@@ -58,30 +135,59 @@ func showProjectInfo() error {
 		return fmt.Errorf("failed to load configuration: %w", err)
 	}
 
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+
+	info := collectProjectInfo(cfg, projectRoot, manager)
+
+	if infoJSON {
+		data, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode project information: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
 	printInfo("📋 Project Information")
 	printInfo("")
-	printInfo("Name:        %s", cfg.Project.Name)
-	printInfo("Description: %s", cfg.Project.Description)
-	printInfo("Root:        %s", projectRoot)
+	printInfo("Name:        %s", info.Name)
+	if info.Description != "" {
+		printInfo("Description: %s", info.Description)
+	}
+	printInfo("Root:        %s", info.Root)
 	printInfo("")
 
 	// Show configured tools
-	if len(cfg.Tools) > 0 {
+	if len(info.Tools) > 0 {
 		printInfo("🛠️  Configured Tools:")
-		for toolName, toolConfig := range cfg.Tools {
+		for _, tool := range info.Tools {
 			distribution := ""
-			if toolConfig.Distribution != "" {
-				distribution = fmt.Sprintf(" (%s)", toolConfig.Distribution)
+			if tool.Distribution != "" {
+				distribution = fmt.Sprintf(" (%s)", tool.Distribution)
+			}
+			resolved := ""
+			if tool.ResolvedVersion != "" && tool.ResolvedVersion != tool.Version {
+				resolved = fmt.Sprintf(" -> %s", tool.ResolvedVersion)
 			}
-			printInfo("  %s: %s%s", toolName, toolConfig.Version, distribution)
+			printInfo("  %s: %s%s%s", tool.Name, tool.Version, resolved, distribution)
 		}
 		printInfo("")
 	}
 
 	// Show available commands
-	if len(cfg.Commands) > 0 {
-		printInfo("⚡ Available Commands: %d", len(cfg.Commands))
-		printInfo("  Run 'mvx run' to see all commands")
+	if len(info.Commands) > 0 {
+		printInfo("⚡ Available Commands: %d", len(info.Commands))
+		for _, command := range info.Commands {
+			if command.Description != "" {
+				printInfo("  %-15s %s", command.Name, command.Description)
+			} else {
+				printInfo("  %s", command.Name)
+			}
+		}
+		printInfo("")
 		printInfo("  Run 'mvx info <command>' for command details")
 	}
 
@@ -123,9 +229,22 @@ func showCommandInfo(commandName string) error {
 		printInfo("")
 	}
 
+	// Pipelines have no script of their own
+	if len(cmdInfo.Pipeline) > 0 {
+		printInfo("Pipeline: %s", strings.Join(cmdInfo.Pipeline, " | "))
+		printInfo("")
+		printInfo("Usage:")
+		printInfo("  mvx run %s", commandName)
+		return nil
+	}
+
 	// Show script and resolved interpreter
-	printInfo("Script:")
-	resolvedScript, resolvedInterpreter, err := config.ResolvePlatformScriptWithInterpreter(cmdInfo.Script, cmdInfo.Interpreter)
+	if cmdInfo.Uses != "" {
+		printInfo("Script (from hook %s):", cmdInfo.Uses)
+	} else {
+		printInfo("Script:")
+	}
+	resolvedScript, resolvedInterpreter, err := cfg.ResolveCommandScript(*cmdInfo)
 	if err != nil {
 		printInfo("  Error resolving script: %v", err)
 	} else {
@@ -154,7 +273,11 @@ func showCommandInfo(commandName string) error {
 	if len(cmdInfo.Requires) > 0 {
 		printInfo("Required Tools:")
 		for _, toolName := range cmdInfo.Requires {
-			if toolConfig, exists := cfg.Tools[toolName]; exists {
+			toolConfig, exists := cmdInfo.Tools[toolName]
+			if !exists {
+				toolConfig, exists = cfg.Tools[toolName]
+			}
+			if exists {
 				status := "❌ Not installed"
 				if tool, err := manager.GetTool(toolName); err == nil {
 					if tool.IsInstalled(toolConfig.Version, toolConfig) {
@@ -169,6 +292,20 @@ func showCommandInfo(commandName string) error {
 		printInfo("")
 	}
 
+	// Show tool versions overriding the project's
+	if len(cmdInfo.Tools) > 0 {
+		printInfo("Tool Overrides:")
+		toolNames := make([]string, 0, len(cmdInfo.Tools))
+		for toolName := range cmdInfo.Tools {
+			toolNames = append(toolNames, toolName)
+		}
+		sort.Strings(toolNames)
+		for _, toolName := range toolNames {
+			printInfo("  %s %s", toolName, cmdInfo.Tools[toolName].Version)
+		}
+		printInfo("")
+	}
+
 	// Show environment variables
 	if len(cmdInfo.Environment) > 0 {
 		printInfo("Environment Variables:")
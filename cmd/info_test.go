@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+func TestCollectProjectInfo(t *testing.T) {
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+
+	cfg := &config.Config{
+		Project: config.ProjectConfig{
+			Name:        "demo",
+			Description: "A demo project",
+		},
+		Tools: map[string]config.ToolConfig{
+			"maven": {Version: "3.9.6"},
+			"java":  {Version: "21.0.1", Distribution: "zulu"},
+		},
+		Commands: map[string]config.CommandConfig{
+			"test":  {Description: "Run tests", Script: "mvn test"},
+			"build": {Description: "Build project", Script: "mvn install"},
+		},
+	}
+
+	info := collectProjectInfo(cfg, "/project", manager)
+
+	if info.Name != "demo" || info.Description != "A demo project" || info.Root != "/project" {
+		t.Errorf("Unexpected project metadata: %+v", info)
+	}
+
+	if len(info.Tools) != 2 || info.Tools[0].Name != "java" || info.Tools[1].Name != "maven" {
+		t.Fatalf("Expected tools sorted by name, got %+v", info.Tools)
+	}
+	if info.Tools[0].ResolvedVersion != "21.0.1" || info.Tools[0].Distribution != "zulu" {
+		t.Errorf("Unexpected java info: %+v", info.Tools[0])
+	}
+
+	if len(info.Commands) != 2 || info.Commands[0].Name != "build" || info.Commands[0].Description != "Build project" {
+		t.Errorf("Expected commands sorted by name with descriptions, got %+v", info.Commands)
+	}
+}
@@ -10,8 +10,9 @@ import (
 
 var (
 	// Init command flags
-	initFormat string
-	initForce  bool
+	initFormat         string
+	initForce          bool
+	initFromDockerfile string
 )
 
 // initCmd represents the init command
@@ -26,7 +27,8 @@ customize for your project's needs.
 Examples:
   mvx init                    # Create config.json5 (default)
   mvx init --format=yaml      # Create config.yml instead
-  mvx init --force            # Overwrite existing configuration`,
+  mvx init --force            # Overwrite existing configuration
+  mvx init --from-dockerfile Dockerfile  # Import tool versions from a Dockerfile`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := initProject(); err != nil {
@@ -39,6 +41,7 @@ Examples:
 func init() {
 	initCmd.Flags().StringVar(&initFormat, "format", "json5", "configuration format (json5, yaml)")
 	initCmd.Flags().BoolVar(&initForce, "force", false, "overwrite existing configuration")
+	initCmd.Flags().StringVar(&initFromDockerfile, "from-dockerfile", "", "generate the configuration from tool versions found in a Dockerfile")
 }
 
 func initProject() error {
@@ -76,6 +79,17 @@ func initProject() error {
 		return fmt.Errorf("configuration file already exists: %s (use --force to overwrite)", configPath)
 	}
 
+	if initFromDockerfile != "" {
+		format := "json5"
+		if configFile == "config.yml" {
+			format = "yaml"
+		}
+		configContent, err = importConfigFromDockerfile(initFromDockerfile, filepath.Base(projectRoot), format)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Write config file
 	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
 		return fmt.Errorf("failed to write configuration file: %w", err)
@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// importedTool is a tool version found in a Dockerfile
+type importedTool struct {
+	Name         string
+	Version      string
+	Distribution string
+	Source       string // Dockerfile instruction the version was found in
+}
+
+// dockerfileImport is the result of scanning a Dockerfile for tool versions
+type dockerfileImport struct {
+	Tools    []importedTool
+	Warnings []string
+}
+
+// dockerImageTools maps base image names to the tool (and Java distribution) they provide
+var dockerImageTools = map[string]struct{ tool, distribution string }{
+	"eclipse-temurin":   {tools.ToolJava, "temurin"},
+	"openjdk":           {tools.ToolJava, ""},
+	"amazoncorretto":    {tools.ToolJava, "corretto"},
+	"azul/zulu-openjdk": {tools.ToolJava, "zulu"},
+	"maven":             {tools.ToolMaven, ""},
+	"node":              {tools.ToolNode, ""},
+	"golang":            {tools.ToolGo, ""},
+}
+
+// dockerVersionVariables maps conventional ARG/ENV names to the tool they pin
+var dockerVersionVariables = map[string]string{
+	"JAVA_VERSION":   tools.ToolJava,
+	"JDK_VERSION":    tools.ToolJava,
+	"MAVEN_VERSION":  tools.ToolMaven,
+	"MVND_VERSION":   tools.ToolMvnd,
+	"NODE_VERSION":   tools.ToolNode,
+	"GO_VERSION":     tools.ToolGo,
+	"GOLANG_VERSION": tools.ToolGo,
+}
+
+var (
+	// leadingVersion matches the version at the start of an image tag (e.g. "21" in "21-jdk-jammy")
+	leadingVersion = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)`)
+	// mavenJDKTag matches the JDK part of Maven image tags (e.g. "eclipse-temurin-21")
+	mavenJDKTag = regexp.MustCompile(`(eclipse-temurin|amazoncorretto|openjdk|zulu)-(\d+)`)
+	// dockerVariable matches ${NAME} and $NAME references
+	dockerVariable = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)\}?`)
+)
+
+// importDockerfile heuristically extracts tool versions from base image tags and ARG/ENV lines
+func importDockerfile(content string) dockerfileImport {
+	result := dockerfileImport{}
+	found := make(map[string]importedTool)
+	variables := make(map[string]string)
+	stages := make(map[string]bool)
+
+	record := func(tool importedTool) {
+		if existing, ok := found[tool.Name]; ok {
+			if existing.Version != tool.Version {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: ignoring %s %s, already using %s from %s", tool.Source, tool.Name, tool.Version, existing.Version, existing.Source))
+			} else if existing.Distribution == "" {
+				// e.g. ARG JAVA_VERSION=21 followed by FROM eclipse-temurin:${JAVA_VERSION}
+				existing.Distribution = tool.Distribution
+				found[tool.Name] = existing
+			}
+			return
+		}
+		found[tool.Name] = tool
+	}
+
+	for _, line := range dockerfileInstructions(content) {
+		instruction, args, _ := strings.Cut(line, " ")
+		args = strings.TrimSpace(args)
+
+		switch strings.ToUpper(instruction) {
+		case "ARG", "ENV":
+			for _, assignment := range parseDockerAssignments(args) {
+				value := expandDockerVariables(assignment.value, variables)
+				variables[assignment.name] = value
+				tool, known := dockerVersionVariables[assignment.name]
+				switch {
+				case known && value != "":
+					record(importedTool{Name: tool, Version: value, Source: line})
+				case known:
+					result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s has no default value", line, assignment.name))
+				case strings.HasSuffix(assignment.name, "_VERSION"):
+					result.Warnings = append(result.Warnings, fmt.Sprintf("%s: unrecognized version variable %s", line, assignment.name))
+				}
+			}
+		case "FROM":
+			image, stage := parseFromInstruction(args)
+			if stage != "" {
+				stages[stage] = true
+			}
+			image = expandDockerVariables(image, variables)
+			if stages[image] || image == "scratch" {
+				continue // Earlier build stage or empty image
+			}
+			imported, warning := toolsFromImage(image, line)
+			for _, tool := range imported {
+				record(tool)
+			}
+			if warning != "" {
+				result.Warnings = append(result.Warnings, warning)
+			}
+		}
+	}
+
+	for _, tool := range found {
+		result.Tools = append(result.Tools, tool)
+	}
+	sort.Slice(result.Tools, func(i, j int) bool {
+		return result.Tools[i].Name < result.Tools[j].Name
+	})
+	return result
+}
+
+// dockerfileInstructions returns the instructions of a Dockerfile, with line continuations
+// joined and comments removed
+func dockerfileInstructions(content string) []string {
+	var instructions []string
+	var current strings.Builder
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") || (line == "" && current.Len() == 0) {
+			continue
+		}
+		if strings.HasSuffix(line, "\\") {
+			current.WriteString(strings.TrimSuffix(line, "\\") + " ")
+			continue
+		}
+		current.WriteString(line)
+		if instruction := strings.Join(strings.Fields(current.String()), " "); instruction != "" {
+			instructions = append(instructions, instruction)
+		}
+		current.Reset()
+	}
+	return instructions
+}
+
+// dockerAssignment is a variable set by an ARG or ENV instruction
+type dockerAssignment struct {
+	name, value string
+}
+
+// parseDockerAssignments parses the arguments of ARG and ENV instructions, supporting
+// "NAME=value ..." as well as the legacy "ENV NAME value" form
+func parseDockerAssignments(args string) []dockerAssignment {
+	fields := strings.Fields(args)
+	if len(fields) >= 2 && !strings.Contains(fields[0], "=") {
+		return []dockerAssignment{{fields[0], strings.Trim(strings.Join(fields[1:], " "), `"'`)}}
+	}
+	var assignments []dockerAssignment
+	for _, field := range fields {
+		name, value, _ := strings.Cut(field, "=")
+		assignments = append(assignments, dockerAssignment{name, strings.Trim(value, `"'`)})
+	}
+	return assignments
+}
+
+// parseFromInstruction returns the image and stage name of a FROM instruction
+func parseFromInstruction(args string) (image, stage string) {
+	var fields []string
+	for _, field := range strings.Fields(args) {
+		if !strings.HasPrefix(field, "--") { // e.g. --platform=linux/amd64
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return "", ""
+	}
+	if len(fields) >= 3 && strings.EqualFold(fields[1], "as") {
+		stage = fields[2]
+	}
+	return fields[0], stage
+}
+
+// expandDockerVariables substitutes ARG/ENV values into a string
+func expandDockerVariables(value string, variables map[string]string) string {
+	return dockerVariable.ReplaceAllStringFunc(value, func(ref string) string {
+		name := dockerVariable.FindStringSubmatch(ref)[1]
+		if v, ok := variables[name]; ok {
+			return v
+		}
+		return ref
+	})
+}
+
+// toolsFromImage maps a base image reference to the tools it provides
+func toolsFromImage(image, source string) ([]importedTool, string) {
+	image, _, _ = strings.Cut(image, "@") // Drop digests
+	name, tag := image, ""
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		name, tag = image[:i], image[i+1:]
+	}
+	name = strings.TrimPrefix(name, "docker.io/")
+	name = strings.TrimPrefix(name, "library/")
+
+	mapping, ok := dockerImageTools[name]
+	if !ok {
+		return nil, fmt.Sprintf("%s: unrecognized base image %s", source, name)
+	}
+
+	match := leadingVersion.FindStringSubmatch(tag)
+	if match == nil {
+		return nil, fmt.Sprintf("%s: no version in tag of %s image, pin one with a tag such as %s:<version>", source, name, name)
+	}
+
+	imported := []importedTool{{Name: mapping.tool, Version: match[1], Distribution: mapping.distribution, Source: source}}
+
+	// Maven images also carry a JDK, e.g. maven:3.9.6-eclipse-temurin-21
+	if mapping.tool == tools.ToolMaven {
+		if jdk := mavenJDKTag.FindStringSubmatch(tag); jdk != nil {
+			distribution := dockerImageTools[jdk[1]].distribution
+			if jdk[1] == "zulu" {
+				distribution = "zulu"
+			}
+			imported = append(imported, importedTool{Name: tools.ToolJava, Version: jdk[2], Distribution: distribution, Source: source})
+		}
+	}
+	return imported, ""
+}
+
+// formatImportedConfig renders the imported tools as a configuration for review
+func formatImportedConfig(projectName, dockerfile, format string, imported []importedTool) string {
+	var b strings.Builder
+	if format == "json5" {
+		fmt.Fprintf(&b, "{\n  // Generated by 'mvx init --from-dockerfile %s', review before use\n\n", dockerfile)
+		fmt.Fprintf(&b, "  project: {\n    name: %q,\n  },\n\n  tools: {\n", projectName)
+		for _, tool := range imported {
+			fmt.Fprintf(&b, "    // From: %s\n    %s: {\n      version: %q,\n", tool.Source, tool.Name, tool.Version)
+			if tool.Distribution != "" {
+				fmt.Fprintf(&b, "      distribution: %q,\n", tool.Distribution)
+			}
+			b.WriteString("    },\n")
+		}
+		b.WriteString("  },\n}\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "# Generated by 'mvx init --from-dockerfile %s', review before use\n\n", dockerfile)
+	fmt.Fprintf(&b, "project:\n  name: %q\n\ntools:", projectName)
+	if len(imported) == 0 {
+		b.WriteString(" {}\n")
+		return b.String()
+	}
+	b.WriteString("\n")
+	for _, tool := range imported {
+		fmt.Fprintf(&b, "  # From: %s\n  %s:\n    version: %q\n", tool.Source, tool.Name, tool.Version)
+		if tool.Distribution != "" {
+			fmt.Fprintf(&b, "    distribution: %s\n", tool.Distribution)
+		}
+	}
+	return b.String()
+}
+
+// importConfigFromDockerfile reads a Dockerfile and returns the configuration generated from it
+func importConfigFromDockerfile(dockerfile, projectName, format string) (string, error) {
+	content, err := os.ReadFile(dockerfile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Dockerfile: %w", err)
+	}
+
+	result := importDockerfile(string(content))
+	for _, warning := range result.Warnings {
+		printWarning("%s", warning)
+	}
+	if len(result.Tools) == 0 {
+		printWarning("No tool versions recognized in %s", dockerfile)
+	}
+	for _, tool := range result.Tools {
+		printInfo("🔎 Found %s %s", tool.Name, tool.Version)
+	}
+
+	return formatImportedConfig(projectName, dockerfile, format, result.Tools), nil
+}
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"gopkg.in/yaml.v3"
+)
+
+const sampleDockerfile = `# Build stage
+ARG NODE_VERSION=18.20.4
+FROM --platform=$BUILDPLATFORM maven:3.9.6-eclipse-temurin-21 AS build
+ENV GRADLE_VERSION 8.5
+RUN curl -fsSL https://nodejs.org/dist/v${NODE_VERSION}/node-v${NODE_VERSION}-linux-x64.tar.gz \
+    | tar -xz -C /opt
+
+FROM golang:1.21-alpine AS tools
+FROM build AS package
+FROM ubuntu:22.04
+`
+
+func TestImportDockerfile(t *testing.T) {
+	result := importDockerfile(sampleDockerfile)
+
+	expected := map[string]importedTool{
+		"go":    {Version: "1.21"},
+		"java":  {Version: "21", Distribution: "temurin"},
+		"maven": {Version: "3.9.6"},
+		"node":  {Version: "18.20.4"},
+	}
+	if len(result.Tools) != len(expected) {
+		t.Fatalf("Expected %d tools, got %+v", len(expected), result.Tools)
+	}
+	for i, tool := range result.Tools {
+		if i > 0 && result.Tools[i-1].Name > tool.Name {
+			t.Errorf("Expected tools sorted by name, got %+v", result.Tools)
+		}
+		want, ok := expected[tool.Name]
+		if !ok || tool.Version != want.Version || tool.Distribution != want.Distribution {
+			t.Errorf("Unexpected tool %+v", tool)
+		}
+	}
+
+	// Unrecognized patterns are reported, build stages are not
+	warnings := strings.Join(result.Warnings, "\n")
+	for _, expected := range []string{"unrecognized version variable GRADLE_VERSION", "unrecognized base image ubuntu"} {
+		if !strings.Contains(warnings, expected) {
+			t.Errorf("Expected warning %q, got:\n%s", expected, warnings)
+		}
+	}
+	if strings.Contains(warnings, "unrecognized base image build") {
+		t.Errorf("Build stages should not be reported, got:\n%s", warnings)
+	}
+}
+
+func TestImportDockerfileArgInImageTag(t *testing.T) {
+	result := importDockerfile("ARG JAVA_VERSION=17\nFROM eclipse-temurin:${JAVA_VERSION}-jdk\n")
+	if len(result.Tools) != 1 || result.Tools[0].Version != "17" || result.Tools[0].Distribution != "temurin" {
+		t.Errorf("Expected java 17 (temurin), got %+v", result.Tools)
+	}
+	if len(result.Warnings) != 0 {
+		t.Errorf("Expected no warnings, got %v", result.Warnings)
+	}
+}
+
+func TestFormatImportedConfig(t *testing.T) {
+	imported := importDockerfile(sampleDockerfile).Tools
+
+	var cfg config.Config
+	if err := config.ParseJSON5([]byte(formatImportedConfig("demo", "Dockerfile", "json5", imported)), &cfg); err != nil {
+		t.Fatalf("Generated JSON5 does not parse: %v", err)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Generated JSON5 config is invalid: %v", err)
+	}
+	if cfg.Tools["java"].Version != "21" || cfg.Tools["java"].Distribution != "temurin" {
+		t.Errorf("Unexpected java configuration: %+v", cfg.Tools["java"])
+	}
+
+	var yamlCfg config.Config
+	if err := yaml.Unmarshal([]byte(formatImportedConfig("demo", "Dockerfile", "yaml", imported)), &yamlCfg); err != nil {
+		t.Fatalf("Generated YAML does not parse: %v", err)
+	}
+	if err := yamlCfg.Validate(); err != nil {
+		t.Errorf("Generated YAML config is invalid: %v", err)
+	}
+	if yamlCfg.Tools["node"].Version != "18.20.4" {
+		t.Errorf("Unexpected node configuration: %+v", yamlCfg.Tools["node"])
+	}
+}
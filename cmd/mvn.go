@@ -114,7 +114,10 @@ Examples:
 		if err != nil {
 			return err
 		}
-		mvnExe := filepath.Join(bin, mvnTool.GetBinaryName())
+		mvnExe, found := mgr.GetPlatformMapper().FindBinary(bin, mvnTool.GetBinaryName())
+		if !found {
+			mvnExe = filepath.Join(bin, mvnTool.GetBinaryName())
+		}
 
 		c := exec.Command(mvnExe, mavenArgs...)
 		c.Dir = projectRoot
@@ -159,6 +162,10 @@ func parseHybridArgs() ([]string, error) {
 			verbose = true
 		case "--quiet", "-q":
 			quiet = true
+		case "--offline":
+			enableOfflineMode()
+		case "--no-auto-install":
+			disableAutoInstall()
 		case "--help", "-h":
 			// Let Cobra handle help
 			continue
@@ -5,11 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gnodet/mvx/pkg/config"
 	"github.com/gnodet/mvx/pkg/executor"
 	"github.com/gnodet/mvx/pkg/tools"
+	"github.com/gnodet/mvx/pkg/util"
 	"github.com/spf13/cobra"
 )
 
@@ -20,8 +23,14 @@ var (
 	date    = "unknown"
 
 	// Global flags
-	verbose bool
-	quiet   bool
+	verbose       bool
+	quiet         bool
+	offline       bool
+	noAutoInstall bool
+	prefix        bool
+	noPrefix      bool
+	strictEnv     bool
+	timeout       time.Duration
 
 	// Auto-setup cache to avoid repeated setup
 	autoSetupDone bool
@@ -42,10 +51,44 @@ Examples:
   mvx test           # Run tests with proper configuration
   mvx demo           # Launch project-specific demos
 
+When run without a subcommand inside a project, mvx runs the project's default
+command (project.default_command, or a command named "default") if one is defined.
+Use 'mvx --help' to show this help instead.
+
 For more information, visit: https://github.com/gnodet/mvx`,
 
-	// Show help if no command is provided
+	// Honor --offline, --no-auto-install, --strict-env and --timeout given after the command name, e.g. 'mvx setup --offline'
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if offline {
+			enableOfflineMode()
+		}
+		if noAutoInstall {
+			disableAutoInstall()
+		}
+		if prefix {
+			os.Setenv(executor.EnvOutputPrefix, "true")
+		}
+		if noPrefix {
+			os.Setenv(executor.EnvOutputPrefix, "false")
+		}
+		if strictEnv {
+			os.Setenv(config.EnvStrictEnv, "true")
+		}
+		if timeout > 0 {
+			setNetworkTimeout(timeout)
+		}
+	},
+
+	// Run the project's default command, or show help if there is none
 	Run: func(cmd *cobra.Command, args []string) {
+		if commandName := findDefaultCommand(); commandName != "" {
+			printVerbose("Running default command: %s", commandName)
+			if err := runCustomCommand(commandName, args); err != nil {
+				printError("%v", err)
+				os.Exit(1)
+			}
+			return
+		}
 		cmd.Help()
 	},
 }
@@ -53,6 +96,30 @@ For more information, visit: https://github.com/gnodet/mvx`,
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() error {
+	// Offline mode must be known before auto-setup, which runs before flags are parsed
+	if hasLeadingFlag(os.Args[1:], "--offline") {
+		enableOfflineMode()
+	}
+	if hasLeadingFlag(os.Args[1:], "--no-auto-install") {
+		disableAutoInstall()
+	}
+	if hasLeadingFlag(os.Args[1:], "--strict-env") {
+		os.Setenv(config.EnvStrictEnv, "true")
+	}
+	if value, found := leadingFlagValue(os.Args[1:], "--timeout"); found {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			setNetworkTimeout(d)
+		}
+	}
+
+	// Versions pinned by the project's lockfile apply to every command, auto-setup included
+	if err := useProjectLockfile(); err != nil {
+		return err
+	}
+	if err := useProjectSettings(); err != nil {
+		return err
+	}
+
 	// Auto-setup tools and environment before executing any command
 	if err := autoSetupEnvironment(); err != nil {
 		// If auto-setup fails, we should fail the command execution
@@ -75,6 +142,113 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// useProjectLockfile makes the tool manager prefer the versions pinned in the project's
+// .mvx/mvx.lock, if there is one
+func useProjectLockfile() error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(tools.GetLockfilePath(projectRoot)); err != nil {
+		return nil
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	if err := manager.UseLockfile(projectRoot); err != nil {
+		return err
+	}
+	printVerbose("Using versions locked in %s", tools.GetLockfilePath(projectRoot))
+	return nil
+}
+
+// useProjectSettings applies the settings of the project configuration, if any, to the tool
+// manager, so that they apply to every command, auto-setup included
+func useProjectSettings() error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return nil
+	}
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil || cfg.Settings == nil {
+		// Invalid configurations are reported by the commands loading them
+		return nil
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	return manager.ApplySettings(cfg)
+}
+
+// leadingValueFlags are the global flags taking a value, which may be given as a separate argument
+var leadingValueFlags = map[string]bool{"--timeout": true}
+
+// hasLeadingFlag reports whether flag is among the flags preceding the command name, so that
+// arguments meant for a tool (e.g. 'mvx mvn --offline') are not mistaken for mvx flags
+func hasLeadingFlag(args []string, flag string) bool {
+	_, found := leadingFlagValue(args, flag)
+	return found
+}
+
+// leadingFlagValue returns the value of flag, given as '--flag=value' or '--flag value', if it is
+// among the flags preceding the command name
+func leadingFlagValue(args []string, flag string) (string, bool) {
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			break
+		}
+		name, value, hasValue := strings.Cut(args[i], "=")
+		takesValue := !hasValue && leadingValueFlags[name] && i+1 < len(args)
+		if takesValue {
+			value = args[i+1]
+			i++
+		}
+		if name == flag {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// enableOfflineMode makes the tool manager, and mvx child processes, stay off the network
+func enableOfflineMode() {
+	offline = true
+	os.Setenv(tools.EnvOffline, "true")
+}
+
+// disableAutoInstall makes commands, including those of mvx child processes, fail on
+// missing tools instead of installing them
+func disableAutoInstall() {
+	noAutoInstall = true
+	os.Setenv(tools.EnvNoAutoInstall, "true")
+}
+
+// leadingCommand returns the command name following the global flags, or "" if there is none
+func leadingCommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			return args[i]
+		}
+		if !strings.Contains(args[i], "=") && leadingValueFlags[args[i]] {
+			i++
+		}
+	}
+	return ""
+}
+
+// setNetworkTimeout bounds network requests and downloads, including those of mvx child processes
+func setNetworkTimeout(d time.Duration) {
+	os.Setenv(tools.EnvTimeout, d.String())
+	// The tool manager may already exist, e.g. after auto-setup
+	if manager, err := tools.NewManager(); err == nil {
+		manager.SetTimeout(d)
+	}
+}
+
 // SetVersionInfo sets the version information from main
 func SetVersionInfo(v, c, d string) {
 	version = v
@@ -88,6 +262,12 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "quiet output (errors only)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "never access the network, only use installed tools (same as MVX_OFFLINE=true)")
+	rootCmd.PersistentFlags().BoolVar(&noAutoInstall, "no-auto-install", false, "fail instead of installing missing tools when running commands (same as MVX_NO_AUTO_INSTALL=true)")
+	rootCmd.PersistentFlags().BoolVar(&prefix, "prefix", false, "prefix all command output lines with the name of the step producing them (same as MVX_OUTPUT_PREFIX=true)")
+	rootCmd.PersistentFlags().BoolVar(&noPrefix, "no-prefix", false, "never prefix the output of hooks and pipeline steps (same as MVX_OUTPUT_PREFIX=false)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "timeout of network requests and downloads, e.g. 30s or 10m (same as MVX_TIMEOUT)")
+	rootCmd.PersistentFlags().BoolVar(&strictEnv, "strict-env", false, "fail on undefined environment variables in tool versions and distributions (same as MVX_STRICT_ENV=true)")
 
 	// Add subcommands
 	rootCmd.AddCommand(versionCmd)
@@ -100,23 +280,40 @@ func init() {
 	rootCmd.AddCommand(envCmd)
 }
 
-// Helper functions for output
+// Helper functions for output, which log JSON entries in JSON log format (MVX_LOG_FORMAT=json)
 func printVerbose(format string, args ...interface{}) {
 	if verbose && !quiet {
+		if util.IsJSONLog() {
+			util.Log(util.LogEntry{Level: util.LevelDebug, Msg: fmt.Sprintf(format, args...)})
+			return
+		}
 		fmt.Fprintf(os.Stderr, "[VERBOSE] "+format+"\n", args...)
 	}
 }
 
 func printInfo(format string, args ...interface{}) {
 	if !quiet {
+		if util.IsJSONLog() {
+			logMessage(util.LevelInfo, format, args...)
+			return
+		}
 		fmt.Printf(format+"\n", args...)
 	}
 }
 
 func printError(format string, args ...interface{}) {
+	if util.IsJSONLog() {
+		logMessage(util.LevelError, format, args...)
+		return
+	}
 	fmt.Fprintf(os.Stderr, "Error: "+format+"\n", args...)
 }
 
+// logMessage logs a message as JSON entries, skipping the blank lines used for layout
+func logMessage(level, format string, args ...interface{}) {
+	util.LogMessage(level, "", fmt.Sprintf(format, args...))
+}
+
 // autoSetupEnvironment automatically installs tools and sets up environment
 func autoSetupEnvironment() error {
 	// Skip auto-setup if already done in this process
@@ -125,6 +322,31 @@ func autoSetupEnvironment() error {
 		return nil
 	}
 
+	// Skip auto-setup for shell completion requests, which must stay fast
+	if len(os.Args) > 1 && (os.Args[1] == cobra.ShellCompRequestCmd || os.Args[1] == cobra.ShellCompNoDescRequestCmd) {
+		return nil
+	}
+
+	// Don't install the tools that uninstalling mvx is about to delete
+	if leadingCommand(os.Args[1:]) == selfUninstallCmd.Name() {
+		return nil
+	}
+
+	// The doctor diagnoses failing setups, it must run without one
+	if leadingCommand(os.Args[1:]) == doctorCmd.Name() {
+		return nil
+	}
+
+	// Shell hooks are printed, and hook-env runs at each prompt, without installing tools
+	if command := leadingCommand(os.Args[1:]); command == shellInitCmd.Name() || command == hookEnvCmd.Name() {
+		return nil
+	}
+
+	// 'mvx setup --check' reports the missing tools, it must not install them
+	if args := os.Args[1:]; leadingCommand(args) == setupCmd.Name() && (slices.Contains(args, "--check") || slices.Contains(args, "--dry-run")) {
+		return nil
+	}
+
 	// Skip auto-setup if explicitly disabled
 	if os.Getenv("MVX_NO_AUTO_SETUP") == "true" {
 		printVerbose("Auto-setup disabled by MVX_NO_AUTO_SETUP")
@@ -160,8 +382,10 @@ func autoSetupEnvironment() error {
 		return fmt.Errorf("failed to create tool manager: %w", err)
 	}
 
-	// Check if tools need installation (excluding system tools)
-	toolsToInstall, err := manager.GetToolsNeedingInstallation(cfg)
+	// Check if tools need installation (excluding system tools), among those required for
+	// the command about to run
+	requiredCfg := manager.ToolsRequiredFor(cfg, leadingCommand(os.Args[1:]))
+	toolsToInstall, err := manager.GetToolsNeedingInstallation(requiredCfg)
 	if err != nil {
 		return fmt.Errorf("failed to check tool installation status: %w", err)
 	}
@@ -177,8 +401,10 @@ func autoSetupEnvironment() error {
 		filteredToolsToInstall[toolName] = toolConfig
 	}
 
-	// Install missing tools if any
-	if len(filteredToolsToInstall) > 0 {
+	// Install missing tools if any, unless commands must fail on them
+	if len(filteredToolsToInstall) > 0 && tools.IsAutoInstallDisabled() {
+		printVerbose("Not auto-installing %d missing tool(s): %s=true", len(filteredToolsToInstall), tools.EnvNoAutoInstall)
+	} else if len(filteredToolsToInstall) > 0 {
 		printInfo("🔧 Auto-installing %d missing tool(s)...", len(filteredToolsToInstall))
 
 		// Create a temporary config with only tools that need installation
@@ -194,8 +420,9 @@ func autoSetupEnvironment() error {
 		printVerbose("All tools already installed or using system versions")
 	}
 
-	// Set up environment variables globally
-	if err := setupGlobalEnvironment(cfg, manager); err != nil {
+	// Set up environment variables globally, for the required tools only: computing the
+	// environment of the other tools would install them on demand
+	if err := setupGlobalEnvironment(requiredCfg, manager); err != nil {
 		return fmt.Errorf("failed to setup global environment: %w", err)
 	}
 
@@ -233,12 +460,20 @@ func setupGlobalEnvironment(cfg *config.Config, manager *tools.Manager) error {
 
 func printWarning(format string, args ...interface{}) {
 	if !quiet {
+		if util.IsJSONLog() {
+			logMessage(util.LevelWarn, format, args...)
+			return
+		}
 		fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
 	}
 }
 
 func printSuccess(format string, args ...interface{}) {
 	if !quiet {
+		if util.IsJSONLog() {
+			logMessage(util.LevelInfo, format, args...)
+			return
+		}
 		fmt.Printf(format+"\n", args...)
 	}
 }
@@ -268,6 +503,25 @@ func findProjectRoot() (string, error) {
 	return os.Getwd()
 }
 
+// findDefaultCommand returns the default command of the current project, if any.
+// It only applies inside an mvx project, so that a bare "mvx" elsewhere still shows help.
+func findDefaultCommand() string {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(filepath.Join(projectRoot, ".mvx")); err != nil || !info.IsDir() {
+		return ""
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		printVerbose("Failed to load configuration for default command: %v", err)
+		return ""
+	}
+	return cfg.GetDefaultCommand()
+}
+
 // addCustomCommands dynamically adds custom commands from configuration as top-level commands
 func addCustomCommands() error {
 	// Try to find project root and load configuration
@@ -308,11 +562,31 @@ func addCustomCommands() error {
 
 // createCustomCommand creates a cobra command for a custom command
 func createCustomCommand(cmdName string, cmdConfig config.CommandConfig, exec *executor.Executor) *cobra.Command {
+	long := fmt.Sprintf("%s\n\nThis is a custom command defined in your .mvx/config file.", cmdConfig.Description)
+	if len(cmdConfig.Args) > 0 {
+		long += "\n\nArguments, given in order or as --name=value, and set as $name and $MVX_ARG_NAME in the script:\n" +
+			strings.TrimRight(executor.ArgsHelp(cmdConfig), "\n")
+	}
+
 	cmd := &cobra.Command{
-		Use:   cmdName + " [args...]",
+		Use:   cmdName + " " + executor.ArgsUsage(cmdConfig),
 		Short: cmdConfig.Description,
-		Long:  fmt.Sprintf("%s\n\nThis is a custom command defined in your .mvx/config file.", cmdConfig.Description),
+		Long:  long,
+		// Commands restricted to other platforms still exist, to report why they cannot run
+		Hidden: !cmdConfig.SupportsCurrentPlatform(),
+		// The arguments of commands declaring some are bound by the executor, e.g. --env=prod
+		DisableFlagParsing: len(cmdConfig.Args) > 0,
 		Run: func(cmd *cobra.Command, args []string) {
+			if cmd.DisableFlagParsing {
+				var help bool
+				if args, help = customCommandFlags(cmdConfig, args); help {
+					cmd.Help()
+					return
+				}
+			}
+			if assumeYes, _ := cmd.Flags().GetBool("yes"); assumeYes {
+				os.Setenv(executor.EnvAssumeYes, "true")
+			}
 			if err := exec.ExecuteCommand(cmdName, args); err != nil {
 				printError("%v", err)
 				os.Exit(1)
@@ -320,16 +594,34 @@ func createCustomCommand(cmdName string, cmdConfig config.CommandConfig, exec *e
 		},
 	}
 
-	// Add arguments if defined
-	for _, arg := range cmdConfig.Args {
-		if arg.Required {
-			cmd.MarkFlagRequired(arg.Name)
-		}
+	// Commands asking for confirmation accept --yes to run without asking
+	if cmdConfig.Confirm != "" {
+		cmd.Flags().BoolP("yes", "y", false, "run without asking for confirmation (same as MVX_YES=true)")
 	}
 
 	return cmd
 }
 
+// customCommandFlags handles the flags of a custom command whose arguments are not parsed by
+// cobra: it reports whether --help is given, and applies --yes, before any "--". The other
+// arguments are returned.
+func customCommandFlags(cmdConfig config.CommandConfig, args []string) ([]string, bool) {
+	var remaining []string
+	for i, arg := range args {
+		switch {
+		case arg == "--":
+			return append(remaining, args[i:]...), false
+		case arg == "--help" || arg == "-h":
+			return nil, true
+		case (arg == "--yes" || arg == "-y") && cmdConfig.Confirm != "":
+			os.Setenv(executor.EnvAssumeYes, "true")
+		default:
+			remaining = append(remaining, arg)
+		}
+	}
+	return remaining, false
+}
+
 // addToolCommands dynamically adds tool commands (mvn, go, node, etc.) as top-level commands
 func addToolCommands() error {
 	// Try to find project root and load configuration
@@ -0,0 +1,62 @@
+package cmd
+
+import "testing"
+
+func TestHasLeadingFlag(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected bool
+	}{
+		{[]string{"--offline", "setup"}, true},
+		{[]string{"-v", "--offline", "build"}, true},
+		{[]string{"setup"}, false},
+		{[]string{"mvn", "--offline", "verify"}, false},
+		{[]string{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := hasLeadingFlag(tt.args, "--offline"); got != tt.expected {
+			t.Errorf("hasLeadingFlag(%v) = %v, expected %v", tt.args, got, tt.expected)
+		}
+	}
+}
+
+func TestLeadingFlagValue(t *testing.T) {
+	tests := []struct {
+		args          []string
+		flag          string
+		expectedValue string
+		expectedFound bool
+	}{
+		{[]string{"--timeout", "5m", "setup"}, "--timeout", "5m", true},
+		{[]string{"-v", "--timeout=30s", "build"}, "--timeout", "30s", true},
+		{[]string{"--timeout", "5m", "--offline", "setup"}, "--offline", "", true},
+		{[]string{"setup", "--timeout", "5m"}, "--timeout", "", false},
+		{[]string{"mvn", "--timeout=5m"}, "--timeout", "", false},
+	}
+
+	for _, tt := range tests {
+		value, found := leadingFlagValue(tt.args, tt.flag)
+		if value != tt.expectedValue || found != tt.expectedFound {
+			t.Errorf("leadingFlagValue(%v, %s) = %q, %v, expected %q, %v", tt.args, tt.flag, value, found, tt.expectedValue, tt.expectedFound)
+		}
+	}
+}
+
+func TestLeadingCommand(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"self-uninstall", "--yes"}, "self-uninstall"},
+		{[]string{"--timeout", "5m", "-v", "setup"}, "setup"},
+		{[]string{"--timeout=5m", "build"}, "build"},
+		{[]string{"--verbose"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := leadingCommand(tt.args); got != tt.expected {
+			t.Errorf("leadingCommand(%v) = %q, expected %q", tt.args, got, tt.expected)
+		}
+	}
+}
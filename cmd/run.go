@@ -21,10 +21,15 @@ var runCmd = &cobra.Command{
 This command executes scripts defined in the .mvx/config file with the proper
 environment setup including tool paths and environment variables.
 
+The arguments following the command name are passed to its script: as its
+positional parameters ($1..$N, $@, $* and $#) when the script references them,
+and otherwise appended to the script.
+
 Examples:
   mvx run build              # Run the build command
   mvx run test               # Run the test command  
   mvx run demo gogo          # Run demo command with arguments
+  mvx run deploy --env=prod  # Arguments after the command name go to the script
   mvx run                    # List all available commands`,
 
 	Run: func(cmd *cobra.Command, args []string) {
@@ -48,6 +53,8 @@ Examples:
 }
 
 func init() {
+	// Everything after the command name belongs to the command, e.g. 'mvx run deploy --env=prod'
+	runCmd.Flags().SetInterspersed(false)
 	rootCmd.AddCommand(runCmd)
 }
 
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+	"github.com/gnodet/mvx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// selfUninstallCmd removes everything mvx stored in the user's home directory
+var selfUninstallCmd = &cobra.Command{
+	Use:   "self-uninstall",
+	Short: "Remove mvx's installed tools and caches",
+	Long: `Remove what mvx stored in ~/.mvx (or MVX_HOME): installed tools, download and
+version caches, the global configuration, and the mvx binaries downloaded by the ./mvx
+wrapper scripts. Other files of the directory are left alone.
+
+Project configurations and wrapper scripts live in their repositories and are not touched.
+
+Examples:
+  mvx self-uninstall                      # Ask before removing ~/.mvx
+  mvx self-uninstall --keep-config --yes  # Keep the global configuration, don't ask
+  mvx self-uninstall --remove-binary      # Also delete the running mvx binary`,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := selfUninstall(selfUninstallKeepConfig, selfUninstallRemoveBinary, selfUninstallYes, os.Stdin); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	selfUninstallKeepConfig   bool
+	selfUninstallRemoveBinary bool
+	selfUninstallYes          bool
+)
+
+func init() {
+	selfUninstallCmd.Flags().BoolVar(&selfUninstallKeepConfig, "keep-config", false, "keep the global configuration (~/.mvx/config.json5)")
+	selfUninstallCmd.Flags().BoolVar(&selfUninstallRemoveBinary, "remove-binary", false, "also delete the running mvx binary")
+	selfUninstallCmd.Flags().BoolVarP(&selfUninstallYes, "yes", "y", false, "don't ask for confirmation")
+
+	rootCmd.AddCommand(selfUninstallCmd)
+}
+
+// selfUninstall removes what mvx stored in its home directory, keeping the global configuration
+// with keepConfig
+func selfUninstall(keepConfig, removeBinary, assumeYes bool, in io.Reader) error {
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	mvxDir := manager.GetCacheDir()
+
+	var kept string
+	if keepConfig {
+		if configPath, err := config.GetGlobalConfigPath(); err == nil {
+			kept = filepath.Base(configPath)
+		}
+	}
+
+	var paths []string
+	var size int64
+	for _, name := range mvxHomeEntries() {
+		path := filepath.Join(mvxDir, name)
+		if _, err := os.Lstat(path); name == kept || err != nil {
+			continue
+		}
+		entrySize, err := util.DirSize(path)
+		if err != nil {
+			printVerbose("Failed to compute disk usage of %s: %v", path, err)
+		}
+		paths = append(paths, path)
+		size += entrySize
+	}
+
+	binary := ""
+	if removeBinary {
+		if binary, err = runningBinary(); err != nil {
+			return err
+		}
+	}
+
+	if len(paths) == 0 && binary == "" {
+		printInfo("✅ Nothing to remove in %s", mvxDir)
+		return nil
+	}
+
+	if len(paths) > 0 {
+		printInfo("🗑️  Removing %s (%s)", mvxDir, formatDiskSize(size))
+		if kept != "" {
+			printInfo("   Keeping %s", filepath.Join(mvxDir, kept))
+		}
+	}
+	if binary != "" {
+		printInfo("🗑️  Removing the mvx binary %s", binary)
+	}
+	printInfo("")
+
+	if !assumeYes {
+		confirmed, err := askYesNo("Uninstall mvx?", in)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			printInfo("Nothing removed")
+			return nil
+		}
+	}
+
+	for _, path := range paths {
+		if err := os.RemoveAll(path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	// Only succeeds if nothing else is left, e.g. unrelated files of a shared MVX_HOME
+	os.Remove(mvxDir)
+	if len(paths) > 0 {
+		printSuccess("✅ Removed %s, reclaimed %s", mvxDir, formatDiskSize(size))
+	}
+
+	if binary != "" {
+		if err := removeRunningBinary(binary); err != nil {
+			return err
+		}
+		printSuccess("✅ Removed %s", binary)
+	}
+	return nil
+}
+
+// mvxHomeEntries returns the names of the files and directories mvx creates in its home
+// directory, the only ones removed by self-uninstall, as MVX_HOME may be shared with other files
+func mvxHomeEntries() []string {
+	entries := tools.HomeEntries()
+	if configPath, err := config.GetGlobalConfigPath(); err == nil {
+		entries = append(entries, filepath.Base(configPath))
+	}
+	// The ./mvx wrapper scripts always cache mvx binaries in ~/.mvx, whatever MVX_HOME is
+	if os.Getenv(config.EnvMvxHome) == "" {
+		entries = append(entries, "versions", "dev")
+	}
+	return entries
+}
+
+// runningBinary returns the path of the running mvx binary, symbolic links resolved
+func runningBinary() (string, error) {
+	binary, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the mvx binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(binary); err == nil {
+		binary = resolved
+	}
+	return binary, nil
+}
+
+// removeRunningBinary deletes the running mvx binary, unless it was already removed with ~/.mvx
+func removeRunningBinary(binary string) error {
+	if _, err := os.Stat(binary); os.IsNotExist(err) {
+		return nil
+	}
+	// Windows doesn't allow deleting a running executable
+	if runtime.GOOS == "windows" {
+		return fmt.Errorf("cannot delete the running binary on Windows, delete %s manually", binary)
+	}
+	if err := os.Remove(binary); err != nil {
+		hint := ""
+		if os.IsPermission(err) {
+			hint = " (try again with sudo)"
+		}
+		return fmt.Errorf("failed to remove %s: %w%s", binary, err, hint)
+	}
+	return nil
+}
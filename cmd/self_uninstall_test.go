@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+func TestSelfUninstall(t *testing.T) {
+	tests := []struct {
+		name       string
+		keepConfig bool
+		assumeYes  bool
+		answer     string
+		wantKept   []string
+	}{
+		{name: "confirmed", answer: "y\n", wantKept: nil},
+		{name: "declined", answer: "n\n", wantKept: []string{"config.json5", "tools", "version_cache.json"}},
+		{name: "keep config without asking", keepConfig: true, assumeYes: true, wantKept: []string{"config.json5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			homeDir := t.TempDir()
+			t.Setenv("HOME", homeDir)
+			tools.ResetManager()
+			defer tools.ResetManager()
+
+			mvxDir := filepath.Join(homeDir, ".mvx")
+			for _, name := range []string{"config.json5", "version_cache.json", "tools/maven/3.9.6/bin/mvn"} {
+				path := filepath.Join(mvxDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := selfUninstall(tt.keepConfig, false, tt.assumeYes, strings.NewReader(tt.answer)); err != nil {
+				t.Fatalf("selfUninstall() error = %v", err)
+			}
+
+			entries, err := os.ReadDir(mvxDir)
+			if err != nil && !os.IsNotExist(err) {
+				t.Fatal(err)
+			}
+			var kept []string
+			for _, entry := range entries {
+				kept = append(kept, entry.Name())
+			}
+			if strings.Join(kept, ",") != strings.Join(tt.wantKept, ",") {
+				t.Errorf("%s contains %v after self-uninstall, want %v", mvxDir, kept, tt.wantKept)
+			}
+			if tt.wantKept == nil && err == nil {
+				t.Errorf("%s still exists after self-uninstall", mvxDir)
+			}
+		})
+	}
+}
+
+func TestSelfUninstallSharedHome(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mvxHome := t.TempDir()
+	t.Setenv(config.EnvMvxHome, mvxHome)
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	for _, name := range []string{"tools/maven/3.9.6/bin/mvn", "http_cache/abc.json", "env_cache.json", "dev/project/README.md", "notes.txt"} {
+		path := filepath.Join(mvxHome, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := selfUninstall(false, false, true, strings.NewReader("")); err != nil {
+		t.Fatalf("selfUninstall() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(mvxHome)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kept []string
+	for _, entry := range entries {
+		kept = append(kept, entry.Name())
+	}
+	if strings.Join(kept, ",") != "dev,notes.txt" {
+		t.Errorf("MVX_HOME contains %v after self-uninstall, want only the files mvx didn't create", kept)
+	}
+}
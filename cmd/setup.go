@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/gnodet/mvx/pkg/config"
 	"github.com/gnodet/mvx/pkg/tools"
@@ -12,10 +14,12 @@ import (
 
 // setupCmd represents the setup command
 var setupCmd = &cobra.Command{
-	Use:   "setup",
+	Use:   "setup [tool...]",
 	Short: "Setup the build environment",
 	Long: `Setup the build environment by installing all required tools and
 configuring the environment as specified in the mvx configuration.
+Tools with a required_for list are skipped, unless --all is given: they are
+installed when one of the commands they are required for runs.
 
 This command will:
   - Read the project configuration (.mvx/config.json5 or .mvx/config.yml)
@@ -31,9 +35,16 @@ Examples:
   mvx setup --tools-only      # Only install tools, skip environment setup
   mvx setup --parallel 5      # Use 5 concurrent downloads
   mvx setup --sequential      # Install tools one by one
+  mvx setup --keep-going      # Install all tools it can, report failures at the end
+  mvx setup --reinstall       # Remove and reinstall all configured tools
+  mvx setup --reinstall java  # Remove and reinstall only Java
+  mvx setup --update          # Resolve versions anew, ignoring and updating .mvx/mvx.lock
+  mvx setup --all             # Also install the tools only required for some commands
+  mvx setup --check           # Report missing tools without installing them (exit code 1 if any)
 
 Environment Variables:
-  MVX_PARALLEL_DOWNLOADS      # Default number of parallel downloads (default: 3)`,
+  MVX_PARALLEL_DOWNLOADS      # Default number of parallel downloads (default: settings.parallelDownloads, or 3)
+  MVX_TOOL_CONCURRENCY_<TOOL> # Maximum concurrent installs of a single tool (e.g. MVX_TOOL_CONCURRENCY_JAVA=2)`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		// Set verbose environment variable for tools package
@@ -41,7 +52,16 @@ Environment Variables:
 			os.Setenv("MVX_VERBOSE", "true")
 		}
 
-		if err := setupEnvironment(); err != nil {
+		if len(args) > 0 && !reinstall {
+			printError("tool names can only be given together with --reinstall")
+			os.Exit(1)
+		}
+		if setupCheck && reinstall {
+			printError("--check cannot be combined with --reinstall")
+			os.Exit(1)
+		}
+
+		if err := setupEnvironment(args); err != nil {
 			printError("%v", err)
 			os.Exit(1)
 		}
@@ -52,15 +72,26 @@ var (
 	toolsOnly         bool
 	parallelDownloads int
 	sequentialInstall bool
+	keepGoing         bool
+	reinstall         bool
+	updateLockfile    bool
+	setupAll          bool
+	setupCheck        bool
 )
 
 func init() {
 	setupCmd.Flags().BoolVar(&toolsOnly, "tools-only", false, "only install tools, skip environment setup")
 	setupCmd.Flags().IntVar(&parallelDownloads, "parallel", 0, "number of parallel downloads (0 = auto, 1 = sequential)")
 	setupCmd.Flags().BoolVar(&sequentialInstall, "sequential", false, "install tools sequentially instead of in parallel")
+	setupCmd.Flags().BoolVar(&keepGoing, "keep-going", false, "keep installing remaining tools when one fails, and report all failures at the end")
+	setupCmd.Flags().BoolVar(&reinstall, "reinstall", false, "remove and reinstall the given tools (or all configured tools)")
+	setupCmd.Flags().BoolVar(&updateLockfile, "update", false, "resolve versions ignoring .mvx/mvx.lock, and update it with the new resolutions")
+	setupCmd.Flags().BoolVar(&setupAll, "all", false, "also install the tools whose required_for list doesn't include setup")
+	setupCmd.Flags().BoolVar(&setupCheck, "check", false, "only report which tools are installed and which would be installed, exiting with 1 if any is missing")
+	setupCmd.Flags().BoolVar(&setupCheck, "dry-run", false, "alias for --check")
 }
 
-func setupEnvironment() error {
+func setupEnvironment(reinstallTools []string) error {
 	projectRoot, err := findProjectRoot()
 	if err != nil {
 		return fmt.Errorf("failed to find project root: %w", err)
@@ -88,6 +119,41 @@ func setupEnvironment() error {
 	if err != nil {
 		return fmt.Errorf("failed to create tool manager: %w", err)
 	}
+	if setupCheck {
+		// Checking for installed tools must not install the missing ones on demand
+		disableAutoInstall()
+	} else {
+		manager.EnableExplicitInstall()
+	}
+	if updateLockfile {
+		manager.IgnoreLockfile()
+	}
+
+	// Warn about known-incompatible tool combinations
+	for _, warning := range manager.CheckToolCompatibility(cfg) {
+		printWarning("%s", warning)
+	}
+
+	// Tools only required for some commands are installed when these commands run
+	installCfg := cfg
+	if !setupAll {
+		installCfg = manager.ToolsRequiredFor(cfg, "setup")
+		for _, toolName := range skippedTools(cfg, installCfg) {
+			printInfo("⏭️  Skipping %s, only required for: %s (use --all to install it)", toolName, strings.Join(cfg.Tools[toolName].RequiredFor, ", "))
+		}
+	}
+
+	// Report what would be installed, without downloading anything
+	if setupCheck {
+		return checkTools(manager, installCfg)
+	}
+
+	// Force a clean reinstall of the requested tools before the regular install
+	if reinstall {
+		if err := reinstallConfiguredTools(manager, installCfg, reinstallTools); err != nil {
+			return err
+		}
+	}
 
 	// Install tools with options
 	printInfo("📦 Installing tools...")
@@ -95,7 +161,7 @@ func setupEnvironment() error {
 	// Configure concurrency
 	maxConcurrent := parallelDownloads
 	if maxConcurrent == 0 {
-		maxConcurrent = tools.GetDefaultConcurrency()
+		maxConcurrent = tools.GetDefaultConcurrency(cfg)
 	}
 
 	// Use sequential if requested
@@ -103,13 +169,31 @@ func setupEnvironment() error {
 		maxConcurrent = 1
 	}
 
-	if err := manager.EnsureTools(cfg, maxConcurrent); err != nil {
+	if keepGoing {
+		results, err := manager.EnsureToolsKeepGoing(installCfg, maxConcurrent)
+		if err != nil {
+			return fmt.Errorf("failed to install tools: %w", err)
+		}
+		if err := reportInstallResults(results); err != nil {
+			return err
+		}
+	} else if err := manager.EnsureTools(installCfg, maxConcurrent); err != nil {
 		return fmt.Errorf("failed to install tools: %w", err)
 	}
 
+	// Pin the new resolutions, if the project locks its versions
+	if updateLockfile {
+		if _, err := os.Stat(tools.GetLockfilePath(projectRoot)); err == nil {
+			if _, err := manager.WriteLockfile(cfg, projectRoot); err != nil {
+				return fmt.Errorf("failed to update lockfile: %w", err)
+			}
+			printInfo("  🔒 Updated %s", tools.GetLockfilePath(projectRoot))
+		}
+	}
+
 	if !toolsOnly {
 		printInfo("🔧 Setting up environment...")
-		env, err := manager.SetupEnvironment(cfg)
+		env, err := manager.SetupEnvironment(installCfg)
 		if err != nil {
 			return fmt.Errorf("failed to setup environment: %w", err)
 		}
@@ -123,6 +207,9 @@ func setupEnvironment() error {
 		}
 
 		printInfo("  ✅ Environment variables configured")
+
+		// Tools earlier on PATH win outside of mvx, a common cause of "wrong version" confusion
+		reportPathShadowing(manager, installCfg)
 	}
 
 	printInfo("")
@@ -134,3 +221,71 @@ func setupEnvironment() error {
 
 	return nil
 }
+
+// skippedTools returns the sorted names of the tools of cfg that are not in installCfg
+func skippedTools(cfg, installCfg *config.Config) []string {
+	var skipped []string
+	for toolName := range cfg.Tools {
+		if _, ok := installCfg.Tools[toolName]; !ok {
+			skipped = append(skipped, toolName)
+		}
+	}
+	sort.Strings(skipped)
+	return skipped
+}
+
+// reportInstallResults prints a summary of tool installation results and
+// returns an error if any tool failed to install
+func reportInstallResults(results []tools.ToolInstallResult) error {
+	var failed []tools.ToolInstallResult
+	for _, result := range results {
+		if result.Error != nil {
+			failed = append(failed, result)
+		}
+	}
+
+	printInfo("")
+	printInfo("📋 Installation summary: %d succeeded, %d failed", len(results)-len(failed), len(failed))
+	for _, result := range results {
+		if result.Error != nil {
+			printInfo("  ❌ %s: %v", result.ToolName, result.Error)
+		} else {
+			printInfo("  ✅ %s", result.ToolName)
+		}
+	}
+
+	if len(failed) > 0 {
+		names := make([]string, 0, len(failed))
+		for _, result := range failed {
+			names = append(names, result.ToolName)
+		}
+		return fmt.Errorf("failed to install %d tool(s): %s", len(failed), strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+// reinstallConfiguredTools removes and reinstalls the given tools, or all configured tools if none are given
+func reinstallConfiguredTools(manager *tools.Manager, cfg *config.Config, toolNames []string) error {
+	if len(toolNames) == 0 {
+		for toolName := range cfg.Tools {
+			toolNames = append(toolNames, toolName)
+		}
+		sort.Strings(toolNames)
+	}
+
+	for _, toolName := range toolNames {
+		toolConfig, exists := cfg.Tools[toolName]
+		if !exists {
+			return fmt.Errorf("tool %s is not configured in this project", toolName)
+		}
+
+		printInfo("♻️  Reinstalling %s %s...", toolName, toolConfig.Version)
+		if _, err := manager.ReinstallTool(toolName, toolConfig); err != nil {
+			return fmt.Errorf("failed to reinstall %s: %w", toolName, err)
+		}
+		printInfo("  ✅ %s reinstalled", toolName)
+	}
+
+	return nil
+}
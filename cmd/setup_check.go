@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// checkTools reports which tools of cfg are installed and which 'mvx setup' would install,
+// without downloading anything, and returns an error if any tool is missing
+func checkTools(manager *tools.Manager, cfg *config.Config) error {
+	missing, err := manager.GetToolsNeedingInstallation(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to check tools: %w", err)
+	}
+	resolvedVersions, err := manager.ResolveVersions(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to check tools: %w", err)
+	}
+
+	toolNames := make([]string, 0, len(cfg.Tools))
+	for toolName := range cfg.Tools {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	printInfo("🔎 Checking tools...")
+	for _, toolName := range toolNames {
+		toolConfig, isMissing := missing[toolName]
+		if !isMissing {
+			printInfo("  ✅ %s %s (installed)", toolName, resolvedVersions[toolName])
+			continue
+		}
+		if size := formatPackageSize(estimateDownloadSize(manager, toolName, toolConfig)); size != "" {
+			printInfo("  📥 %s %s (not installed, ~%s)", toolName, toolConfig.Version, size)
+		} else {
+			printInfo("  📥 %s %s (not installed)", toolName, toolConfig.Version)
+		}
+	}
+	printInfo("")
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%d tool(s) not installed, run 'mvx setup' to install them", len(missing))
+	}
+	printSuccess("All tools are installed")
+	return nil
+}
+
+// estimateDownloadSize returns the size of the package mvx would download for a tool, or 0 if
+// unknown. Only tools publishing several packages per version report their sizes.
+func estimateDownloadSize(manager *tools.Manager, toolName string, toolConfig config.ToolConfig) int64 {
+	if tools.IsOffline() || tools.UseSystemTool(toolName) {
+		return 0
+	}
+	packages, err := manager.ListToolPackages(toolName, toolConfig)
+	if err != nil || len(packages) == 0 {
+		return 0
+	}
+	if packageID := toolConfig.Options[tools.OptionPackageID]; packageID != "" {
+		for _, pkg := range packages {
+			if pkg.ID == packageID {
+				return pkg.Size
+			}
+		}
+	}
+	for _, pkg := range packages {
+		if pkg.Type == "jdk" {
+			return pkg.Size
+		}
+	}
+	return packages[0].Size
+}
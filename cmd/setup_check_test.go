@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+func TestCheckTools(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(tools.EnvNoAutoInstall, "true")
+	t.Setenv(tools.EnvOffline, "true")
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	mvn := filepath.Join(homeDir, ".mvx", "tools", "maven", "3.9.6", "bin", "mvn")
+	if err := os.MkdirAll(filepath.Dir(mvn), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mvn, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	installed := &config.Config{Tools: map[string]config.ToolConfig{
+		"maven": {Version: "3.9.6"},
+	}}
+	if err := checkTools(manager, installed); err != nil {
+		t.Errorf("checkTools() error = %v, want nil when every tool is installed", err)
+	}
+
+	missing := &config.Config{Tools: map[string]config.ToolConfig{
+		"maven": {Version: "3.9.6"},
+		"go":    {Version: "1.22.0"},
+	}}
+	if err := checkTools(manager, missing); err == nil || !strings.Contains(err.Error(), "1 tool(s) not installed") {
+		t.Errorf("checkTools() error = %v, want go reported as not installed", err)
+	}
+	if _, err := os.Stat(filepath.Join(homeDir, ".mvx", "tools", "go")); !os.IsNotExist(err) {
+		t.Errorf("checkTools() installed go, want nothing downloaded")
+	}
+}
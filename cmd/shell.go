@@ -2,7 +2,9 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/config"
@@ -25,7 +27,8 @@ Examples:
   mvx shell env                         # Show all environment variables
   mvx shell "mvn --version"             # Run Maven with mvx environment
   mvx shell "echo '$PATH' | grep mvx"   # Show mvx paths in PATH
-  mvx shell cd /tmp && pwd              # Change directory and show current path`,
+  mvx shell cd /tmp && pwd              # Change directory and show current path
+  mvx shell --print-env -- mvn -v       # Print the computed environment to stderr, then run`,
 
 	Run: func(cmd *cobra.Command, args []string) {
 		if err := runShellCommand(args); err != nil {
@@ -35,7 +38,10 @@ Examples:
 	},
 }
 
+var shellPrintEnv bool
+
 func init() {
+	shellCmd.Flags().BoolVar(&shellPrintEnv, "print-env", false, "print the computed environment to stderr before executing")
 	rootCmd.AddCommand(shellCmd)
 }
 
@@ -76,6 +82,10 @@ func runShellCommand(args []string) error {
 		command = strings.Join(args, " ")
 	}
 
+	if shellPrintEnv {
+		printEnvironment(os.Stderr, env)
+	}
+
 	printVerbose("Executing shell command: %s", command)
 	printVerbose("Working directory: %s", workDir)
 	printVerbose("Environment variables: %d", len(env))
@@ -102,6 +112,17 @@ func setupShellEnvironment(cfg *config.Config, manager *tools.Manager, workDir s
 	return env, nil
 }
 
+// printEnvironment writes the given environment, sorted by variable name, to w.
+// This makes it easy to see exactly what a child process will receive.
+func printEnvironment(w io.Writer, env []string) {
+	sorted := make([]string, len(env))
+	copy(sorted, env)
+	sort.Strings(sorted)
+	for _, envVar := range sorted {
+		fmt.Fprintln(w, envVar)
+	}
+}
+
 // mergeEnvironment merges tool environment variables into the shell environment
 func mergeEnvironment(baseEnv []string, toolEnv map[string]string) []string {
 	// Create a map of existing environment variables
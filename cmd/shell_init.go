@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/shell"
+	"github.com/gnodet/mvx/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+// hookEnvStateVar is the shell variable in which hook-env records the changes it made
+const hookEnvStateVar = "MVX_HOOK_STATE"
+
+var hookEnvShell string
+
+// shellInitCmd represents the shell-init command
+var shellInitCmd = &cobra.Command{
+	Use:   "shell-init <bash|zsh|fish>",
+	Short: "Print a shell hook putting the project tools on PATH when entering a project",
+	Long: `Print shell code that updates the environment at each prompt: entering a
+directory of an mvx project (or one of its subdirectories) sets the variables
+of its tools and environment, and leaving it restores the previous values.
+
+Unlike 'mvx activate', the variables are unset when leaving the project, and
+changes to the configuration or newly installed tools are picked up at the next
+prompt. Tools are not installed automatically: run 'mvx setup' in the project.
+
+Examples:
+  # Bash - add to ~/.bashrc
+  eval "$(mvx shell-init bash)"
+
+  # Zsh - add to ~/.zshrc
+  eval "$(mvx shell-init zsh)"
+
+  # Fish - add to ~/.config/fish/config.fish
+  mvx shell-init fish | source`,
+
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		mvxPath, err := getMvxBinaryPath()
+		if err != nil {
+			printError("Failed to determine mvx binary path: %v", err)
+			os.Exit(1)
+		}
+		hook, err := shell.GenerateInitHook(args[0], mvxPath)
+		if err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+		fmt.Print(hook)
+	},
+}
+
+// hookEnvCmd represents the internal hook-env command run by the shell-init hooks
+var hookEnvCmd = &cobra.Command{
+	Use:    "hook-env",
+	Short:  "Output the statements updating the shell environment for the current directory",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		// The hook runs at every prompt, missing tools must not be downloaded there
+		disableAutoInstall()
+		statements, err := hookEnv(hookEnvShell)
+		if err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+		fmt.Print(statements)
+	},
+}
+
+func init() {
+	hookEnvCmd.Flags().StringVar(&hookEnvShell, "shell", "bash", "shell type (bash, zsh, fish)")
+	rootCmd.AddCommand(shellInitCmd)
+	rootCmd.AddCommand(hookEnvCmd)
+}
+
+// hookEnvState records the changes hook-env made to the shell environment, to revert them
+type hookEnvState struct {
+	Root     string             `json:"root"`
+	Previous map[string]*string `json:"previous,omitempty"` // Values before mvx set them, nil if unset
+	Path     []string           `json:"path,omitempty"`     // Directories prepended to PATH
+}
+
+// hookEnv returns the statements turning the current environment into the environment of the
+// project of the current directory, if any, after reverting the changes made for the project
+// the shell was in. Variables already set to their value are left out.
+func hookEnv(shellType string) (string, error) {
+	if shellType != "bash" && shellType != "zsh" && shellType != "fish" {
+		return "", fmt.Errorf("unsupported shell: %s", shellType)
+	}
+
+	// The shell's current values, to only output what changes
+	current := make(map[string]*string)
+	lookup := func(key string) *string {
+		if value, ok := os.LookupEnv(key); ok {
+			return &value
+		}
+		return nil
+	}
+	final := make(map[string]*string)
+	track := func(key string, value *string) {
+		if _, tracked := current[key]; !tracked {
+			current[key] = lookup(key)
+		}
+		final[key] = value
+	}
+
+	// Revert the changes made for the previous project, in this process too so that the
+	// project's environment is computed from the original one
+	current[hookEnvStateVar] = lookup(hookEnvStateVar)
+	if previousState := decodeHookEnvState(os.Getenv(hookEnvStateVar)); previousState != nil {
+		for key, previous := range previousState.Previous {
+			track(key, previous)
+			setProcessEnv(key, previous)
+		}
+		basePath := removePathDirs(os.Getenv("PATH"), previousState.Path)
+		track("PATH", &basePath)
+		os.Setenv("PATH", basePath)
+	}
+	os.Unsetenv(hookEnvStateVar)
+
+	var state *hookEnvState
+	if projectRoot, ok := hookEnvProjectRoot(); ok {
+		changes, err := hookEnvChanges(projectRoot)
+		if err != nil {
+			printVerbose("Not activating %s: %v", projectRoot, err)
+		} else {
+			state = &hookEnvState{Root: projectRoot, Previous: make(map[string]*string)}
+			for key, value := range changes {
+				if key == "PATH" {
+					continue
+				}
+				state.Previous[key] = lookup(key)
+				track(key, &value)
+			}
+			if pathValue, exists := changes["PATH"]; exists {
+				basePath := os.Getenv("PATH")
+				state.Path = pathAdditions(pathValue, basePath)
+				newPath := strings.Join(append(append([]string{}, state.Path...), filepath.SplitList(basePath)...), string(os.PathListSeparator))
+				track("PATH", &newPath)
+			}
+		}
+	}
+
+	if state != nil {
+		encoded := encodeHookEnvState(state)
+		track(hookEnvStateVar, &encoded)
+	} else {
+		track(hookEnvStateVar, nil)
+	}
+
+	keys := make([]string, 0, len(final))
+	for key := range final {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		value, was := final[key], current[key]
+		if (value == nil && was == nil) || (value != nil && was != nil && *value == *was) {
+			continue
+		}
+		b.WriteString(hookEnvStatement(shellType, key, value))
+	}
+	return b.String(), nil
+}
+
+// hookEnvProjectRoot returns the root of the mvx project of the current directory, if any
+func hookEnvProjectRoot() (string, bool) {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return "", false
+	}
+	if info, err := os.Stat(filepath.Join(projectRoot, ".mvx")); err != nil || !info.IsDir() {
+		return "", false
+	}
+	return projectRoot, true
+}
+
+// hookEnvChanges returns the variables the environment of a project adds or changes
+func hookEnvChanges(projectRoot string) (map[string]string, error) {
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	manager, err := tools.NewManager()
+	if err != nil {
+		return nil, err
+	}
+	return manager.EnvironmentChanges(cfg)
+}
+
+// hookEnvStatement returns the statement setting, or unsetting if value is nil, a variable
+func hookEnvStatement(shellType, key string, value *string) string {
+	if shellType == "fish" {
+		if value == nil {
+			return fmt.Sprintf("set -e %s\n", key)
+		}
+		quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`)
+		if key == "PATH" {
+			var dirs []string
+			for _, dir := range filepath.SplitList(*value) {
+				dirs = append(dirs, `"`+quote.Replace(dir)+`"`)
+			}
+			return fmt.Sprintf("set -gx PATH %s\n", strings.Join(dirs, " "))
+		}
+		return fmt.Sprintf("set -gx %s \"%s\"\n", key, quote.Replace(*value))
+	}
+
+	if value == nil {
+		return fmt.Sprintf("unset %s\n", key)
+	}
+	quote := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`")
+	return fmt.Sprintf("export %s=\"%s\"\n", key, quote.Replace(*value))
+}
+
+// removePathDirs removes from path the first occurrence of each of dirs
+func removePathDirs(path string, dirs []string) string {
+	remaining := filepath.SplitList(path)
+	for _, dir := range dirs {
+		for i, candidate := range remaining {
+			if candidate == dir {
+				remaining = append(remaining[:i], remaining[i+1:]...)
+				break
+			}
+		}
+	}
+	return strings.Join(remaining, string(os.PathListSeparator))
+}
+
+// setProcessEnv sets, or unsets if value is nil, a variable of this process
+func setProcessEnv(key string, value *string) {
+	if value == nil {
+		os.Unsetenv(key)
+	} else {
+		os.Setenv(key, *value)
+	}
+}
+
+func encodeHookEnvState(state *hookEnvState) string {
+	data, _ := json.Marshal(state)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeHookEnvState decodes the state recorded in the shell, ignoring invalid ones
+func decodeHookEnvState(encoded string) *hookEnvState {
+	if encoded == "" {
+		return nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+	var state hookEnvState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	return &state
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+func TestHookEnv(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".mvx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	config := `{project: {name: "demo"}, environment: {GREETING: "hello", MVX_TEST_ONLY: "project"}}`
+	if err := os.WriteFile(filepath.Join(projectRoot, ".mvx", "config.json5"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GREETING", "system")
+	t.Setenv(hookEnvStateVar, "")
+	os.Unsetenv(hookEnvStateVar)
+	os.Unsetenv("MVX_TEST_ONLY")
+
+	// Entering the project sets its variables
+	t.Chdir(filepath.Join(projectRoot, ".mvx"))
+	entering, err := hookEnv("bash")
+	if err != nil {
+		t.Fatalf("hookEnv() error = %v", err)
+	}
+	for _, expected := range []string{"export GREETING=\"hello\"\n", "export MVX_TEST_ONLY=\"project\"\n", "export " + hookEnvStateVar + "="} {
+		if !strings.Contains(entering, expected) {
+			t.Errorf("hookEnv() entering the project = %q, want it to contain %q", entering, expected)
+		}
+	}
+	applyHookEnv(t, entering)
+
+	// Nothing changes at the next prompt
+	if output, err := hookEnv("bash"); err != nil || output != "" {
+		t.Errorf("hookEnv() in the same project = %q, %v, want no statements", output, err)
+	}
+	applyHookEnv(t, entering) // hookEnv reverts the process environment to compute the project's
+
+	// Leaving the project restores the previous values
+	t.Chdir(t.TempDir())
+	output, err := hookEnv("fish")
+	if err != nil {
+		t.Fatalf("hookEnv() error = %v", err)
+	}
+	expected := "set -gx GREETING \"system\"\nset -e MVX_HOOK_STATE\nset -e MVX_TEST_ONLY\n"
+	if output != expected {
+		t.Errorf("hookEnv() leaving the project =\n%s\nwant\n%s", output, expected)
+	}
+}
+
+// applyHookEnv applies the export statements of hookEnv, which here have no escaped characters
+func applyHookEnv(t *testing.T, output string) {
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		key, value, _ := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		t.Setenv(key, strings.Trim(value, `"`))
+	}
+}
+
+func TestRemovePathDirs(t *testing.T) {
+	sep := string(os.PathListSeparator)
+	path := strings.Join([]string{"/tools/java/bin", "/usr/bin", "/tools/java/bin", "/bin"}, sep)
+	expected := strings.Join([]string{"/usr/bin", "/tools/java/bin", "/bin"}, sep)
+	if got := removePathDirs(path, []string{"/tools/java/bin", "/missing"}); got != expected {
+		t.Errorf("removePathDirs() = %q, want %q", got, expected)
+	}
+}
+
+func TestHookEnvDoesNotInstall(t *testing.T) {
+	var mutex sync.Mutex
+	var downloads []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "go1.23.1") {
+			mutex.Lock()
+			downloads = append(downloads, r.URL.Path)
+			mutex.Unlock()
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv(config.EnvMvxHome, "")
+	t.Setenv(tools.EnvNoAutoInstall, "")
+	t.Setenv(tools.EnvMaxRetries, "0")
+	t.Setenv(hookEnvStateVar, "")
+	os.Unsetenv(hookEnvStateVar)
+	defer func() { noAutoInstall = false }()
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	// Send every download to the test server, which records them
+	globalConfig := `{url_replacements: {"regex:^https?://[^/]+/(.*)": "` + server.URL + `/$1"}}`
+	if err := os.MkdirAll(filepath.Join(homeDir, ".mvx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(homeDir, ".mvx", "config.json5"), []byte(globalConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	projectRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".mvx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	projectConfig := `{project: {name: "demo"}, tools: {go: {version: "1.23.1"}}}`
+	if err := os.WriteFile(filepath.Join(projectRoot, ".mvx", "config.json5"), []byte(projectConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(projectRoot)
+
+	stdout := os.Stdout
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devNull.Close()
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	rootCmd.SetArgs([]string{"hook-env", "--shell", "bash"})
+	defer rootCmd.SetArgs(nil)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("mvx hook-env error = %v", err)
+	}
+	os.Stdout = stdout
+
+	if len(downloads) > 0 {
+		t.Errorf("hook-env downloaded %v, want missing tools left uninstalled", downloads)
+	}
+	if _, err := os.Stat(filepath.Join(homeDir, ".mvx", "tools", "go")); !os.IsNotExist(err) {
+		t.Errorf("hook-env installed go, want missing tools left uninstalled")
+	}
+}
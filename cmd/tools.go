@@ -1,88 +1,389 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/config"
 	"github.com/gnodet/mvx/pkg/tools"
+	mvxversion "github.com/gnodet/mvx/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 // toolsCmd represents the tools command
 var toolsCmd = &cobra.Command{
-	Use:   "tools [subcommand]",
+	Use:   "tools",
 	Short: "Manage and discover tools",
 	Long: `Manage and discover available tools and versions.
 
-Subcommands:
-  list       List available tools and their versions
-  search     Search for specific tool versions
-  info       Show detailed information about a tool
-  add        Add a tool to the project configuration`,
+Without a subcommand, lists the available tools and their versions.
 
+Examples:
+  mvx tools add maven 3.9.6                             # Add Maven 3.9.6
+  mvx tools add maven 3.9.6 --repo-local .mvx/repository  # Share a Maven local repository
+  mvx tools reinstall java 21.0.1 zulu                  # Force a clean reinstall of Java
+  mvx tools search java --limit 5 --sort asc            # Show the 5 oldest Java versions
+  mvx tools add java 21 --os linux --arch arm64         # Also install Java for linux/arm64
+  mvx tools add java 21 --json                          # Print what was written as JSON
+  mvx tools add java 21 zulu --no-fallback              # Fail instead of using another distribution
+  mvx tools add go 1.23.1 --reformat                    # Also normalize the whole config file
+  mvx tools add java 17 --resolve lowest                # Use the oldest Java 17 release
+  mvx tools add rust 1.81.0 --env 'RUSTFLAGS=-C target-cpu=native'  # Set a variable with the tool
+  mvx tools search java 21 zulu --packages              # List the JDK/JRE builds of Java 21
+  mvx tools add java 21 --interactive                   # Choose which build to install
+  mvx tools add java 25-ea --allow-ea                   # Pin an early-access build on purpose
+  mvx tools remove node --purge                         # Drop Node and delete its installations
+  mvx tools prune --keep-latest 1 --yes                 # Delete unused versions but the newest
+  mvx tools list --installed                            # Show installed versions and disk usage
+  mvx tools outdated --exit-code=false                  # Report newer versions without failing
+  mvx tools lock                                        # Pin resolved versions for the whole team
+  mvx tools update java --dry-run                       # Show the Java version "21" would now resolve to
+  mvx tools which java --home                           # Print the JAVA_HOME mvx uses`,
+	Args: cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) == 0 {
-			// Default to list
-			if err := listTools(); err != nil {
-				printError("%v", err)
-				os.Exit(1)
-			}
-			return
+		// Default to list
+		if err := listTools(); err != nil {
+			printError("%v", err)
+			os.Exit(1)
 		}
+	},
+}
 
-		subcommand := args[0]
-		switch subcommand {
-		case "list":
-			if err := listTools(); err != nil {
-				printError("%v", err)
-				os.Exit(1)
-			}
-		case "search":
-			if len(args) < 2 {
-				printError("search requires a tool name")
-				os.Exit(1)
-			}
-			if err := searchTool(args[1], args[2:]); err != nil {
-				printError("%v", err)
-				os.Exit(1)
-			}
-		case "info":
-			if len(args) < 2 {
-				printError("info requires a tool name")
+// toolsListCmd lists the available or installed tools
+var toolsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available tools and their versions",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		list := listTools
+		if toolsInstalled {
+			list = listInstalledTools
+		}
+		if err := list(); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsSearchCmd searches the versions, or the packages of a version, of a tool
+var toolsSearchCmd = &cobra.Command{
+	Use:               "search <tool> [version] [distribution]",
+	Short:             "Search for specific tool versions",
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completeToolsSubcommand("search"),
+	Run: func(cmd *cobra.Command, args []string) {
+		search := searchTool
+		if toolsPackages {
+			search = searchToolPackages
+		}
+		if err := search(args[0], args[1:]); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsInfoCmd shows detailed information about a tool
+var toolsInfoCmd = &cobra.Command{
+	Use:               "info <tool>",
+	Short:             "Show detailed information about a tool",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeToolsSubcommand("info"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := showToolInfo(args[0]); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsAddCmd adds a tool to the project configuration
+var toolsAddCmd = &cobra.Command{
+	Use:               "add <tool> <version> [distribution]",
+	Short:             "Add a tool to the project configuration",
+	Args:              cobra.RangeArgs(2, 3),
+	ValidArgsFunction: completeToolsSubcommand("add"),
+	Run: func(cmd *cobra.Command, args []string) {
+		var distribution *string
+		if len(args) >= 3 {
+			distribution = &args[2]
+		}
+		toolVersion, dist, err := normalizeToolAddArgs(args[1], distribution)
+		if err != nil {
+			printError("%v", err)
+			printError("Usage: mvx tools add <tool> <version> [distribution]")
+			os.Exit(1)
+		}
+		options := make(map[string]string)
+		if toolsRepoLocal != "" {
+			if strings.ContainsAny(toolsRepoLocal, " \t\n") {
+				printError("Invalid --repo-local %q: Maven cannot use a local repository path containing whitespace from MAVEN_OPTS", toolsRepoLocal)
 				os.Exit(1)
 			}
-			if err := showToolInfo(args[1]); err != nil {
+			options[tools.OptionRepoLocal] = toolsRepoLocal
+		}
+		if toolsResolve != "" {
+			preference, err := mvxversion.ParsePreference(toolsResolve)
+			if err != nil {
 				printError("%v", err)
 				os.Exit(1)
 			}
-		case "add":
-			if len(args) < 3 {
-				printError("add requires a tool name and version")
-				printError("Usage: mvx tools add <tool> <version> [distribution]")
-				os.Exit(1)
-			}
-			distribution := ""
-			if len(args) >= 4 {
-				distribution = args[3]
-			}
-			if err := addTool(args[1], args[2], distribution); err != nil {
+			options[tools.OptionResolve] = string(preference)
+		}
+		fallback, err := distributionFallbackOption(toolsDistributionFallback, toolsNoFallback)
+		if err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+		if fallback != "" {
+			options[tools.OptionDistributionFallback] = fallback
+		}
+		platform, err := targetPlatform(toolsOS, toolsArch)
+		if err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+		if platform != nil && toolsArchive != "" {
+			printError("--archive cannot be combined with --os or --arch")
+			os.Exit(1)
+		}
+		if err := addTool(args[0], toolVersion, dist, options); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+		if platform != nil {
+			if err := installForPlatform(args[0], toolVersion, dist, *platform); err != nil {
 				printError("%v", err)
 				os.Exit(1)
 			}
-		default:
-			printError("unknown subcommand: %s", subcommand)
-			cmd.Help()
+		}
+	},
+}
+
+// toolsReinstallCmd removes and reinstalls a tool version
+var toolsReinstallCmd = &cobra.Command{
+	Use:               "reinstall <tool> <version> [distribution]",
+	Short:             "Remove and reinstall a specific tool version",
+	Args:              cobra.RangeArgs(2, 3),
+	ValidArgsFunction: completeToolsSubcommand("reinstall"),
+	Run: func(cmd *cobra.Command, args []string) {
+		distribution := ""
+		if len(args) >= 3 {
+			distribution = args[2]
+		}
+		if err := reinstallTool(args[0], args[1], distribution); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsRemoveCmd removes a tool from the project configuration
+var toolsRemoveCmd = &cobra.Command{
+	Use:               "remove <tool>",
+	Short:             "Remove a tool from the project configuration",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeToolsSubcommand("remove"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := removeTool(args[0], toolsPurge, toolsForce); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsPruneCmd deletes the installed tool versions the project doesn't use
+var toolsPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete installed tool versions the project doesn't use",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := pruneTools(toolsKeepLatest, toolsYes, os.Stdin); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsOutdatedCmd reports the configured tools with newer versions available
+var toolsOutdatedCmd = &cobra.Command{
+	Use:   "outdated",
+	Short: "Report configured tools with newer versions available",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		outdated, err := outdatedTools()
+		if err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+		if outdated > 0 && toolsExitCode {
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsLockCmd pins the resolved tool versions
+var toolsLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Pin the resolved tool versions in .mvx/mvx.lock",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := lockTools(); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// toolsUpdateCmd re-resolves versions against the latest releases
+var toolsUpdateCmd = &cobra.Command{
+	Use:               "update [tool...]",
+	Short:             "Re-resolve versions against the latest releases and install them",
+	ValidArgsFunction: completeToolsSubcommand("update"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := updateTools(args, toolsDryRun); err != nil {
+			printError("%v", err)
 			os.Exit(1)
 		}
 	},
 }
 
+// toolsWhichCmd prints the path of the binary mvx runs for a tool
+var toolsWhichCmd = &cobra.Command{
+	Use:               "which <tool>",
+	Short:             "Print the path of the binary mvx runs for a tool",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeToolsSubcommand("which"),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := whichTool(args[0], toolsHome, toolsNoInstall); err != nil {
+			printError("%v", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	toolsRepoLocal   string
+	toolsSearchLimit int
+	toolsSearchSort  string
+	toolsOS          string
+	toolsArch        string
+	toolsJSON        bool
+	toolsReformat    bool
+	toolsResolve     string
+	toolsPackages    bool
+	toolsInteractive bool
+	toolsPurge       bool
+	toolsForce       bool
+	toolsYes         bool
+	toolsAllowEA     bool
+	toolsInstalled   bool
+	toolsKeepLatest  int
+	toolsExitCode    bool
+	toolsDryRun      bool
+	toolsEnv         []string
+	toolsArchive     string
+	toolsChecksum    string
+	toolsHome        bool
+	toolsNoInstall   bool
+
+	toolsDistributionFallback string
+	toolsNoFallback           bool
+)
+
 func init() {
+	toolsListCmd.Flags().BoolVar(&toolsInstalled, "installed", false, "list the installed versions and their disk usage instead")
+	toolsListCmd.Flags().BoolVar(&toolsJSON, "json", false, "print the installed versions as JSON (with --installed)")
+
+	toolsSearchCmd.Flags().IntVar(&toolsSearchLimit, "limit", 20, "maximum number of versions to show (0 for no limit)")
+	toolsSearchCmd.Flags().StringVar(&toolsSearchSort, "sort", "desc", "version ordering: desc (newest first) or asc")
+	toolsSearchCmd.Flags().BoolVar(&toolsPackages, "packages", false, "list the builds of a version instead of versions: search <tool> <version> [distribution]")
+
+	toolsAddCmd.Flags().StringArrayVar(&toolsEnv, "env", nil, "environment variable set with the tool, as KEY=VALUE; values may use ${TOOL_HOME}, ${TOOL_BIN} and ${TOOL_VERSION} (repeatable)")
+	toolsAddCmd.Flags().StringVar(&toolsRepoLocal, "repo-local", "", "Maven local repository to use for all Maven invocations, relative to the project root (maven only)")
+	toolsAddCmd.Flags().StringVar(&toolsDistributionFallback, "distribution-fallback", "", "on or off: whether Java may fall back to another distribution lacking the version (java only)")
+	toolsAddCmd.Flags().BoolVar(&toolsNoFallback, "no-fallback", false, "shorthand for --distribution-fallback off (java only)")
+	toolsAddCmd.Flags().StringVar(&toolsResolve, "resolve", "", "resolve version specifications to the highest (default) or lowest match")
+	toolsAddCmd.Flags().BoolVar(&toolsReformat, "reformat", false, "rewrite the whole configuration file in canonical form")
+	toolsAddCmd.Flags().BoolVar(&toolsJSON, "json", false, "print the result as JSON")
+	toolsAddCmd.Flags().BoolVar(&toolsInteractive, "interactive", false, "choose among the builds of the version and record the choice")
+	toolsAddCmd.Flags().BoolVar(&toolsAllowEA, "allow-ea", false, "accept an early-access version such as java 24-ea without asking")
+	toolsAddCmd.Flags().StringVar(&toolsOS, "os", "", "also install the tool for this operating system, e.g. for cross builds")
+	toolsAddCmd.Flags().StringVar(&toolsArch, "arch", "", "also install the tool for this architecture, e.g. for cross builds")
+	toolsAddCmd.Flags().StringVar(&toolsArchive, "archive", "", "install the version from a local archive instead of downloading it, e.g. in air-gapped setups")
+	toolsAddCmd.Flags().StringVar(&toolsChecksum, "checksum", "", "SHA-256 checksum the download or --archive must match, recorded in the configuration")
+
+	toolsRemoveCmd.Flags().BoolVar(&toolsPurge, "purge", false, "also delete the installed versions of the tool")
+	toolsRemoveCmd.Flags().BoolVar(&toolsPurge, "uninstall", false, "same as --purge")
+	toolsRemoveCmd.Flags().BoolVar(&toolsForce, "force", false, "remove the tool even if commands still require it")
+
+	toolsPruneCmd.Flags().IntVar(&toolsKeepLatest, "keep-latest", 0, "also keep the N most recent installed versions of each tool")
+	toolsPruneCmd.Flags().BoolVarP(&toolsYes, "yes", "y", false, "don't ask for confirmation")
+
+	toolsOutdatedCmd.Flags().BoolVar(&toolsExitCode, "exit-code", true, "exit with status 1 when some tools are outdated")
+
+	toolsUpdateCmd.Flags().BoolVar(&toolsDryRun, "dry-run", false, "only print the versions that would change")
+
+	toolsWhichCmd.Flags().BoolVar(&toolsHome, "home", false, "print JAVA_HOME instead of the java binary (java only)")
+	toolsWhichCmd.Flags().BoolVar(&toolsNoInstall, "no-install", false, "fail instead of installing a missing tool")
+
+	// Add subcommands
+	toolsCmd.AddCommand(toolsListCmd)
+	toolsCmd.AddCommand(toolsSearchCmd)
+	toolsCmd.AddCommand(toolsInfoCmd)
+	toolsCmd.AddCommand(toolsAddCmd)
+	toolsCmd.AddCommand(toolsReinstallCmd)
+	toolsCmd.AddCommand(toolsRemoveCmd)
+	toolsCmd.AddCommand(toolsPruneCmd)
+	toolsCmd.AddCommand(toolsOutdatedCmd)
+	toolsCmd.AddCommand(toolsLockCmd)
+	toolsCmd.AddCommand(toolsUpdateCmd)
+	toolsCmd.AddCommand(toolsWhichCmd)
+
 	rootCmd.AddCommand(toolsCmd)
 }
 
+// completeToolsSubcommand returns the shell completion of the arguments of a tools subcommand
+func completeToolsSubcommand(subcommand string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeToolsArgs(cmd, append([]string{subcommand}, args...), toComplete)
+	}
+}
+
+// completeToolsArgs provides shell completion for the tool names and versions of tools subcommands,
+// given the subcommand name followed by its arguments.
+// Version completion only uses local data (installed versions and cached resolutions) to keep TAB fast.
+func completeToolsArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 1:
+		manager, err := tools.NewManager()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return manager.GetToolNames(), cobra.ShellCompDirectiveNoFileComp
+	case 2:
+		if args[0] != "add" && args[0] != "search" && args[0] != "reinstall" {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		manager, err := tools.NewManager()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		return manager.GetVersionCompletions(args[1]), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// toolOrder is the order in which tools are displayed
+var toolOrder = []string{tools.ToolJava, tools.ToolMaven, tools.ToolMvnd, tools.ToolNode, tools.ToolGo, tools.ToolClojure, tools.ToolGradle, tools.ToolKotlin, tools.ToolRust, tools.ToolDeno, tools.ToolBun}
+
 // listTools shows all available tools
 func listTools() error {
 	manager, err := tools.NewManager()
@@ -96,9 +397,6 @@ func listTools() error {
 	// Get all tools from manager
 	allTools := manager.GetAllTools()
 
-	// Define tool order for consistent display
-	toolOrder := []string{tools.ToolJava, tools.ToolMaven, tools.ToolMvnd, tools.ToolNode, tools.ToolGo}
-
 	for _, toolName := range toolOrder {
 		tool, exists := allTools[toolName]
 		if !exists {
@@ -153,6 +451,11 @@ func listTools() error {
 	printInfo("  mvx tools search mvnd           # Search Maven Daemon versions")
 	printInfo("  mvx tools search node           # Search Node.js versions")
 	printInfo("  mvx tools search go             # Search Go versions")
+	printInfo("  mvx tools search clojure        # Search Clojure CLI versions")
+	printInfo("  mvx tools search gradle         # Search Gradle versions")
+	printInfo("  mvx tools search kotlin         # Search Kotlin versions")
+	printInfo("  mvx tools search deno           # Search Deno versions")
+	printInfo("  mvx tools search bun            # Search Bun versions")
 
 	printInfo("  mvx tools info java             # Show Java details")
 	printInfo("")
@@ -167,6 +470,116 @@ func listTools() error {
 	return nil
 }
 
+// listInstalledTools shows the installed versions of each tool with their disk usage
+func listInstalledTools() error {
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+
+	installed := collectInstalledVersions(manager)
+	toolTotals := make(map[string]int64)
+	var grandTotal int64
+	for _, version := range installed {
+		toolTotals[version.Tool] += version.Size
+		grandTotal += version.Size
+	}
+
+	if toolsJSON {
+		result := installedToolsResult{ToolsDir: manager.GetToolsDir(), Versions: installed, TotalSize: grandTotal}
+		if result.Versions == nil {
+			result.Versions = []installedToolVersion{}
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printInfo("💾 Installed Tools (%s)", manager.GetToolsDir())
+	printInfo("")
+
+	for i, version := range installed {
+		if i == 0 || installed[i-1].Tool != version.Tool {
+			if i > 0 {
+				printInfo("")
+			}
+			printInfo("📦 %s (%s)", version.Tool, formatDiskSize(toolTotals[version.Tool]))
+		}
+		label := version.Version
+		if version.Distribution != "" {
+			label += "@" + version.Distribution
+		}
+		printInfo("  %-24s %10s  %s", label, formatDiskSize(version.Size), version.Path)
+	}
+
+	if len(installed) == 0 {
+		printInfo("No tools installed")
+		return nil
+	}
+	printInfo("")
+	printInfo("Total: %s", formatDiskSize(grandTotal))
+	return nil
+}
+
+// installedToolsResult is the output of tools list --installed --json
+type installedToolsResult struct {
+	ToolsDir  string                 `json:"tools_dir"`
+	Versions  []installedToolVersion `json:"versions"`
+	TotalSize int64                  `json:"total_size"`
+}
+
+// installedToolVersion is an installed version of a tool and the disk space it uses
+type installedToolVersion struct {
+	Tool         string `json:"tool"`
+	Version      string `json:"version"`
+	Distribution string `json:"distribution,omitempty"`
+	Path         string `json:"path"`
+	Size         int64  `json:"size"`
+}
+
+// collectInstalledVersions lists the installed versions of all tools, in display order and
+// newest first for each tool
+func collectInstalledVersions(manager *tools.Manager) []installedToolVersion {
+	var installed []installedToolVersion
+	for _, toolName := range toolOrder {
+		perVersion, _ := manager.GetToolDiskUsage(toolName)
+		for _, dirName := range sortedInstalledVersions(perVersion) {
+			version, distribution, _ := strings.Cut(manager.CanonicalInstalledVersion(toolName, dirName), "@")
+			installed = append(installed, installedToolVersion{
+				Tool:         toolName,
+				Version:      version,
+				Distribution: distribution,
+				Path:         filepath.Join(manager.GetToolDir(toolName), dirName),
+				Size:         perVersion[dirName],
+			})
+		}
+	}
+	return installed
+}
+
+// sortedInstalledVersions returns the installed versions of a tool, newest first
+func sortedInstalledVersions(perVersion map[string]int64) []string {
+	versions := make([]string, 0, len(perVersion))
+	for installed := range perVersion {
+		versions = append(versions, installed)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return tools.CompareInstalledVersions(versions[i], versions[j]) > 0
+	})
+	return versions
+}
+
+// formatDiskSize formats a disk usage for display
+func formatDiskSize(size int64) string {
+	if size <= 0 {
+		return "0 B"
+	}
+	return formatPackageSize(size)
+}
+
 // searchTool searches for versions of a specific tool
 func searchTool(toolName string, filters []string) error {
 	manager, err := tools.NewManager()
@@ -174,14 +587,23 @@ func searchTool(toolName string, filters []string) error {
 		return fmt.Errorf("failed to create tool manager: %w", err)
 	}
 
+	// Stale cached metadata is better than failing offline or when rate-limited
+	manager.AllowStaleMetadata()
+
 	// Use manager's search functionality instead of switch statement
 	versions, err := manager.SearchToolVersions(toolName, filters)
 	if err != nil {
 		return err
 	}
 
+	// Sort after filtering, so that limits apply to the filtered versions
+	versions, err = sortSearchResults(versions, toolsSearchSort)
+	if err != nil {
+		return err
+	}
+
 	// Print the search results
-	printInfo("🔍 %s Versions", strings.Title(toolName))
+	printInfo("🔍 %s Versions%s", strings.Title(toolName), staleMetadataLabel(manager))
 	printInfo("")
 
 	if len(versions) == 0 {
@@ -189,18 +611,18 @@ func searchTool(toolName string, filters []string) error {
 		return nil
 	}
 
-	// Display versions (limit to first 20 for readability)
+	// Display versions (limited for readability)
 	displayed := versions
-	if len(versions) > 20 {
-		displayed = versions[:20]
+	if toolsSearchLimit > 0 && len(versions) > toolsSearchLimit {
+		displayed = versions[:toolsSearchLimit]
 	}
 
-	for _, version := range displayed {
-		printInfo("  %s", version)
+	for _, v := range displayed {
+		printInfo("  %s", v)
 	}
 
-	if len(versions) > 20 {
-		printInfo("  ... and %d more", len(versions)-20)
+	if len(displayed) < len(versions) {
+		printInfo("  ... and %d more", len(versions)-len(displayed))
 	}
 
 	printInfo("")
@@ -209,6 +631,39 @@ func searchTool(toolName string, filters []string) error {
 	return nil
 }
 
+// staleMetadataLabel labels search results that were not fetched live
+func staleMetadataLabel(manager *tools.Manager) string {
+	if manager.UsedStaleMetadata() {
+		return " (cached/offline)"
+	}
+	return ""
+}
+
+// sortSearchResults orders versions newest first ("desc") or oldest first ("asc").
+// Versions that cannot be parsed are kept, in their original order, after the sorted ones.
+func sortSearchResults(versions []string, order string) ([]string, error) {
+	if order != "asc" && order != "desc" {
+		return nil, fmt.Errorf("invalid sort order %q: must be asc or desc", order)
+	}
+
+	sorted := mvxversion.SortVersions(versions)
+	if order == "asc" {
+		for i, j := 0, len(sorted)-1; i < j; i, j = i+1, j-1 {
+			sorted[i], sorted[j] = sorted[j], sorted[i]
+		}
+	}
+
+	if len(sorted) < len(versions) {
+		for _, v := range versions {
+			if _, err := mvxversion.ParseVersion(v); err != nil {
+				sorted = append(sorted, v)
+			}
+		}
+	}
+
+	return sorted, nil
+}
+
 // showToolInfo shows detailed information about a tool
 func showToolInfo(toolName string) error {
 	manager, err := tools.NewManager()
@@ -254,8 +709,85 @@ func showToolInfo(toolName string) error {
 	return nil
 }
 
+// toolAddResult describes what "tools add" wrote to the configuration, for --json output
+type toolAddResult struct {
+	Tool            string `json:"tool"`
+	WrittenVersion  string `json:"written_version"`
+	ResolvedVersion string `json:"resolved_version,omitempty"`
+	Distribution    string `json:"distribution,omitempty"`
+	ConfigPath      string `json:"config_path"`
+}
+
+// normalizeToolAddArgs trims the version and optional distribution given to tools add,
+// rejecting empty or whitespace-only values that would only fail when the config is loaded
+func normalizeToolAddArgs(version string, distribution *string) (string, string, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return "", "", fmt.Errorf("version must not be empty")
+	}
+	if distribution == nil {
+		return version, "", nil
+	}
+	dist := strings.TrimSpace(*distribution)
+	if dist == "" {
+		return "", "", fmt.Errorf("distribution must not be empty (omit it to use the default)")
+	}
+	return version, dist, nil
+}
+
+// distributionFallbackOption returns the distribution-fallback option value to store, or ""
+// to keep the default (fallback enabled) without writing anything to the configuration.
+func distributionFallbackOption(value string, noFallback bool) (string, error) {
+	if noFallback {
+		if value != "" && value != "off" {
+			return "", fmt.Errorf("--no-fallback conflicts with --distribution-fallback %s", value)
+		}
+		return "off", nil
+	}
+	switch value {
+	case "", "on":
+		return "", nil
+	case "off":
+		return "off", nil
+	default:
+		return "", fmt.Errorf("invalid --distribution-fallback value %q: must be on or off", value)
+	}
+}
+
+// parseToolEnv parses the KEY=VALUE variables given with --env
+func parseToolEnv(values []string) (map[string]string, error) {
+	env := make(map[string]string, len(values))
+	for _, value := range values {
+		key, val, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --env value %q: expected KEY=VALUE", value)
+		}
+		env[key] = val
+	}
+	return env, nil
+}
+
+// sortedEnvKeys returns the names of environment variables in order
+func sortedEnvKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// checksumWarning returns a warning when downloads of a tool configuration cannot be
+// checksum-verified: no checksum is configured and the tool publishes none
+func checksumWarning(manager *tools.Manager, toolName string, toolConfig config.ToolConfig) string {
+	if manager.HasChecksum(toolName, toolConfig) {
+		return ""
+	}
+	return fmt.Sprintf("no checksum available for %s %s; installs will be unverified (use --checksum to pin one)", toolName, toolConfig.Version)
+}
+
 // addTool adds a tool to the project configuration
-func addTool(toolName, version, distribution string) error {
+func addTool(toolName, version, distribution string, options map[string]string) error {
 	// Find project root
 	projectRoot, err := findProjectRoot()
 	if err != nil {
@@ -273,11 +805,27 @@ func addTool(toolName, version, distribution string) error {
 		return fmt.Errorf("failed to create tool manager: %w", err)
 	}
 
-	// Validate that the tool exists and version is valid
-	if err := manager.ValidateToolVersion(toolName, version, distribution); err != nil {
+	// A local archive provides the version as given, so it is not looked up
+	archive, err := localArchivePath(toolsArchive)
+	if err != nil {
+		return err
+	}
+	if archive != "" {
+		if _, err := manager.GetTool(toolName); err != nil {
+			return err
+		}
+	} else if err := manager.ValidateToolVersion(toolName, version, distribution); err != nil {
+		// Validate that the tool exists and version is valid
 		return err
 	}
 
+	// Early-access builds change or vanish without notice, don't pin one by accident
+	if toolName == tools.ToolJava && tools.IsEarlyAccessVersion(version) {
+		if err := confirmEarlyAccess(toolName, version, toolsAllowEA, os.Stdin, isInteractive(os.Stdin)); err != nil {
+			return err
+		}
+	}
+
 	// Initialize tools map if it doesn't exist
 	if cfg.Tools == nil {
 		cfg.Tools = make(map[string]config.ToolConfig)
@@ -297,27 +845,323 @@ func addTool(toolName, version, distribution string) error {
 		}
 	}
 
+	// Add the Maven local repository option if specified and applicable
+	if repoLocal := options[tools.OptionRepoLocal]; repoLocal != "" {
+		if toolName == tools.ToolMaven {
+			toolConfig.Options = map[string]string{tools.OptionRepoLocal: repoLocal}
+		} else {
+			printWarning("Option --repo-local ignored for tool '%s' (only applicable to Maven)", toolName)
+		}
+	}
+
+	// Record the resolution preference, only when it differs from the default
+	if preference := options[tools.OptionResolve]; preference != "" && preference != string(mvxversion.PreferHighest) {
+		if toolConfig.Options == nil {
+			toolConfig.Options = make(map[string]string)
+		}
+		toolConfig.Options[tools.OptionResolve] = preference
+	}
+
+	// Disable the distribution fallback chain if requested and applicable
+	if fallback := options[tools.OptionDistributionFallback]; fallback != "" {
+		if toolName == tools.ToolJava {
+			if toolConfig.Options == nil {
+				toolConfig.Options = make(map[string]string)
+			}
+			toolConfig.Options[tools.OptionDistributionFallback] = fallback
+		} else {
+			printWarning("Option --distribution-fallback ignored for tool '%s' (only applicable to Java)", toolName)
+		}
+	}
+
+	// Keep the variables already configured for the tool, and add or replace those given
+	toolEnv, err := parseToolEnv(toolsEnv)
+	if err != nil {
+		return err
+	}
+	for key, value := range cfg.Tools[toolName].Env {
+		if _, replaced := toolEnv[key]; !replaced {
+			toolEnv[key] = value
+		}
+	}
+	if len(toolEnv) > 0 {
+		toolConfig.Env = toolEnv
+	}
+
+	toolConfig.Archive = archive
+	if toolsChecksum != "" {
+		toolConfig.Checksum = &config.ChecksumConfig{Type: "sha256", Value: toolsChecksum}
+	}
+
+	// Let the user pick among multiple builds (e.g. JDK or JRE, glibc or musl)
+	if toolsInteractive {
+		if err := chooseToolPackage(manager, toolName, &toolConfig, os.Stdin); err != nil {
+			return err
+		}
+	}
+
+	// Warn up front if installs of this tool cannot be checksum-verified
+	if warning := checksumWarning(manager, toolName, toolConfig); warning != "" {
+		printWarning("%s", warning)
+	}
+
 	// Check if tool already exists
-	if existingConfig, exists := cfg.Tools[toolName]; exists {
+	if existingConfig, exists := cfg.Tools[toolName]; exists && !toolsJSON {
 		printInfo("Tool '%s' already configured with version '%s'", toolName, existingConfig.Version)
 		printInfo("Updating to version '%s'", version)
 	}
 
-	// Add/update the tool
-	cfg.Tools[toolName] = toolConfig
+	// Install from the local archive right away, so that a wrong archive is not recorded
+	if archive != "" {
+		manager.EnableExplicitInstall()
+		if _, err := manager.EnsureTool(toolName, toolConfig); err != nil {
+			return fmt.Errorf("failed to install %s %s from %s: %w", toolName, version, archive, err)
+		}
+	}
 
-	// Save the configuration
-	if err := config.SaveConfig(cfg, projectRoot); err != nil {
-		return fmt.Errorf("failed to save configuration: %w", err)
+	// Add/update the tool, recording the archive relative to the project so that the
+	// configuration can be shared: where the file is missing, the version is downloaded
+	recordedConfig := toolConfig
+	if archive != "" {
+		recordedConfig.Archive = projectRelativePath(projectRoot, archive)
+	}
+	cfg.Tools[toolName] = recordedConfig
+
+	// Warn about known-incompatible tool combinations (overrides are legitimate, so don't block)
+	for _, warning := range manager.CheckToolCompatibility(cfg) {
+		printWarning("%s", warning)
+	}
+
+	// Save the configuration: by default only the tool entry changes, --reformat rewrites
+	// the whole file in canonical form (sorted tools, stable key order)
+	if toolsReformat {
+		if err := config.SaveConfig(cfg, projectRoot); err != nil {
+			return fmt.Errorf("failed to save configuration: %w", err)
+		}
+	} else if err := config.SaveToolConfig(cfg, projectRoot, toolName); err != nil {
+		return fmt.Errorf("failed to save configuration (use --reformat to rewrite the whole file): %w", err)
+	}
+
+	if toolsJSON {
+		result := toolAddResult{
+			Tool:           toolName,
+			WrittenVersion: toolConfig.Version,
+			Distribution:   toolConfig.Distribution,
+			ConfigPath:     config.GetProjectConfigPath(projectRoot),
+		}
+		if resolved, err := manager.ResolveVersion(toolName, toolConfig); err == nil {
+			result.ResolvedVersion = manager.CanonicalVersion(toolName, resolved)
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode result: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
 	}
 
 	printSuccess("✅ Added %s %s to project configuration", toolName, version)
 	if distribution != "" && toolName == "java" {
 		printSuccess("   Distribution: %s", distribution)
 	}
+	if toolConfig.Options[tools.OptionRepoLocal] != "" {
+		printSuccess("   Maven local repository: %s", toolConfig.Options[tools.OptionRepoLocal])
+	}
+	if toolConfig.Options[tools.OptionDistributionFallback] == "off" {
+		printSuccess("   Distribution fallback: off")
+	}
+	if packageID := toolConfig.Options[tools.OptionPackageID]; packageID != "" {
+		printSuccess("   Package: %s (%s)", packageID, toolConfig.Options[tools.OptionPackagePlatform])
+	}
+	for _, key := range sortedEnvKeys(toolConfig.Env) {
+		printSuccess("   Environment: %s=%s", key, toolConfig.Env[key])
+	}
+	if archive != "" {
+		printSuccess("   Installed from local archive: %s", archive)
+		return nil
+	}
 
 	printInfo("")
 	printInfo("To install the tool, run: mvx setup")
 
 	return nil
 }
+
+// projectRelativePath returns path relative to the project root when it is inside the project,
+// and unchanged otherwise
+func projectRelativePath(projectRoot, path string) string {
+	rel, err := filepath.Rel(projectRoot, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// localArchivePath returns the absolute path of the archive given with --archive, after
+// checking that it is an archive mvx can install from
+func localArchivePath(archive string) (string, error) {
+	if archive == "" {
+		return "", nil
+	}
+	absPath, err := filepath.Abs(archive)
+	if err != nil {
+		return "", fmt.Errorf("invalid archive path %s: %w", archive, err)
+	}
+	if err := tools.ValidateArchive(absPath); err != nil {
+		return "", err
+	}
+	return absPath, nil
+}
+
+// confirmEarlyAccess warns that an early-access version is unstable and, unless allowed with
+// --allow-ea, asks for confirmation or fails when not running interactively
+func confirmEarlyAccess(toolName, version string, allow bool, in io.Reader, interactive bool) error {
+	printWarning("%s %s is an early-access build: it may change or disappear, so installs are not reproducible", toolName, version)
+	if allow {
+		return nil
+	}
+	if !interactive {
+		return fmt.Errorf("refusing to add early-access %s %s, use --allow-ea to add it anyway", toolName, version)
+	}
+
+	fmt.Fprintf(os.Stderr, "Add %s %s anyway? [y/N] ", toolName, version)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("failed to read answer: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		return fmt.Errorf("early-access %s %s not added", toolName, version)
+	}
+	return nil
+}
+
+// isInteractive reports whether a file is a terminal, i.e. whether a prompt can be answered
+func isInteractive(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// targetPlatform returns the platform selected with --os/--arch, or nil if neither is set.
+// A missing value defaults to the host's operating system or architecture.
+func targetPlatform(osName, arch string) (*tools.PlatformInfo, error) {
+	if osName == "" && arch == "" {
+		return nil, nil
+	}
+
+	platform := tools.GetPlatformInfo()
+	if osName != "" {
+		platform.OS = osName
+	}
+	if arch != "" {
+		platform.Arch = arch
+	}
+
+	if err := tools.ValidatePlatform(platform); err != nil {
+		return nil, err
+	}
+	return &platform, nil
+}
+
+// installForPlatform installs a tool for another platform into a platform-tagged directory
+func installForPlatform(toolName, version, distribution string, platform tools.PlatformInfo) error {
+	manager, err := tools.NewManagerForPlatform(platform)
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager for %s: %w", platform, err)
+	}
+
+	toolConfig := config.ToolConfig{Version: version}
+	if toolName == tools.ToolJava {
+		toolConfig.Distribution = distribution
+	}
+
+	printInfo("📦 Installing %s %s for %s (verification skipped)...", toolName, version, platform)
+	installDir, err := manager.InstallForPlatform(toolName, toolConfig)
+	if err != nil {
+		return err
+	}
+
+	printSuccess("✅ Installed %s %s for %s", toolName, version, platform)
+	printSuccess("   Location: %s", installDir)
+	return nil
+}
+
+// reinstallTool removes an existing installation of a tool version and installs it again
+func reinstallTool(toolName, version, distribution string) error {
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	manager.EnableExplicitInstall()
+
+	toolConfig := config.ToolConfig{
+		Version:      version,
+		Distribution: distribution,
+	}
+
+	printInfo("♻️  Reinstalling %s %s...", toolName, version)
+	path, err := manager.ReinstallTool(toolName, toolConfig)
+	if err != nil {
+		return fmt.Errorf("failed to reinstall %s %s: %w", toolName, version, err)
+	}
+
+	printSuccess("✅ Reinstalled %s %s", toolName, version)
+	printVerbose("Binary path: %s", path)
+
+	return nil
+}
+
+// removeTool removes a tool from the project configuration and, with purge, deletes its installations
+func removeTool(toolName string, purge, force bool) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	removed := cfg.Tools[toolName]
+	if err := removeToolFromConfig(cfg, toolName, force); err != nil {
+		return err
+	}
+
+	// Only the tool's entry is rewritten, keeping the comments of config.json5
+	if err := config.SaveToolConfig(cfg, projectRoot, toolName); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+	printSuccess("✅ Removed %s %s from project configuration", toolName, removed.Version)
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	if !purge {
+		printInfo("Installed versions are kept in %s (use --purge to delete them)", manager.GetToolDir(toolName))
+		return nil
+	}
+	if err := manager.UninstallTool(toolName, "", ""); err != nil {
+		return err
+	}
+	printSuccess("🗑️  Deleted installed versions of %s from %s", toolName, manager.GetToolDir(toolName))
+	return nil
+}
+
+// removeToolFromConfig deletes a tool entry, refusing while commands still require it unless forced
+func removeToolFromConfig(cfg *config.Config, toolName string, force bool) error {
+	if _, exists := cfg.Tools[toolName]; !exists {
+		return fmt.Errorf("tool '%s' is not configured in this project", toolName)
+	}
+
+	if commands := cfg.CommandsRequiringTool(toolName); len(commands) > 0 {
+		if !force {
+			return fmt.Errorf("tool '%s' is required by command(s) %s, use --force to remove it anyway", toolName, strings.Join(commands, ", "))
+		}
+		printWarning("Commands %s still require %s", strings.Join(commands, ", "), toolName)
+	}
+
+	delete(cfg.Tools, toolName)
+	return nil
+}
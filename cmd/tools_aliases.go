@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// addCmd is a shorthand for 'mvx tools add'
+var addCmd = &cobra.Command{
+	Use:   "add <tool> <version> [distribution]",
+	Short: "Add a tool to the project configuration (same as 'tools add')",
+	Long: `Add a tool to the project configuration and install it.
+
+This is a shorthand for 'mvx tools add', which remains the canonical form.
+
+Examples:
+  mvx add maven 3.9.6                 # Add Maven 3.9.6
+  mvx add java 21 zulu                # Add Java 21 from Zulu`,
+	Args:              toolsAddCmd.Args,
+	ValidArgsFunction: toolsAddCmd.ValidArgsFunction,
+	Run: func(cmd *cobra.Command, args []string) {
+		toolsAddCmd.Run(cmd, args)
+	},
+}
+
+// removeCmd is a shorthand for 'mvx tools remove'
+var removeCmd = &cobra.Command{
+	Use:   "remove <tool>",
+	Short: "Remove a tool from the project configuration (same as 'tools remove')",
+	Long: `Remove a tool from the project configuration, keeping its installed versions
+unless --uninstall is given.
+
+This is a shorthand for 'mvx tools remove', which remains the canonical form.
+
+Examples:
+  mvx remove node                     # Drop Node from the configuration
+  mvx remove node --uninstall         # Also delete its installed versions`,
+	Args:              toolsRemoveCmd.Args,
+	ValidArgsFunction: toolsRemoveCmd.ValidArgsFunction,
+	Run: func(cmd *cobra.Command, args []string) {
+		toolsRemoveCmd.Run(cmd, args)
+	},
+}
+
+func init() {
+	// The shorthands share the flags, and their values, of the tools subcommands
+	addCmd.Flags().AddFlagSet(toolsAddCmd.Flags())
+	removeCmd.Flags().AddFlagSet(toolsRemoveCmd.Flags())
+
+	rootCmd.AddCommand(addCmd)
+	rootCmd.AddCommand(removeCmd)
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// lockTools resolves the configured tools and pins their versions in the project's lockfile
+func lockTools() error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+
+	lockfile, err := manager.WriteLockfile(cfg, projectRoot)
+	if err != nil {
+		return err
+	}
+	toolNames := make([]string, 0, len(lockfile.Tools))
+	for toolName := range lockfile.Tools {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+	for _, toolName := range toolNames {
+		locked := lockfile.Tools[toolName]
+		printInfo("  🔒 %s %s → %s", toolName, locked.Spec, locked.Version)
+	}
+	printSuccess("Wrote %s", tools.GetLockfilePath(projectRoot))
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// outdatedTools reports the configured tools for which a newer version of their release line is
+// available, without changing anything, and returns how many are outdated
+func outdatedTools() (int, error) {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return 0, fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if len(cfg.Tools) == 0 {
+		printInfo("No tools configured")
+		return 0, nil
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return 0, fmt.Errorf("failed to create tool manager: %w", err)
+	}
+
+	printInfo("🔎 Checking configured tools for newer versions...")
+	printInfo("")
+	outdated := 0
+	for _, update := range manager.CheckUpdates(cfg) {
+		switch {
+		case update.Err != nil:
+			printWarning("%s: failed to check for updates: %v", update.Tool, update.Err)
+		case update.Outdated:
+			outdated++
+			printInfo("  ⬆️  %s %s → %s", update.Tool, update.Current, update.Latest)
+		default:
+			printInfo("  ✅ %s %s (up to date)", update.Tool, update.Current)
+		}
+	}
+	printInfo("")
+
+	if outdated == 0 {
+		printSuccess("All configured tools are up to date")
+	} else {
+		printInfo("%d tool(s) can be updated, e.g. with: mvx tools add <tool> <version>", outdated)
+	}
+	return outdated, nil
+}
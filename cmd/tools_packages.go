@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// searchToolPackages lists the builds of a tool version, for 'mvx tools search <tool> <version> [distribution] --packages'
+func searchToolPackages(toolName string, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("usage: mvx tools search %s <version> [distribution] --packages", toolName)
+	}
+	toolConfig := config.ToolConfig{Version: args[0]}
+	if len(args) == 2 {
+		toolConfig.Distribution = args[1]
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	manager.AllowStaleMetadata()
+
+	packages, err := manager.ListToolPackages(toolName, toolConfig)
+	if err != nil {
+		return err
+	}
+
+	printInfo("📦 %s %s packages for %s%s", strings.Title(toolName), toolConfig.Version, manager.GetPlatform(), staleMetadataLabel(manager))
+	printInfo("")
+	if len(packages) == 0 {
+		printInfo("No packages found")
+		return nil
+	}
+	printPackages(packages)
+	printInfo("")
+	printInfo("Usage: mvx tools add %s %s --interactive", toolName, toolConfig.Version)
+	return nil
+}
+
+// chooseToolPackage lets the user pick the build to install and records it in the tool options.
+// Tools publishing a single build per version are left unchanged.
+func chooseToolPackage(manager *tools.Manager, toolName string, toolConfig *config.ToolConfig, in io.Reader) error {
+	tool, err := manager.GetTool(toolName)
+	if err != nil {
+		return err
+	}
+	if _, ok := tool.(tools.PackageLister); !ok {
+		printWarning("Option --interactive ignored for tool '%s' (a single package per version)", toolName)
+		return nil
+	}
+
+	packages, err := manager.ListToolPackages(toolName, *toolConfig)
+	if err != nil {
+		return err
+	}
+	if len(packages) == 0 {
+		return fmt.Errorf("no %s %s packages found for %s", toolName, toolConfig.Version, manager.GetPlatform())
+	}
+
+	printInfo("📦 Available packages for %s:", manager.GetPlatform())
+	printPackages(packages)
+	chosen, err := choosePackage(packages, in)
+	if err != nil {
+		return err
+	}
+	if chosen == nil {
+		printInfo("Keeping automatic package selection")
+		return nil
+	}
+
+	if toolConfig.Options == nil {
+		toolConfig.Options = make(map[string]string)
+	}
+	toolConfig.Options[tools.OptionPackageID] = chosen.ID
+	toolConfig.Options[tools.OptionPackagePlatform] = manager.GetPlatform().String()
+	printInfo("Using %s", chosen.Filename)
+	return nil
+}
+
+// choosePackage prompts for a package number. An empty answer keeps the automatic selection
+// and returns nil.
+func choosePackage(packages []tools.PackageInfo, in io.Reader) (*tools.PackageInfo, error) {
+	fmt.Fprintf(os.Stderr, "Choose a package [1-%d], or press Enter for automatic selection: ", len(packages))
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read choice: %w", err)
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return nil, nil
+	}
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > len(packages) {
+		return nil, fmt.Errorf("invalid choice %q: expected a number between 1 and %d", answer, len(packages))
+	}
+	return &packages[choice-1], nil
+}
+
+// printPackages prints a numbered table of packages
+func printPackages(packages []tools.PackageInfo) {
+	for i, pkg := range packages {
+		var details []string
+		for _, detail := range []string{pkg.Type, pkg.LibC, formatPackageSize(pkg.Size)} {
+			if detail != "" {
+				details = append(details, detail)
+			}
+		}
+		printInfo("  %2d. %s (%s)", i+1, pkg.Filename, strings.Join(details, ", "))
+	}
+}
+
+// formatPackageSize formats a size in bytes for display, or returns "" if unknown
+func formatPackageSize(size int64) string {
+	switch {
+	case size <= 0:
+		return ""
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
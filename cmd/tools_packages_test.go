@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+func TestChoosePackage(t *testing.T) {
+	packages := []tools.PackageInfo{
+		{ID: "jdk-glibc", Filename: "jdk.tar.gz", Type: "jdk", LibC: "glibc"},
+		{ID: "jdk-musl", Filename: "jdk-musl.tar.gz", Type: "jdk", LibC: "musl"},
+		{ID: "jre-glibc", Filename: "jre.tar.gz", Type: "jre", LibC: "glibc"},
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "choice", input: "2\n", expected: "jdk-musl"},
+		{name: "choice without newline", input: " 3 ", expected: "jre-glibc"},
+		{name: "automatic selection", input: "\n", expected: ""},
+		{name: "end of input", input: "", expected: ""},
+		{name: "out of range", input: "4\n", wantErr: true},
+		{name: "not a number", input: "jre\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chosen, err := choosePackage(packages, strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("choosePackage() expected error, got %+v", chosen)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("choosePackage() error = %v", err)
+			}
+			got := ""
+			if chosen != nil {
+				got = chosen.ID
+			}
+			if got != tt.expected {
+				t.Errorf("choosePackage() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatPackageSize(t *testing.T) {
+	tests := []struct {
+		size     int64
+		expected string
+	}{
+		{0, ""},
+		{512, "512 B"},
+		{2048, "2.0 KB"},
+		{195035136, "186.0 MB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatPackageSize(tt.size); got != tt.expected {
+			t.Errorf("formatPackageSize(%d) = %q, want %q", tt.size, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// pruneTools removes the installed tool versions the current project doesn't use
+func pruneTools(keepLatest int, assumeYes bool, in io.Reader) error {
+	if keepLatest < 0 {
+		return fmt.Errorf("invalid --keep-latest %d: must not be negative", keepLatest)
+	}
+
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+
+	keep := manager.ConfiguredVersions(cfg)
+	if err := manager.KeepLatestVersions(keep, keepLatest); err != nil {
+		return fmt.Errorf("failed to list installed versions: %w", err)
+	}
+
+	unused, err := manager.UnusedVersions(keep)
+	if err != nil {
+		return err
+	}
+	if len(unused) == 0 {
+		printInfo("✅ No unused tool versions in %s", manager.GetToolsDir())
+		return nil
+	}
+
+	var total int64
+	printInfo("🧹 Unused tool versions:")
+	for _, v := range unused {
+		printInfo("  %s %s (%s)", v.Tool, v.Version, formatPackageSize(v.Size))
+		total += v.Size
+	}
+	printInfo("")
+
+	if !assumeYes {
+		confirmed, err := confirmPrune(len(unused), total, in)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			printInfo("Nothing removed")
+			return nil
+		}
+	}
+
+	removed, err := manager.PruneUnusedVersions(keep)
+	var reclaimed int64
+	for _, v := range removed {
+		reclaimed += v.Size
+	}
+	if len(removed) > 0 {
+		printSuccess("✅ Removed %d tool version(s), reclaimed %s", len(removed), formatPackageSize(reclaimed))
+	}
+	return err
+}
+
+// confirmPrune asks whether to remove the unused versions, defaulting to no
+func confirmPrune(count int, size int64, in io.Reader) (bool, error) {
+	return askYesNo(fmt.Sprintf("Remove %d version(s) and reclaim %s?", count, formatPackageSize(size)), in)
+}
+
+// askYesNo asks a question on stderr and reads the answer, defaulting to no
+func askYesNo(question string, in io.Reader) (bool, error) {
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
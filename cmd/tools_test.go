@@ -0,0 +1,456 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+func TestSortSearchResults(t *testing.T) {
+	versions := []string{"3.9.6", "4.0.0-rc-2", "not-a-version", "3.8.8", "3.9.11"}
+
+	tests := []struct {
+		order    string
+		expected string
+		wantErr  bool
+	}{
+		{order: "desc", expected: "4.0.0-rc-2,3.9.11,3.9.6,3.8.8,not-a-version"},
+		{order: "asc", expected: "3.8.8,3.9.6,3.9.11,4.0.0-rc-2,not-a-version"},
+		{order: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			sorted, err := sortSearchResults(versions, tt.order)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("sortSearchResults(%s) error = %v, wantErr %v", tt.order, err, tt.wantErr)
+			}
+			if !tt.wantErr && strings.Join(sorted, ",") != tt.expected {
+				t.Errorf("sortSearchResults(%s) = %v, want %s", tt.order, sorted, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDistributionFallbackOption(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		noFallback bool
+		expected   string
+		wantErr    bool
+	}{
+		{name: "default", expected: ""},
+		{name: "on", value: "on", expected: ""},
+		{name: "off", value: "off", expected: "off"},
+		{name: "no-fallback", noFallback: true, expected: "off"},
+		{name: "conflict", value: "on", noFallback: true, wantErr: true},
+		{name: "invalid", value: "maybe", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := distributionFallbackOption(tt.value, tt.noFallback)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("distributionFallbackOption(%q, %v) error = %v, wantErr %v", tt.value, tt.noFallback, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("distributionFallbackOption(%q, %v) = %q, want %q", tt.value, tt.noFallback, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeToolAddArgs(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name         string
+		version      string
+		distribution *string
+		wantVersion  string
+		wantDist     string
+		wantErr      bool
+	}{
+		{name: "plain", version: "21", wantVersion: "21"},
+		{name: "trimmed", version: " 21 ", distribution: ptr("\tzulu "), wantVersion: "21", wantDist: "zulu"},
+		{name: "empty version", version: "", wantErr: true},
+		{name: "whitespace version", version: "  ", wantErr: true},
+		{name: "empty distribution", version: "21", distribution: ptr(""), wantErr: true},
+		{name: "whitespace distribution", version: "21", distribution: ptr(" "), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, dist, err := normalizeToolAddArgs(tt.version, tt.distribution)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("normalizeToolAddArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.wantVersion || dist != tt.wantDist {
+				t.Errorf("normalizeToolAddArgs() = (%q, %q), want (%q, %q)", version, dist, tt.wantVersion, tt.wantDist)
+			}
+		})
+	}
+}
+
+func TestProjectRelativePath(t *testing.T) {
+	projectRoot := filepath.Join(string(filepath.Separator), "work", "demo")
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: filepath.Join(projectRoot, "archives", "jdk-21.tar.gz"), expected: "archives/jdk-21.tar.gz"},
+		{path: filepath.Join(projectRoot, "jdk-21.tar.gz"), expected: "jdk-21.tar.gz"},
+		{path: filepath.Join(string(filepath.Separator), "work", "jdk-21.tar.gz"), expected: filepath.Join(string(filepath.Separator), "work", "jdk-21.tar.gz")},
+		{path: filepath.Join(string(filepath.Separator), "work", "demo-archives", "jdk-21.tar.gz"), expected: filepath.Join(string(filepath.Separator), "work", "demo-archives", "jdk-21.tar.gz")},
+	}
+	for _, tt := range tests {
+		if got := projectRelativePath(projectRoot, tt.path); got != tt.expected {
+			t.Errorf("projectRelativePath(%q) = %q, want %q", tt.path, got, tt.expected)
+		}
+	}
+}
+
+func TestRemoveToolFromConfig(t *testing.T) {
+	newConfig := func() *config.Config {
+		return &config.Config{
+			Tools: map[string]config.ToolConfig{
+				"java":  {Version: "21"},
+				"maven": {Version: "3.9.6"},
+				"node":  {Version: "20.0.0"},
+			},
+			Commands: map[string]config.CommandConfig{
+				"build": {Script: "mvn package", Requires: []string{"java", "maven"}},
+				"test":  {Script: "mvn test", Requires: []string{"maven"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		tool      string
+		force     bool
+		wantError string
+	}{
+		{"unreferenced tool", "node", false, ""},
+		{"not configured", "go", false, "not configured"},
+		{"required by commands", "maven", false, "required by command(s) build, test"},
+		{"required but forced", "maven", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			err := removeToolFromConfig(cfg, tt.tool, tt.force)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("removeToolFromConfig() error = %v, want containing %q", err, tt.wantError)
+				}
+				if len(cfg.Tools) != 3 {
+					t.Errorf("Expected configuration to be unchanged, got %v", cfg.Tools)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("removeToolFromConfig() error = %v", err)
+			}
+			if _, exists := cfg.Tools[tt.tool]; exists {
+				t.Errorf("Expected %s to be removed", tt.tool)
+			}
+		})
+	}
+}
+
+func TestRemoveTool(t *testing.T) {
+	tests := []struct {
+		name          string
+		purge         bool
+		wantInstalled bool
+	}{
+		{name: "configuration only", purge: false, wantInstalled: true},
+		{name: "purge", purge: true, wantInstalled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			homeDir := t.TempDir()
+			t.Setenv("HOME", homeDir)
+			tools.ResetManager()
+			defer tools.ResetManager()
+
+			installDir := filepath.Join(homeDir, ".mvx", "tools", "node", "20.0.0")
+			if err := os.MkdirAll(installDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			projectDir := t.TempDir()
+			mvxDir := filepath.Join(projectDir, ".mvx")
+			if err := os.MkdirAll(mvxDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			configJSON := `{
+  project: { name: "remove" },
+  tools: {
+    node: { version: "20.0.0" },
+    go: { version: "1.23.1" }, // Backend
+  },
+}
+`
+			configPath := filepath.Join(mvxDir, "config.json5")
+			if err := os.WriteFile(configPath, []byte(configJSON), 0644); err != nil {
+				t.Fatal(err)
+			}
+			originalDir, _ := os.Getwd()
+			defer os.Chdir(originalDir)
+			os.Chdir(projectDir)
+
+			if err := removeTool("node", tt.purge, false); err != nil {
+				t.Fatalf("removeTool() error = %v", err)
+			}
+
+			data, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := strings.Replace(configJSON, "    node: { version: \"20.0.0\" },\n", "", 1)
+			if string(data) != expected {
+				t.Errorf("configuration after removing node =\n%s\nwant\n%s", data, expected)
+			}
+			if _, err := os.Stat(installDir); (err == nil) != tt.wantInstalled {
+				t.Errorf("node 20.0.0 installed after removal = %v, want %v", err == nil, tt.wantInstalled)
+			}
+		})
+	}
+}
+
+func TestRemoveShorthand(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	projectDir := t.TempDir()
+	mvxDir := filepath.Join(projectDir, ".mvx")
+	if err := os.MkdirAll(mvxDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	configJSON := `{ project: { name: "remove" }, tools: { node: { version: "20.0.0" }, go: { version: "1.23.1" } } }`
+	if err := os.WriteFile(filepath.Join(mvxDir, "config.json5"), []byte(configJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(projectDir)
+
+	defer func() { toolsPurge = false }()
+	rootCmd.SetArgs([]string{"remove", "node", "--uninstall"})
+	defer rootCmd.SetArgs(nil)
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("mvx remove node error = %v", err)
+	}
+
+	if !toolsPurge {
+		t.Errorf("--uninstall did not set the tools remove --purge flag")
+	}
+	cfg, err := config.LoadConfig(projectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := cfg.Tools["node"]; exists || len(cfg.Tools) != 1 {
+		t.Errorf("tools after 'mvx remove node' = %v, want only go", cfg.Tools)
+	}
+}
+
+func TestToolsSubcommandFlags(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"tools", "search", "java", "--purge"}, wantErr: "unknown flag: --purge"},
+		{args: []string{"tools", "remove", "node", "--limit", "5"}, wantErr: "unknown flag: --limit"},
+		{args: []string{"add", "java", "21", "--force"}, wantErr: "unknown flag: --force"},
+		{args: []string{"tools", "bogus"}, wantErr: `unknown command "bogus"`},
+		{args: []string{"tools", "which"}, wantErr: "accepts 1 arg(s), received 0"},
+	}
+
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetErr(nil)
+	defer rootCmd.SetArgs(nil)
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			rootCmd.SetArgs(tt.args)
+			if err := rootCmd.Execute(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("mvx %s error = %v, want %q", strings.Join(tt.args, " "), err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfirmPrune(t *testing.T) {
+	tests := []struct {
+		answer   string
+		expected bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		got, err := confirmPrune(2, 1024, strings.NewReader(tt.answer))
+		if err != nil {
+			t.Fatalf("confirmPrune(%q) error = %v", tt.answer, err)
+		}
+		if got != tt.expected {
+			t.Errorf("confirmPrune(%q) = %v, expected %v", tt.answer, got, tt.expected)
+		}
+	}
+}
+
+func TestConfirmEarlyAccess(t *testing.T) {
+	tests := []struct {
+		name        string
+		allow       bool
+		interactive bool
+		answer      string
+		wantErr     bool
+	}{
+		{"allowed", true, false, "", false},
+		{"non-interactive", false, false, "", true},
+		{"confirmed", false, true, "y\n", false},
+		{"declined", false, true, "\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := confirmEarlyAccess("java", "25-ea", tt.allow, strings.NewReader(tt.answer), tt.interactive)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("confirmEarlyAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSortedInstalledVersions(t *testing.T) {
+	perVersion := map[string]int64{"17.0.9@zulu": 1, "21.0.1@temurin": 2, "8.0.392@temurin": 3, "unknown": 4}
+	got := sortedInstalledVersions(perVersion)
+	expected := []string{"21.0.1@temurin", "17.0.9@zulu", "8.0.392@temurin", "unknown"}
+	if strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("sortedInstalledVersions() = %v, expected %v", got, expected)
+	}
+}
+
+func TestCollectInstalledVersions(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	toolsDir := filepath.Join(homeDir, ".mvx", "tools")
+	files := map[string]int{
+		"java/21.0.5@zulu/release":     100,
+		"java/17.0.9@temurin/release":  50,
+		"maven/3.9.6/lib/maven.jar":    200,
+		"maven/3.9.6/bin/mvn":          10,
+		"unknown-tool/1.0.0/something": 1,
+	}
+	for name, size := range files {
+		path := filepath.Join(toolsDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []installedToolVersion{
+		{Tool: "java", Version: "21.0.5", Distribution: "zulu", Path: filepath.Join(toolsDir, "java", "21.0.5@zulu"), Size: 100},
+		{Tool: "java", Version: "17.0.9", Distribution: "temurin", Path: filepath.Join(toolsDir, "java", "17.0.9@temurin"), Size: 50},
+		{Tool: "maven", Version: "3.9.6", Path: filepath.Join(toolsDir, "maven", "3.9.6"), Size: 210},
+	}
+	got := collectInstalledVersions(manager)
+	if len(got) != len(expected) {
+		t.Fatalf("collectInstalledVersions() = %+v, want %+v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("collectInstalledVersions()[%d] = %+v, want %+v", i, got[i], expected[i])
+		}
+	}
+}
+
+// checksumTestTool is a minimal tool whose downloads may or may not publish a checksum
+type checksumTestTool struct {
+	*tools.BaseTool
+	checksum string
+}
+
+func (c *checksumTestTool) Install(version string, cfg config.ToolConfig) error { return nil }
+
+func (c *checksumTestTool) IsInstalled(version string, cfg config.ToolConfig) bool { return false }
+
+func (c *checksumTestTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return "", nil
+}
+
+func (c *checksumTestTool) Verify(version string, cfg config.ToolConfig) error { return nil }
+
+func (c *checksumTestTool) ListVersions() ([]string, error) { return []string{"1.0.0"}, nil }
+
+func (c *checksumTestTool) GetDownloadURL(version string) string {
+	return "https://example.com/" + c.GetToolName() + "-" + version + ".tar.gz"
+}
+
+func (c *checksumTestTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (tools.ChecksumInfo, error) {
+	if c.checksum == "" {
+		return tools.ChecksumInfo{}, nil
+	}
+	return tools.ChecksumInfo{Type: tools.SHA256, Value: c.checksum}, nil
+}
+
+func TestChecksumWarning(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv(config.EnvMvxHome, "")
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager.RegisterTool(&checksumTestTool{BaseTool: tools.NewBaseTool(manager, "unverified", "unverified")})
+	manager.RegisterTool(&checksumTestTool{BaseTool: tools.NewBaseTool(manager, "published", "published"), checksum: strings.Repeat("a", 64)})
+
+	pinned := &config.ChecksumConfig{Type: "sha256", Value: strings.Repeat("b", 64)}
+	tests := []struct {
+		name        string
+		tool        string
+		checksum    *config.ChecksumConfig
+		wantWarning bool
+	}{
+		{name: "no checksum available", tool: "unverified", wantWarning: true},
+		{name: "checksum pinned in configuration", tool: "unverified", checksum: pinned},
+		{name: "checksum published by the tool", tool: "published"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warning := checksumWarning(manager, tt.tool, config.ToolConfig{Version: "1.0.0", Checksum: tt.checksum})
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("checksumWarning(%s) = %q, want a warning: %v", tt.tool, warning, tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// updateTools re-resolves the version specifications of the given tools, or of all the
+// configured tools, against the latest releases, then installs the new versions and updates
+// the lockfile. With dryRun, it only reports what would change.
+func updateTools(toolNames []string, dryRun bool) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if len(cfg.Tools) == 0 {
+		printInfo("No tools configured")
+		return nil
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	if err := manager.UseLockfile(projectRoot); err != nil {
+		return err
+	}
+	_, lockfileErr := os.Stat(tools.GetLockfilePath(projectRoot))
+	hasLockfile := lockfileErr == nil
+
+	printInfo("🔄 Resolving tool versions against the latest releases...")
+	printInfo("")
+	updates, err := manager.UpdateResolutions(cfg, toolNames, projectRoot, dryRun)
+	if err != nil {
+		return err
+	}
+
+	var changed []tools.VersionUpdate
+	for _, update := range updates {
+		switch {
+		case update.Err != nil:
+			printWarning("%s: failed to resolve %s: %v", update.Tool, update.Spec, update.Err)
+		case update.Changed():
+			changed = append(changed, update)
+			previous := update.Previous
+			if previous == "" {
+				previous = "unresolved"
+			}
+			printInfo("  ⬆️  %s %s: %s → %s", update.Tool, update.Spec, previous, update.Resolved)
+		default:
+			printInfo("  ✅ %s %s: %s (up to date)", update.Tool, update.Spec, update.Resolved)
+		}
+	}
+	printInfo("")
+
+	if len(changed) == 0 {
+		printSuccess("All tool versions are up to date")
+		return nil
+	}
+	if dryRun {
+		printInfo("%d tool(s) would be updated (dry run, nothing was changed)", len(changed))
+		return nil
+	}
+
+	manager.EnableExplicitInstall()
+	for _, update := range changed {
+		toolConfig := cfg.Tools[update.Tool]
+		toolConfig.Version = update.Resolved
+		if _, err := manager.EnsureTool(update.Tool, toolConfig); err != nil {
+			return fmt.Errorf("failed to install %s %s: %w", update.Tool, update.Resolved, err)
+		}
+	}
+
+	if hasLockfile {
+		printSuccess("Updated %d tool(s) and %s", len(changed), tools.GetLockfilePath(projectRoot))
+	} else {
+		printSuccess("Updated %d tool(s)", len(changed))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/tools"
+)
+
+// whichTool prints the absolute path of the binary mvx runs for a configured tool, or of its
+// JAVA_HOME with home, installing the tool first unless noInstall is set
+func whichTool(toolName string, home, noInstall bool) error {
+	projectRoot, err := findProjectRoot()
+	if err != nil {
+		return fmt.Errorf("failed to find project root: %w", err)
+	}
+
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	toolConfig, configured := cfg.Tools[toolName]
+	if !configured {
+		return fmt.Errorf("tool %s is not configured in this project", toolName)
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		return fmt.Errorf("failed to create tool manager: %w", err)
+	}
+	tool, err := manager.GetTool(toolName)
+	if err != nil {
+		return err
+	}
+	javaTool, isJava := tool.(*tools.JavaTool)
+	if home && !isJava {
+		return fmt.Errorf("--home is only supported for Java")
+	}
+
+	path, err := manager.FindToolBinary(toolName, toolConfig, !noInstall)
+	if err != nil {
+		return err
+	}
+	if home {
+		resolvedConfig := toolConfig
+		if resolvedConfig.Version, err = manager.ResolveVersion(toolName, toolConfig); err != nil {
+			return err
+		}
+		if path, err = javaTool.GetJavaHome(resolvedConfig.Version, resolvedConfig); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(path)
+	return nil
+}
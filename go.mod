@@ -3,8 +3,10 @@ module github.com/gnodet/mvx
 go 1.24.2
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/adhocore/jsonc v0.10.0
 	github.com/spf13/cobra v1.9.1
+	golang.org/x/crypto v0.38.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -1,75 +1,280 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/BurntSushi/toml"
+	"github.com/gnodet/mvx/pkg/shell"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the mvx project configuration
 type Config struct {
-	Project     ProjectConfig            `json:"project" yaml:"project"`
-	Tools       map[string]ToolConfig    `json:"tools" yaml:"tools"`
-	Environment map[string]string        `json:"environment" yaml:"environment"`
-	Commands    map[string]CommandConfig `json:"commands" yaml:"commands"`
+	Extends     []string                 `json:"extends,omitempty" yaml:"extends,omitempty" toml:"extends,omitempty"` // Base configurations (relative to this file) this one is merged over
+	Project     ProjectConfig            `json:"project" yaml:"project" toml:"project"`
+	Tools       map[string]ToolConfig    `json:"tools" yaml:"tools" toml:"tools"`
+	Environment map[string]string        `json:"environment" yaml:"environment" toml:"environment"`
+	EnvFiles    []string                 `json:"env_files,omitempty" yaml:"env_files,omitempty" toml:"env_files,omitempty"` // Dotenv files (relative to project root) loaded below environment
+	Commands    map[string]CommandConfig `json:"commands" yaml:"commands" toml:"commands"`
+	Hooks       map[string]CommandConfig `json:"hooks,omitempty" yaml:"hooks,omitempty" toml:"hooks,omitempty"`          // Scripts referenced by name from pre, post and uses
+	Settings    *SettingsConfig          `json:"settings,omitempty" yaml:"settings,omitempty" toml:"settings,omitempty"` // Defaults for the tool manager, overridden by environment variables
+
+	toolSpecs   map[string]toolSpec // Tools whose version or distribution reference variables, as written
+	projectRoot string              // Directory the configuration was loaded from, if any
 }
 
 // ProjectConfig contains project metadata
 type ProjectConfig struct {
-	Name        string `json:"name" yaml:"name"`
-	Description string `json:"description" yaml:"description"`
+	Name           string `json:"name" yaml:"name" toml:"name"`
+	Description    string `json:"description" yaml:"description" toml:"description"`
+	DefaultCommand string `json:"default_command,omitempty" yaml:"default_command,omitempty" toml:"default_command,omitempty"` // command run by a bare "mvx"
 }
 
 // ToolConfig represents a tool requirement
 type ToolConfig struct {
-	Version      string            `json:"version" yaml:"version"`
-	Distribution string            `json:"distribution,omitempty" yaml:"distribution,omitempty"`
-	RequiredFor  []string          `json:"required_for,omitempty" yaml:"required_for,omitempty"`
-	Options      map[string]string `json:"options,omitempty" yaml:"options,omitempty"`
-	Checksum     *ChecksumConfig   `json:"checksum,omitempty" yaml:"checksum,omitempty"`
+	Version      string            `json:"version" yaml:"version" toml:"version"`
+	Distribution string            `json:"distribution,omitempty" yaml:"distribution,omitempty" toml:"distribution,omitempty"`
+	RequiredFor  []string          `json:"required_for,omitempty" yaml:"required_for,omitempty" toml:"required_for,omitempty"`
+	Options      map[string]string `json:"options,omitempty" yaml:"options,omitempty" toml:"options,omitempty"`
+	Env          map[string]string `json:"env,omitempty" yaml:"env,omitempty" toml:"env,omitempty"` // Variables set with the tool, e.g. "${TOOL_HOME}/conf"
+	Checksum     *ChecksumConfig   `json:"checksum,omitempty" yaml:"checksum,omitempty" toml:"checksum,omitempty"`
+	Signature    *SignatureConfig  `json:"signature,omitempty" yaml:"signature,omitempty" toml:"signature,omitempty"`
+	Archive      string            `json:"archive,omitempty" yaml:"archive,omitempty" toml:"archive,omitempty"` // Local archive installed instead of downloading the version
+
+	archivePath string // Archive resolved against the project root
+}
+
+// SettingsConfig holds project defaults for the tool manager, for instance for slow mirrors.
+// The corresponding environment variables take precedence.
+type SettingsConfig struct {
+	ParallelDownloads int    `json:"parallelDownloads,omitempty" yaml:"parallelDownloads,omitempty" toml:"parallelDownloads,omitempty"` // Like MVX_PARALLEL_DOWNLOADS
+	HTTPTimeout       string `json:"httpTimeout,omitempty" yaml:"httpTimeout,omitempty" toml:"httpTimeout,omitempty"`                   // Like MVX_HTTP_TIMEOUT, e.g. "3m"
+}
+
+// GetHTTPTimeout returns the parsed HTTP timeout, or 0 if not set
+func (s *SettingsConfig) GetHTTPTimeout() (time.Duration, error) {
+	if s == nil || s.HTTPTimeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(s.HTTPTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid httpTimeout '%s': %w", s.HTTPTimeout, err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid httpTimeout '%s': must be positive", s.HTTPTimeout)
+	}
+	return timeout, nil
 }
 
 // ChecksumConfig represents checksum verification configuration
 type ChecksumConfig struct {
-	Type     string `json:"type,omitempty" yaml:"type,omitempty"`         // sha256, etc.
-	Value    string `json:"value,omitempty" yaml:"value,omitempty"`       // direct checksum value
-	URL      string `json:"url,omitempty" yaml:"url,omitempty"`           // URL to fetch checksum from
-	Filename string `json:"filename,omitempty" yaml:"filename,omitempty"` // filename to look for in checksum file
-	Required bool   `json:"required,omitempty" yaml:"required,omitempty"` // whether checksum verification is required
+	Type     string `json:"type,omitempty" yaml:"type,omitempty" toml:"type,omitempty"`             // sha256, etc.
+	Value    string `json:"value,omitempty" yaml:"value,omitempty" toml:"value,omitempty"`          // direct checksum value
+	URL      string `json:"url,omitempty" yaml:"url,omitempty" toml:"url,omitempty"`                // URL to fetch checksum from
+	Filename string `json:"filename,omitempty" yaml:"filename,omitempty" toml:"filename,omitempty"` // filename to look for in checksum file
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty" toml:"required,omitempty"` // whether checksum verification is required
+}
+
+// SignatureConfig represents PGP signature verification configuration
+type SignatureConfig struct {
+	Keyring  string `json:"keyring,omitempty" yaml:"keyring,omitempty" toml:"keyring,omitempty"`    // local file with the trusted public keys
+	KeyURL   string `json:"key_url,omitempty" yaml:"key_url,omitempty" toml:"key_url,omitempty"`    // URL to fetch the trusted public keys from
+	URL      string `json:"url,omitempty" yaml:"url,omitempty" toml:"url,omitempty"`                // URL of the detached signature (default: download URL + .asc)
+	Required bool   `json:"required,omitempty" yaml:"required,omitempty" toml:"required,omitempty"` // whether signature verification is required
 }
 
 // CommandConfig represents a command definition
 type CommandConfig struct {
-	Description string             `json:"description" yaml:"description"`
-	Script      interface{}        `json:"script" yaml:"script"` // Can be string or PlatformScript
-	WorkingDir  string             `json:"working_dir,omitempty" yaml:"working_dir,omitempty"`
-	Requires    []string           `json:"requires,omitempty" yaml:"requires,omitempty"`
-	Args        []CommandArgConfig `json:"args,omitempty" yaml:"args,omitempty"`
-	Environment map[string]string  `json:"environment,omitempty" yaml:"environment,omitempty"`
-	Interpreter string             `json:"interpreter,omitempty" yaml:"interpreter,omitempty"` // "native" (default), "mvx-shell"
+	Description string                `json:"description" yaml:"description" toml:"description"`
+	Script      interface{}           `json:"script" yaml:"script" toml:"script"` // Can be string or PlatformScript
+	WorkingDir  string                `json:"working_dir,omitempty" yaml:"working_dir,omitempty" toml:"working_dir,omitempty"`
+	Requires    []string              `json:"requires,omitempty" yaml:"requires,omitempty" toml:"requires,omitempty"`
+	Args        []CommandArgConfig    `json:"args,omitempty" yaml:"args,omitempty" toml:"args,omitempty"`
+	Passthrough bool                  `json:"passthrough,omitempty" yaml:"passthrough,omitempty" toml:"passthrough,omitempty"` // Pass undeclared arguments to the script instead of rejecting them
+	Uses        string                `json:"uses,omitempty" yaml:"uses,omitempty" toml:"uses,omitempty"`                      // Hook whose script the command runs instead of its own
+	Environment map[string]string     `json:"environment,omitempty" yaml:"environment,omitempty" toml:"environment,omitempty"`
+	EnvFile     string                `json:"env_file,omitempty" yaml:"env_file,omitempty" toml:"env_file,omitempty"`          // Dotenv file (relative to project root) loaded below environment
+	Interpreter string                `json:"interpreter,omitempty" yaml:"interpreter,omitempty" toml:"interpreter,omitempty"` // "native" (default), "mvx-shell"
+	Silent      bool                  `json:"silent,omitempty" yaml:"silent,omitempty" toml:"silent,omitempty"`                // Suppress mvx's "Running command" framing
+	Pipeline    []string              `json:"pipeline,omitempty" yaml:"pipeline,omitempty" toml:"pipeline,omitempty"`          // Commands whose stdout feeds the next one's stdin
+	OutputFile  string                `json:"output_file,omitempty" yaml:"output_file,omitempty" toml:"output_file,omitempty"` // File (relative to project root) also receiving the output
+	OS          []string              `json:"os,omitempty" yaml:"os,omitempty" toml:"os,omitempty"`                            // Operating systems the command runs on (all when empty)
+	Arch        []string              `json:"arch,omitempty" yaml:"arch,omitempty" toml:"arch,omitempty"`                      // Architectures the command runs on (all when empty)
+	Retries     int                   `json:"retries,omitempty" yaml:"retries,omitempty" toml:"retries,omitempty"`             // Times a failing command is rerun before giving up
+	RetryDelay  string                `json:"retry_delay,omitempty" yaml:"retry_delay,omitempty" toml:"retry_delay,omitempty"` // Delay between attempts (e.g. "5s")
+	Tools       map[string]ToolConfig `json:"tools,omitempty" yaml:"tools,omitempty" toml:"tools,omitempty"`                   // Tool versions overriding the project's for this command
+	Pre         interface{}           `json:"pre,omitempty" yaml:"pre,omitempty" toml:"pre,omitempty"`                         // Hook run before the command: a script or a list of scripts
+	Post        interface{}           `json:"post,omitempty" yaml:"post,omitempty" toml:"post,omitempty"`                      // Hook run after the command succeeded
+	Confirm     string                `json:"confirm,omitempty" yaml:"confirm,omitempty" toml:"confirm,omitempty"`             // Question asked before running the command on a terminal
+	// What to do when the confirmation can't be asked: "proceed" (default) or "refuse"
+	ConfirmNonInteractive string `json:"confirm_non_interactive,omitempty" yaml:"confirm_non_interactive,omitempty" toml:"confirm_non_interactive,omitempty"`
+}
+
+// Values of confirm_non_interactive
+const (
+	ConfirmProceed = "proceed"
+	ConfirmRefuse  = "refuse"
+)
+
+// GetRetryDelay returns the delay between attempts of a retried command (no delay by default)
+func (c CommandConfig) GetRetryDelay() (time.Duration, error) {
+	if c.RetryDelay == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(c.RetryDelay)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retry_delay '%s': %w", c.RetryDelay, err)
+	}
+	if delay < 0 {
+		return 0, fmt.Errorf("invalid retry_delay '%s': must not be negative", c.RetryDelay)
+	}
+	return delay, nil
+}
+
+// commandOSNames lists the values accepted in a command's os constraint, mapped to GOOS
+var commandOSNames = map[string]string{
+	"linux":   "linux",
+	"darwin":  "darwin",
+	"macos":   "darwin", // Alias for darwin, as in platform scripts
+	"windows": "windows",
+	"freebsd": "freebsd",
+}
+
+// commandArchNames lists the values accepted in a command's arch constraint, mapped to GOARCH
+var commandArchNames = map[string]string{
+	"amd64":   "amd64",
+	"x64":     "amd64",
+	"arm64":   "arm64",
+	"aarch64": "arm64",
+	"386":     "386",
+	"arm":     "arm",
+}
+
+// EnvFilePaths returns the paths of the configured env files, relative ones being resolved
+// against the project root
+func (c *Config) EnvFilePaths() []string {
+	paths := make([]string, 0, len(c.EnvFiles))
+	for _, envFile := range c.EnvFiles {
+		paths = append(paths, c.ResolvePath(envFile))
+	}
+	return paths
+}
+
+// ResolvePath resolves a configured path relative to the project root. Absolute paths, and
+// paths of a configuration not loaded from a project, are returned unchanged.
+func (c *Config) ResolvePath(path string) string {
+	if filepath.IsAbs(path) || c.projectRoot == "" {
+		return path
+	}
+	return filepath.Join(c.projectRoot, path)
+}
+
+// LocalArchive returns the path of the local archive to install the version from, or "" when
+// none is configured or it does not exist on this machine, so that the version is downloaded
+func (t ToolConfig) LocalArchive() string {
+	path := t.archivePath
+	if path == "" {
+		path = t.Archive
+	}
+	if path == "" {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// IsRequiredFor reports whether a tool is needed to run a command: tools without required_for
+// are needed by every command, the others only by the commands they list
+func (t ToolConfig) IsRequiredFor(command string) bool {
+	if len(t.RequiredFor) == 0 {
+		return true
+	}
+	for _, name := range t.RequiredFor {
+		if name == command {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsPlatform reports whether the command may run on the given GOOS/GOARCH
+func (c CommandConfig) SupportsPlatform(goos, goarch string) bool {
+	return matchesPlatformConstraint(c.OS, commandOSNames, goos) && matchesPlatformConstraint(c.Arch, commandArchNames, goarch)
+}
+
+// SupportsCurrentPlatform reports whether the command may run on this platform
+func (c CommandConfig) SupportsCurrentPlatform() bool {
+	return c.SupportsPlatform(runtime.GOOS, runtime.GOARCH)
+}
+
+// matchesPlatformConstraint reports whether value is allowed by a list of os or arch names,
+// an empty list allowing everything
+func matchesPlatformConstraint(allowed []string, names map[string]string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, name := range allowed {
+		if names[strings.ToLower(name)] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateRetries checks the retries and retry_delay fields of a command
+func validateRetries(cmdConfig CommandConfig) error {
+	if cmdConfig.Retries < 0 {
+		return fmt.Errorf("invalid retries %d, must not be negative", cmdConfig.Retries)
+	}
+	if _, err := cmdConfig.GetRetryDelay(); err != nil {
+		return err
+	}
+	if cmdConfig.RetryDelay != "" && cmdConfig.Retries == 0 {
+		return fmt.Errorf("retry_delay is set but retries is not")
+	}
+	return nil
+}
 
+// validatePlatformConstraint checks that every entry of an os or arch constraint is known
+func validatePlatformConstraint(field string, values []string, names map[string]string) error {
+	for _, value := range values {
+		if _, ok := names[strings.ToLower(value)]; !ok {
+			known := make([]string, 0, len(names))
+			for name := range names {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("invalid %s '%s', must be one of: %s", field, value, strings.Join(known, ", "))
+		}
+	}
+	return nil
 }
 
 // PlatformScript represents platform-specific script definitions
 type PlatformScript struct {
-	Windows string `json:"windows,omitempty" yaml:"windows,omitempty"`
-	Unix    string `json:"unix,omitempty" yaml:"unix,omitempty"`
-	Linux   string `json:"linux,omitempty" yaml:"linux,omitempty"`
-	MacOS   string `json:"macos,omitempty" yaml:"macos,omitempty"`
-	Darwin  string `json:"darwin,omitempty" yaml:"darwin,omitempty"` // Alias for macOS
-	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	Windows string `json:"windows,omitempty" yaml:"windows,omitempty" toml:"windows,omitempty"`
+	Unix    string `json:"unix,omitempty" yaml:"unix,omitempty" toml:"unix,omitempty"`
+	Linux   string `json:"linux,omitempty" yaml:"linux,omitempty" toml:"linux,omitempty"`
+	MacOS   string `json:"macos,omitempty" yaml:"macos,omitempty" toml:"macos,omitempty"`
+	Darwin  string `json:"darwin,omitempty" yaml:"darwin,omitempty" toml:"darwin,omitempty"` // Alias for macOS
+	Default string `json:"default,omitempty" yaml:"default,omitempty" toml:"default,omitempty"`
 }
 
 // CommandArgConfig represents a command argument
 type CommandArgConfig struct {
-	Name        string `json:"name" yaml:"name"`
-	Description string `json:"description" yaml:"description"`
-	Default     string `json:"default,omitempty" yaml:"default,omitempty"`
-	Required    bool   `json:"required,omitempty" yaml:"required,omitempty"`
+	Name        string `json:"name" yaml:"name" toml:"name"`
+	Description string `json:"description" yaml:"description" toml:"description"`
+	Default     string `json:"default,omitempty" yaml:"default,omitempty" toml:"default,omitempty"`
+	Required    bool   `json:"required,omitempty" yaml:"required,omitempty" toml:"required,omitempty"`
 }
 
 // LoadConfig loads configuration from the project directory
@@ -81,13 +286,20 @@ func LoadConfig(projectRoot string) (*Config, error) {
 		"config.json5",
 		"config.yml",
 		"config.yaml",
+		"config.toml",
 		"config.json",
 	}
 
 	for _, filename := range configFiles {
 		configPath := filepath.Join(mvxDir, filename)
 		if _, err := os.Stat(configPath); err == nil {
-			return loadConfigFile(configPath)
+			config, err := loadConfigFile(configPath)
+			if err != nil {
+				return nil, err
+			}
+			config.projectRoot = projectRoot
+			config.resolveArchives()
+			return config, nil
 		}
 	}
 
@@ -95,8 +307,39 @@ func LoadConfig(projectRoot string) (*Config, error) {
 		mvxDir, strings.Join(configFiles, ", "))
 }
 
-// loadConfigFile loads configuration from a specific file
+// resolveArchives resolves the local archives of the tools against the project root, keeping
+// the configured paths, relative to the project, for when the configuration is saved
+func (c *Config) resolveArchives() {
+	for name, tool := range c.Tools {
+		if tool.Archive != "" {
+			tool.archivePath = c.ResolvePath(tool.Archive)
+			c.Tools[name] = tool
+		}
+	}
+}
+
+// loadConfigFile loads configuration from a specific file, merged over the files it extends
 func loadConfigFile(path string) (*Config, error) {
+	config, err := loadExtendedConfigFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve tool versions and distributions such as "${JAVA_VERSION}" from the environment
+	if err := config.expandToolVariables(isStrictEnv()); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	// Validate configuration
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// parseConfigFile parses a configuration file according to its extension
+func parseConfigFile(path string) (*Config, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
@@ -111,6 +354,8 @@ func loadConfigFile(path string) (*Config, error) {
 		err = ParseJSON5(data, &config)
 	case ".yml", ".yaml":
 		err = yaml.Unmarshal(data, &config)
+	case ".toml":
+		_, err = toml.Decode(string(data), &config)
 	case ".json":
 		// Use JSON5 preprocessor for .json files too (allows comments)
 		err = ParseJSON5(data, &config)
@@ -122,16 +367,18 @@ func loadConfigFile(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
 	}
 
-	// Validate configuration
-	if err := config.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid configuration: %w", err)
-	}
-
 	return &config, nil
 }
 
-// SaveConfig saves configuration to the project directory in JSON5 format
+// SaveConfig saves configuration to the project directory, in TOML when the project already
+// has a config.toml and in JSON5 format otherwise
 func SaveConfig(cfg *Config, projectRoot string) error {
+	// A configuration extending others holds their content, which rewriting it would copy
+	if len(cfg.Extends) > 0 {
+		return fmt.Errorf("the configuration extends %s and cannot be rewritten, edit it by hand",
+			strings.Join(cfg.Extends, ", "))
+	}
+
 	mvxDir := filepath.Join(projectRoot, ".mvx")
 
 	// Ensure .mvx directory exists
@@ -139,13 +386,23 @@ func SaveConfig(cfg *Config, projectRoot string) error {
 		return fmt.Errorf("failed to create .mvx directory: %w", err)
 	}
 
-	// Use JSON5 format as the default
-	configPath := filepath.Join(mvxDir, "config.json5")
+	configPath := GetProjectConfigPath(projectRoot)
+	cfg = cfg.withUnexpandedTools()
 
-	// Convert config to JSON5 format
-	content, err := FormatAsJSON5(cfg)
-	if err != nil {
-		return fmt.Errorf("failed to format configuration as JSON5: %w", err)
+	var content string
+	if filepath.Ext(configPath) == ".toml" {
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(cfg); err != nil {
+			return fmt.Errorf("failed to format configuration as TOML: %w", err)
+		}
+		content = buf.String()
+	} else {
+		// Convert config to JSON5 format
+		var err error
+		content, err = FormatAsJSON5(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to format configuration as JSON5: %w", err)
+		}
 	}
 
 	// Write to file
@@ -156,23 +413,117 @@ func SaveConfig(cfg *Config, projectRoot string) error {
 	return nil
 }
 
+// GetProjectConfigPath returns the path of the configuration file written by SaveConfig:
+// config.toml when the project has one, config.json5 otherwise
+func GetProjectConfigPath(projectRoot string) string {
+	tomlPath := filepath.Join(projectRoot, ".mvx", "config.toml")
+	if _, err := os.Stat(tomlPath); err == nil {
+		return tomlPath
+	}
+	return filepath.Join(projectRoot, ".mvx", "config.json5")
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if c.Project.Name == "" {
 		return fmt.Errorf("project.name is required")
 	}
 
+	if c.Project.DefaultCommand != "" {
+		if _, exists := c.Commands[c.Project.DefaultCommand]; !exists {
+			return fmt.Errorf("project.default_command: '%s' is not a defined command", c.Project.DefaultCommand)
+		}
+	}
+
+	if c.Settings != nil {
+		if c.Settings.ParallelDownloads < 0 {
+			return fmt.Errorf("settings: invalid parallelDownloads %d, must not be negative", c.Settings.ParallelDownloads)
+		}
+		if _, err := c.Settings.GetHTTPTimeout(); err != nil {
+			return fmt.Errorf("settings: %w", err)
+		}
+	}
+
 	// Validate tool configurations
 	for toolName, toolConfig := range c.Tools {
 		if toolConfig.Version == "" {
 			return fmt.Errorf("tool %s: version is required", toolName)
 		}
+		for key := range toolConfig.Env {
+			if key == "" || strings.ContainsAny(key, "= \t") {
+				return fmt.Errorf("tool %s: invalid environment variable name '%s'", toolName, key)
+			}
+		}
+	}
+
+	// Validate the named hooks, which define a script but no hooks or uses of their own
+	for hookName, hookConfig := range c.Hooks {
+		if hookConfig.Uses != "" || hookConfig.Pre != nil || hookConfig.Post != nil {
+			return fmt.Errorf("hook %s: hooks cannot use other hooks", hookName)
+		}
+		if !HasValidScript(hookConfig.Script) {
+			return fmt.Errorf("hook %s: script is required", hookName)
+		}
+		if hookConfig.Interpreter != "" && hookConfig.Interpreter != "native" && hookConfig.Interpreter != "mvx-shell" {
+			return fmt.Errorf("hook %s: invalid interpreter '%s', must be 'native' or 'mvx-shell'", hookName, hookConfig.Interpreter)
+		}
+		if err := validateMVXShellScript(hookConfig); err != nil {
+			return fmt.Errorf("hook %s: %w", hookName, err)
+		}
 	}
 
 	// Validate command configurations
 	for cmdName, cmdConfig := range c.Commands {
-		// All commands require a script
-		if !HasValidScript(cmdConfig.Script) {
+		if err := validatePlatformConstraint("os", cmdConfig.OS, commandOSNames); err != nil {
+			return fmt.Errorf("command %s: %w", cmdName, err)
+		}
+		if err := validatePlatformConstraint("arch", cmdConfig.Arch, commandArchNames); err != nil {
+			return fmt.Errorf("command %s: %w", cmdName, err)
+		}
+		if err := validateRetries(cmdConfig); err != nil {
+			return fmt.Errorf("command %s: %w", cmdName, err)
+		}
+		switch cmdConfig.ConfirmNonInteractive {
+		case "", ConfirmProceed, ConfirmRefuse:
+		default:
+			return fmt.Errorf("command %s: invalid confirm_non_interactive '%s', must be '%s' or '%s'", cmdName, cmdConfig.ConfirmNonInteractive, ConfirmProceed, ConfirmRefuse)
+		}
+		if cmdConfig.ConfirmNonInteractive != "" && cmdConfig.Confirm == "" {
+			return fmt.Errorf("command %s: confirm_non_interactive is set but confirm is not", cmdName)
+		}
+
+		for toolName, toolConfig := range cmdConfig.Tools {
+			if toolConfig.Version == "" {
+				return fmt.Errorf("command %s: tool %s: version is required", cmdName, toolName)
+			}
+		}
+		if err := c.validateHook("pre", cmdConfig.Pre); err != nil {
+			return fmt.Errorf("command %s: %w", cmdName, err)
+		}
+		if err := c.validateHook("post", cmdConfig.Post); err != nil {
+			return fmt.Errorf("command %s: %w", cmdName, err)
+		}
+		if err := c.validateArgs(cmdConfig); err != nil {
+			return fmt.Errorf("command %s: %w", cmdName, err)
+		}
+
+		// Pipelines reference other commands instead of defining a script
+		if len(cmdConfig.Pipeline) > 0 {
+			if err := c.validatePipeline(cmdName, cmdConfig.Pipeline); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// All other commands require a script, or a hook providing it
+		if cmdConfig.Uses != "" {
+			if _, exists := c.Hooks[cmdConfig.Uses]; !exists {
+				return fmt.Errorf("command %s: uses '%s', which is not a defined hook", cmdName, cmdConfig.Uses)
+			}
+			if cmdConfig.Script != nil {
+				return fmt.Errorf("command %s: script and uses cannot both be set", cmdName)
+			}
+		} else if !HasValidScript(cmdConfig.Script) {
 			return fmt.Errorf("command %s: script is required", cmdName)
 		}
 
@@ -180,11 +531,108 @@ func (c *Config) Validate() error {
 		if cmdConfig.Interpreter != "" && cmdConfig.Interpreter != "native" && cmdConfig.Interpreter != "mvx-shell" {
 			return fmt.Errorf("command %s: invalid interpreter '%s', must be 'native' or 'mvx-shell'", cmdName, cmdConfig.Interpreter)
 		}
+
+		// Dry-parse mvx-shell scripts so that unsupported syntax is reported on load
+		if err := validateMVXShellScript(cmdConfig); err != nil {
+			return fmt.Errorf("command %s: %w", cmdName, err)
+		}
+	}
+
+	return nil
+}
+
+// reservedArgNames are the variables that an argument, exported under its own name, must not
+// replace: they would break the environment the script runs in
+var reservedArgNames = []string{
+	"PATH", "HOME", "PWD", "OLDPWD", "SHELL", "USER", "IFS", "TMPDIR", "TEMP", "TMP",
+	"USERPROFILE", "SYSTEMROOT", "COMSPEC", "PATHEXT",
+}
+
+// validateArgs checks that the arguments of a command are named and do not collide with the
+// variables of the environment. Names are compared case-insensitively, as on Windows, and as
+// for path, which zsh ties to PATH.
+func (c *Config) validateArgs(cmdConfig CommandConfig) error {
+	for _, arg := range cmdConfig.Args {
+		if arg.Name == "" {
+			return fmt.Errorf("argument name is required")
+		}
+		for _, reserved := range reservedArgNames {
+			if strings.EqualFold(arg.Name, reserved) {
+				return fmt.Errorf("argument '%s' would replace the %s environment variable", arg.Name, reserved)
+			}
+		}
+		if len(arg.Name) >= 4 && strings.EqualFold(arg.Name[:4], "MVX_") {
+			return fmt.Errorf("argument '%s' uses the MVX_ prefix reserved for mvx variables", arg.Name)
+		}
+		for _, environment := range []map[string]string{c.Environment, cmdConfig.Environment} {
+			for name := range environment {
+				if strings.EqualFold(arg.Name, name) {
+					return fmt.Errorf("argument '%s' collides with the environment variable %s", arg.Name, name)
+				}
+			}
+		}
 	}
+	return nil
+}
+
+// validateMVXShellScript checks that a command run by mvx-shell on this platform can be parsed.
+// Scripts run by the native interpreter are left to the system shell.
+func validateMVXShellScript(cmdConfig CommandConfig) error {
+	script, interpreter, err := ResolvePlatformScriptWithInterpreter(cmdConfig.Script, cmdConfig.Interpreter)
+	if err != nil || interpreter != "mvx-shell" {
+		// Scripts for other platforms or the native shell are not checked here
+		return nil
+	}
+	if err := shell.ValidateScript(script); err != nil {
+		return fmt.Errorf("invalid mvx-shell script: %w", err)
+	}
+	return nil
+}
 
+// validatePipeline checks that all steps of a pipeline reference existing, non-pipeline commands
+func (c *Config) validatePipeline(cmdName string, steps []string) error {
+	if len(steps) < 2 {
+		return fmt.Errorf("command %s: pipeline requires at least 2 steps", cmdName)
+	}
+	for _, step := range steps {
+		stepConfig, exists := c.Commands[step]
+		if !exists {
+			return fmt.Errorf("command %s: pipeline step '%s' is not a defined command", cmdName, step)
+		}
+		if len(stepConfig.Pipeline) > 0 {
+			return fmt.Errorf("command %s: pipeline step '%s' is itself a pipeline", cmdName, step)
+		}
+	}
 	return nil
 }
 
+// GetDefaultCommand returns the command to run when mvx is invoked without a subcommand.
+// It is either project.default_command or a command named "default", or empty if none.
+func (c *Config) GetDefaultCommand() string {
+	if c.Project.DefaultCommand != "" {
+		return c.Project.DefaultCommand
+	}
+	if _, exists := c.Commands["default"]; exists {
+		return "default"
+	}
+	return ""
+}
+
+// CommandsRequiringTool returns the names of the commands listing a tool in their requires, sorted
+func (c *Config) CommandsRequiringTool(toolName string) []string {
+	var commands []string
+	for name, cmd := range c.Commands {
+		for _, required := range cmd.Requires {
+			if required == toolName {
+				commands = append(commands, name)
+				break
+			}
+		}
+	}
+	sort.Strings(commands)
+	return commands
+}
+
 // GetRequiredTools returns a list of tools required for a specific command
 func (c *Config) GetRequiredTools(commandName string) []string {
 	if cmd, exists := c.Commands[commandName]; exists {
@@ -435,3 +883,130 @@ func HasValidScript(script interface{}) bool {
 		return false
 	}
 }
+
+// HookScript is a script run before or after a command
+type HookScript struct {
+	Script      string
+	Interpreter string
+}
+
+// ResolveHookScripts resolves a pre or post hook to the scripts to run on the current platform.
+// A hook is a script, as accepted by the script field, an object with script and interpreter
+// fields, or a list of those. Scripts default to the interpreter of their command.
+func ResolveHookScripts(hook interface{}, defaultInterpreter string) ([]HookScript, error) {
+	return (&Config{}).ResolveHookScripts(hook, defaultInterpreter)
+}
+
+// ResolveHookScripts resolves a pre or post hook like the ResolveHookScripts function, the
+// scripts also being the name of a hook of the configuration or an object with a uses field
+// naming it. The scripts of named hooks default to the interpreter of the command using them.
+func (c *Config) ResolveHookScripts(hook interface{}, defaultInterpreter string) ([]HookScript, error) {
+	var scripts []HookScript
+	for _, item := range hookItems(hook) {
+		script, interpreter := hookItemScript(item, defaultInterpreter)
+		if hookConfig, named, err := c.namedHook(item); err != nil {
+			return nil, err
+		} else if named {
+			script, interpreter = hookConfig.Script, hookConfig.Interpreter
+			if interpreter == "" {
+				interpreter = defaultInterpreter
+			}
+		}
+		resolved, resolvedInterpreter, err := ResolvePlatformScriptWithInterpreter(script, interpreter)
+		if err != nil {
+			return nil, err
+		}
+		scripts = append(scripts, HookScript{Script: resolved, Interpreter: resolvedInterpreter})
+	}
+	return scripts, nil
+}
+
+// hookItems returns the scripts of a hook, which is either a single script or a list
+func hookItems(hook interface{}) []interface{} {
+	switch h := hook.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		return h
+	case []string:
+		items := make([]interface{}, len(h))
+		for i, script := range h {
+			items[i] = script
+		}
+		return items
+	default:
+		return []interface{}{h}
+	}
+}
+
+// hookItemScript unwraps a hook script given as an object with script and interpreter fields
+func hookItemScript(item interface{}, defaultInterpreter string) (interface{}, string) {
+	if fields, ok := item.(map[string]interface{}); ok {
+		if script, exists := fields["script"]; exists {
+			if interpreter, ok := fields["interpreter"].(string); ok {
+				return script, interpreter
+			}
+			return script, defaultInterpreter
+		}
+	}
+	return item, defaultInterpreter
+}
+
+// namedHook returns the hook a pre or post hook item refers to: either the name of a hook, or an
+// object with a uses field. It reports whether the item is such a reference.
+func (c *Config) namedHook(item interface{}) (CommandConfig, bool, error) {
+	switch i := item.(type) {
+	case string:
+		hookConfig, exists := c.Hooks[i]
+		return hookConfig, exists, nil
+	case map[string]interface{}:
+		uses, exists := i["uses"]
+		if !exists {
+			return CommandConfig{}, false, nil
+		}
+		name, _ := uses.(string)
+		hookConfig, exists := c.Hooks[name]
+		if !exists {
+			return CommandConfig{}, false, fmt.Errorf("hook '%v' is not defined", uses)
+		}
+		return hookConfig, true, nil
+	}
+	return CommandConfig{}, false, nil
+}
+
+// ResolveCommandScript resolves the script of a command, or of the hook it uses, and its
+// interpreter for the current platform
+func (c *Config) ResolveCommandScript(cmdConfig CommandConfig) (string, string, error) {
+	script, interpreter := cmdConfig.Script, cmdConfig.Interpreter
+	if cmdConfig.Uses != "" {
+		hookConfig, exists := c.Hooks[cmdConfig.Uses]
+		if !exists {
+			return "", "", fmt.Errorf("hook '%s' is not defined", cmdConfig.Uses)
+		}
+		script = hookConfig.Script
+		if interpreter == "" {
+			interpreter = hookConfig.Interpreter
+		}
+	}
+	return ResolvePlatformScriptWithInterpreter(script, interpreter)
+}
+
+// validateHook checks that every script of a hook is defined, and that the named hooks it
+// references exist
+func (c *Config) validateHook(name string, hook interface{}) error {
+	for _, item := range hookItems(hook) {
+		if _, named, err := c.namedHook(item); err != nil {
+			return fmt.Errorf("%s hook: %w", name, err)
+		} else if named {
+			continue
+		}
+		script, interpreter := hookItemScript(item, "")
+		if !HasValidScript(script) {
+			return fmt.Errorf("%s hook: script is required", name)
+		}
+		if interpreter != "" && interpreter != "native" && interpreter != "mvx-shell" {
+			return fmt.Errorf("%s hook: invalid interpreter '%s', must be 'native' or 'mvx-shell'", name, interpreter)
+		}
+	}
+	return nil
+}
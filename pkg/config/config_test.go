@@ -0,0 +1,657 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValidatePipeline(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands map[string]CommandConfig
+		wantErr  string
+	}{
+		{
+			name: "valid pipeline",
+			commands: map[string]CommandConfig{
+				"generate": {Script: "echo b a"},
+				"format":   {Script: "sort"},
+				"pipe":     {Pipeline: []string{"generate", "format"}},
+			},
+		},
+		{
+			name: "unknown step",
+			commands: map[string]CommandConfig{
+				"generate": {Script: "echo b a"},
+				"pipe":     {Pipeline: []string{"generate", "missing"}},
+			},
+			wantErr: "pipeline step 'missing' is not a defined command",
+		},
+		{
+			name: "nested pipeline",
+			commands: map[string]CommandConfig{
+				"generate": {Script: "echo b a"},
+				"format":   {Script: "sort"},
+				"inner":    {Pipeline: []string{"generate", "format"}},
+				"outer":    {Pipeline: []string{"inner", "format"}},
+			},
+			wantErr: "is itself a pipeline",
+		},
+		{
+			name: "single step",
+			commands: map[string]CommandConfig{
+				"generate": {Script: "echo b a"},
+				"pipe":     {Pipeline: []string{"generate"}},
+			},
+			wantErr: "at least 2 steps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:  ProjectConfig{Name: "test"},
+				Commands: tt.commands,
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMVXShellScripts(t *testing.T) {
+	tests := []struct {
+		name    string
+		command CommandConfig
+		wantErr string
+	}{
+		{
+			name:    "valid mvx-shell script",
+			command: CommandConfig{Script: "mkdir dist && echo done", Interpreter: "mvx-shell"},
+		},
+		{
+			name:    "command substitution with explicit mvx-shell",
+			command: CommandConfig{Script: "echo $(git describe --tags)", Interpreter: "mvx-shell"},
+		},
+		{
+			name:    "unterminated command substitution",
+			command: CommandConfig{Script: "echo $(git describe --tags", Interpreter: "mvx-shell"},
+			wantErr: "command deploy: invalid mvx-shell script: unterminated command substitution",
+		},
+		{
+			name:    "arithmetic expansion",
+			command: CommandConfig{Script: "echo $((1+2))", Interpreter: "mvx-shell"},
+			wantErr: "arithmetic expansion",
+		},
+		{
+			name:    "backticks in default interpreter string script",
+			command: CommandConfig{Script: "echo `date`"},
+			wantErr: "command substitution",
+		},
+		{
+			name:    "heredoc",
+			command: CommandConfig{Script: "cat <<EOF", Interpreter: "mvx-shell"},
+			wantErr: "heredocs",
+		},
+		{
+			name:    "unterminated quote",
+			command: CommandConfig{Script: "echo 'oops", Interpreter: "mvx-shell"},
+			wantErr: "unterminated quote",
+		},
+		{
+			name:    "substitution in single quotes is literal",
+			command: CommandConfig{Script: "echo '$(not run)'", Interpreter: "mvx-shell"},
+		},
+		{
+			name:    "native interpreter is not checked",
+			command: CommandConfig{Script: "echo $(git describe --tags)", Interpreter: "native"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:  ProjectConfig{Name: "test"},
+				Commands: map[string]CommandConfig{"deploy": tt.command},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetDefaultCommand(t *testing.T) {
+	tests := []struct {
+		name     string
+		project  ProjectConfig
+		commands map[string]CommandConfig
+		expected string
+		wantErr  string
+	}{
+		{
+			name:     "no default command",
+			project:  ProjectConfig{Name: "test"},
+			commands: map[string]CommandConfig{"build": {Script: "echo build"}},
+		},
+		{
+			name:     "explicit default command",
+			project:  ProjectConfig{Name: "test", DefaultCommand: "build"},
+			commands: map[string]CommandConfig{"build": {Script: "echo build"}, "default": {Script: "echo default"}},
+			expected: "build",
+		},
+		{
+			name:     "command named default",
+			project:  ProjectConfig{Name: "test"},
+			commands: map[string]CommandConfig{"default": {Script: "echo default"}},
+			expected: "default",
+		},
+		{
+			name:     "unknown default command",
+			project:  ProjectConfig{Name: "test", DefaultCommand: "missing"},
+			commands: map[string]CommandConfig{"build": {Script: "echo build"}},
+			expected: "missing",
+			wantErr:  "'missing' is not a defined command",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Project: tt.project, Commands: tt.commands}
+			if got := cfg.GetDefaultCommand(); got != tt.expected {
+				t.Errorf("GetDefaultCommand() = %q, want %q", got, tt.expected)
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommandSupportsPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		command  CommandConfig
+		goos     string
+		goarch   string
+		expected bool
+	}{
+		{"no constraint", CommandConfig{}, "linux", "amd64", true},
+		{"matching os", CommandConfig{OS: []string{"darwin"}}, "darwin", "arm64", true},
+		{"macos alias", CommandConfig{OS: []string{"macOS"}}, "darwin", "amd64", true},
+		{"other os", CommandConfig{OS: []string{"darwin"}}, "linux", "amd64", false},
+		{"matching os and arch", CommandConfig{OS: []string{"linux", "darwin"}, Arch: []string{"arm64"}}, "linux", "arm64", true},
+		{"other arch", CommandConfig{Arch: []string{"arm64"}}, "darwin", "amd64", false},
+		{"arch alias", CommandConfig{Arch: []string{"x64"}}, "windows", "amd64", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.command.SupportsPlatform(tt.goos, tt.goarch); got != tt.expected {
+				t.Errorf("SupportsPlatform(%s, %s) = %v, want %v", tt.goos, tt.goarch, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidatePlatformConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		command CommandConfig
+		wantErr string
+	}{
+		{"valid", CommandConfig{Script: "echo", OS: []string{"macos"}, Arch: []string{"arm64"}}, ""},
+		{"unknown os", CommandConfig{Script: "echo", OS: []string{"osx"}}, "invalid os 'osx'"},
+		{"unknown arch", CommandConfig{Script: "echo", Arch: []string{"sparc"}}, "invalid arch 'sparc'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:  ProjectConfig{Name: "test"},
+				Commands: map[string]CommandConfig{"notarize": tt.command},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		command CommandConfig
+		wantErr string
+	}{
+		{"retries with delay", CommandConfig{Script: "echo", Retries: 2, RetryDelay: "5s"}, ""},
+		{"retries without delay", CommandConfig{Script: "echo", Retries: 2}, ""},
+		{"negative retries", CommandConfig{Script: "echo", Retries: -1}, "invalid retries -1"},
+		{"invalid delay", CommandConfig{Script: "echo", Retries: 1, RetryDelay: "soon"}, "invalid retry_delay 'soon'"},
+		{"delay without retries", CommandConfig{Script: "echo", RetryDelay: "5s"}, "retry_delay is set but retries is not"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:  ProjectConfig{Name: "test"},
+				Commands: map[string]CommandConfig{"flaky": tt.command},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateArgs(t *testing.T) {
+	args := func(names ...string) []CommandArgConfig {
+		var declared []CommandArgConfig
+		for _, name := range names {
+			declared = append(declared, CommandArgConfig{Name: name})
+		}
+		return declared
+	}
+	tests := []struct {
+		name    string
+		command CommandConfig
+		wantErr string
+	}{
+		{"plain names", CommandConfig{Script: "echo", Args: args("env", "dry-run")}, ""},
+		{"missing name", CommandConfig{Script: "echo", Args: args("")}, "argument name is required"},
+		{"PATH", CommandConfig{Script: "echo", Args: args("PATH")}, "would replace the PATH environment variable"},
+		{"lowercase path", CommandConfig{Script: "echo", Args: args("path")}, "would replace the PATH environment variable"},
+		{"home", CommandConfig{Script: "echo", Args: args("home")}, "would replace the HOME environment variable"},
+		{"mvx prefix", CommandConfig{Script: "echo", Args: args("mvx_verbose")}, "MVX_ prefix"},
+		{"command environment", CommandConfig{Script: "echo", Args: args("profile"), Environment: map[string]string{"PROFILE": "dev"}}, "collides with the environment variable PROFILE"},
+		{"project environment", CommandConfig{Script: "echo", Args: args("region")}, "collides with the environment variable REGION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:     ProjectConfig{Name: "test"},
+				Environment: map[string]string{"REGION": "eu"},
+				Commands:    map[string]CommandConfig{"deploy": tt.command},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings SettingsConfig
+		wantErr  string
+	}{
+		{"valid settings", SettingsConfig{ParallelDownloads: 4, HTTPTimeout: "3m"}, ""},
+		{"negative parallel downloads", SettingsConfig{ParallelDownloads: -1}, "invalid parallelDownloads -1"},
+		{"invalid timeout", SettingsConfig{HTTPTimeout: "slow"}, "invalid httpTimeout 'slow'"},
+		{"zero timeout", SettingsConfig{HTTPTimeout: "0s"}, "must be positive"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Project: ProjectConfig{Name: "test"}, Settings: &tt.settings}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		command CommandConfig
+		wantErr string
+	}{
+		{"confirm", CommandConfig{Script: "echo", Confirm: "Deploy to production?"}, ""},
+		{"refuse when non-interactive", CommandConfig{Script: "echo", Confirm: "Drop the database?", ConfirmNonInteractive: ConfirmRefuse}, ""},
+		{"invalid non-interactive behavior", CommandConfig{Script: "echo", Confirm: "Sure?", ConfirmNonInteractive: "ask"}, "invalid confirm_non_interactive 'ask'"},
+		{"non-interactive behavior without confirm", CommandConfig{Script: "echo", ConfirmNonInteractive: ConfirmRefuse}, "confirm_non_interactive is set but confirm is not"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:  ProjectConfig{Name: "test"},
+				Commands: map[string]CommandConfig{"deploy": tt.command},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateHooks(t *testing.T) {
+	tests := []struct {
+		name    string
+		command CommandConfig
+		wantErr string
+	}{
+		{"string hooks", CommandConfig{Script: "echo", Pre: "echo pre", Post: "echo post"}, ""},
+		{"list hook", CommandConfig{Script: "echo", Post: []interface{}{"echo a", "echo b"}}, ""},
+		{"object hook", CommandConfig{Script: "echo", Pre: map[string]interface{}{"script": "echo pre", "interpreter": "mvx-shell"}}, ""},
+		{"platform hook", CommandConfig{Script: "echo", Pre: map[string]interface{}{"unix": "echo pre", "windows": "echo pre"}}, ""},
+		{"empty hook", CommandConfig{Script: "echo", Pre: ""}, "pre hook: script is required"},
+		{"invalid interpreter", CommandConfig{Script: "echo", Post: map[string]interface{}{"script": "echo", "interpreter": "bash"}}, "post hook: invalid interpreter 'bash'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:  ProjectConfig{Name: "test"},
+				Commands: map[string]CommandConfig{"build": tt.command},
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNamedHooks(t *testing.T) {
+	hooks := map[string]CommandConfig{
+		"lint":   {Script: "mvn spotless:check", Interpreter: "native"},
+		"notify": {Script: "echo done"},
+	}
+	tests := []struct {
+		name    string
+		command CommandConfig
+		hooks   map[string]CommandConfig
+		wantErr string
+	}{
+		{"hook names", CommandConfig{Script: "mvn verify", Pre: "lint", Post: []interface{}{"notify", "echo inline"}}, hooks, ""},
+		{"uses in hooks", CommandConfig{Script: "mvn verify", Pre: map[string]interface{}{"uses": "lint"}}, hooks, ""},
+		{"undefined uses in hook", CommandConfig{Script: "mvn verify", Post: []interface{}{map[string]interface{}{"uses": "deploy"}}}, hooks, "post hook: hook 'deploy' is not defined"},
+		{"command uses", CommandConfig{Uses: "lint"}, hooks, ""},
+		{"undefined command uses", CommandConfig{Uses: "format"}, hooks, "uses 'format', which is not a defined hook"},
+		{"script and uses", CommandConfig{Script: "mvn verify", Uses: "lint"}, hooks, "script and uses cannot both be set"},
+		{"hook using a hook", CommandConfig{Uses: "lint"}, map[string]CommandConfig{"lint": {Script: "mvn", Pre: "echo"}}, "hook lint: hooks cannot use other hooks"},
+		{"hook without script", CommandConfig{Uses: "lint"}, map[string]CommandConfig{"lint": {}}, "hook lint: script is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Project:  ProjectConfig{Name: "test"},
+				Commands: map[string]CommandConfig{"build": tt.command},
+				Hooks:    tt.hooks,
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	cfg := &Config{Hooks: hooks}
+	scripts, err := cfg.ResolveHookScripts([]interface{}{"lint", map[string]interface{}{"uses": "notify"}, "echo inline"}, "mvx-shell")
+	if err != nil {
+		t.Fatalf("ResolveHookScripts() error = %v", err)
+	}
+	expected := []HookScript{
+		{Script: "mvn spotless:check", Interpreter: "native"},
+		{Script: "echo done", Interpreter: "mvx-shell"},
+		{Script: "echo inline", Interpreter: "mvx-shell"},
+	}
+	if !reflect.DeepEqual(scripts, expected) {
+		t.Errorf("ResolveHookScripts() = %+v, want %+v", scripts, expected)
+	}
+}
+
+func TestValidateCommandTools(t *testing.T) {
+	cfg := &Config{
+		Project: ProjectConfig{Name: "test"},
+		Commands: map[string]CommandConfig{
+			"legacy-it": {Script: "mvn verify", Tools: map[string]ToolConfig{"java": {}}},
+		},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "tool java: version is required") {
+		t.Errorf("Validate() error = %v, want a missing version error", err)
+	}
+
+	cfg.Commands["legacy-it"] = CommandConfig{Script: "mvn verify", Tools: map[string]ToolConfig{"java": {Version: "8"}}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func TestIsRequiredFor(t *testing.T) {
+	tests := []struct {
+		requiredFor []string
+		command     string
+		expected    bool
+	}{
+		{requiredFor: nil, command: "build", expected: true},
+		{requiredFor: []string{"website"}, command: "website", expected: true},
+		{requiredFor: []string{"website"}, command: "setup", expected: false},
+		{requiredFor: []string{"website", "setup"}, command: "setup", expected: true},
+	}
+	for _, tt := range tests {
+		toolConfig := ToolConfig{Version: "1.0.0", RequiredFor: tt.requiredFor}
+		if got := toolConfig.IsRequiredFor(tt.command); got != tt.expected {
+			t.Errorf("IsRequiredFor(%q) with required_for %v = %v, want %v", tt.command, tt.requiredFor, got, tt.expected)
+		}
+	}
+}
+
+func TestEnvFilePaths(t *testing.T) {
+	projectRoot := t.TempDir()
+	mvxDir := filepath.Join(projectRoot, ".mvx")
+	if err := os.MkdirAll(mvxDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	absolute := filepath.Join(t.TempDir(), "shared.env")
+	content := `{project: {name: "demo"}, env_files: [".env", ` + strconv.Quote(absolute) + `]}`
+	if err := os.WriteFile(filepath.Join(mvxDir, "config.json5"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	expected := []string{filepath.Join(projectRoot, ".env"), absolute}
+	if paths := cfg.EnvFilePaths(); !slices.Equal(paths, expected) {
+		t.Errorf("EnvFilePaths() = %v, want %v", paths, expected)
+	}
+}
+
+func TestLocalArchive(t *testing.T) {
+	projectRoot := t.TempDir()
+	mvxDir := filepath.Join(projectRoot, ".mvx")
+	if err := os.MkdirAll(filepath.Join(projectRoot, "archives"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(mvxDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(projectRoot, "archives", "jdk-21.tar.gz")
+	if err := os.WriteFile(archive, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	content := `{
+		project: {name: "demo"},
+		tools: {
+			java: {version: "21", archive: "archives/jdk-21.tar.gz"},
+			maven: {version: "3.9.6", archive: "archives/maven-3.9.6.zip"},
+			go: {version: "1.23.1"}
+		}
+	}`
+	if err := os.WriteFile(filepath.Join(mvxDir, "config.json5"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Load from another directory, the archive is relative to the project root
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
+	cfg, err := LoadConfig(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	expected := map[string]string{"java": archive, "maven": "", "go": ""}
+	for tool, want := range expected {
+		if got := cfg.Tools[tool].LocalArchive(); got != want {
+			t.Errorf("%s LocalArchive() = %q, want %q", tool, got, want)
+		}
+	}
+	if got := cfg.Tools["java"].Archive; got != "archives/jdk-21.tar.gz" {
+		t.Errorf("java Archive = %q, want the configured relative path", got)
+	}
+}
+
+func TestTOMLConfigRoundTrip(t *testing.T) {
+	projectRoot := t.TempDir()
+	mvxDir := filepath.Join(projectRoot, ".mvx")
+	if err := os.MkdirAll(mvxDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `[project]
+name = "demo"
+
+[tools.java]
+version = "21"
+distribution = "temurin"
+
+[tools.maven]
+version = "3.9.9"
+
+[environment]
+MAVEN_OPTS = "-Xmx1g"
+
+[commands.build]
+description = "Build the project"
+script = "mvn package"
+
+[commands.clean]
+description = "Clean the project"
+
+[commands.clean.script]
+unix = "rm -rf target"
+windows = "rmdir /s /q target"
+`
+	if err := os.WriteFile(filepath.Join(mvxDir, "config.toml"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadConfig(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := GetProjectConfigPath(projectRoot); got != filepath.Join(mvxDir, "config.toml") {
+		t.Errorf("GetProjectConfigPath() = %q, want the config.toml", got)
+	}
+	if err := SaveConfig(cfg, projectRoot); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(mvxDir, "config.json5")); err == nil {
+		t.Errorf("SaveConfig() wrote a config.json5 next to the config.toml")
+	}
+
+	reloaded, err := LoadConfig(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadConfig() after SaveConfig() error = %v", err)
+	}
+	if !reflect.DeepEqual(reloaded, cfg) {
+		t.Errorf("round-tripped configuration = %+v, want %+v", reloaded, cfg)
+	}
+	script, ok := reloaded.Commands["clean"].Script.(map[string]interface{})
+	if !ok || script["windows"] != "rmdir /s /q target" || script["unix"] != "rm -rf target" {
+		t.Errorf("clean script = %#v, want the platform-specific scripts", reloaded.Commands["clean"].Script)
+	}
+}
+
+func TestValidateToolEnv(t *testing.T) {
+	cfg := &Config{
+		Project: ProjectConfig{Name: "test"},
+		Tools:   map[string]ToolConfig{"rust": {Version: "1.81.0", Env: map[string]string{"RUSTFLAGS=": "-g"}}},
+	}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid environment variable name") {
+		t.Errorf("Validate() error = %v, want an invalid name error", err)
+	}
+
+	cfg.Tools["rust"] = ToolConfig{Version: "1.81.0", Env: map[string]string{"RUSTFLAGS": "-g"}}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvStrictEnv makes references to undefined environment variables in tool versions and
+// distributions an error instead of expanding them to an empty string
+const EnvStrictEnv = "MVX_STRICT_ENV"
+
+// toolSpec records a tool configuration as written, before its variables were expanded
+type toolSpec struct {
+	raw      ToolConfig
+	expanded ToolConfig
+}
+
+// isStrictEnv returns true if undefined variables in tool specifications are errors
+func isStrictEnv() bool {
+	return os.Getenv(EnvStrictEnv) == "true"
+}
+
+// expandToolVariables expands environment variables in the version and distribution of the
+// project tools and of the tools overridden by commands, e.g. version: "${JAVA_VERSION:-21}".
+// The configuration as written is kept, so that saving it does not replace references with
+// the values of the current environment.
+func (c *Config) expandToolVariables(strict bool) error {
+	for toolName, toolConfig := range c.Tools {
+		expanded, err := c.expandToolConfig(toolSpecKey("", toolName), toolConfig, strict)
+		if err != nil {
+			return fmt.Errorf("tool %s: %w", toolName, err)
+		}
+		c.Tools[toolName] = expanded
+	}
+	for cmdName, cmdConfig := range c.Commands {
+		for toolName, toolConfig := range cmdConfig.Tools {
+			expanded, err := c.expandToolConfig(toolSpecKey(cmdName, toolName), toolConfig, strict)
+			if err != nil {
+				return fmt.Errorf("command %s: tool %s: %w", cmdName, toolName, err)
+			}
+			cmdConfig.Tools[toolName] = expanded
+		}
+	}
+	return nil
+}
+
+// expandToolConfig expands the variables of a tool configuration, remembering it under key
+// when it references any
+func (c *Config) expandToolConfig(key string, toolConfig ToolConfig, strict bool) (ToolConfig, error) {
+	expanded := toolConfig
+	var err error
+	if expanded.Version, err = expandEnvReferences(toolConfig.Version, strict); err != nil {
+		return toolConfig, fmt.Errorf("version: %w", err)
+	}
+	if expanded.Distribution, err = expandEnvReferences(toolConfig.Distribution, strict); err != nil {
+		return toolConfig, fmt.Errorf("distribution: %w", err)
+	}
+
+	if expanded.Version != toolConfig.Version || expanded.Distribution != toolConfig.Distribution {
+		if c.toolSpecs == nil {
+			c.toolSpecs = make(map[string]toolSpec)
+		}
+		c.toolSpecs[key] = toolSpec{raw: toolConfig, expanded: expanded}
+	}
+	return expanded, nil
+}
+
+// toolSpecKey identifies a project tool (empty cmdName) or a tool overridden by a command
+func toolSpecKey(cmdName, toolName string) string {
+	return cmdName + "/" + toolName
+}
+
+// withUnexpandedTools returns a copy of the configuration in which tools still set to the
+// values their variables expanded to are written as they were in the configuration file
+func (c *Config) withUnexpandedTools() *Config {
+	if len(c.toolSpecs) == 0 {
+		return c
+	}
+
+	result := *c
+	result.Tools = make(map[string]ToolConfig, len(c.Tools))
+	for toolName, toolConfig := range c.Tools {
+		result.Tools[toolName] = c.unexpandedTool(toolSpecKey("", toolName), toolConfig)
+	}
+	result.Commands = make(map[string]CommandConfig, len(c.Commands))
+	for cmdName, cmdConfig := range c.Commands {
+		if len(cmdConfig.Tools) > 0 {
+			tools := make(map[string]ToolConfig, len(cmdConfig.Tools))
+			for toolName, toolConfig := range cmdConfig.Tools {
+				tools[toolName] = c.unexpandedTool(toolSpecKey(cmdName, toolName), toolConfig)
+			}
+			cmdConfig.Tools = tools
+		}
+		result.Commands[cmdName] = cmdConfig
+	}
+	return &result
+}
+
+// unexpandedTool restores the version and distribution of a tool as written, unless they were
+// changed since the configuration was loaded
+func (c *Config) unexpandedTool(key string, toolConfig ToolConfig) ToolConfig {
+	spec, ok := c.toolSpecs[key]
+	if !ok {
+		return toolConfig
+	}
+	if toolConfig.Version == spec.expanded.Version {
+		toolConfig.Version = spec.raw.Version
+	}
+	if toolConfig.Distribution == spec.expanded.Distribution {
+		toolConfig.Distribution = spec.raw.Distribution
+	}
+	return toolConfig
+}
+
+// expandEnvReferences expands $VAR, ${VAR} and ${VAR:-default} references from the
+// environment. In strict mode, referencing an undefined variable without a default is an error.
+func expandEnvReferences(value string, strict bool) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var undefined []string
+	expanded := os.Expand(value, func(reference string) string {
+		name, defaultValue, hasDefault := strings.Cut(reference, ":-")
+		if val, ok := os.LookupEnv(name); ok && (val != "" || !hasDefault) {
+			return val
+		}
+		if hasDefault {
+			return defaultValue
+		}
+		undefined = append(undefined, name)
+		return ""
+	})
+
+	if strict && len(undefined) > 0 {
+		sort.Strings(undefined)
+		return "", fmt.Errorf("undefined environment variable %s (%s=true)", strings.Join(undefined, ", "), EnvStrictEnv)
+	}
+	return expanded, nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExpandEnvReferences(t *testing.T) {
+	t.Setenv("MVX_TEST_JAVA_VERSION", "17")
+	t.Setenv("MVX_TEST_EMPTY", "")
+
+	tests := []struct {
+		value    string
+		strict   bool
+		expected string
+		err      string
+	}{
+		{value: "21", expected: "21"},
+		{value: "${MVX_TEST_JAVA_VERSION}", expected: "17"},
+		{value: "$MVX_TEST_JAVA_VERSION.0.2", expected: "17.0.2"},
+		{value: "${MVX_TEST_UNDEFINED:-21}", strict: true, expected: "21"},
+		{value: "${MVX_TEST_EMPTY:-21}", strict: true, expected: "21"},
+		{value: "${MVX_TEST_EMPTY}", strict: true, expected: ""},
+		{value: "${MVX_TEST_UNDEFINED}", expected: ""},
+		{value: "${MVX_TEST_UNDEFINED}", strict: true, err: "undefined environment variable MVX_TEST_UNDEFINED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := expandEnvReferences(tt.value, tt.strict)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("expandEnvReferences(%q) error = %v, want %q", tt.value, err, tt.err)
+				}
+				return
+			}
+			if err != nil || got != tt.expected {
+				t.Errorf("expandEnvReferences(%q) = %q, %v, want %q", tt.value, got, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadConfigExpandsToolVariables(t *testing.T) {
+	projectRoot := t.TempDir()
+	mvxDir := filepath.Join(projectRoot, ".mvx")
+	if err := os.MkdirAll(mvxDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{
+  project: { name: "matrix" },
+  tools: {
+    java: { version: "${JAVA_VERSION}", distribution: "${JAVA_DIST:-temurin}" },
+    maven: { version: "3.9.9" },
+  },
+  commands: {
+    legacy: { script: "mvn verify", tools: { java: { version: "${LEGACY_JAVA_VERSION:-8}" } } },
+  },
+}`
+	if err := os.WriteFile(filepath.Join(mvxDir, "config.json5"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("JAVA_VERSION", "17")
+	cfg, err := LoadConfig(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if java := cfg.Tools["java"]; java.Version != "17" || java.Distribution != "temurin" {
+		t.Errorf("java = %+v, want version 17 and distribution temurin", java)
+	}
+	if java := cfg.Commands["legacy"].Tools["java"]; java.Version != "8" {
+		t.Errorf("legacy java = %+v, want version 8", java)
+	}
+
+	// Saving keeps the references rather than the values of the current environment
+	if err := SaveConfig(cfg, projectRoot); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	saved, err := os.ReadFile(filepath.Join(mvxDir, "config.json5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, reference := range []string{"${JAVA_VERSION}", "${JAVA_DIST:-temurin}", "${LEGACY_JAVA_VERSION:-8}"} {
+		if !strings.Contains(string(saved), reference) {
+			t.Errorf("saved configuration lost %s:\n%s", reference, saved)
+		}
+	}
+
+	// In strict mode, an undefined variable without default is an error
+	os.Unsetenv("JAVA_VERSION")
+	t.Setenv(EnvStrictEnv, "true")
+	if _, err := LoadConfig(projectRoot); err == nil || !strings.Contains(err.Error(), "tool java: version: undefined environment variable JAVA_VERSION") {
+		t.Errorf("LoadConfig() error = %v, want an undefined JAVA_VERSION error", err)
+	}
+}
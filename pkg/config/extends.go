@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// loadExtendedConfigFile parses a configuration file and merges it over the files it extends,
+// which are loaded first, recursively. Relative paths are resolved against the directory of the
+// extending file. including lists the files being loaded, to detect cycles.
+func loadExtendedConfigFile(path string, including []string) (*Config, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	for i, included := range including {
+		if included == absPath {
+			cycle := append(append([]string{}, including[i:]...), absPath)
+			return nil, fmt.Errorf("configuration extends itself: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	including = append(including, absPath)
+
+	config, err := parseConfigFile(absPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(config.Extends) == 0 {
+		return config, nil
+	}
+
+	merged := &Config{}
+	for _, base := range config.Extends {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(absPath), base)
+		}
+		baseConfig, err := loadExtendedConfigFile(base, including)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s extended by %s: %w", base, path, err)
+		}
+		mergeConfig(merged, baseConfig)
+	}
+	mergeConfig(merged, config)
+	merged.Extends = config.Extends
+	return merged, nil
+}
+
+// mergeConfig merges src over dst. Maps, such as tools, commands, environment and the options
+// of a tool, are merged key by key, and the entries present in both, like settings, are merged
+// field by field.
+// Other values set in src, such as versions, scripts and lists, replace those of dst. Empty
+// values, including false, leave those of dst unchanged.
+func mergeConfig(dst, src *Config) {
+	mergeValue(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem())
+}
+
+func mergeValue(dst, src reflect.Value) {
+	if src.IsZero() {
+		return
+	}
+	switch src.Kind() {
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				mergeValue(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(src)
+			return
+		}
+		merged := reflect.New(src.Elem().Type())
+		merged.Elem().Set(dst.Elem())
+		mergeValue(merged.Elem(), src.Elem())
+		dst.Set(merged)
+	case reflect.Map:
+		if dst.IsNil() {
+			dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		}
+		iter := src.MapRange()
+		for iter.Next() {
+			value := iter.Value()
+			if existing := dst.MapIndex(iter.Key()); existing.IsValid() && value.Kind() == reflect.Struct {
+				merged := reflect.New(value.Type()).Elem()
+				merged.Set(existing)
+				mergeValue(merged, value)
+				value = merged
+			}
+			dst.SetMapIndex(iter.Key(), value)
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestExtends(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "shared", "base.json5"), `{
+		tools: {
+			java: {version: "21", distribution: "temurin", options: {mavenToolchains: "true"}},
+			maven: {version: "3.9.6"},
+		},
+		environment: {MAVEN_OPTS: "-Xmx1g", CI: "false"},
+		commands: {build: {description: "Build", script: "mvn package"}},
+		settings: {parallelDownloads: 2, httpTimeout: "3m"},
+	}`)
+	writeConfigFile(t, filepath.Join(root, "shared", "java17.yml"), `
+tools:
+  java:
+    version: "17"
+`)
+	projectRoot := filepath.Join(root, "module")
+	writeConfigFile(t, filepath.Join(projectRoot, ".mvx", "config.json5"), `{
+		extends: ["../../shared/base.json5", "../../shared/java17.yml"],
+		project: {name: "module"},
+		tools: {maven: {version: "3.9.9"}},
+		environment: {CI: "true"},
+		commands: {build: {script: "mvn verify"}},
+		settings: {parallelDownloads: 4},
+	}`)
+
+	cfg, err := LoadConfig(projectRoot)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	java := cfg.Tools["java"]
+	if java.Version != "17" || java.Distribution != "temurin" || java.Options["mavenToolchains"] != "true" {
+		t.Errorf("java = %+v, want version 17 from java17.yml and the rest from base.json5", java)
+	}
+	if version := cfg.Tools["maven"].Version; version != "3.9.9" {
+		t.Errorf("maven version = %q, want the module's 3.9.9", version)
+	}
+	if cfg.Environment["MAVEN_OPTS"] != "-Xmx1g" || cfg.Environment["CI"] != "true" {
+		t.Errorf("environment = %v, want MAVEN_OPTS from the base and CI from the module", cfg.Environment)
+	}
+	build := cfg.Commands["build"]
+	if build.Description != "Build" || build.Script != "mvn verify" {
+		t.Errorf("build = %+v, want the base description and the module script", build)
+	}
+	if settings := cfg.Settings; settings == nil || settings.ParallelDownloads != 4 || settings.HTTPTimeout != "3m" {
+		t.Errorf("settings = %+v, want parallelDownloads from the module and httpTimeout from the base", settings)
+	}
+	if cfg.Project.Name != "module" {
+		t.Errorf("project name = %q, want module", cfg.Project.Name)
+	}
+
+	if err := SaveConfig(cfg, projectRoot); err == nil {
+		t.Errorf("SaveConfig() succeeded, want an error for a configuration extending others")
+	}
+}
+
+func TestExtendsCycle(t *testing.T) {
+	root := t.TempDir()
+	writeConfigFile(t, filepath.Join(root, "a.json5"), `{extends: ["b.json5"]}`)
+	writeConfigFile(t, filepath.Join(root, "b.json5"), `{extends: ["a.json5"]}`)
+	projectRoot := filepath.Join(root, "project")
+	writeConfigFile(t, filepath.Join(projectRoot, ".mvx", "config.json5"), `{
+		extends: ["../../a.json5"],
+		project: {name: "cycle"},
+	}`)
+
+	_, err := LoadConfig(projectRoot)
+	if err == nil || !strings.Contains(err.Error(), "extends itself") {
+		t.Fatalf("LoadConfig() error = %v, want a cycle error", err)
+	}
+	if !strings.Contains(err.Error(), filepath.Join(root, "a.json5")+" -> "+filepath.Join(root, "b.json5")) {
+		t.Errorf("error %q does not show the cycle", err)
+	}
+}
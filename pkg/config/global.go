@@ -5,12 +5,14 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 )
 
 // GlobalConfig represents the global mvx configuration
 type GlobalConfig struct {
-	URLReplacements map[string]string `json:"url_replacements,omitempty" yaml:"url_replacements,omitempty"`
+	URLReplacements map[string]string   `json:"url_replacements,omitempty" yaml:"url_replacements,omitempty"`
+	Mirrors         map[string][]string `json:"mirrors,omitempty" yaml:"mirrors,omitempty"` // Base URLs tried in order before the upstream URL of each tool
 }
 
 // globalConfigDirFunc is a function variable that can be overridden for testing
@@ -23,6 +25,19 @@ func getGlobalConfigDir() (string, error) {
 
 // getGlobalConfigDirImpl is the actual implementation
 func getGlobalConfigDirImpl() (string, error) {
+	return GetMvxHome()
+}
+
+// EnvMvxHome overrides the directory where mvx keeps its tools, caches and global configuration
+const EnvMvxHome = "MVX_HOME"
+
+// GetMvxHome returns the directory where mvx keeps its tools, caches and global configuration:
+// MVX_HOME if set, or else ~/.mvx
+func GetMvxHome() (string, error) {
+	if mvxHome := os.Getenv(EnvMvxHome); mvxHome != "" {
+		return filepath.Abs(mvxHome)
+	}
+
 	var homeDir string
 	var err error
 
@@ -154,6 +169,34 @@ func FormatGlobalAsJSON5(cfg *GlobalConfig) (string, error) {
 			content = content[:len(content)-2] + "\n"
 		}
 
+		content += "  }"
+		if len(cfg.Mirrors) > 0 {
+			content += ","
+		}
+		content += "\n"
+	}
+
+	if len(cfg.Mirrors) > 0 {
+		content += "  // Mirrors tried in order before the upstream URL of each tool\n"
+		content += "  mirrors: {\n"
+
+		toolNames := make([]string, 0, len(cfg.Mirrors))
+		for toolName := range cfg.Mirrors {
+			toolNames = append(toolNames, toolName)
+		}
+		sort.Strings(toolNames)
+		for i, toolName := range toolNames {
+			var urls []string
+			for _, url := range cfg.Mirrors[toolName] {
+				urls = append(urls, fmt.Sprintf("\"%s\"", escapeJSONString(url)))
+			}
+			content += fmt.Sprintf("    \"%s\": [%s]", escapeJSONString(toolName), strings.Join(urls, ", "))
+			if i < len(toolNames)-1 {
+				content += ","
+			}
+			content += "\n"
+		}
+
 		content += "  }\n"
 	}
 
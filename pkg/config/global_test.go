@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -33,6 +34,10 @@ func TestGlobalConfig_LoadSaveRoundtrip(t *testing.T) {
 			"regex:^http://(.+)": "https://$1",
 			"regex:https://github\\.com/([^/]+)/([^/]+)/releases/download/(.+)": "https://hub.corp.com/artifactory/github/$1/$2/$3",
 		},
+		Mirrors: map[string][]string{
+			"maven": {"https://nexus.mycompany.net/repository/apache", "https://archive.apache.org"},
+			"node":  {"https://nexus.mycompany.net/repository/nodejs"},
+		},
 	}
 
 	// Save the configuration
@@ -66,6 +71,10 @@ func TestGlobalConfig_LoadSaveRoundtrip(t *testing.T) {
 			t.Errorf("Pattern %s: got %s, expected %s", pattern, actualReplacement, expectedReplacement)
 		}
 	}
+
+	if !reflect.DeepEqual(loadedConfig.Mirrors, testConfig.Mirrors) {
+		t.Errorf("Loaded mirrors = %v, expected %v", loadedConfig.Mirrors, testConfig.Mirrors)
+	}
 }
 
 func TestGlobalConfig_LoadNonExistent(t *testing.T) {
@@ -265,3 +274,34 @@ func TestGlobalConfig_SaveCreatesDirectory(t *testing.T) {
 		t.Errorf("Config file was not created at %s", configPath)
 	}
 }
+
+func TestGetMvxHome(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("USERPROFILE", homeDir)
+	shared := filepath.Join(t.TempDir(), "shared")
+
+	tests := []struct {
+		name     string
+		mvxHome  string
+		expected string
+	}{
+		{"default", "", filepath.Join(homeDir, ".mvx")},
+		{"MVX_HOME", shared, shared},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvMvxHome, tt.mvxHome)
+			mvxHome, err := GetMvxHome()
+			if err != nil {
+				t.Fatalf("GetMvxHome() error = %v", err)
+			}
+			if mvxHome != tt.expected {
+				t.Errorf("GetMvxHome() = %q, want %q", mvxHome, tt.expected)
+			}
+			if configPath, _ := GetGlobalConfigPath(); configPath != filepath.Join(tt.expected, "config.json5") {
+				t.Errorf("GetGlobalConfigPath() = %q, want it in %s", configPath, tt.expected)
+			}
+		})
+	}
+}
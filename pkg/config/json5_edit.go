@@ -0,0 +1,375 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// identifierKey matches JSON5 keys that may be written without quotes
+var identifierKey = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// SaveToolConfig writes a single tool entry into the project configuration, or removes it when
+// the tool is no longer in cfg.Tools. When the project already has a config.json5, only that
+// entry is rewritten, leaving comments, ordering and the formatting of everything else untouched.
+// Otherwise, including for a config.toml, the whole configuration is written with SaveConfig.
+func SaveToolConfig(cfg *Config, projectRoot, toolName string) error {
+	configPath := GetProjectConfigPath(projectRoot)
+	if filepath.Ext(configPath) != ".json5" {
+		return SaveConfig(cfg, projectRoot)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return SaveConfig(cfg, projectRoot)
+	}
+
+	var updated []byte
+	if toolConfig, exists := cfg.Tools[toolName]; exists {
+		updated, err = SetToolInJSON5(data, toolName, toolConfig)
+	} else {
+		updated, err = RemoveToolFromJSON5(data, toolName)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", configPath, err)
+	}
+
+	if err := os.WriteFile(configPath, updated, 0644); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+	return nil
+}
+
+// SetToolInJSON5 replaces or inserts the tools.<toolName> entry of a JSON5 document,
+// leaving the rest of the document byte-for-byte identical.
+func SetToolInJSON5(data []byte, toolName string, toolConfig ToolConfig) ([]byte, error) {
+	s := &json5Scanner{data: data}
+
+	root := s.skipSpace(0)
+	if root >= len(data) || data[root] != '{' {
+		return nil, fmt.Errorf("configuration is not a JSON5 object")
+	}
+
+	tools, err := s.findMember(root, "tools")
+	if err != nil {
+		return nil, err
+	}
+
+	if tools == nil {
+		// No tools section yet: add one to the root object
+		section := map[string]ToolConfig{toolName: toolConfig}
+		return s.insertMember(root, "tools", section)
+	}
+
+	if tools.valueStart >= len(data) || data[tools.valueStart] != '{' {
+		return nil, fmt.Errorf("tools is not an object")
+	}
+
+	tool, err := s.findMember(tools.valueStart, toolName)
+	if err != nil {
+		return nil, err
+	}
+	if tool == nil {
+		return s.insertMember(tools.valueStart, toolName, toolConfig)
+	}
+
+	value, err := formatJSON5Value(toolConfig, lineIndent(data, tool.keyStart))
+	if err != nil {
+		return nil, err
+	}
+	return splice(data, tool.valueStart, tool.valueEnd, value), nil
+}
+
+// RemoveToolFromJSON5 deletes the tools.<toolName> entry of a JSON5 document, along with its
+// trailing comma and comment, leaving the rest of the document byte-for-byte identical
+func RemoveToolFromJSON5(data []byte, toolName string) ([]byte, error) {
+	s := &json5Scanner{data: data}
+
+	root := s.skipSpace(0)
+	if root >= len(data) || data[root] != '{' {
+		return nil, fmt.Errorf("configuration is not a JSON5 object")
+	}
+
+	tools, err := s.findMember(root, "tools")
+	if err != nil {
+		return nil, err
+	}
+	if tools == nil || tools.valueStart >= len(data) || data[tools.valueStart] != '{' {
+		return data, nil
+	}
+	tool, err := s.findMember(tools.valueStart, toolName)
+	if err != nil || tool == nil {
+		return data, err
+	}
+
+	start, end := tool.keyStart, tool.valueEnd
+	if next := s.skipSpace(end); next < len(data) && data[next] == ',' {
+		end = next + 1
+	}
+
+	// Remove whole lines when the entry is alone on its lines
+	lineStart := strings.LastIndexByte(string(data[:start]), '\n') + 1
+	lineEnd := endOfLineComment(data, end)
+	for lineEnd < len(data) && (data[lineEnd] == ' ' || data[lineEnd] == '\t' || data[lineEnd] == '\r') {
+		lineEnd++
+	}
+	if strings.TrimSpace(string(data[lineStart:start])) == "" && lineEnd < len(data) && data[lineEnd] == '\n' {
+		return splice(data, lineStart, lineEnd+1, ""), nil
+	}
+
+	// Otherwise remove the entry and the spaces following it
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return splice(data, start, end, ""), nil
+}
+
+// json5Member locates a member of a JSON5 object within the document
+type json5Member struct {
+	key        string
+	keyStart   int
+	quotedKey  bool
+	valueStart int
+	valueEnd   int
+}
+
+// json5Scanner walks JSON5 text just enough to locate object members
+type json5Scanner struct {
+	data []byte
+}
+
+// skipSpace skips whitespace and comments starting at i
+func (s *json5Scanner) skipSpace(i int) int {
+	for i < len(s.data) {
+		switch {
+		case s.data[i] == ' ' || s.data[i] == '\t' || s.data[i] == '\n' || s.data[i] == '\r':
+			i++
+		case strings.HasPrefix(string(s.data[i:min(i+2, len(s.data))]), "//"):
+			for i < len(s.data) && s.data[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(string(s.data[i:min(i+2, len(s.data))]), "/*"):
+			end := strings.Index(string(s.data[i+2:]), "*/")
+			if end < 0 {
+				return len(s.data)
+			}
+			i += end + 4
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// scanString returns the index just past the quoted string starting at i
+func (s *json5Scanner) scanString(i int) (int, error) {
+	quote := s.data[i]
+	for j := i + 1; j < len(s.data); j++ {
+		switch s.data[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string at offset %d", i)
+}
+
+// scanValue returns the index just past the value starting at i
+func (s *json5Scanner) scanValue(i int) (int, error) {
+	if i >= len(s.data) {
+		return 0, fmt.Errorf("unexpected end of configuration")
+	}
+	switch s.data[i] {
+	case '"', '\'':
+		return s.scanString(i)
+	case '{', '[':
+		depth := 0
+		for j := i; j < len(s.data); {
+			j = s.skipSpace(j)
+			if j >= len(s.data) {
+				break
+			}
+			switch s.data[j] {
+			case '"', '\'':
+				end, err := s.scanString(j)
+				if err != nil {
+					return 0, err
+				}
+				j = end
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return j + 1, nil
+				}
+			}
+			j++
+		}
+		return 0, fmt.Errorf("unterminated object or array at offset %d", i)
+	default:
+		j := i
+		for j < len(s.data) && !strings.ContainsRune(",}] \t\r\n/", rune(s.data[j])) {
+			j++
+		}
+		return j, nil
+	}
+}
+
+// members lists the members of the object whose opening brace is at objStart,
+// along with the index of its closing brace
+func (s *json5Scanner) members(objStart int) ([]json5Member, int, error) {
+	var members []json5Member
+	i := objStart + 1
+	for {
+		i = s.skipSpace(i)
+		if i >= len(s.data) {
+			return nil, 0, fmt.Errorf("unterminated object at offset %d", objStart)
+		}
+		if s.data[i] == '}' {
+			return members, i, nil
+		}
+
+		member := json5Member{keyStart: i}
+		if s.data[i] == '"' || s.data[i] == '\'' {
+			end, err := s.scanString(i)
+			if err != nil {
+				return nil, 0, err
+			}
+			member.key = string(s.data[i+1 : end-1])
+			member.quotedKey = true
+			i = end
+		} else {
+			start := i
+			for i < len(s.data) && !strings.ContainsRune(": \t\r\n", rune(s.data[i])) {
+				i++
+			}
+			member.key = string(s.data[start:i])
+		}
+
+		i = s.skipSpace(i)
+		if i >= len(s.data) || s.data[i] != ':' {
+			return nil, 0, fmt.Errorf("expected ':' after key %q", member.key)
+		}
+		member.valueStart = s.skipSpace(i + 1)
+		end, err := s.scanValue(member.valueStart)
+		if err != nil {
+			return nil, 0, err
+		}
+		member.valueEnd = end
+		members = append(members, member)
+
+		i = s.skipSpace(end)
+		if i < len(s.data) && s.data[i] == ',' {
+			i++
+		}
+	}
+}
+
+// findMember returns the member with the given key in the object at objStart, or nil
+func (s *json5Scanner) findMember(objStart int, key string) (*json5Member, error) {
+	members, _, err := s.members(objStart)
+	if err != nil {
+		return nil, err
+	}
+	for i := range members {
+		if members[i].key == key {
+			return &members[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// insertMember appends a member to the object at objStart, following the indentation
+// and key quoting of its existing members
+func (s *json5Scanner) insertMember(objStart int, key string, value interface{}) ([]byte, error) {
+	members, objEnd, err := s.members(objStart)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		// The closing brace of an empty object moves to its own line
+		member, err := formatJSON5Member(key, value, lineIndent(s.data, objStart)+"  ", true)
+		if err != nil {
+			return nil, err
+		}
+		return splice(s.data, objStart+1, objEnd, member+"\n"+lineIndent(s.data, objStart)), nil
+	}
+
+	last := members[len(members)-1]
+	member, err := formatJSON5Member(key, value, lineIndent(s.data, last.keyStart), members[0].quotedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Insert after the last member, its trailing comma and any comment ending its line
+	data := s.data
+	insertAt := last.valueEnd
+	if next := s.skipSpace(last.valueEnd); next < objEnd && data[next] == ',' {
+		insertAt = next + 1
+	} else {
+		data = splice(data, last.valueEnd, last.valueEnd, ",")
+		insertAt++
+	}
+	insertAt = endOfLineComment(data, insertAt)
+	return splice(data, insertAt, insertAt, member), nil
+}
+
+// formatJSON5Member formats a "key: value" member on a new line at the given indentation
+func formatJSON5Member(key string, value interface{}, indent string, quoted bool) (string, error) {
+	formatted, err := formatJSON5Value(value, indent)
+	if err != nil {
+		return "", err
+	}
+	if quoted || !identifierKey.MatchString(key) {
+		key = fmt.Sprintf("%q", key)
+	}
+	return "\n" + indent + key + ": " + formatted, nil
+}
+
+// endOfLineComment returns the end of a line comment following offset i on the same line, or i
+func endOfLineComment(data []byte, i int) int {
+	j := i
+	for j < len(data) && (data[j] == ' ' || data[j] == '\t') {
+		j++
+	}
+	if !strings.HasPrefix(string(data[j:]), "//") {
+		return i
+	}
+	for j < len(data) && data[j] != '\n' {
+		j++
+	}
+	return j
+}
+
+// formatJSON5Value marshals a value indented to continue a line at the given indentation
+func formatJSON5Value(value interface{}, indent string) (string, error) {
+	data, err := json.MarshalIndent(value, indent, "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal configuration: %w", err)
+	}
+	return string(data), nil
+}
+
+// lineIndent returns the leading whitespace of the line containing offset i
+func lineIndent(data []byte, i int) string {
+	start := strings.LastIndexByte(string(data[:i]), '\n') + 1
+	end := start
+	for end < len(data) && (data[end] == ' ' || data[end] == '\t') {
+		end++
+	}
+	return string(data[start:end])
+}
+
+// splice replaces data[start:end] with replacement
+func splice(data []byte, start, end int, replacement string) []byte {
+	result := make([]byte, 0, len(data)-(end-start)+len(replacement))
+	result = append(result, data[:start]...)
+	result = append(result, replacement...)
+	return append(result, data[end:]...)
+}
@@ -0,0 +1,226 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetToolInJSON5(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		tool     string
+		config   ToolConfig
+		expected string
+	}{
+		{
+			name: "replace existing tool keeps comments",
+			input: `{
+  // Project settings
+  project: { name: "demo" },
+  tools: {
+    maven: { version: "3.9.6" }, // build tool
+    java: { version: "17" },
+  },
+}`,
+			tool:   "java",
+			config: ToolConfig{Version: "21"},
+			expected: `{
+  // Project settings
+  project: { name: "demo" },
+  tools: {
+    maven: { version: "3.9.6" }, // build tool
+    java: {
+      "version": "21"
+    },
+  },
+}`,
+		},
+		{
+			name: "insert after trailing comment",
+			input: `{
+  tools: {
+    maven: { version: "3.9.6" } // build tool
+  }
+}`,
+			tool:   "go",
+			config: ToolConfig{Version: "1.23.1"},
+			expected: `{
+  tools: {
+    maven: { version: "3.9.6" }, // build tool
+    go: {
+      "version": "1.23.1"
+    }
+  }
+}`,
+		},
+		{
+			name: "insert into empty tools",
+			input: `{
+  "tools": {}
+}`,
+			tool:   "node",
+			config: ToolConfig{Version: "lts"},
+			expected: `{
+  "tools": {
+    "node": {
+      "version": "lts"
+    }
+  }
+}`,
+		},
+		{
+			name: "add tools section",
+			input: `{
+  "project": {
+    "name": "demo"
+  }
+}`,
+			tool:   "go",
+			config: ToolConfig{Version: "1.23.1"},
+			expected: `{
+  "project": {
+    "name": "demo"
+  },
+  "tools": {
+    "go": {
+      "version": "1.23.1"
+    }
+  }
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := SetToolInJSON5([]byte(tt.input), tt.tool, tt.config)
+			if err != nil {
+				t.Fatalf("SetToolInJSON5() error = %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("SetToolInJSON5() =\n%s\nwant\n%s", result, tt.expected)
+			}
+
+			// The result must still be a valid configuration
+			var parsed Config
+			if err := ParseJSON5(result, &parsed); err != nil {
+				t.Fatalf("result is not valid JSON5: %v", err)
+			}
+			if parsed.Tools[tt.tool].Version != tt.config.Version {
+				t.Errorf("parsed %s version = %q, want %q", tt.tool, parsed.Tools[tt.tool].Version, tt.config.Version)
+			}
+		})
+	}
+}
+
+func TestRemoveToolFromJSON5(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		tool     string
+		expected string
+	}{
+		{
+			name: "remove entry with its comment",
+			input: `{
+  // Project settings
+  project: { name: "demo" },
+  tools: {
+    maven: { version: "3.9.6" }, // build tool
+    node: {
+      version: "20.0.0",
+    }, // frontend
+    java: { version: "17" },
+  },
+}`,
+			tool: "node",
+			expected: `{
+  // Project settings
+  project: { name: "demo" },
+  tools: {
+    maven: { version: "3.9.6" }, // build tool
+    java: { version: "17" },
+  },
+}`,
+		},
+		{
+			name:     "remove inline entry",
+			input:    `{ project: { name: "demo" }, tools: { node: { version: "20" }, java: { version: "21" } } }`,
+			tool:     "node",
+			expected: `{ project: { name: "demo" }, tools: { java: { version: "21" } } }`,
+		},
+		{
+			name:     "remove last inline entry",
+			input:    `{ project: { name: "demo" }, tools: { java: { version: "21" }, node: { version: "20" } } }`,
+			tool:     "node",
+			expected: `{ project: { name: "demo" }, tools: { java: { version: "21" }, } }`,
+		},
+		{
+			name:     "missing tool",
+			input:    `{ project: { name: "demo" }, tools: { java: { version: "21" } } }`,
+			tool:     "node",
+			expected: `{ project: { name: "demo" }, tools: { java: { version: "21" } } }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := RemoveToolFromJSON5([]byte(tt.input), tt.tool)
+			if err != nil {
+				t.Fatalf("RemoveToolFromJSON5() error = %v", err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("RemoveToolFromJSON5() =\n%s\nwant\n%s", result, tt.expected)
+			}
+
+			var parsed Config
+			if err := ParseJSON5(result, &parsed); err != nil {
+				t.Fatalf("result is not valid JSON5: %v", err)
+			}
+			if _, exists := parsed.Tools[tt.tool]; exists {
+				t.Errorf("parsed configuration still has %s", tt.tool)
+			}
+		})
+	}
+}
+
+func TestFormatAsJSON5CanonicalOrdering(t *testing.T) {
+	cfg := &Config{
+		Project: ProjectConfig{Name: "demo"},
+		Tools: map[string]ToolConfig{
+			"node":  {Version: "lts"},
+			"go":    {Version: "1.23.1"},
+			"maven": {Version: "3.9.6"},
+			"java":  {Version: "21", Distribution: "zulu"},
+		},
+	}
+
+	first, err := FormatAsJSON5(cfg)
+	if err != nil {
+		t.Fatalf("FormatAsJSON5() error = %v", err)
+	}
+
+	// Tools are sorted regardless of insertion order
+	var positions []int
+	for _, tool := range []string{`"go"`, `"java"`, `"maven"`, `"node"`} {
+		positions = append(positions, strings.Index(first, tool))
+	}
+	for i := 1; i < len(positions); i++ {
+		if positions[i-1] < 0 || positions[i] < positions[i-1] {
+			t.Fatalf("tools are not in sorted order:\n%s", first)
+		}
+	}
+
+	// Reformatting a parsed canonical file is stable
+	var parsed Config
+	if err := ParseJSON5([]byte(first), &parsed); err != nil {
+		t.Fatalf("ParseJSON5() error = %v", err)
+	}
+	second, err := FormatAsJSON5(&parsed)
+	if err != nil {
+		t.Fatalf("FormatAsJSON5() error = %v", err)
+	}
+	if first != second {
+		t.Errorf("reformatting is not stable:\n%s\nvs\n%s", first, second)
+	}
+}
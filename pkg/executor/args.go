@@ -0,0 +1,153 @@
+package executor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+// EnvArgPrefix prefixes the variables set to the values of command arguments, e.g. MVX_ARG_ENV
+const EnvArgPrefix = "MVX_ARG_"
+
+// bindArgs binds the arguments given to a command to the arguments it declares. A declared
+// argument is given as --name=value or --name value, or positionally in declaration order, and
+// takes its default when not given. It returns the variables exposing the values, and the
+// remaining arguments, passed to the script. Commands declaring no arguments receive all the
+// arguments; the others only accept undeclared ones when they opt into passthrough, and "--"
+// marks the start of those.
+func bindArgs(commandName string, cmdConfig config.CommandConfig, args []string) (map[string]string, []string, error) {
+	if len(cmdConfig.Args) == 0 {
+		return nil, args, nil
+	}
+
+	declared := make(map[string]bool, len(cmdConfig.Args))
+	for _, arg := range cmdConfig.Args {
+		declared[arg.Name] = true
+	}
+
+	values := make(map[string]string)
+	var positional, rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !declared[name] {
+			if !cmdConfig.Passthrough {
+				return nil, nil, fmt.Errorf("unknown argument %s for command %s", arg, commandName)
+			}
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("argument --%s of command %s needs a value", name, commandName)
+			}
+			i++
+			value = args[i]
+		}
+		values[name] = value
+	}
+
+	// Positional arguments fill the declared arguments not given by name, in order
+	for _, arg := range cmdConfig.Args {
+		if _, given := values[arg.Name]; given || len(positional) == 0 {
+			continue
+		}
+		values[arg.Name] = positional[0]
+		positional = positional[1:]
+	}
+	if len(positional) > 0 {
+		if !cmdConfig.Passthrough {
+			return nil, nil, fmt.Errorf("too many arguments for command %s: %s", commandName, strings.Join(positional, " "))
+		}
+		rest = append(positional, rest...)
+	}
+
+	env := make(map[string]string)
+	for _, arg := range cmdConfig.Args {
+		value, given := values[arg.Name]
+		if !given {
+			if arg.Required && arg.Default == "" {
+				return nil, nil, fmt.Errorf("missing required argument %s for command %s", arg.Name, commandName)
+			}
+			value = arg.Default
+		}
+		env[argEnvVar(arg.Name)] = value
+		if isValidEnvVarName(arg.Name) {
+			env[arg.Name] = value
+		}
+	}
+	return env, rest, nil
+}
+
+// argEnvVar returns the variable set to the value of an argument, e.g. MVX_ARG_DRY_RUN for dry-run
+func argEnvVar(name string) string {
+	return EnvArgPrefix + strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		return '_'
+	}, name)
+}
+
+// isValidEnvVarName reports whether a name can be used as a shell variable
+func isValidEnvVarName(name string) bool {
+	for i, r := range name {
+		if !(r == '_' || (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (i > 0 && r >= '0' && r <= '9')) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// ArgsUsage returns the usage line of the arguments of a command, e.g. "<env> [region] [args...]"
+func ArgsUsage(cmdConfig config.CommandConfig) string {
+	if len(cmdConfig.Args) == 0 {
+		return "[args...]"
+	}
+	var parts []string
+	for _, arg := range cmdConfig.Args {
+		if arg.Required && arg.Default == "" {
+			parts = append(parts, "<"+arg.Name+">")
+		} else {
+			parts = append(parts, "["+arg.Name+"]")
+		}
+	}
+	if cmdConfig.Passthrough {
+		parts = append(parts, "[args...]")
+	}
+	return strings.Join(parts, " ")
+}
+
+// ArgsHelp describes the arguments of a command, one per line, for its help
+func ArgsHelp(cmdConfig config.CommandConfig) string {
+	width := 0
+	for _, arg := range cmdConfig.Args {
+		width = max(width, len(arg.Name))
+	}
+
+	var b strings.Builder
+	for _, arg := range cmdConfig.Args {
+		fmt.Fprintf(&b, "  %-*s  %s", width, arg.Name, arg.Description)
+		switch {
+		case arg.Default != "":
+			fmt.Fprintf(&b, " (default %q)", arg.Default)
+		case arg.Required:
+			b.WriteString(" (required)")
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
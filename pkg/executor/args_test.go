@@ -0,0 +1,102 @@
+package executor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestBindArgs(t *testing.T) {
+	deploy := config.CommandConfig{Args: []config.CommandArgConfig{
+		{Name: "env", Required: true},
+		{Name: "dry-run", Default: "false"},
+	}}
+	passthrough := deploy
+	passthrough.Passthrough = true
+
+	tests := []struct {
+		name         string
+		cmdConfig    config.CommandConfig
+		args         []string
+		expectedEnv  map[string]string
+		expectedRest []string
+		expectError  bool
+	}{
+		{
+			name:         "no declared arguments",
+			cmdConfig:    config.CommandConfig{},
+			args:         []string{"--anything", "goes"},
+			expectedRest: []string{"--anything", "goes"},
+		},
+		{
+			name:        "positional with default",
+			cmdConfig:   deploy,
+			args:        []string{"prod"},
+			expectedEnv: map[string]string{"env": "prod", "MVX_ARG_ENV": "prod", "MVX_ARG_DRY_RUN": "false"},
+		},
+		{
+			name:        "named",
+			cmdConfig:   deploy,
+			args:        []string{"--dry-run=true", "--env", "staging"},
+			expectedEnv: map[string]string{"env": "staging", "MVX_ARG_ENV": "staging", "MVX_ARG_DRY_RUN": "true"},
+		},
+		{
+			name:        "named and positional",
+			cmdConfig:   deploy,
+			args:        []string{"--env=prod", "true"},
+			expectedEnv: map[string]string{"env": "prod", "MVX_ARG_ENV": "prod", "MVX_ARG_DRY_RUN": "true"},
+		},
+		{name: "missing required", cmdConfig: deploy, args: []string{"--dry-run=true"}, expectError: true},
+		{name: "unknown flag", cmdConfig: deploy, args: []string{"prod", "--force"}, expectError: true},
+		{name: "too many", cmdConfig: deploy, args: []string{"prod", "true", "extra"}, expectError: true},
+		{name: "missing value", cmdConfig: deploy, args: []string{"--env"}, expectError: true},
+		{
+			name:         "passthrough",
+			cmdConfig:    passthrough,
+			args:         []string{"prod", "--force", "false", "extra", "--", "--env=x"},
+			expectedEnv:  map[string]string{"env": "prod", "MVX_ARG_ENV": "prod", "MVX_ARG_DRY_RUN": "false"},
+			expectedRest: []string{"extra", "--force", "--env=x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env, rest, err := bindArgs("deploy", tt.cmdConfig, tt.args)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("bindArgs() = %v, %v, expected an error", env, rest)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("bindArgs() error = %v", err)
+			}
+			if len(env) != 0 || len(tt.expectedEnv) != 0 {
+				if !reflect.DeepEqual(env, tt.expectedEnv) {
+					t.Errorf("bindArgs() env = %v, expected %v", env, tt.expectedEnv)
+				}
+			}
+			if !reflect.DeepEqual(rest, tt.expectedRest) {
+				t.Errorf("bindArgs() rest = %q, expected %q", rest, tt.expectedRest)
+			}
+		})
+	}
+}
+
+func TestArgsUsage(t *testing.T) {
+	cmdConfig := config.CommandConfig{
+		Args: []config.CommandArgConfig{
+			{Name: "env", Description: "Target environment", Required: true},
+			{Name: "region", Description: "Cloud region", Default: "eu-west-1"},
+		},
+		Passthrough: true,
+	}
+	if usage := ArgsUsage(cmdConfig); usage != "<env> [region] [args...]" {
+		t.Errorf("ArgsUsage() = %q", usage)
+	}
+	expected := "  env     Target environment (required)\n  region  Cloud region (default \"eu-west-1\")\n"
+	if help := ArgsHelp(cmdConfig); help != expected {
+		t.Errorf("ArgsHelp() = %q, expected %q", help, expected)
+	}
+}
@@ -0,0 +1,56 @@
+package executor
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+// EnvAssumeYes ("true") runs commands that ask for confirmation without asking, as --yes does
+const EnvAssumeYes = "MVX_YES"
+
+// confirmCommand asks for confirmation before running a command that requires it. When the
+// question can't be asked, because in isn't a terminal, the command's confirm_non_interactive
+// setting decides.
+func confirmCommand(commandName string, cmdConfig config.CommandConfig, in *os.File) error {
+	if cmdConfig.Confirm == "" || os.Getenv(EnvAssumeYes) == "true" {
+		return nil
+	}
+
+	if !isTerminal(in) {
+		if cmdConfig.ConfirmNonInteractive == config.ConfirmRefuse {
+			return fmt.Errorf("command %s requires confirmation, run it with --yes (or %s=true) when not on a terminal", commandName, EnvAssumeYes)
+		}
+		return nil
+	}
+
+	confirmed, err := askConfirmation(cmdConfig.Confirm, in, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if !confirmed {
+		return fmt.Errorf("command %s not confirmed", commandName)
+	}
+	return nil
+}
+
+// askConfirmation asks a question and reads the answer, defaulting to no
+func askConfirmation(question string, in io.Reader, out io.Writer) (bool, error) {
+	fmt.Fprintf(out, "%s [y/N] ", strings.TrimSpace(question))
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("failed to read answer: %w", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// isTerminal reports whether a file is a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
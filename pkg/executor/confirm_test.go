@@ -0,0 +1,73 @@
+package executor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestConfirmCommandNonInteractive(t *testing.T) {
+	// A regular file is never a terminal, whatever runs the tests
+	in, err := os.Create(filepath.Join(t.TempDir(), "stdin"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer in.Close()
+
+	tests := []struct {
+		name      string
+		command   config.CommandConfig
+		assumeYes bool
+		wantErr   bool
+	}{
+		{name: "no confirmation", command: config.CommandConfig{}},
+		{name: "proceed by default", command: config.CommandConfig{Confirm: "Deploy?"}},
+		{name: "refuse", command: config.CommandConfig{Confirm: "Deploy?", ConfirmNonInteractive: config.ConfirmRefuse}, wantErr: true},
+		{name: "refuse unless --yes", command: config.CommandConfig{Confirm: "Deploy?", ConfirmNonInteractive: config.ConfirmRefuse}, assumeYes: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assumeYes := ""
+			if tt.assumeYes {
+				assumeYes = "true"
+			}
+			t.Setenv(EnvAssumeYes, assumeYes)
+
+			err := confirmCommand("deploy", tt.command, in)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("confirmCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAskConfirmation(t *testing.T) {
+	tests := []struct {
+		answer   string
+		expected bool
+	}{
+		{"y\n", true},
+		{"YES\n", true},
+		{"n\n", false},
+		{"\n", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		var out strings.Builder
+		got, err := askConfirmation("Deploy to production?", strings.NewReader(tt.answer), &out)
+		if err != nil {
+			t.Fatalf("askConfirmation(%q) error = %v", tt.answer, err)
+		}
+		if got != tt.expected {
+			t.Errorf("askConfirmation(%q) = %v, expected %v", tt.answer, got, tt.expected)
+		}
+		if out.String() != "Deploy to production? [y/N] " {
+			t.Errorf("askConfirmation() asked %q", out.String())
+		}
+	}
+}
@@ -1,12 +1,19 @@
 package executor
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gnodet/mvx/pkg/config"
 	"github.com/gnodet/mvx/pkg/shell"
@@ -37,12 +44,42 @@ func (e *Executor) ExecuteCommand(commandName string, args []string) error {
 	if !exists {
 		return fmt.Errorf("unknown command: %s", commandName)
 	}
+	if err := checkCommandPlatform(commandName, cmdConfig); err != nil {
+		return err
+	}
+	argEnv, args, err := bindArgs(commandName, cmdConfig, args)
+	if err != nil {
+		return err
+	}
+	if err := confirmCommand(commandName, cmdConfig, os.Stdin); err != nil {
+		return err
+	}
 
-	// Setup environment
-	env, err := e.setupEnvironment(cmdConfig)
+	// Pipelines chain other commands instead of running a script of their own
+	if len(cmdConfig.Pipeline) > 0 {
+		if !cmdConfig.Silent {
+			fmt.Fprintf(util.StatusOutput(), "🔨 Running command: %s\n", commandName)
+			if cmdConfig.Description != "" {
+				fmt.Fprintf(util.StatusOutput(), "   %s\n", cmdConfig.Description)
+			}
+		}
+		stdout, stderr, closeOutput, err := e.outputWriters(cmdConfig, envWithOverrides(envWithOverrides(os.Environ(), cmdConfig.Environment), argEnv))
+		if err != nil {
+			return err
+		}
+		return closeAfter(e.runWithHooks(commandName, cmdConfig, nil, stdout, stderr, func(stdout, stderr io.Writer) error {
+			return runWithRetries(commandName, cmdConfig, func() error {
+				return e.executePipeline(commandName, cmdConfig, args, argEnv, stdout, stderr)
+			})
+		}), closeOutput)
+	}
+
+	// Setup environment, with the values of the command's arguments
+	env, err := e.setupEnvironment(commandName, cmdConfig)
 	if err != nil {
 		return fmt.Errorf("failed to setup environment: %w", err)
 	}
+	env = envWithOverrides(env, argEnv)
 
 	// Determine working directory
 	workDir := e.projectRoot
@@ -51,7 +88,7 @@ func (e *Executor) ExecuteCommand(commandName string, args []string) error {
 	}
 
 	// Process script and resolve interpreter (handle platform-specific scripts)
-	script, interpreter, err := config.ResolvePlatformScriptWithInterpreter(cmdConfig.Script, cmdConfig.Interpreter)
+	script, interpreter, err := e.config.ResolveCommandScript(cmdConfig)
 	if err != nil {
 		return fmt.Errorf("failed to resolve script: %w", err)
 	}
@@ -59,13 +96,233 @@ func (e *Executor) ExecuteCommand(commandName string, args []string) error {
 	// Process script arguments
 	processedScript := e.processScriptString(script, args)
 
-	// Execute command
-	fmt.Printf("🔨 Running command: %s\n", commandName)
-	if cmdConfig.Description != "" {
-		fmt.Printf("   %s\n", cmdConfig.Description)
+	// Execute command (silent commands skip the framing so their output can be consumed as-is)
+	if !cmdConfig.Silent {
+		fmt.Fprintf(util.StatusOutput(), "🔨 Running command: %s\n", commandName)
+		if cmdConfig.Description != "" {
+			fmt.Fprintf(util.StatusOutput(), "   %s\n", cmdConfig.Description)
+		}
+	}
+
+	stdout, stderr, closeOutput, err := e.outputWriters(cmdConfig, env)
+	if err != nil {
+		return err
+	}
+	return closeAfter(e.runWithHooks(commandName, cmdConfig, env, stdout, stderr, func(stdout, stderr io.Writer) error {
+		return runWithRetries(commandName, cmdConfig, func() error {
+			return e.executeScriptWithIO(processedScript, args, workDir, env, interpreter, os.Stdin, stdout, stderr)
+		})
+	}), closeOutput)
+}
+
+// runWithHooks runs a command between its pre and post hooks, the post hook only running when
+// the command succeeded. Hook output is prefixed with the hook and command names, e.g.
+// "[pre:build] ", while the command's own output is only prefixed when MVX_OUTPUT_PREFIX=true.
+// env is the command's environment, computed when needed by hooks if nil.
+func (e *Executor) runWithHooks(commandName string, cmdConfig config.CommandConfig, env []string, stdout, stderr io.Writer, run func(stdout, stderr io.Writer) error) error {
+	if cmdConfig.Pre == nil && cmdConfig.Post == nil {
+		return run(prefixOutput(stdout, stderr, commandName, commandOutput))
+	}
+
+	if env == nil {
+		var err error
+		if env, err = e.setupEnvironment(commandName, cmdConfig); err != nil {
+			return fmt.Errorf("failed to setup environment: %w", err)
+		}
+	}
+	if err := e.runHook("pre", commandName, cmdConfig.Pre, cmdConfig, env, stdout, stderr); err != nil {
+		return err
+	}
+	if err := run(prefixOutput(stdout, stderr, commandName, commandOutput)); err != nil {
+		return err
+	}
+	return e.runHook("post", commandName, cmdConfig.Post, cmdConfig, env, stdout, stderr)
+}
+
+// runHook runs the scripts of a pre or post hook of a command, in the command's environment
+// and working directory
+func (e *Executor) runHook(hookName, commandName string, hook interface{}, cmdConfig config.CommandConfig, env []string, stdout, stderr io.Writer) error {
+	scripts, err := e.config.ResolveHookScripts(hook, cmdConfig.Interpreter)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s hook of %s: %w", hookName, commandName, err)
 	}
 
-	return e.executeScriptWithInterpreter(processedScript, workDir, env, interpreter)
+	workDir := e.projectRoot
+	if cmdConfig.WorkingDir != "" {
+		workDir = filepath.Join(e.projectRoot, cmdConfig.WorkingDir)
+	}
+
+	hookStdout, hookStderr := prefixOutput(stdout, stderr, hookName+":"+commandName, stepOutput)
+	for _, hookScript := range scripts {
+		util.LogVerbose("Running %s hook of %s: %s", hookName, commandName, hookScript.Script)
+		if err := e.executeScriptWithIO(hookScript.Script, nil, workDir, env, hookScript.Interpreter, os.Stdin, hookStdout, hookStderr); err != nil {
+			return fmt.Errorf("%s hook of %s failed: %w", hookName, commandName, err)
+		}
+	}
+	return nil
+}
+
+// runWithRetries runs a command, rerunning it after the configured delay while it fails and
+// retries remain. The error of the last attempt is returned, preserving its exit code.
+func runWithRetries(commandName string, cmdConfig config.CommandConfig, run func() error) error {
+	delay, err := cmdConfig.GetRetryDelay()
+	if err != nil {
+		return err
+	}
+
+	err = run()
+	for attempt := 1; err != nil && attempt <= cmdConfig.Retries; attempt++ {
+		fmt.Fprintf(os.Stderr, "🔁 Command %s failed: %v, retrying (attempt %d of %d)", commandName, err, attempt+1, cmdConfig.Retries+1)
+		if delay > 0 {
+			fmt.Fprintf(os.Stderr, " in %s", delay)
+		}
+		fmt.Fprintln(os.Stderr)
+		time.Sleep(delay)
+		err = run()
+	}
+	if err != nil && cmdConfig.Retries > 0 {
+		util.LogVerbose("Command %s failed after %d attempts", commandName, cmdConfig.Retries+1)
+	}
+	return err
+}
+
+// outputWriters returns the writers a command's output goes to. With an output file configured,
+// both streams are also copied to that file, while still being shown on the terminal.
+func (e *Executor) outputWriters(cmdConfig config.CommandConfig, env []string) (io.Writer, io.Writer, func() error, error) {
+	if cmdConfig.OutputFile == "" {
+		return os.Stdout, os.Stderr, func() error { return nil }, nil
+	}
+
+	outputPath := expandWithEnv(cmdConfig.OutputFile, env)
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(e.projectRoot, outputPath)
+	}
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+	file, err := os.Create(outputPath)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	util.LogVerbose("Capturing command output to %s", outputPath)
+
+	// stdout and stderr are copied concurrently, so writes to the file are serialized
+	output := &syncWriter{w: file}
+	return io.MultiWriter(os.Stdout, output), io.MultiWriter(os.Stderr, output), file.Close, nil
+}
+
+// closeAfter runs closeFn once a command finished, returning the command's own error first
+// so that its exit status is preserved
+func closeAfter(runErr error, closeFn func() error) error {
+	closeErr := closeFn()
+	if runErr != nil {
+		return runErr
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to write output file: %w", closeErr)
+	}
+	return nil
+}
+
+// EnvOutputPrefix forces ("true") or disables ("false") the prefixing of command output with
+// the name of the step producing it. By default, only sub-steps (hooks and pipeline steps)
+// are prefixed, and the output of the command itself is left unchanged.
+const EnvOutputPrefix = "MVX_OUTPUT_PREFIX"
+
+// outputKind tells apart the output of a command from that of its sub-steps
+type outputKind int
+
+const (
+	commandOutput outputKind = iota // Prefixed only when MVX_OUTPUT_PREFIX=true
+	stepOutput                      // Prefixed unless MVX_OUTPUT_PREFIX=false
+)
+
+// prefixOutput wraps stdout and stderr to prefix each line with "[name] ", if output of that
+// kind is prefixed
+func prefixOutput(stdout, stderr io.Writer, name string, kind outputKind) (io.Writer, io.Writer) {
+	if !isPrefixed(kind) {
+		return stdout, stderr
+	}
+	return newPrefixWriter(stdout, name), newPrefixWriter(stderr, name)
+}
+
+// isPrefixed reports whether output of a kind is prefixed, according to MVX_OUTPUT_PREFIX
+func isPrefixed(kind outputKind) bool {
+	switch os.Getenv(EnvOutputPrefix) {
+	case "true":
+		return true
+	case "false":
+		return false
+	default:
+		return kind == stepOutput
+	}
+}
+
+// prefixWriter prefixes each line written to an underlying writer
+type prefixWriter struct {
+	mutex   sync.Mutex
+	w       io.Writer
+	prefix  []byte
+	midLine bool // The last write did not end a line
+}
+
+func newPrefixWriter(w io.Writer, name string) *prefixWriter {
+	return &prefixWriter{w: w, prefix: []byte("[" + name + "] ")}
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var buf bytes.Buffer
+	for rest := data; len(rest) > 0; {
+		if !p.midLine {
+			buf.Write(p.prefix)
+		}
+		line, remaining, found := bytes.Cut(rest, []byte("\n"))
+		buf.Write(line)
+		if found {
+			buf.WriteByte('\n')
+		}
+		p.midLine = !found
+		rest = remaining
+	}
+	if _, err := p.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// syncWriter serializes writes to an underlying writer
+type syncWriter struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.w.Write(p)
+}
+
+// expandWithEnv expands ${VAR} and $VAR references using the given environment
+func expandWithEnv(value string, env []string) string {
+	vars := make(map[string]string, len(env))
+	for _, envVar := range env {
+		if name, val, ok := strings.Cut(envVar, "="); ok {
+			vars[name] = val
+		}
+	}
+	return os.Expand(value, func(name string) string { return vars[name] })
+}
+
+// envWithOverrides returns env with the given variables added or replaced
+func envWithOverrides(env []string, overrides map[string]string) []string {
+	result := append([]string(nil), env...)
+	for key, value := range overrides {
+		result = append(result, key+"="+value)
+	}
+	return result
 }
 
 // ExecuteTool executes a tool command with mvx-managed environment
@@ -105,10 +362,29 @@ func (e *Executor) ExecuteTool(toolName string, args []string) error {
 	return cmd.Run()
 }
 
-// ListCommands returns available commands from configuration
+// checkCommandPlatform refuses commands restricted to other operating systems or architectures
+func checkCommandPlatform(commandName string, cmdConfig config.CommandConfig) error {
+	if cmdConfig.SupportsCurrentPlatform() {
+		return nil
+	}
+	var constraints []string
+	if len(cmdConfig.OS) > 0 {
+		constraints = append(constraints, "os: "+strings.Join(cmdConfig.OS, ", "))
+	}
+	if len(cmdConfig.Arch) > 0 {
+		constraints = append(constraints, "arch: "+strings.Join(cmdConfig.Arch, ", "))
+	}
+	return fmt.Errorf("command %s is not supported on this platform (%s/%s), it requires %s",
+		commandName, runtime.GOOS, runtime.GOARCH, strings.Join(constraints, "; "))
+}
+
+// ListCommands returns the commands from configuration that can run on this platform
 func (e *Executor) ListCommands() map[string]string {
 	commands := make(map[string]string)
 	for name, cmd := range e.config.Commands {
+		if !cmd.SupportsCurrentPlatform() {
+			continue // Hidden on platforms it cannot run on
+		}
 		commands[name] = cmd.Description
 	}
 	return commands
@@ -124,7 +400,7 @@ func (e *Executor) GetCommandInfo(commandName string) (*config.CommandConfig, er
 }
 
 // setupEnvironment prepares the environment for command execution
-func (e *Executor) setupEnvironment(cmdConfig config.CommandConfig) ([]string, error) {
+func (e *Executor) setupEnvironment(commandName string, cmdConfig config.CommandConfig) ([]string, error) {
 	// Create environment manager starting with current environment
 	envManager := tools.NewEnvironmentManager()
 	for _, envVar := range os.Environ() {
@@ -143,8 +419,46 @@ func (e *Executor) setupEnvironment(cmdConfig config.CommandConfig) ([]string, e
 		}
 	}
 
+	// Tool versions of the command override the project's
+	cfg, err := e.commandToolsConfig(cmdConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the tools required for this command are installed and put on PATH: computing the
+	// environment of the other tools would install them on demand
+	requiredCfg := e.toolManager.ToolsRequiredFor(cfg, commandName)
+	for _, toolName := range cmdConfig.Requires {
+		if toolConfig, exists := cfg.Tools[toolName]; exists {
+			requiredCfg.Tools[toolName] = toolConfig
+		}
+	}
+
+	// Ensure required tools are installed (auto-install if needed), before the environment
+	// is computed with their paths
+	requiredTools := cmdConfig.Requires
+	if len(requiredTools) == 0 {
+		// If no specific requirements, use the configured tools required for this command
+		for toolName := range requiredCfg.Tools {
+			requiredTools = append(requiredTools, toolName)
+		}
+	}
+	util.LogVerbose("Required tools for command: %v", requiredTools)
+
+	// Ensure all required tools are installed (this may trigger auto-installation)
+	for _, toolName := range requiredTools {
+		if toolConfig, exists := cfg.Tools[toolName]; exists {
+			// EnsureTool handles version resolution, installation check, and auto-install
+			_, err := e.toolManager.EnsureTool(toolName, toolConfig)
+			if err != nil {
+				util.LogVerbose("Failed to ensure tool %s: %v", toolName, err)
+				// Continue anyway - the tool might still work if it's a system tool
+			}
+		}
+	}
+
 	// Add global environment variables from config (includes tool paths and environment)
-	globalEnv, err := e.toolManager.SetupEnvironment(e.config)
+	globalEnv, err := e.toolManager.SetupEnvironment(requiredCfg)
 	if err != nil {
 		return nil, err
 	}
@@ -166,77 +480,259 @@ func (e *Executor) setupEnvironment(cmdConfig config.CommandConfig) ([]string, e
 		}
 	}
 
+	// Add variables from the command's env file (overriding global ones, overridden by the command's)
+	if cmdConfig.EnvFile != "" {
+		fileEnv, err := e.loadEnvFile(cmdConfig.EnvFile, envWithOverrides(envManager.ToSlice(), cmdConfig.Environment))
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileEnv {
+			envManager.SetEnv(key, value)
+		}
+	}
+
 	// Add command-specific environment variables (these override global ones)
 	for key, value := range cmdConfig.Environment {
 		envManager.SetEnv(key, value)
 	}
 
-	// Ensure required tools are installed (auto-install if needed)
-	requiredTools := cmdConfig.Requires
-	if len(requiredTools) == 0 {
-		// If no specific requirements, use all configured tools
-		for toolName := range e.config.Tools {
-			requiredTools = append(requiredTools, toolName)
-		}
+	// Convert environment manager to slice format
+	return envManager.ToSlice(), nil
+}
+
+// commandToolsConfig returns the configuration a command runs with: the project configuration
+// with the tools of the command replacing the project's. These tools are installed if needed,
+// as the command cannot run with the project's versions instead.
+func (e *Executor) commandToolsConfig(cmdConfig config.CommandConfig) (*config.Config, error) {
+	if len(cmdConfig.Tools) == 0 {
+		return e.config, nil
 	}
-	util.LogVerbose("Required tools for command: %v", requiredTools)
 
-	// Ensure all required tools are installed (this may trigger auto-installation)
-	for _, toolName := range requiredTools {
-		if toolConfig, exists := e.config.Tools[toolName]; exists {
-			// EnsureTool handles version resolution, installation check, and auto-install
-			_, err := e.toolManager.EnsureTool(toolName, toolConfig)
-			if err != nil {
-				util.LogVerbose("Failed to ensure tool %s: %v", toolName, err)
-				// Continue anyway - the tool might still work if it's a system tool
-			}
+	cfg := *e.config
+	cfg.Tools = make(map[string]config.ToolConfig, len(e.config.Tools)+len(cmdConfig.Tools))
+	for toolName, toolConfig := range e.config.Tools {
+		cfg.Tools[toolName] = toolConfig
+	}
+	for toolName, toolConfig := range cmdConfig.Tools {
+		if _, err := e.toolManager.GetTool(toolName); err != nil {
+			return nil, err
+		}
+		if _, err := e.toolManager.EnsureTool(toolName, toolConfig); err != nil {
+			return nil, fmt.Errorf("failed to ensure %s %s is installed: %w", toolName, toolConfig.Version, err)
 		}
+		util.LogVerbose("Using %s %s for this command", toolName, toolConfig.Version)
+		cfg.Tools[toolName] = toolConfig
 	}
+	return &cfg, nil
+}
 
-	// Convert environment manager to slice format
-	return envManager.ToSlice(), nil
+// loadEnvFile reads a command's env file, whose path may reference variables of env
+// and is relative to the project root
+func (e *Executor) loadEnvFile(envFile string, env []string) (map[string]string, error) {
+	path := expandWithEnv(envFile, env)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(e.projectRoot, path)
+	}
+	fileEnv, err := util.ReadDotenvFile(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("env file not found: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	util.LogVerbose("Loaded %d variable(s) from env file %s", len(fileEnv), path)
+	return fileEnv, nil
 }
 
-// processScriptString processes a script string with arguments
+// positionalArgPattern matches the references to positional parameters: $1..$9, $@, $* and $#
+var positionalArgPattern = regexp.MustCompile(`\$\{?[1-9@*#]`)
+
+// processScriptString processes a script string with arguments. The arguments are appended to
+// the script, unless it references them as positional parameters ($1..$N, $@, $* or $#).
 func (e *Executor) processScriptString(script string, args []string) string {
-	// If there are arguments, append them to the script
-	if len(args) > 0 {
-		// Join arguments with spaces and append to script
-		argsStr := strings.Join(args, " ")
-		script = script + " " + argsStr
+	if len(args) == 0 || positionalArgPattern.MatchString(script) {
+		return script
 	}
 
-	return script
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quoteScriptArg(arg)
+	}
+	return script + " " + strings.Join(quoted, " ")
 }
 
-// executeScriptWithInterpreter executes a script using the specified interpreter
-func (e *Executor) executeScriptWithInterpreter(script, workDir string, env []string, interpreter string) error {
+// quoteScriptArg quotes an argument appended to a script if it contains spaces or characters
+// the shell would interpret
+func quoteScriptArg(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-+=.,/:@%") == "" {
+		return arg
+	}
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(arg, `"`, `""`) + `"`
+	}
+	if !strings.Contains(arg, "'") {
+		return "'" + arg + "'"
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(arg) + `"`
+}
+
+// executeScriptWithIO executes a script using the specified interpreter and standard streams,
+// args being its positional parameters
+func (e *Executor) executeScriptWithIO(script string, args []string, workDir string, env []string, interpreter string, stdin io.Reader, stdout, stderr io.Writer) error {
 	util.LogVerbose("executeScriptWithInterpreter called with interpreter: '%s', script: '%s'", interpreter, script)
 
 	// Default to native interpreter if not specified
 	if interpreter == "" || interpreter == "native" {
 		util.LogVerbose("Using native interpreter")
-		return e.executeNativeScript(script, workDir, env)
+		return e.executeNativeScript(script, args, workDir, env, stdin, stdout, stderr)
 	}
 
 	// Use mvx-shell interpreter
 	if interpreter == "mvx-shell" {
 		mvxShell := shell.NewMVXShell(workDir, env)
+		mvxShell.SetIO(stdin, stdout, stderr)
+		mvxShell.SetArgs(args)
 		return mvxShell.Execute(script)
 	}
 
 	return fmt.Errorf("unknown interpreter: %s", interpreter)
 }
 
-// executeNativeScript executes a script using the native system shell
-func (e *Executor) executeNativeScript(script, workDir string, env []string) error {
+// pipelineStage holds a fully resolved pipeline step ready for execution
+type pipelineStage struct {
+	name        string
+	script      string
+	workDir     string
+	interpreter string
+	env         []string
+	args        []string
+}
+
+// executePipeline runs the commands listed in a pipeline concurrently, feeding each
+// step's stdout into the next step's stdin. Arguments are passed to the first step, and the
+// variables of the pipeline's arguments are set for all the steps.
+func (e *Executor) executePipeline(commandName string, cmdConfig config.CommandConfig, args []string, argEnv map[string]string, pipelineStdout, pipelineStderr io.Writer) error {
+	stages := make([]pipelineStage, 0, len(cmdConfig.Pipeline))
+	for i, stepName := range cmdConfig.Pipeline {
+		stepConfig, exists := e.config.Commands[stepName]
+		if !exists {
+			return fmt.Errorf("pipeline %s: unknown command: %s", commandName, stepName)
+		}
+		if len(stepConfig.Pipeline) > 0 {
+			return fmt.Errorf("pipeline %s: step %s is itself a pipeline, which is not supported", commandName, stepName)
+		}
+		if err := checkCommandPlatform(stepName, stepConfig); err != nil {
+			return fmt.Errorf("pipeline %s: %w", commandName, err)
+		}
+
+		env, err := e.setupEnvironment(stepName, stepConfig)
+		if err != nil {
+			return fmt.Errorf("failed to setup environment for %s: %w", stepName, err)
+		}
+
+		workDir := e.projectRoot
+		if stepConfig.WorkingDir != "" {
+			workDir = filepath.Join(e.projectRoot, stepConfig.WorkingDir)
+		}
+
+		script, interpreter, err := e.config.ResolveCommandScript(stepConfig)
+		if err != nil {
+			return fmt.Errorf("failed to resolve script for %s: %w", stepName, err)
+		}
+		var stageArgs []string
+		if i == 0 {
+			script = e.processScriptString(script, args)
+			stageArgs = args
+		}
+
+		stages = append(stages, pipelineStage{
+			name:        stepName,
+			script:      script,
+			workDir:     workDir,
+			interpreter: interpreter,
+			env:         envWithOverrides(env, argEnv),
+			args:        stageArgs,
+		})
+	}
+
+	errs := make([]error, len(stages))
+	var wg sync.WaitGroup
+	var stdin io.Reader = os.Stdin
+	for i, stage := range stages {
+		stdout := pipelineStdout
+		var writer *io.PipeWriter
+		var nextStdin *io.PipeReader
+		if i < len(stages)-1 {
+			nextStdin, writer = io.Pipe()
+			stdout = writer
+		}
+
+		wg.Add(1)
+		go func(i int, stage pipelineStage, stdin io.Reader, stdout io.Writer, writer *io.PipeWriter) {
+			defer wg.Done()
+			util.LogVerbose("Running pipeline step %d: %s", i+1, stage.name)
+			// Only stderr is prefixed, stdout being the data flowing through the pipeline
+			stderr := pipelineStderr
+			if isPrefixed(stepOutput) {
+				stderr = newPrefixWriter(pipelineStderr, stage.name)
+			}
+			errs[i] = e.executeScriptWithIO(stage.script, stage.args, stage.workDir, stage.env, stage.interpreter, stdin, stdout, stderr)
+			// Signal end of input to the next step
+			if writer != nil {
+				writer.Close()
+			}
+			// Unblock the previous step if this one stopped reading early
+			if reader, ok := stdin.(*io.PipeReader); ok {
+				reader.Close()
+			}
+		}(i, stage, stdin, stdout, writer)
+
+		if nextStdin != nil {
+			stdin = nextStdin
+		}
+	}
+	wg.Wait()
+
+	// As in a shell, the status of the pipeline is the one of its last step
+	last := len(stages) - 1
+	if errs[last] != nil {
+		return fmt.Errorf("pipeline step %s failed: %w", stages[last].name, errs[last])
+	}
+	// An earlier step writing to a step that stopped reading early, like head, is not a failure
+	for i, err := range errs[:last] {
+		if err != nil && !isBrokenPipe(err) {
+			return fmt.Errorf("pipeline step %s failed: %w", stages[i].name, err)
+		}
+	}
+	return nil
+}
+
+// isBrokenPipe reports whether a step failed because the next step closed its input: writing
+// to the pipe failed, or the process was killed by SIGPIPE
+func isBrokenPipe(err error) bool {
+	if errors.Is(err, io.ErrClosedPipe) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.Signaled() && status.Signal() == syscall.SIGPIPE
+		}
+	}
+	return false
+}
+
+// executeNativeScript executes a script using the native system shell. With bash, args are the
+// positional parameters of the script, while cmd has no equivalent.
+func (e *Executor) executeNativeScript(script string, args []string, workDir string, env []string, stdin io.Reader, stdout, stderr io.Writer) error {
 	// Determine shell
 	shell := "/bin/bash"
-	shellArgs := []string{"-c"}
+	shellArgs := []string{"-c", script, "mvx"}
+	shellArgs = append(shellArgs, args...)
 
 	if runtime.GOOS == "windows" {
 		shell = "cmd"
-		shellArgs = []string{"/c"}
+		shellArgs = []string{"/c", script}
 	}
 
 	util.LogVerbose("Executing native script: %s", script)
@@ -252,12 +748,12 @@ func (e *Executor) executeNativeScript(script, workDir string, env []string) err
 	}
 
 	// Create command
-	cmd := exec.Command(shell, append(shellArgs, script)...)
+	cmd := exec.Command(shell, shellArgs...)
 	cmd.Dir = workDir
 	cmd.Env = env
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Stdin = stdin
 
 	// Execute command
 	return cmd.Run()
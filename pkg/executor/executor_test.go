@@ -1,6 +1,12 @@
 package executor
 
 import (
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -51,7 +57,7 @@ func TestExecutor_SetupEnvironment(t *testing.T) {
 
 	// Test environment setup
 	cmdConfig := cfg.Commands["test-cmd"]
-	env, err := executor.setupEnvironment(cmdConfig)
+	env, err := executor.setupEnvironment("test-cmd", cmdConfig)
 	if err != nil {
 		t.Fatalf("setupEnvironment() error = %v", err)
 	}
@@ -114,6 +120,12 @@ func TestExecutor_ProcessScriptString(t *testing.T) {
 			args:     []string{"./...", "-timeout=30s"},
 			expected: "go test -v ./... -timeout=30s",
 		},
+		{
+			name:     "script referencing positional args",
+			script:   "deploy --target \"$1\" && notify ${@}",
+			args:     []string{"prod"},
+			expected: "deploy --target \"$1\" && notify ${@}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -169,6 +181,47 @@ func TestExecutor_ListCommands(t *testing.T) {
 	}
 }
 
+func TestExecutor_PlatformConstraint(t *testing.T) {
+	// Reset manager for test isolation
+	tools.ResetManager()
+
+	otherOS := "windows"
+	if runtime.GOOS == "windows" {
+		otherOS = "linux"
+	}
+	cfg := &config.Config{
+		Commands: map[string]config.CommandConfig{
+			"build": {
+				Description: "Build the project",
+				Script:      "echo build",
+			},
+			"notarize": {
+				Description: "Notarize the app",
+				Script:      "echo notarize",
+				OS:          []string{otherOS},
+			},
+		},
+	}
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	executor := NewExecutor(cfg, manager, t.TempDir())
+
+	commands := executor.ListCommands()
+	if _, listed := commands["notarize"]; listed {
+		t.Errorf("Expected notarize to be hidden on %s, got %v", runtime.GOOS, commands)
+	}
+	if _, listed := commands["build"]; !listed {
+		t.Errorf("Expected build to be listed, got %v", commands)
+	}
+
+	err = executor.ExecuteCommand("notarize", nil)
+	if err == nil || !strings.Contains(err.Error(), "not supported on this platform") {
+		t.Errorf("ExecuteCommand(notarize) error = %v, want a platform error", err)
+	}
+}
+
 func TestExecutor_GetCommandInfo(t *testing.T) {
 	// Reset manager for test isolation
 	tools.ResetManager()
@@ -313,3 +366,725 @@ func TestExecutor_InterpreterSelection(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_SilentCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	// Reset manager for test isolation
+	tools.ResetManager()
+
+	cfg := &config.Config{
+		Commands: map[string]config.CommandConfig{
+			"loud": {
+				Description: "Loud command",
+				Script:      "echo loud-output",
+				Interpreter: "native",
+			},
+			"quiet": {
+				Description: "Silent command",
+				Script:      "echo silent-output",
+				Interpreter: "native",
+				Silent:      true,
+			},
+		},
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	executor := NewExecutor(cfg, manager, t.TempDir())
+
+	runCaptured := func(commandName string) string {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		execErr := executor.ExecuteCommand(commandName, nil)
+
+		w.Close()
+		os.Stdout = oldStdout
+		output, _ := io.ReadAll(r)
+
+		if execErr != nil {
+			t.Fatalf("ExecuteCommand(%s) error = %v", commandName, execErr)
+		}
+		return string(output)
+	}
+
+	output := runCaptured("loud")
+	if !strings.Contains(output, "Running command: loud") || !strings.Contains(output, "loud-output") {
+		t.Errorf("Expected framing and command output, got:\n%s", output)
+	}
+
+	output = runCaptured("quiet")
+	if strings.Contains(output, "Running command") || strings.Contains(output, "Silent command") {
+		t.Errorf("Expected no framing for silent command, got:\n%s", output)
+	}
+	if strings.TrimSpace(output) != "silent-output" {
+		t.Errorf("Expected only command output for silent command, got:\n%s", output)
+	}
+}
+
+func TestExecutor_CommandArgs(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	// Reset manager for test isolation
+	tools.ResetManager()
+
+	cfg := &config.Config{
+		Commands: map[string]config.CommandConfig{
+			"shell":    {Script: `echo "[$1]" $# "$@"`, Interpreter: "mvx-shell", Silent: true},
+			"native":   {Script: `echo "$2|$1"`, Interpreter: "native", Silent: true},
+			"appended": {Script: `printf '[%s]\n'`, Interpreter: "native", Silent: true},
+			"bound": {
+				Script:      `echo "$env/$MVX_ARG_ENV" "$@"`,
+				Interpreter: "mvx-shell",
+				Silent:      true,
+				Args:        []config.CommandArgConfig{{Name: "env", Required: true}},
+				Passthrough: true,
+			},
+		},
+	}
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	executor := NewExecutor(cfg, manager, t.TempDir())
+
+	tests := []struct {
+		command  string
+		expected string
+	}{
+		{command: "shell", expected: "[--env=prod] 2 --env=prod it's me\n"},
+		{command: "native", expected: "it's me|--env=prod\n"},
+		{command: "appended", expected: "[--env=prod]\n[it's me]\n"},
+		{command: "bound", expected: "prod/prod it's me\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+			execErr := executor.ExecuteCommand(tt.command, []string{"--env=prod", "it's me"})
+			w.Close()
+			os.Stdout = oldStdout
+			output, _ := io.ReadAll(r)
+
+			if execErr != nil {
+				t.Fatalf("ExecuteCommand(%s) error = %v", tt.command, execErr)
+			}
+			if string(output) != tt.expected {
+				t.Errorf("ExecuteCommand(%s) output = %q, expected %q", tt.command, output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExecutor_Pipeline(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	// Reset manager for test isolation
+	tools.ResetManager()
+
+	cfg := &config.Config{
+		Commands: map[string]config.CommandConfig{
+			"generate": {
+				Script:      "printf 'banana\\napple\\ncherry\\n'",
+				Interpreter: "native",
+			},
+			"sort": {
+				Script:      "sort",
+				Interpreter: "native",
+			},
+			"count": {
+				Script:      "wc -l | tr -d ' '",
+				Interpreter: "native",
+			},
+			"sorted": {
+				Pipeline: []string{"generate", "sort"},
+				Silent:   true,
+			},
+			"counted": {
+				Pipeline: []string{"generate", "sort", "count"},
+				Silent:   true,
+			},
+			"broken": {
+				Pipeline: []string{"generate", "missing"},
+			},
+			"endless": {
+				Script:      "yes",
+				Interpreter: "native",
+			},
+			"first-two": {
+				Script:      "head -n 2",
+				Interpreter: "native",
+			},
+			"fail": {
+				Script:      "cat >/dev/null; exit 3",
+				Interpreter: "native",
+			},
+			"fail-early": {
+				Script:      "echo partial; exit 2",
+				Interpreter: "native",
+			},
+			"head": {
+				Pipeline: []string{"endless", "first-two"},
+				Silent:   true,
+			},
+			"failing-last": {
+				Pipeline: []string{"generate", "fail"},
+				Silent:   true,
+			},
+			"failing-first": {
+				Pipeline: []string{"fail-early", "sort"},
+				Silent:   true,
+			},
+		},
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	executor := NewExecutor(cfg, manager, t.TempDir())
+
+	runCaptured := func(commandName string) (string, error) {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		execErr := executor.ExecuteCommand(commandName, nil)
+
+		w.Close()
+		os.Stdout = oldStdout
+		output, _ := io.ReadAll(r)
+		return string(output), execErr
+	}
+
+	output, err := runCaptured("sorted")
+	if err != nil {
+		t.Fatalf("ExecuteCommand(sorted) error = %v", err)
+	}
+	if output != "apple\nbanana\ncherry\n" {
+		t.Errorf("Expected sorted output, got %q", output)
+	}
+
+	output, err = runCaptured("counted")
+	if err != nil {
+		t.Fatalf("ExecuteCommand(counted) error = %v", err)
+	}
+	if strings.TrimSpace(output) != "3" {
+		t.Errorf("Expected three lines counted, got %q", output)
+	}
+
+	if _, err := runCaptured("broken"); err == nil {
+		t.Error("Expected error for pipeline referencing an unknown command")
+	}
+
+	// A consumer exiting early ends the producer with a broken pipe, which is not a failure
+	output, err = runCaptured("head")
+	if err != nil {
+		t.Fatalf("ExecuteCommand(head) error = %v", err)
+	}
+	if output != "y\ny\n" {
+		t.Errorf("Expected the first two lines, got %q", output)
+	}
+
+	// The status of the pipeline is the one of its last step
+	_, err = runCaptured("failing-last")
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("ExecuteCommand(failing-last) error = %v, want exit status 3", err)
+	}
+
+	// Other failures of earlier steps still fail the pipeline
+	_, err = runCaptured("failing-first")
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 2 {
+		t.Errorf("ExecuteCommand(failing-first) error = %v, want exit status 2", err)
+	}
+}
+
+func TestExecutor_OutputFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	// Reset manager for test isolation
+	tools.ResetManager()
+
+	cfg := &config.Config{
+		Commands: map[string]config.CommandConfig{
+			"report": {
+				Script:      "echo to-stdout; echo to-stderr >&2; exit 3",
+				Interpreter: "native",
+				Environment: map[string]string{"REPORT_DIR": "build"},
+				OutputFile:  "${REPORT_DIR}/logs/report.log",
+				Silent:      true,
+			},
+			"shell-report": {
+				Script:      "echo from-mvx-shell",
+				Interpreter: "mvx-shell",
+				OutputFile:  "logs/shell.log",
+				Silent:      true,
+			},
+		},
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	projectRoot := t.TempDir()
+	executor := NewExecutor(cfg, manager, projectRoot)
+
+	runCaptured := func(commandName string) (string, error) {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		execErr := executor.ExecuteCommand(commandName, nil)
+
+		w.Close()
+		os.Stdout = oldStdout
+		output, _ := io.ReadAll(r)
+		return string(output), execErr
+	}
+
+	output, err := runCaptured("report")
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("Expected exit code 3 to be preserved, got %v", err)
+	}
+	if !strings.Contains(output, "to-stdout") {
+		t.Errorf("Expected output to still be streamed to the terminal, got %q", output)
+	}
+
+	captured, err := os.ReadFile(filepath.Join(projectRoot, "build", "logs", "report.log"))
+	if err != nil {
+		t.Fatalf("Expected output file to be created: %v", err)
+	}
+	for _, expected := range []string{"to-stdout", "to-stderr"} {
+		if !strings.Contains(string(captured), expected) {
+			t.Errorf("Expected output file to contain %q, got %q", expected, captured)
+		}
+	}
+
+	if _, err := runCaptured("shell-report"); err != nil {
+		t.Fatalf("ExecuteCommand(shell-report) error = %v", err)
+	}
+	captured, err = os.ReadFile(filepath.Join(projectRoot, "logs", "shell.log"))
+	if err != nil {
+		t.Fatalf("Expected output file to be created: %v", err)
+	}
+	if strings.TrimSpace(string(captured)) != "from-mvx-shell" {
+		t.Errorf("Expected mvx-shell output in file, got %q", captured)
+	}
+}
+
+func TestExecutor_Hooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	// Reset manager for test isolation
+	tools.ResetManager()
+
+	cfg := &config.Config{
+		Commands: map[string]config.CommandConfig{
+			"build": {
+				Script:      "echo building",
+				Interpreter: "native",
+				Pre:         "echo checking",
+				Post:        []interface{}{"echo done", map[string]interface{}{"script": "echo reported >&2", "interpreter": "native"}},
+				OutputFile:  "build.log",
+				Silent:      true,
+			},
+			"broken": {
+				Script:      "exit 2",
+				Interpreter: "native",
+				Post:        "echo should-not-run",
+				OutputFile:  "broken.log",
+				Silent:      true,
+			},
+			"reuse": {
+				Uses:       "announce",
+				Pre:        []interface{}{"announce", map[string]interface{}{"uses": "announce"}},
+				OutputFile: "reuse.log",
+				Silent:     true,
+			},
+		},
+		Hooks: map[string]config.CommandConfig{
+			"announce": {Script: "echo announced", Interpreter: "native"},
+		},
+	}
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	projectRoot := t.TempDir()
+	executor := NewExecutor(cfg, manager, projectRoot)
+
+	tests := []struct {
+		name     string
+		prefix   string
+		expected []string
+	}{
+		{"default", "", []string{"[pre:build] checking\n", "\nbuilding\n", "[post:build] done\n", "[post:build] reported\n"}},
+		{"forced", "true", []string{"[pre:build] checking\n", "[build] building\n", "[post:build] done\n"}},
+		{"disabled", "false", []string{"checking\nbuilding\ndone\n"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvOutputPrefix, tt.prefix)
+			if err := executor.ExecuteCommand("build", nil); err != nil {
+				t.Fatalf("ExecuteCommand(build) error = %v", err)
+			}
+			captured, err := os.ReadFile(filepath.Join(projectRoot, "build.log"))
+			if err != nil {
+				t.Fatalf("Expected output file to be created: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(string(captured), expected) {
+					t.Errorf("Expected output to contain %q, got %q", expected, captured)
+				}
+			}
+		})
+	}
+
+	// Named hooks provide the scripts of hooks and commands referencing them
+	t.Setenv(EnvOutputPrefix, "")
+	if err := executor.ExecuteCommand("reuse", nil); err != nil {
+		t.Fatalf("ExecuteCommand(reuse) error = %v", err)
+	}
+	captured, _ := os.ReadFile(filepath.Join(projectRoot, "reuse.log"))
+	if expected := "[pre:reuse] announced\n[pre:reuse] announced\nannounced\n"; string(captured) != expected {
+		t.Errorf("Expected output %q, got %q", expected, captured)
+	}
+
+	if err := executor.ExecuteCommand("broken", nil); err == nil {
+		t.Fatal("Expected broken command to fail")
+	}
+	captured, _ = os.ReadFile(filepath.Join(projectRoot, "broken.log"))
+	if strings.Contains(string(captured), "should-not-run") {
+		t.Errorf("Expected post hook not to run after a failure, got %q", captured)
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	var out strings.Builder
+	w := newPrefixWriter(&out, "pre:build")
+	for _, chunk := range []string{"first li", "ne\nsecond\n", "", "third"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := "[pre:build] first line\n[pre:build] second\n[pre:build] third"
+	if out.String() != expected {
+		t.Errorf("prefixWriter output = %q, want %q", out.String(), expected)
+	}
+}
+
+func TestExecutor_Retries(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	// Reset manager for test isolation
+	tools.ResetManager()
+
+	// Each run counts its attempt in a file and fails until the third one
+	flaky := `n=$(cat attempts 2>/dev/null || echo 0); n=$((n+1)); echo $n > attempts; [ $n -ge 3 ]`
+
+	tests := []struct {
+		name         string
+		command      config.CommandConfig
+		wantExitCode int
+		wantAttempts string
+	}{
+		{
+			name:         "succeeds on retry",
+			command:      config.CommandConfig{Script: flaky, Interpreter: "native", Retries: 3, RetryDelay: "10ms"},
+			wantAttempts: "3",
+		},
+		{
+			name:         "retries exhausted",
+			command:      config.CommandConfig{Script: flaky + " || exit 7", Interpreter: "native", Retries: 1},
+			wantExitCode: 7,
+			wantAttempts: "2",
+		},
+		{
+			name:         "no retries",
+			command:      config.CommandConfig{Script: flaky, Interpreter: "native"},
+			wantExitCode: 1,
+			wantAttempts: "1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectRoot := t.TempDir()
+			cfg := &config.Config{Commands: map[string]config.CommandConfig{"flaky": tt.command}}
+			manager, err := tools.NewManager()
+			if err != nil {
+				t.Fatalf("Failed to create tool manager: %v", err)
+			}
+
+			err = NewExecutor(cfg, manager, projectRoot).ExecuteCommand("flaky", nil)
+			if tt.wantExitCode == 0 {
+				if err != nil {
+					t.Errorf("ExecuteCommand() error = %v", err)
+				}
+			} else {
+				var exitErr *exec.ExitError
+				if !errors.As(err, &exitErr) || exitErr.ExitCode() != tt.wantExitCode {
+					t.Errorf("ExecuteCommand() error = %v, want exit code %d", err, tt.wantExitCode)
+				}
+			}
+
+			attempts, _ := os.ReadFile(filepath.Join(projectRoot, "attempts"))
+			if got := strings.TrimSpace(string(attempts)); got != tt.wantAttempts {
+				t.Errorf("attempts = %s, want %s", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestExecutor_EnvFile(t *testing.T) {
+	tools.ResetManager()
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+
+	projectRoot := t.TempDir()
+	envFile := "# Production settings\n" +
+		"export DEPLOY_TARGET=prod\n" +
+		"GLOBAL_VAR=from-file\n" +
+		"CMD_VAR=from-file\n" +
+		"QUOTED=\"line one\\nline two\"\n" +
+		"LITERAL='$HOME' # not expanded\n"
+	if err := os.WriteFile(filepath.Join(projectRoot, ".env.prod"), []byte(envFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		Environment: map[string]string{"GLOBAL_VAR": "from-config"},
+	}
+	executor := NewExecutor(cfg, manager, projectRoot)
+
+	t.Run("precedence", func(t *testing.T) {
+		env, err := executor.setupEnvironment("test", config.CommandConfig{
+			EnvFile:     ".env.${STAGE}",
+			Environment: map[string]string{"STAGE": "prod", "CMD_VAR": "from-command"},
+		})
+		if err != nil {
+			t.Fatalf("setupEnvironment() error = %v", err)
+		}
+		envMap := make(map[string]string)
+		for _, envVar := range env {
+			if key, value, ok := strings.Cut(envVar, "="); ok {
+				envMap[key] = value
+			}
+		}
+
+		expected := map[string]string{
+			"DEPLOY_TARGET": "prod",
+			"GLOBAL_VAR":    "from-file",    // env file overrides config environment
+			"CMD_VAR":       "from-command", // command environment overrides env file
+			"QUOTED":        "line one\nline two",
+			"LITERAL":       "$HOME",
+		}
+		for key, value := range expected {
+			if envMap[key] != value {
+				t.Errorf("%s = %q, expected %q", key, envMap[key], value)
+			}
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := executor.setupEnvironment("test", config.CommandConfig{EnvFile: ".env.missing"})
+		if err == nil || !strings.Contains(err.Error(), "env file not found") {
+			t.Errorf("setupEnvironment() error = %v, expected an env file not found error", err)
+		}
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		if err := os.WriteFile(filepath.Join(projectRoot, ".env.bad"), []byte("NOT A VARIABLE\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		_, err := executor.setupEnvironment("test", config.CommandConfig{EnvFile: ".env.bad"})
+		if err == nil || !strings.Contains(err.Error(), "line 1") {
+			t.Errorf("setupEnvironment() error = %v, expected a parse error on line 1", err)
+		}
+	})
+}
+
+// fakeTool installs empty versions, to run commands with tools without downloads
+type fakeTool struct {
+	*tools.BaseTool
+}
+
+func (f *fakeTool) Install(version string, cfg config.ToolConfig) error {
+	return os.MkdirAll(filepath.Join(f.GetInstallDir(version, cfg), "bin"), 0755)
+}
+
+func (f *fakeTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	_, err := os.Stat(f.GetInstallDir(version, cfg))
+	return err == nil
+}
+
+func (f *fakeTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return filepath.Join(f.GetInstallDir(version, cfg), "bin"), nil
+}
+
+func (f *fakeTool) Verify(version string, cfg config.ToolConfig) error {
+	return nil
+}
+
+func (f *fakeTool) ListVersions() ([]string, error) {
+	return []string{"1.0.0", "2.0.0"}, nil
+}
+
+func (f *fakeTool) GetDownloadURL(version string) string {
+	return ""
+}
+
+func (f *fakeTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (tools.ChecksumInfo, error) {
+	return tools.ChecksumInfo{}, nil
+}
+
+func TestExecutor_CommandTools(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	// Install the fake tool in an isolated home
+	t.Setenv("HOME", t.TempDir())
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	manager.RegisterTool(&fakeTool{BaseTool: tools.NewBaseTool(manager, "faketool", "faketool")})
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{"faketool": {Version: "1.0.0"}},
+		Commands: map[string]config.CommandConfig{
+			"legacy": {
+				Script:      "echo $PATH",
+				Interpreter: "native",
+				Tools:       map[string]config.ToolConfig{"faketool": {Version: "2.0.0"}},
+				OutputFile:  "legacy.log",
+				Silent:      true,
+			},
+			"unknown": {
+				Script: "echo",
+				Tools:  map[string]config.ToolConfig{"nosuchtool": {Version: "1.0.0"}},
+			},
+		},
+	}
+	projectRoot := t.TempDir()
+	executor := NewExecutor(cfg, manager, projectRoot)
+
+	if err := executor.ExecuteCommand("legacy", nil); err != nil {
+		t.Fatalf("ExecuteCommand(legacy) error = %v", err)
+	}
+	captured, err := os.ReadFile(filepath.Join(projectRoot, "legacy.log"))
+	if err != nil {
+		t.Fatalf("Expected output file to be created: %v", err)
+	}
+	path := string(captured)
+	if !strings.Contains(path, filepath.Join("faketool", "2.0.0", "bin")) {
+		t.Errorf("Expected PATH to contain the command's faketool 2.0.0, got %q", path)
+	}
+	if strings.Contains(path, filepath.Join("faketool", "1.0.0", "bin")) {
+		t.Errorf("Expected PATH not to contain the project's faketool 1.0.0, got %q", path)
+	}
+
+	if err := executor.ExecuteCommand("unknown", nil); err == nil || !strings.Contains(err.Error(), "unknown tool: nosuchtool") {
+		t.Errorf("Expected an unknown tool error, got %v", err)
+	}
+}
+
+// onDemandFakeTool is a fakeTool installing missing versions when checked, as real tools do
+type onDemandFakeTool struct {
+	*fakeTool
+}
+
+func (f *onDemandFakeTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	if !f.fakeTool.IsInstalled(version, cfg) {
+		return f.Install(version, cfg) == nil
+	}
+	return true
+}
+
+func TestExecutor_RequiredForTools(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping native shell test on Windows")
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	tools.ResetManager()
+	defer tools.ResetManager()
+
+	manager, err := tools.NewManager()
+	if err != nil {
+		t.Fatalf("Failed to create tool manager: %v", err)
+	}
+	manager.RegisterTool(&onDemandFakeTool{&fakeTool{BaseTool: tools.NewBaseTool(manager, "buildtool", "buildtool")}})
+	siteTool := &onDemandFakeTool{&fakeTool{BaseTool: tools.NewBaseTool(manager, "sitetool", "sitetool")}}
+	manager.RegisterTool(siteTool)
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"buildtool": {Version: "1.0.0"},
+			"sitetool":  {Version: "1.0.0", RequiredFor: []string{"website"}},
+		},
+		Commands: map[string]config.CommandConfig{
+			"build":   {Script: "echo $PATH", Interpreter: "native", OutputFile: "build.log", Silent: true},
+			"website": {Script: "echo $PATH", Interpreter: "native", OutputFile: "website.log", Silent: true},
+		},
+	}
+	projectRoot := t.TempDir()
+	executor := NewExecutor(cfg, manager, projectRoot)
+	siteBin := filepath.Join("sitetool", "1.0.0", "bin")
+
+	if err := executor.ExecuteCommand("build", nil); err != nil {
+		t.Fatalf("ExecuteCommand(build) error = %v", err)
+	}
+	captured, err := os.ReadFile(filepath.Join(projectRoot, "build.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(captured), filepath.Join("buildtool", "1.0.0", "bin")) {
+		t.Errorf("build PATH = %q, want buildtool on it", captured)
+	}
+	if strings.Contains(string(captured), siteBin) {
+		t.Errorf("build PATH = %q, want sitetool, only required for website, left out", captured)
+	}
+	if siteTool.fakeTool.IsInstalled("1.0.0", cfg.Tools["sitetool"]) {
+		t.Errorf("sitetool installed by build, want it installed only for website")
+	}
+
+	if err := executor.ExecuteCommand("website", nil); err != nil {
+		t.Fatalf("ExecuteCommand(website) error = %v", err)
+	}
+	captured, err = os.ReadFile(filepath.Join(projectRoot, "website.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(captured), siteBin) {
+		t.Errorf("website PATH = %q, want sitetool on it", captured)
+	}
+}
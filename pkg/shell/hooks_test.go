@@ -337,3 +337,30 @@ func TestHookDirectoryChangeDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateInitHook(t *testing.T) {
+	mvxPath := "/opt/it's mvx/mvx"
+	tests := []struct {
+		shellType string
+		expected  []string
+	}{
+		{shellType: "bash", expected: []string{`'/opt/it'\''s mvx/mvx' hook-env --shell bash`, "PROMPT_COMMAND="}},
+		{shellType: "zsh", expected: []string{`'/opt/it'\''s mvx/mvx' hook-env --shell zsh`, "precmd_functions=", "chpwd_functions="}},
+		{shellType: "fish", expected: []string{`'/opt/it\'s mvx/mvx' hook-env --shell fish | source`, "--on-variable PWD"}},
+	}
+	for _, tt := range tests {
+		hook, err := GenerateInitHook(tt.shellType, mvxPath)
+		if err != nil {
+			t.Fatalf("GenerateInitHook(%s) error = %v", tt.shellType, err)
+		}
+		for _, expected := range tt.expected {
+			if !strings.Contains(hook, expected) {
+				t.Errorf("GenerateInitHook(%s) missing %q:\n%s", tt.shellType, expected, hook)
+			}
+		}
+	}
+
+	if _, err := GenerateInitHook("powershell", mvxPath); err == nil {
+		t.Error("GenerateInitHook(powershell) should fail")
+	}
+}
@@ -1,12 +1,16 @@
 package shell
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/gnodet/mvx/pkg/util"
 )
@@ -15,6 +19,10 @@ import (
 type MVXShell struct {
 	workDir string
 	env     []string
+	args    []string // Positional parameters, $1..$N
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
 }
 
 // NewMVXShell creates a new cross-platform shell instance
@@ -22,9 +30,24 @@ func NewMVXShell(workDir string, env []string) *MVXShell {
 	return &MVXShell{
 		workDir: workDir,
 		env:     env,
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
 	}
 }
 
+// SetIO sets the standard streams used by built-in and external commands
+func (s *MVXShell) SetIO(stdin io.Reader, stdout, stderr io.Writer) {
+	s.stdin = stdin
+	s.stdout = stdout
+	s.stderr = stderr
+}
+
+// SetArgs sets the positional parameters of the script, expanded by $1..$N, $@, $* and $#
+func (s *MVXShell) SetArgs(args []string) {
+	s.args = args
+}
+
 // Execute executes a script using the cross-platform interpreter
 func (s *MVXShell) Execute(script string) error {
 	chains, err := parseCommands(script)
@@ -44,9 +67,16 @@ func (s *MVXShell) Execute(script string) error {
 
 // Command represents a parsed command
 type Command struct {
-	Name string
-	Args []string
-	Env  map[string]string // Environment variables for this command
+	Name      string
+	Args      []string
+	Env       map[string]string // Environment variables for this command
+	Redirects []Redirect        // Output redirections, applied in order
+}
+
+// Redirect represents an output redirection of a command
+type Redirect struct {
+	Operator string // ">", ">>", "2>", "2>>" or "2>&1"
+	Target   string // File path, relative to the working directory (empty for "2>&1")
 }
 
 // CommandChain represents a chain of commands with operators
@@ -66,6 +96,43 @@ func parseCommands(script string) ([]CommandChain, error) {
 	return parseTokens(tokens)
 }
 
+// ValidateScript checks that a script can be run by mvx-shell without executing it.
+// It reports parse errors as well as bash-only constructs that mvx-shell does not
+// support, such as backtick command substitution and heredocs.
+func ValidateScript(script string) error {
+	if err := checkUnsupportedSyntax(script); err != nil {
+		return err
+	}
+	if _, err := parseCommands(script); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkUnsupportedSyntax detects bash-only syntax outside of single quotes
+func checkUnsupportedSyntax(script string) error {
+	inSingleQuotes := false
+	for i := 0; i < len(script); i++ {
+		char := script[i]
+		if char == '\'' {
+			inSingleQuotes = !inSingleQuotes
+			continue
+		}
+		if inSingleQuotes {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(script[i:], "$(("):
+			return fmt.Errorf("arithmetic expansion $((...)) is not supported by mvx-shell")
+		case char == '`':
+			return fmt.Errorf("command substitution `...` is not supported by mvx-shell")
+		case char == '<' && i+1 < len(script) && script[i+1] == '<':
+			return fmt.Errorf("heredocs (<<) are not supported by mvx-shell")
+		}
+	}
+	return nil
+}
+
 // Token represents a parsed token
 type Token struct {
 	Type  TokenType
@@ -81,6 +148,7 @@ const (
 	TokenLeftParen
 	TokenRightParen
 	TokenSemicolon
+	TokenRedirect
 )
 
 // tokenize breaks a script into tokens
@@ -93,6 +161,17 @@ func tokenize(script string) ([]Token, error) {
 	for i := 0; i < len(script); i++ {
 		char := script[i]
 
+		// Keep command substitutions whole, outside of single quotes
+		if char == '$' && i+1 < len(script) && script[i+1] == '(' && quoteChar != '\'' {
+			end := substitutionEnd(script, i)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated command substitution in script")
+			}
+			current.WriteString(script[i : end+1])
+			i = end
+			continue
+		}
+
 		// Handle quotes
 		if (char == '"' || char == '\'') && !inQuotes {
 			inQuotes = true
@@ -157,6 +236,27 @@ func tokenize(script string) ([]Token, error) {
 				current.Reset()
 			}
 			tokens = append(tokens, Token{TokenRightParen, ")"})
+		case '>':
+			// A "2" standing alone before ">" redirects stderr
+			operator := ">"
+			text := current.String()
+			if text == "2" || strings.HasSuffix(text, " 2") || strings.HasSuffix(text, "\t2") {
+				operator = "2>"
+				current.Reset()
+				current.WriteString(text[:len(text)-1])
+			}
+			if current.Len() > 0 {
+				tokens = append(tokens, Token{TokenCommand, strings.TrimSpace(current.String())})
+				current.Reset()
+			}
+			if i+1 < len(script) && script[i+1] == '>' {
+				operator += ">"
+				i++ // Skip next >
+			} else if operator == "2>" && strings.HasPrefix(script[i+1:], "&1") {
+				operator = "2>&1"
+				i += 2 // Skip &1
+			}
+			tokens = append(tokens, Token{TokenRedirect, operator})
 		case ' ', '\t', '\n', '\r':
 			if current.Len() > 0 {
 				// Don't break on whitespace, just add it to current command
@@ -183,13 +283,31 @@ func parseTokens(tokens []Token) ([]CommandChain, error) {
 	var chains []CommandChain
 	var currentChain CommandChain
 	lastWasOperator := false
+	var pendingRedirect *Redirect
 
 	for _, token := range tokens {
+		if pendingRedirect != nil && token.Type != TokenCommand {
+			return nil, fmt.Errorf("missing target for redirection %s", pendingRedirect.Operator)
+		}
+
 		switch token.Type {
 		case TokenCommand:
 			if token.Value == "" {
 				continue
 			}
+			if pendingRedirect != nil {
+				// The first word is the redirection target, the others are more arguments
+				fields, err := parseShellArgs(token.Value)
+				if err != nil {
+					return nil, err
+				}
+				last := &currentChain.Commands[len(currentChain.Commands)-1]
+				pendingRedirect.Target = fields[0]
+				last.Redirects = append(last.Redirects, *pendingRedirect)
+				last.Args = append(last.Args, fields[1:]...)
+				pendingRedirect = nil
+				continue
+			}
 			cmd, err := parseCommand(token.Value)
 			if err != nil {
 				return nil, err
@@ -208,6 +326,17 @@ func parseTokens(tokens []Token) ([]CommandChain, error) {
 			currentChain.Operators = append(currentChain.Operators, token.Value)
 			lastWasOperator = true
 
+		case TokenRedirect:
+			if len(currentChain.Commands) == 0 || lastWasOperator {
+				return nil, fmt.Errorf("redirection %s without preceding command", token.Value)
+			}
+			last := &currentChain.Commands[len(currentChain.Commands)-1]
+			if token.Value == "2>&1" {
+				last.Redirects = append(last.Redirects, Redirect{Operator: token.Value})
+			} else {
+				pendingRedirect = &Redirect{Operator: token.Value}
+			}
+
 		case TokenSemicolon:
 			// Semicolon ends the current chain (even if empty)
 			if len(currentChain.Commands) > 0 {
@@ -226,6 +355,10 @@ func parseTokens(tokens []Token) ([]CommandChain, error) {
 		}
 	}
 
+	if pendingRedirect != nil {
+		return nil, fmt.Errorf("missing target for redirection %s", pendingRedirect.Operator)
+	}
+
 	if len(currentChain.Commands) > 0 {
 		chains = append(chains, currentChain)
 	}
@@ -265,7 +398,8 @@ func parseCommand(cmdStr string) (Command, error) {
 	}
 
 	if cmdStart >= len(fields) {
-		return Command{}, fmt.Errorf("no command found after environment variables")
+		// Only assignments, which set shell variables
+		return Command{Env: env}, nil
 	}
 
 	return Command{
@@ -285,6 +419,15 @@ func parseShellArgs(cmdStr string) ([]string, error) {
 	for i := 0; i < len(cmdStr); i++ {
 		char := cmdStr[i]
 
+		// Keep command substitutions, including their quotes, for execution
+		if char == '$' && i+1 < len(cmdStr) && cmdStr[i+1] == '(' && quoteChar != '\'' {
+			if end := substitutionEnd(cmdStr, i); end != -1 {
+				current.WriteString(cmdStr[i : end+1])
+				i = end
+				continue
+			}
+		}
+
 		// Handle quotes
 		if (char == '"' || char == '\'') && !inQuotes {
 			inQuotes = true
@@ -348,27 +491,23 @@ func isValidEnvVarName(name string) bool {
 	return true
 }
 
-// executeCommandChain executes a chain of commands with operators
+// executeCommandChain executes a chain of commands with operators.
+// Pipes bind tighter than && and ||, so "a | b && c" runs the pipeline "a | b" before c.
 func (s *MVXShell) executeCommandChain(chain CommandChain) error {
-	if len(chain.Commands) == 0 {
-		return nil
-	}
-
-	// Handle single command
-	if len(chain.Commands) == 1 {
-		return s.executeCommand(chain.Commands[0])
-	}
-
-	// Handle command chain with operators
-	for i, cmd := range chain.Commands {
-		err := s.executeCommand(cmd)
+	for i := 0; i < len(chain.Commands); {
+		// Collect the commands of the pipeline starting at i
+		end := i
+		for end < len(chain.Operators) && chain.Operators[end] == "|" {
+			end++
+		}
+		err := s.executePipeline(chain.Commands[i : end+1])
 
-		// If this is the last command, we're done
-		if i >= len(chain.Operators) {
+		// If this is the last pipeline, we're done
+		if end >= len(chain.Operators) {
 			return err
 		}
 
-		operator := chain.Operators[i]
+		operator := chain.Operators[end]
 
 		switch operator {
 		case "&&":
@@ -383,20 +522,77 @@ func (s *MVXShell) executeCommandChain(chain CommandChain) error {
 				return nil
 			}
 			// Command failed, continue to next command
-		case "|":
-			// For pipes, we need to handle input/output redirection
-			// For now, treat as sequential execution
-			if err != nil {
-				return err
-			}
 		default:
 			return fmt.Errorf("unsupported operator: %s", operator)
 		}
+		i = end + 1
 	}
 
 	return nil
 }
 
+// executePipeline runs commands concurrently, connecting the output of each command to the
+// input of the next one. Like in POSIX shells, its status is the one of the last command,
+// and built-ins such as cd only affect their own pipeline stage.
+func (s *MVXShell) executePipeline(commands []Command) error {
+	if len(commands) == 1 {
+		return s.executeCommand(commands[0])
+	}
+
+	// All commands write to stderr concurrently
+	stderr := s.stderr
+	if _, ok := stderr.(*os.File); !ok {
+		stderr = &syncWriter{w: stderr}
+	}
+
+	errs := make([]error, len(commands))
+	var wg sync.WaitGroup
+	var stdin io.Reader = s.stdin
+	for i, cmd := range commands {
+		stage := *s
+		stage.stdin = stdin
+		stage.stderr = stderr
+
+		var reader *io.PipeReader
+		var writer *io.PipeWriter
+		if i < len(commands)-1 {
+			reader, writer = io.Pipe()
+			stage.stdout = writer
+			stdin = reader
+		}
+		input, _ := stage.stdin.(*io.PipeReader)
+
+		wg.Add(1)
+		go func(i int, cmd Command) {
+			defer wg.Done()
+			errs[i] = stage.executeCommand(cmd)
+			// Signal end of output to the next command
+			if writer != nil {
+				writer.Close()
+			}
+			// Unblock the previous command if this one exited without reading all its input
+			if input != nil {
+				input.CloseWithError(io.ErrClosedPipe)
+			}
+		}(i, cmd)
+	}
+	wg.Wait()
+
+	return errs[len(errs)-1]
+}
+
+// syncWriter serializes writes to a writer shared by concurrent commands
+type syncWriter struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.w.Write(p)
+}
+
 func expandHome(path string) string {
 	if strings.HasPrefix(path, "~/") {
 		if home, err := os.UserHomeDir(); err == nil {
@@ -419,16 +615,31 @@ func (s *MVXShell) executeCommand(cmd Command) error {
 		}
 	}
 
-	// Override with command-specific environment variables
+	// Override with command-specific environment variables, e.g. VAR=$(command) assignments
+	cmdEnv := make(map[string]string, len(cmd.Env))
 	for key, value := range cmd.Env {
-		envMap[key] = value
+		cmdEnv[key] = s.substituteCommands(value)
+		envMap[key] = cmdEnv[key]
 	}
 
-	// Expand variables in command name and arguments
-	expandedName := s.ExpandVariables(cmd.Name, envMap)
-	expandedArgs := make([]string, len(cmd.Args))
-	for i, arg := range cmd.Args {
-		expandedArgs[i] = s.ExpandVariables(arg, envMap)
+	// Assignments without a command set shell variables for the following commands
+	if cmd.Name == "" {
+		for key, value := range cmdEnv {
+			s.setEnv(key, value)
+		}
+		return nil
+	}
+
+	// Expand command substitutions and variables in command name and arguments
+	expandedName := s.ExpandVariables(s.substituteCommands(cmd.Name), envMap)
+	// "$@" expands to one argument per positional parameter
+	expandedArgs := make([]string, 0, len(cmd.Args))
+	for _, arg := range cmd.Args {
+		if arg == "$@" || arg == "${@}" {
+			expandedArgs = append(expandedArgs, s.args...)
+			continue
+		}
+		expandedArgs = append(expandedArgs, s.ExpandVariables(s.substituteCommands(arg), envMap))
 	}
 
 	// Expand ~ in all arguments
@@ -440,7 +651,15 @@ func (s *MVXShell) executeCommand(cmd Command) error {
 	expandedCmd := Command{
 		Name: expandedName,
 		Args: expandedArgs,
-		Env:  cmd.Env,
+		Env:  cmdEnv,
+	}
+
+	if len(cmd.Redirects) > 0 {
+		restore, err := s.applyRedirects(cmd.Redirects, envMap)
+		if err != nil {
+			return err
+		}
+		defer restore()
 	}
 
 	switch expandedCmd.Name {
@@ -462,6 +681,117 @@ func (s *MVXShell) executeCommand(cmd Command) error {
 	}
 }
 
+// substituteCommands replaces the $(command) substitutions in text with the output of the
+// commands, run by this shell, without trailing newlines. Unlike POSIX shells, the output
+// is not split into several arguments.
+func (s *MVXShell) substituteCommands(text string) string {
+	offset := 0
+	for {
+		start := strings.Index(text[offset:], "$(")
+		if start == -1 {
+			return text
+		}
+		start += offset
+		end := substitutionEnd(text, start)
+		if end == -1 {
+			return text
+		}
+		output := s.captureOutput(text[start+2 : end])
+		text = text[:start] + output + text[end+1:]
+		offset = start + len(output)
+	}
+}
+
+// captureOutput runs a script and returns its output without trailing newlines.
+// As in POSIX shells, the status of the script is ignored.
+func (s *MVXShell) captureOutput(script string) string {
+	var output bytes.Buffer
+	subshell := *s
+	subshell.stdout = &output
+	if err := subshell.Execute(script); err != nil {
+		util.LogVerbose("mvx-shell command substitution $(%s) failed: %v", script, err)
+	}
+	return strings.TrimRight(output.String(), "\r\n")
+}
+
+// substitutionEnd returns the index of the parenthesis closing the command substitution
+// starting with "$(" at start, or -1 if it is unterminated
+func substitutionEnd(text string, start int) int {
+	depth := 0
+	quote := byte(0)
+	for i := start + 1; i < len(text); i++ {
+		switch char := text[i]; {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+		case char == '\'' || char == '"':
+			quote = char
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// setEnv sets a variable of the shell environment
+func (s *MVXShell) setEnv(key, value string) {
+	env := make([]string, 0, len(s.env)+1)
+	for _, envVar := range s.env {
+		if name, _, _ := strings.Cut(envVar, "="); name != key {
+			env = append(env, envVar)
+		}
+	}
+	s.env = append(env, key+"="+value)
+}
+
+// applyRedirects points the shell's stdout and stderr to the redirection targets of a command.
+// The returned function closes the files and restores the previous streams.
+func (s *MVXShell) applyRedirects(redirects []Redirect, envMap map[string]string) (func(), error) {
+	stdout, stderr := s.stdout, s.stderr
+	var files []*os.File
+	restore := func() {
+		for _, file := range files {
+			file.Close()
+		}
+		s.stdout, s.stderr = stdout, stderr
+	}
+
+	for _, redirect := range redirects {
+		if redirect.Operator == "2>&1" {
+			s.stderr = s.stdout
+			continue
+		}
+
+		target := expandHome(s.ExpandVariables(redirect.Target, envMap))
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(s.workDir, target)
+		}
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if strings.HasSuffix(redirect.Operator, ">>") {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		file, err := os.OpenFile(target, flags, 0644)
+		if err != nil {
+			restore()
+			return nil, fmt.Errorf("cannot redirect to %s: %w", redirect.Target, err)
+		}
+		files = append(files, file)
+
+		if strings.HasPrefix(redirect.Operator, "2") {
+			s.stderr = file
+		} else {
+			s.stdout = file
+		}
+	}
+	return restore, nil
+}
+
 // changeDirectory changes the current working directory
 func (s *MVXShell) changeDirectory(args []string) error {
 	if len(args) != 1 {
@@ -484,7 +814,7 @@ func (s *MVXShell) changeDirectory(args []string) error {
 
 // echo prints text to stdout (variable expansion handled at command level)
 func (s *MVXShell) echo(args []string, cmdEnv map[string]string) error {
-	fmt.Println(strings.Join(args, " "))
+	fmt.Fprintln(s.stdout, strings.Join(args, " "))
 	return nil
 }
 
@@ -505,7 +835,7 @@ func (s *MVXShell) ExpandVariables(text string, envMap map[string]string) string
 		end += start
 
 		varName := result[start+2 : end]
-		varValue := envMap[varName]
+		varValue := s.lookupVariable(varName, envMap)
 		result = result[:start] + varValue + result[end+1:]
 	}
 
@@ -516,14 +846,18 @@ func (s *MVXShell) ExpandVariables(text string, envMap map[string]string) string
 			break
 		}
 
-		// Find the end of the variable name
+		// Find the end of the variable name, special parameters being a single character
 		end := start + 1
-		for end < len(result) {
-			c := result[end]
-			if !((c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_') {
-				break
-			}
+		if end < len(result) && strings.IndexByte("@*#0123456789", result[end]) >= 0 {
 			end++
+		} else {
+			for end < len(result) {
+				c := result[end]
+				if !((c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || c == '_') {
+					break
+				}
+				end++
+			}
 		}
 
 		if end == start+1 {
@@ -532,13 +866,30 @@ func (s *MVXShell) ExpandVariables(text string, envMap map[string]string) string
 		}
 
 		varName := result[start+1 : end]
-		varValue := envMap[varName]
+		varValue := s.lookupVariable(varName, envMap)
 		result = result[:start] + varValue + result[end:]
 	}
 
 	return result
 }
 
+// lookupVariable returns the value of a variable or of a positional or special parameter
+func (s *MVXShell) lookupVariable(name string, envMap map[string]string) string {
+	switch name {
+	case "@", "*":
+		return strings.Join(s.args, " ")
+	case "#":
+		return strconv.Itoa(len(s.args))
+	}
+	if index, err := strconv.Atoi(name); err == nil && index >= 1 {
+		if index <= len(s.args) {
+			return s.args[index-1]
+		}
+		return ""
+	}
+	return envMap[name]
+}
+
 // makeDirectory creates directories
 func (s *MVXShell) makeDirectory(args []string) error {
 	if len(args) == 0 {
@@ -676,9 +1027,9 @@ func (s *MVXShell) executeExternal(cmd Command) error {
 	}
 
 	execCmd.Env = env
-	execCmd.Stdout = os.Stdout
-	execCmd.Stderr = os.Stderr
-	execCmd.Stdin = os.Stdin
+	execCmd.Stdout = s.stdout
+	execCmd.Stderr = s.stderr
+	execCmd.Stdin = s.stdin
 
 	return execCmd.Run()
 }
@@ -0,0 +1,72 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenerateInitHook generates the code 'mvx shell-init' prints: a prompt hook running
+// 'mvx hook-env', whose output updates the environment when entering or leaving a project
+func GenerateInitHook(shellType, mvxPath string) (string, error) {
+	switch shellType {
+	case "bash":
+		return fmt.Sprintf(`# mvx shell integration for bash
+# Generated by: mvx shell-init bash
+
+_mvx_hook_env() {
+    local previous_exit_status=$?
+    eval "$(%s hook-env --shell bash)"
+    return $previous_exit_status
+}
+
+if [[ ";${PROMPT_COMMAND:-};" != *";_mvx_hook_env;"* ]]; then
+    PROMPT_COMMAND="_mvx_hook_env${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+
+_mvx_hook_env
+`, quotePOSIX(mvxPath)), nil
+
+	case "zsh":
+		return fmt.Sprintf(`# mvx shell integration for zsh
+# Generated by: mvx shell-init zsh
+
+_mvx_hook_env() {
+    eval "$(%s hook-env --shell zsh)"
+}
+
+typeset -ag precmd_functions chpwd_functions
+if (( ! ${precmd_functions[(I)_mvx_hook_env]} )); then
+    precmd_functions=(_mvx_hook_env $precmd_functions)
+fi
+if (( ! ${chpwd_functions[(I)_mvx_hook_env]} )); then
+    chpwd_functions=(_mvx_hook_env $chpwd_functions)
+fi
+
+_mvx_hook_env
+`, quotePOSIX(mvxPath)), nil
+
+	case "fish":
+		return fmt.Sprintf(`# mvx shell integration for fish
+# Generated by: mvx shell-init fish
+
+function _mvx_hook_env --on-event fish_prompt --on-variable PWD
+    %s hook-env --shell fish | source
+end
+
+_mvx_hook_env
+`, quoteFish(mvxPath)), nil
+
+	default:
+		return "", fmt.Errorf("unsupported shell: %s (supported: bash, zsh, fish)", shellType)
+	}
+}
+
+// quotePOSIX single-quotes a word for bash and zsh
+func quotePOSIX(word string) string {
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+// quoteFish single-quotes a word for fish, where backslashes and quotes are escaped
+func quoteFish(word string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(word) + "'"
+}
@@ -1,7 +1,9 @@
 package shell
 
 import (
+	"bytes"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -76,6 +78,38 @@ func TestTokenize(t *testing.T) {
 				{TokenCommand, "echo \"test\""},
 			},
 		},
+		{
+			name:   "output redirections",
+			script: "go test ./... > test.log 2>> errors.log && echo done >>done.log",
+			expected: []Token{
+				{TokenCommand, "go test ./..."},
+				{TokenRedirect, ">"},
+				{TokenCommand, "test.log"},
+				{TokenRedirect, "2>>"},
+				{TokenCommand, "errors.log"},
+				{TokenOperator, "&&"},
+				{TokenCommand, "echo done"},
+				{TokenRedirect, ">>"},
+				{TokenCommand, "done.log"},
+			},
+		},
+		{
+			name:   "stderr redirections",
+			script: "mvn verify 2> err.log 2>&1",
+			expected: []Token{
+				{TokenCommand, "mvn verify"},
+				{TokenRedirect, "2>"},
+				{TokenCommand, "err.log"},
+				{TokenRedirect, "2>&1"},
+			},
+		},
+		{
+			name:   "quoted redirection",
+			script: "echo 'a > b'",
+			expected: []Token{
+				{TokenCommand, "echo 'a > b'"},
+			},
+		},
 		{
 			name:   "complex chain",
 			script: "cd test && mvn clean install || echo failed; echo done",
@@ -414,7 +448,7 @@ func TestMVXShell_ExecuteCommandChain(t *testing.T) {
 				Operators: []string{"|"},
 			},
 			expectError: false,
-			description: "Pipe should work as sequential execution for now",
+			description: "Pipeline status should follow the last command",
 		},
 	}
 
@@ -968,3 +1002,206 @@ func TestCommandChainOperatorLogic(t *testing.T) {
 		os.Remove(testDir)
 	})
 }
+
+func TestMVXShell_Pipes(t *testing.T) {
+	if _, err := exec.LookPath("grep"); err != nil {
+		t.Skip("grep not available")
+	}
+
+	tests := []struct {
+		name        string
+		script      string
+		expectError bool
+		expected    string
+	}{
+		{"matching filter", "echo foo | grep foo", false, "foo\n"},
+		{"non-matching filter", "echo foo | grep bar", true, ""},
+		{"status follows last command", "nonexistent-command | echo done", false, "done\n"},
+		{"multiple stages", "echo foo bar | grep foo | grep -c bar", false, "1\n"},
+		{"pipeline before AND", "echo foo | grep bar && echo unreachable", true, ""},
+		{"pipeline before OR", "echo foo | grep bar || echo fallback", false, "fallback\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			shell := NewMVXShell(t.TempDir(), os.Environ())
+			shell.SetIO(strings.NewReader(""), &stdout, &bytes.Buffer{})
+
+			err := shell.Execute(tt.script)
+			if (err != nil) != tt.expectError {
+				t.Errorf("Execute(%q) error = %v, expectError %v", tt.script, err, tt.expectError)
+			}
+			if stdout.String() != tt.expected {
+				t.Errorf("Execute(%q) output = %q, expected %q", tt.script, stdout.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCommandsRedirects(t *testing.T) {
+	chains, err := parseCommands("go test ./... > test.log -v 2>&1")
+	if err != nil {
+		t.Fatalf("parseCommands() error = %v", err)
+	}
+	cmd := chains[0].Commands[0]
+	if strings.Join(cmd.Args, " ") != "test ./... -v" {
+		t.Errorf("Args = %v, expected [test ./... -v]", cmd.Args)
+	}
+	expected := []Redirect{{Operator: ">", Target: "test.log"}, {Operator: "2>&1"}}
+	if len(cmd.Redirects) != len(expected) {
+		t.Fatalf("Redirects = %+v, expected %+v", cmd.Redirects, expected)
+	}
+	for i, redirect := range cmd.Redirects {
+		if redirect != expected[i] {
+			t.Errorf("Redirects[%d] = %+v, expected %+v", i, redirect, expected[i])
+		}
+	}
+
+	for _, script := range []string{"echo hello >", "echo hello > && echo world", "> out.txt"} {
+		if _, err := parseCommands(script); err == nil {
+			t.Errorf("parseCommands(%q) expected an error", script)
+		}
+	}
+}
+
+func TestMVXShell_Redirects(t *testing.T) {
+	tempDir := t.TempDir()
+	shell := NewMVXShell(tempDir, os.Environ())
+	var stdout bytes.Buffer
+	shell.SetIO(strings.NewReader(""), &stdout, &bytes.Buffer{})
+
+	readFile := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(tempDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		return string(content)
+	}
+
+	t.Run("redirect to new file", func(t *testing.T) {
+		if err := shell.Execute("echo first > out.log"); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if got := readFile("out.log"); got != "first\n" {
+			t.Errorf("out.log = %q, expected %q", got, "first\n")
+		}
+		if stdout.Len() != 0 {
+			t.Errorf("stdout = %q, expected nothing", stdout.String())
+		}
+	})
+
+	t.Run("append", func(t *testing.T) {
+		if err := shell.Execute("echo second >> out.log; echo third >>out.log"); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if got := readFile("out.log"); got != "first\nsecond\nthird\n" {
+			t.Errorf("out.log = %q, expected three lines", got)
+		}
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		if err := shell.Execute("echo replaced > out.log"); err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		if got := readFile("out.log"); got != "replaced\n" {
+			t.Errorf("out.log = %q, expected %q", got, "replaced\n")
+		}
+	})
+
+	t.Run("stderr redirection", func(t *testing.T) {
+		if _, err := exec.LookPath("ls"); err != nil {
+			t.Skip("ls not available")
+		}
+		if err := shell.Execute("ls does-not-exist 2> err.log"); err == nil {
+			t.Error("Execute() expected an error from ls")
+		}
+		if got := readFile("err.log"); !strings.Contains(got, "does-not-exist") {
+			t.Errorf("err.log = %q, expected the ls error", got)
+		}
+
+		if err := shell.Execute("ls does-not-exist > all.log 2>&1"); err == nil {
+			t.Error("Execute() expected an error from ls")
+		}
+		if got := readFile("all.log"); !strings.Contains(got, "does-not-exist") {
+			t.Errorf("all.log = %q, expected the ls error", got)
+		}
+	})
+}
+
+func TestMVXShell_CommandSubstitution(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		expected string
+	}{
+		{"simple", "echo $(echo hi)", "hi\n"},
+		{"within an argument", "echo version-$(echo 1.0).jar", "version-1.0.jar\n"},
+		{"nested", "echo $(echo $(echo nested))", "nested\n"},
+		{"inside double quotes", `echo "result: $(echo "a b")"`, "result: a b\n"},
+		{"operators inside", "echo $(echo first && echo second | grep second)", "first\nsecond\n"},
+		{"assignment prefix", "GREETING=$(echo hello) echo $GREETING", "hello\n"},
+		{"assignment", "unformatted=$(echo main.go); echo files: $unformatted", "files: main.go\n"},
+		{"failing command", "echo [$(nonexistent-command)]", "[]\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if strings.Contains(tt.script, "grep") {
+				if _, err := exec.LookPath("grep"); err != nil {
+					t.Skip("grep not available")
+				}
+			}
+			var stdout bytes.Buffer
+			shell := NewMVXShell(t.TempDir(), os.Environ())
+			shell.SetIO(strings.NewReader(""), &stdout, &bytes.Buffer{})
+
+			if err := shell.Execute(tt.script); err != nil {
+				t.Fatalf("Execute(%q) error = %v", tt.script, err)
+			}
+			if stdout.String() != tt.expected {
+				t.Errorf("Execute(%q) output = %q, expected %q", tt.script, stdout.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestMVXShell_PositionalArgs(t *testing.T) {
+	tempDir := t.TempDir()
+	shell := NewMVXShell(tempDir, os.Environ())
+	shell.SetArgs([]string{"--env=prod", "two words"})
+
+	tests := []struct {
+		script   string
+		expected string
+	}{
+		{script: "echo $# $1 ${2}", expected: "2 --env=prod two words\n"},
+		{script: `echo "args: $@"`, expected: "args: --env=prod two words\n"},
+		{script: "echo $*", expected: "--env=prod two words\n"},
+		{script: "echo [$3] $10", expected: "[] --env=prod0\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.script, func(t *testing.T) {
+			var stdout bytes.Buffer
+			shell.SetIO(strings.NewReader(""), &stdout, &bytes.Buffer{})
+			if err := shell.Execute(tt.script); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if stdout.String() != tt.expected {
+				t.Errorf("output = %q, expected %q", stdout.String(), tt.expected)
+			}
+		})
+	}
+
+	// A "$@" argument expands to one argument per parameter, keeping spaces within them
+	shell.SetArgs([]string{"one", "two words"})
+	if err := shell.Execute(`mkdir "$@"`); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	for _, dir := range []string{"one", "two words"} {
+		if info, err := os.Stat(filepath.Join(tempDir, dir)); err != nil || !info.IsDir() {
+			t.Errorf("mkdir \"$@\" should have created %q: %v", dir, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrAutoInstallDisabled is returned instead of installing a missing tool when auto-install is disabled
+var ErrAutoInstallDisabled = errors.New("auto-install disabled (" + EnvNoAutoInstall + "=true)")
+
+// IsAutoInstallDisabled reports whether missing tools must not be installed on demand,
+// e.g. when running commands. Explicit installs (mvx setup) are still performed.
+func IsAutoInstallDisabled() bool {
+	return os.Getenv(EnvNoAutoInstall) == "true"
+}
+
+// NotInstalledError reports a missing tool version that auto-install is not allowed to install
+func NotInstalledError(tool, version string) error {
+	return fmt.Errorf("%s %s is not installed; run 'mvx setup' to install it: %w", tool, version, ErrAutoInstallDisabled)
+}
+
+// EnableExplicitInstall marks installs as explicitly requested by the user (mvx setup,
+// mvx tools reinstall), so that they are performed even when auto-install is disabled
+func (m *Manager) EnableExplicitInstall() {
+	m.explicitInstall = true
+}
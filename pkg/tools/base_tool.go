@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gnodet/mvx/pkg/config"
 	"github.com/gnodet/mvx/pkg/util"
@@ -103,6 +105,11 @@ func (b *BaseTool) SupportsChecksumVerification() bool {
 	return true
 }
 
+// GetInstallDir returns the installation directory for the specified version (implements InstallDirProvider)
+func (b *BaseTool) GetInstallDir(version string, cfg config.ToolConfig) string {
+	return b.manager.GetToolVersionDir(b.toolName, version, cfg.Distribution)
+}
+
 // getCacheKey generates a cache key for path operations
 func (b *BaseTool) getCacheKey(version string, cfg config.ToolConfig, operation string) string {
 	return fmt.Sprintf("%s:%s:%s:%s", operation, version, cfg.Distribution, b.toolName)
@@ -138,24 +145,20 @@ func (b *BaseTool) clearPathCache() {
 	b.pathCache = make(map[string]pathCacheEntry)
 }
 
-// getPlatformBinaryPath returns the platform-specific binary path
+// getPlatformBinaryPath returns the platform-specific binary path, probing the binary's
+// alternative names on the target platform (e.g. .cmd or .bat launchers on Windows)
 func (b *BaseTool) getPlatformBinaryPath(binPath, binaryName string) string {
-	return b.getPlatformBinaryPathWithExtension(binPath, binaryName)
-}
-
-// getPlatformBinaryPathWithExtension returns the platform-specific binary path with custom extension
-func (b *BaseTool) getPlatformBinaryPathWithExtension(binPath, binaryName string) string {
+	if path, found := b.manager.GetPlatformMapper().FindBinary(binPath, binaryName); found {
+		return path
+	}
 	return filepath.Join(binPath, binaryName)
 }
 
 // checkSystemBinaryExists checks if a system binary exists with platform-specific extensions
 func (b *BaseTool) checkSystemBinaryExists(basePath, binaryName string) (bool, string) {
-	// Try the exact binary name first
-	fullPath := filepath.Join(basePath, binaryName)
-	if _, err := os.Stat(fullPath); err == nil {
-		return true, fullPath
+	if path, found := b.manager.GetPlatformMapper().FindBinary(basePath, binaryName); found {
+		return true, path
 	}
-
 	return false, ""
 }
 
@@ -167,8 +170,13 @@ func (b *BaseTool) CreateInstallDir(version, distribution string) (string, error
 
 // Download performs a robust download with checksum verification
 func (b *BaseTool) Download(url, version string, cfg config.ToolConfig) (string, error) {
-	// Always determine file extension from the download URL
+	// Always determine file extension from the download URL, or from the local archive
+	// installed instead
 	fileExtension := detectFileExtensionFromURL(url)
+	archive := cfg.LocalArchive()
+	if archive != "" {
+		fileExtension = detectFileExtensionFromURL(archive)
+	}
 
 	// Create temporary file for download
 	tmpFile, err := os.CreateTemp("", fmt.Sprintf("%s-*%s", b.toolName, fileExtension))
@@ -183,10 +191,14 @@ func (b *BaseTool) Download(url, version string, cfg config.ToolConfig) (string,
 	downloadConfig.ToolName = b.toolName
 	downloadConfig.Version = version
 	downloadConfig.Config = cfg
+	downloadConfig.Output = b.manager.output(b.toolName)
 
 	// Get the tool instance for checksum verification
 	if tool, err := b.manager.GetTool(b.toolName); err == nil {
 		downloadConfig.Tool = tool
+		if provider, ok := tool.(FallbackURLProvider); ok {
+			downloadConfig.FallbackURLs = provider.GetFallbackURLs(version)
+		}
 	}
 
 	// Perform robust download with checksum verification
@@ -196,9 +208,14 @@ func (b *BaseTool) Download(url, version string, cfg config.ToolConfig) (string,
 		return "", fmt.Errorf("%s download failed: %s", strings.Title(b.toolName), DiagnoseDownloadError(url, err))
 	}
 
+	if archive != "" {
+		b.printf("  📦 Copied %d bytes from %s\n", result.Size, archive)
+		return tmpFile.Name(), nil
+	}
+
 	// Show user-friendly URL instead of long redirect URLs
 	displayURL := getUserFriendlyURL(result.FinalURL)
-	fmt.Printf("  📦 Downloaded %d bytes from %s\n", result.Size, displayURL)
+	b.printf("  📦 Downloaded %d bytes from %s\n", result.Size, displayURL)
 
 	// Return the path to the downloaded file
 	return tmpFile.Name(), nil
@@ -206,16 +223,19 @@ func (b *BaseTool) Download(url, version string, cfg config.ToolConfig) (string,
 
 // Extract extracts an archive file to the destination directory
 func (b *BaseTool) Extract(archivePath, destDir string) error {
+	b.manager.notify(InstallEvent{Type: EventExtractStart, Tool: b.toolName, Path: archivePath})
 	// Use automatic archive type detection based on file extension
 	return ExtractArchive(archivePath, destDir)
 }
 
 // VerificationConfig contains configuration for tool verification
 type VerificationConfig struct {
-	BinaryName      string   // Binary name to verify
-	VersionArgs     []string // Arguments to get version (e.g., ["--version"])
-	ExpectedVersion string   // Expected version string in output
-	DebugInfo       bool     // Whether to show debug information on failure
+	BinaryName      string        // Binary name to verify
+	VersionArgs     []string      // Arguments to get version (e.g., ["--version"])
+	ExpectedVersion string        // Expected version string in output
+	DebugInfo       bool          // Whether to show debug information on failure
+	Timeout         time.Duration // Maximum duration of the version command (0 for no limit)
+	RequiredEnv     []string      // Environment variables that must be set for the version command
 }
 
 // Verify performs post-installation verification of a tool
@@ -249,7 +269,7 @@ func (b *BaseTool) VerifyWithConfig(version string, cfg config.ToolConfig, verif
 	// Use FindBinaryParentDir to locate the binary directory
 	installDir := b.manager.GetToolVersionDir(b.toolName, version, cfg.Distribution)
 	binaryName := verifyConfig.BinaryName
-	pathResolver := NewPathResolver(b.manager.GetToolsDir())
+	pathResolver := b.manager.GetPathResolver()
 	binDir, err := pathResolver.FindBinaryParentDir(installDir, binaryName)
 	if err != nil {
 		if verifyConfig.DebugInfo {
@@ -414,12 +434,28 @@ func (b *BaseTool) VerifyBinaryWithConfig(binPath string, verifyConfig Verificat
 		return fmt.Errorf("%s executable not found at %s: %w", verifyConfig.BinaryName, exe, err)
 	}
 
+	// Check required environment variables (e.g., JAVA_HOME for JVM-based tools)
+	for _, name := range verifyConfig.RequiredEnv {
+		if !hasEnvVar(env, name) {
+			return fmt.Errorf("%s verification requires %s to be set (configure java in your project or set %s)", b.toolName, name, name)
+		}
+	}
+
 	// Run version command
-	cmd := exec.Command(exe, verifyConfig.VersionArgs...)
+	ctx := context.Background()
+	if verifyConfig.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, verifyConfig.Timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, exe, verifyConfig.VersionArgs...)
 	if env != nil {
 		cmd.Env = env
 	}
 	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s verification timed out after %s\nOutput: %s", b.toolName, verifyConfig.Timeout, output)
+	}
 	if err != nil {
 		return fmt.Errorf("%s verification failed: %w\nOutput: %s", b.toolName, err, output)
 	}
@@ -435,21 +471,34 @@ func (b *BaseTool) VerifyBinaryWithConfig(binPath string, verifyConfig Verificat
 	return nil
 }
 
+// hasEnvVar checks whether a non-empty variable is set in env, or in the process environment if env is nil
+func hasEnvVar(env []string, name string) bool {
+	if env == nil {
+		return os.Getenv(name) != ""
+	}
+	for _, envVar := range env {
+		if key, value, ok := strings.Cut(envVar, "="); ok && key == name && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // printVerificationDebugInfo prints detailed debug information for verification failures
 func (b *BaseTool) printVerificationDebugInfo(version string, cfg config.ToolConfig, pathErr error) {
-	fmt.Printf("  🔍 Debug: %s installation verification failed\n", b.toolName)
+	b.printf("  🔍 Debug: %s installation verification failed\n", b.toolName)
 
 	// Try to determine install directory
 	installDir := b.manager.GetToolVersionDir(b.toolName, version, cfg.Distribution)
 
-	fmt.Printf("     Install directory: %s\n", installDir)
-	fmt.Printf("     Error getting bin path: %v\n", pathErr)
+	b.printf("     Install directory: %s\n", installDir)
+	b.printf("     Error getting bin path: %v\n", pathErr)
 
 	// List contents of install directory for debugging
 	if entries, readErr := os.ReadDir(installDir); readErr == nil {
-		fmt.Printf("     Install directory contents:\n")
+		b.printf("     Install directory contents:\n")
 		for _, entry := range entries {
-			fmt.Printf("       - %s (dir: %t)\n", entry.Name(), entry.IsDir())
+			b.printf("       - %s (dir: %t)\n", entry.Name(), entry.IsDir())
 		}
 	}
 }
@@ -471,19 +520,25 @@ func (b *BaseTool) GetDisplayName() string {
 // PrintDownloadMessage prints a standardized download message
 func (b *BaseTool) PrintDownloadMessage(version string) {
 	toolDisplayName := b.GetDisplayName()
-	fmt.Printf("  ⏳ Downloading %s %s...\n", toolDisplayName, version)
+	b.printf("  ⏳ Downloading %s %s...\n", toolDisplayName, version)
 }
 
 // StandardInstall provides a standard installation flow for most tools
 func (b *BaseTool) StandardInstall(version string, cfg config.ToolConfig, getDownloadURL func(string) string) error {
+	return b.StandardInstallWithExtractor(version, cfg, getDownloadURL, b.Extract)
+}
+
+// StandardInstallWithExtractor is StandardInstall for tools whose archives need a custom
+// extraction, e.g. to rearrange their content in the installation directory
+func (b *BaseTool) StandardInstallWithExtractor(version string, cfg config.ToolConfig, getDownloadURL func(string) string, extract func(archivePath, installDir string) error) error {
 	// Check if we should use system tool instead of downloading
 	if UseSystemTool(b.toolName) {
 		util.LogVerbose("%s=true, forcing use of system %s", getSystemToolEnvVar(b.toolName), b.toolName)
 
 		// Try primary binary name in PATH
 		if toolPath, err := exec.LookPath(b.binaryName); err == nil {
-			fmt.Printf("  🔗 Using system %s from PATH: %s\n", b.toolName, toolPath)
-			fmt.Printf("  ✅ System %s configured (mvx will use system PATH)\n", b.toolName)
+			b.printf("  🔗 Using system %s from PATH: %s\n", b.toolName, toolPath)
+			b.printf("  ✅ System %s configured (mvx will use system PATH)\n", b.toolName)
 			return nil
 		}
 
@@ -510,7 +565,7 @@ func (b *BaseTool) StandardInstall(version string, cfg config.ToolConfig, getDow
 	defer os.Remove(archivePath) // Clean up downloaded file
 
 	// Extract the file
-	if err := b.Extract(archivePath, installDir); err != nil {
+	if err := extract(archivePath, installDir); err != nil {
 		return InstallError(b.toolName, version, err)
 	}
 
@@ -521,14 +576,14 @@ func (b *BaseTool) StandardInstall(version string, cfg config.ToolConfig, getDow
 		}); hasVerify {
 			if err := verifier.Verify(version, cfg); err != nil {
 				// Installation verification failed, clean up the installation directory
-				fmt.Printf("  ❌ %s installation verification failed: %v\n", b.toolName, err)
-				fmt.Printf("  🧹 Cleaning up failed installation directory...\n")
+				b.printf("  ❌ %s installation verification failed: %v\n", b.toolName, err)
+				b.printf("  🧹 Cleaning up failed installation directory...\n")
 				// if removeErr := os.RemoveAll(installDir); removeErr != nil {
 				// 	fmt.Printf("  ⚠️  Warning: failed to clean up installation directory: %v\n", removeErr)
 				// }
 				return InstallError(b.toolName, version, fmt.Errorf("installation verification failed: %w", err))
 			}
-			fmt.Printf("  ✅ %s %s installation verification successful\n", b.toolName, version)
+			b.printf("  ✅ %s %s installation verification successful\n", b.toolName, version)
 		}
 	}
 
@@ -592,6 +647,11 @@ func detectFileExtensionFromURL(downloadURL string) string {
 
 // StandardVerifyWithConfig provides standard verification using VerificationConfig
 func (b *BaseTool) StandardVerifyWithConfig(version string, cfg config.ToolConfig, verifyConfig VerificationConfig) error {
+	// Binaries installed for another platform cannot be executed
+	if b.manager.IsForeignPlatform() {
+		util.LogVerbose("Skipping verification of %s %s installed for %s", b.toolName, version, b.manager.GetPlatform())
+		return nil
+	}
 	return b.VerifyWithConfig(version, cfg, verifyConfig)
 }
 
@@ -659,7 +719,14 @@ func (b *BaseTool) StandardIsInstalled(versionSpec string, cfg config.ToolConfig
 		return false
 	}
 
-	targetVersion, resolveErr := b.resolveTargetVersion(tool, spec, versionSpec, cfg)
+	// Offline, only the installations on disk are considered
+	var targetVersion string
+	var resolveErr error
+	if IsOffline() {
+		resolveErr = ErrOffline
+	} else {
+		targetVersion, resolveErr = b.resolveTargetVersion(tool, spec, versionSpec, cfg)
+	}
 	if resolveErr != nil {
 		util.LogVerbose("Failed to resolve target version for %s %s: %v", b.toolName, versionSpec, resolveErr)
 	}
@@ -678,7 +745,8 @@ func (b *BaseTool) StandardIsInstalled(versionSpec string, cfg config.ToolConfig
 			continue
 		}
 		if spec.Matches(parsed) {
-			if spec.Constraint == "latest" && resolveErr == nil && targetVersion != "" && inst.Version != targetVersion {
+			if spec.Constraint == "latest" && resolveErr == nil && targetVersion != "" &&
+				b.manager.CanonicalVersion(b.toolName, inst.Version) != b.manager.CanonicalVersion(b.toolName, targetVersion) {
 				continue
 			}
 			candidates = append(candidates, installedCandidate{info: inst, parsed: parsed})
@@ -729,9 +797,24 @@ func (b *BaseTool) StandardIsInstalled(versionSpec string, cfg config.ToolConfig
 		return false
 	}
 
+	if IsAutoInstallDisabled() && !b.manager.explicitInstall {
+		util.LogVerbose("Not installing %s %s automatically: %v", b.toolName, targetVersion, ErrAutoInstallDisabled)
+		return false
+	}
+
 	installCfg := cfg
 	installCfg.Version = targetVersion
 
+	if err := b.manager.CheckPlatformSupport(tool, targetVersion, b.manager.GetPlatform()); err != nil {
+		util.LogVerbose("Not installing %s automatically: %v", b.toolName, err)
+		return false
+	}
+
+	if err := b.manager.checkWritableHome(); err != nil {
+		util.LogVerbose("Not installing %s automatically: %v", b.toolName, err)
+		return false
+	}
+
 	util.LogVerbose("%s version %s not installed, attempting automatic installation", b.toolName, targetVersion)
 	if err := tool.Install(targetVersion, installCfg); err != nil {
 		util.LogVerbose("Automatic installation of %s %s failed: %v", b.toolName, targetVersion, err)
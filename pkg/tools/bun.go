@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// Compile-time interface validation
+var _ Tool = (*BunTool)(nil)
+var _ VersionResolver = (*BunTool)(nil)
+
+// bunArchitectures maps Go architectures to the ones of Bun's release assets
+var bunArchitectures = map[string]string{
+	"amd64": "x64",
+	"arm64": "aarch64",
+}
+
+// BunTool implements Tool interface for the Bun JavaScript runtime
+type BunTool struct {
+	*BaseTool
+}
+
+// NewBunTool creates a new Bun tool instance
+func NewBunTool(manager *Manager) *BunTool {
+	return &BunTool{
+		BaseTool: NewBaseTool(manager, ToolBun, manager.GetPlatformMapper().BinaryName(BinaryBun, ExtExe)),
+	}
+}
+
+// Install downloads and installs the specified Bun version
+func (b *BunTool) Install(version string, cfg config.ToolConfig) error {
+	return b.StandardInstallWithExtractor(version, cfg, b.getDownloadURL, b.extractStandaloneBinary)
+}
+
+// IsInstalled checks if the specified version is installed
+func (b *BunTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	return b.StandardIsInstalled(version, cfg, b.GetPath)
+}
+
+// GetPath returns the binary path for the specified version (for PATH management)
+func (b *BunTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return b.StandardGetPath(version, cfg, b.getInstalledPath)
+}
+
+// getInstalledPath returns the directory containing the bun binary of an installed version
+func (b *BunTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
+	installDir := b.manager.GetToolVersionDir(b.GetToolName(), version, "")
+	return b.manager.GetPathResolver().FindBinaryParentDir(installDir, b.GetBinaryName())
+}
+
+// Verify runs 'bun --version', which prints the bare version
+func (b *BunTool) Verify(version string, cfg config.ToolConfig) error {
+	verifyConfig := VerificationConfig{
+		BinaryName:      b.GetBinaryName(),
+		VersionArgs:     []string{"--version"},
+		ExpectedVersion: version,
+	}
+	return b.StandardVerifyWithConfig(version, cfg, verifyConfig)
+}
+
+// ListVersions returns available Bun versions, newest first
+func (b *BunTool) ListVersions() ([]string, error) {
+	versions, err := b.manager.fetchGitHubReleaseVersions(BunAPIBase, bunReleaseVersion)
+	if err != nil || len(versions) == 0 {
+		// Fallback to known versions if the releases feed is unavailable
+		return b.getFallbackBunVersions(), nil
+	}
+	return version.SortVersions(versions), nil
+}
+
+// GetDisplayName returns the human-readable name for Bun (implements ToolMetadataProvider)
+func (b *BunTool) GetDisplayName() string {
+	return "Bun"
+}
+
+// ResolveVersion resolves a Bun version specification to a concrete version
+func (b *BunTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	availableVersions, err := b.ListVersions()
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil {
+		return "", fmt.Errorf("invalid version specification %s: %w", versionSpec, err)
+	}
+
+	resolved, err := spec.Resolve(availableVersions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Bun version %s: %w", versionSpec, err)
+	}
+
+	return resolved, nil
+}
+
+// bunAssetName returns the name of the release zip for the platform, e.g. bun-linux-x64.zip
+func (b *BunTool) bunAssetName() string {
+	platformMapper := b.manager.GetPlatformMapper()
+	return fmt.Sprintf("bun-%s-%s%s", platformMapper.GetOS(), platformMapper.MapArchitecture(bunArchitectures), ExtZip)
+}
+
+// getDownloadURL returns the download URL of the zip attached to a release
+func (b *BunTool) getDownloadURL(version string) string {
+	return fmt.Sprintf("%s/releases/download/bun-v%s/%s", BunGithubBase, version, b.bunAssetName())
+}
+
+// GetDownloadURL implements URLProvider interface for Bun
+func (b *BunTool) GetDownloadURL(version string) string {
+	return b.getDownloadURL(version)
+}
+
+// getChecksumURL returns the URL of the SHASUMS256.txt file listing the hashes of a release
+func (b *BunTool) getChecksumURL(version string) string {
+	return fmt.Sprintf("%s/releases/download/bun-v%s/SHASUMS256.txt", BunGithubBase, version)
+}
+
+// GetChecksum implements Tool interface for Bun using the SHASUMS256.txt file of the release
+func (b *BunTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
+	hash, err := NewChecksumVerifier(b.manager).fetchChecksumFromURL(b.getChecksumURL(version), b.bunAssetName())
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to fetch Bun checksum: %w", err)
+	}
+	return ChecksumInfo{
+		Type:  SHA256,
+		Value: hash,
+	}, nil
+}
+
+// bunReleaseVersion returns the version of a release tag such as "bun-v1.1.38", rejecting
+// the canary release
+func bunReleaseVersion(tag string) (string, bool) {
+	return releaseTagVersion(tag, "bun-v")
+}
+
+// getFallbackBunVersions returns known Bun versions as fallback
+func (b *BunTool) getFallbackBunVersions() []string {
+	return []string{
+		"1.1.38", "1.1.37", "1.1.36", "1.1.35", "1.1.34", "1.1.33", "1.1.32", "1.1.31", "1.1.30",
+		"1.1.0", "1.0.36", "1.0.0",
+	}
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestBunReleaseVersion(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+		ok       bool
+	}{
+		{"bun-v1.1.38", "1.1.38", true},
+		{"bun-v1.0.0", "1.0.0", true},
+		{"canary", "", false},
+		{"v1.1.38", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := bunReleaseVersion(tt.tag)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("bunReleaseVersion(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestBunURLs(t *testing.T) {
+	tests := []struct {
+		os       string
+		arch     string
+		expected string
+		binary   string
+	}{
+		{"linux", "amd64", "https://github.com/oven-sh/bun/releases/download/bun-v1.1.38/bun-linux-x64.zip", "bun"},
+		{"darwin", "arm64", "https://github.com/oven-sh/bun/releases/download/bun-v1.1.38/bun-darwin-aarch64.zip", "bun"},
+		{"windows", "amd64", "https://github.com/oven-sh/bun/releases/download/bun-v1.1.38/bun-windows-x64.zip", "bun.exe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.os+"-"+tt.arch, func(t *testing.T) {
+			manager := newTestManager(t)
+			manager.platform = &PlatformInfo{OS: tt.os, Arch: tt.arch}
+			bunTool := NewBunTool(manager)
+			if got := bunTool.GetDownloadURL("1.1.38"); got != tt.expected {
+				t.Errorf("GetDownloadURL() = %s, want %s", got, tt.expected)
+			}
+			if got := bunTool.GetBinaryName(); got != tt.binary {
+				t.Errorf("GetBinaryName() = %s, want %s", got, tt.binary)
+			}
+		})
+	}
+
+	bunTool := NewBunTool(newTestManager(t))
+	if got, want := bunTool.getChecksumURL("1.1.38"), "https://github.com/oven-sh/bun/releases/download/bun-v1.1.38/SHASUMS256.txt"; got != want {
+		t.Errorf("getChecksumURL() = %s, want %s", got, want)
+	}
+}
+
+func TestBunExtractStandaloneBinary(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Executable permissions are not used on Windows")
+	}
+	bunTool := NewBunTool(newTestManager(t))
+	archive := filepath.Join(t.TempDir(), "bun-linux-x64.zip")
+	writeTestZip(t, archive, map[string]string{"bun-linux-x64/bun": "#!/bin/sh\n"})
+
+	installDir := t.TempDir()
+	if err := bunTool.extractStandaloneBinary(archive, installDir); err != nil {
+		t.Fatalf("extractStandaloneBinary() error = %v", err)
+	}
+	binDir, err := bunTool.manager.GetPathResolver().FindBinaryParentDir(installDir, "bun")
+	if err != nil {
+		t.Fatalf("bun not found after extraction: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(binDir, "bun"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0111 == 0 {
+		t.Errorf("bun has mode %v, want it executable", info.Mode().Perm())
+	}
+
+	writeTestZip(t, archive, map[string]string{"README.md": "no binary"})
+	if err := bunTool.extractStandaloneBinary(archive, t.TempDir()); err == nil {
+		t.Error("extractStandaloneBinary() should fail for an archive without the binary")
+	}
+}
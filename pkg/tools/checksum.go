@@ -10,6 +10,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/gnodet/mvx/pkg/util"
 )
 
 // ChecksumType represents the type of checksum algorithm
@@ -33,6 +35,7 @@ type ChecksumInfo struct {
 // ChecksumVerifier handles checksum verification for downloaded files
 type ChecksumVerifier struct {
 	manager *Manager
+	out     io.Writer // Where messages go, the status output if nil
 }
 
 // NewChecksumVerifier creates a new checksum verifier
@@ -42,6 +45,14 @@ func NewChecksumVerifier(manager *Manager) *ChecksumVerifier {
 	}
 }
 
+// output returns where the messages of the verifier go
+func (cv *ChecksumVerifier) output() io.Writer {
+	if cv.out == nil {
+		return util.StatusOutput()
+	}
+	return cv.out
+}
+
 // VerifyFile verifies a file against the provided checksum information
 func (cv *ChecksumVerifier) VerifyFile(filePath string, checksum ChecksumInfo) error {
 	if checksum.Value == "" && checksum.URL == "" {
@@ -122,8 +133,8 @@ func (cv *ChecksumVerifier) fetchChecksumFromURL(url, filename string) (string,
 		checksum, err := cv.parseChecksumFile(content, filename)
 		if err != nil {
 			// Add debug information for checksum parsing failures
-			fmt.Printf("⚠️  Debug: Failed to parse checksum for file '%s' from URL '%s'\n", filename, url)
-			fmt.Printf("   Content preview (first 200 chars): %s\n", truncateString(content, 200))
+			fmt.Fprintf(cv.output(), "⚠️  Debug: Failed to parse checksum for file '%s' from URL '%s'\n", filename, url)
+			fmt.Fprintf(cv.output(), "   Content preview (first 200 chars): %s\n", truncateString(content, 200))
 			return "", fmt.Errorf("failed to parse checksum file: %w", err)
 		}
 		return checksum, nil
@@ -179,8 +190,8 @@ func (cv *ChecksumVerifier) parseChecksumFile(content, filename string) (string,
 	}
 
 	// If we get here, no match was found - provide helpful debug info
-	fmt.Printf("⚠️  Debug: Available files in checksum file: %v\n", candidateFiles)
-	fmt.Printf("   Looking for: %s\n", filename)
+	fmt.Fprintf(cv.output(), "⚠️  Debug: Available files in checksum file: %v\n", candidateFiles)
+	fmt.Fprintf(cv.output(), "   Looking for: %s\n", filename)
 	return "", fmt.Errorf("checksum not found for file %s", filename)
 }
 
@@ -195,10 +206,10 @@ func truncateString(s string, maxLen int) string {
 // VerifyFileWithWarning verifies a file with checksum, but only warns if verification fails
 func (cv *ChecksumVerifier) VerifyFileWithWarning(filePath string, checksum ChecksumInfo) {
 	if err := cv.VerifyFile(filePath, checksum); err != nil {
-		fmt.Printf("  ⚠️  Checksum verification failed: %v\n", err)
-		fmt.Printf("      File: %s\n", filePath)
-		fmt.Printf("      This could indicate a corrupted download or security issue.\n")
+		fmt.Fprintf(cv.output(), "  ⚠️  Checksum verification failed: %v\n", err)
+		fmt.Fprintf(cv.output(), "      File: %s\n", filePath)
+		fmt.Fprintf(cv.output(), "      This could indicate a corrupted download or security issue.\n")
 	} else {
-		fmt.Printf("  ✅ Checksum verified successfully\n")
+		fmt.Fprintf(cv.output(), "  ✅ Checksum verified successfully\n")
 	}
 }
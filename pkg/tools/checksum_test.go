@@ -218,3 +218,67 @@ func TestNodeToolChecksum(t *testing.T) {
 	}
 	t.Logf("Node.js 22.14.0 checksum value: %s", checksum.Value)
 }
+
+func TestVerifyChecksumPinnedInConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := "go archive content"
+	sum := sha256.Sum256([]byte(content))
+	expectedChecksum := hex.EncodeToString(sum[:])
+
+	manager := newTestManager(t)
+	tools := []Tool{NewGoTool(manager), NewNodeTool(manager)}
+
+	tests := []struct {
+		name        string
+		value       string
+		expectError bool
+	}{
+		{name: "matching pinned checksum", value: expectedChecksum, expectError: false},
+		{name: "mismatching pinned checksum is strict", value: "deadbeef", expectError: true},
+	}
+
+	for _, tool := range tools {
+		for _, tt := range tests {
+			t.Run(tool.GetToolName()+"/"+tt.name, func(t *testing.T) {
+				testFile := filepath.Join(tmpDir, "archive.tar.gz")
+				if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to create test file: %v", err)
+				}
+
+				cfg := config.ToolConfig{
+					Version:  "1.0.0",
+					Checksum: &config.ChecksumConfig{Value: tt.value},
+				}
+
+				// The pinned value must be used without any network lookup
+				err := verifyChecksum(testFile, &DownloadConfig{
+					URL:      "https://example.invalid/archive.tar.gz",
+					ToolName: tool.GetToolName(),
+					Version:  "1.0.0",
+					Config:   cfg,
+					Tool:     tool,
+				})
+				if tt.expectError && err == nil {
+					t.Error("Expected checksum mismatch to fail")
+				}
+				if !tt.expectError && err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestConfiguredChecksum(t *testing.T) {
+	if _, ok := configuredChecksum(config.ToolConfig{}); ok {
+		t.Error("Expected no checksum without checksum configuration")
+	}
+	if _, ok := configuredChecksum(config.ToolConfig{Checksum: &config.ChecksumConfig{Required: true}}); ok {
+		t.Error("Expected required-only configuration to fall back to the tool's checksum lookup")
+	}
+
+	info, ok := configuredChecksum(config.ToolConfig{Checksum: &config.ChecksumConfig{Type: "sha512", Value: "abc"}})
+	if !ok || info.Type != SHA512 || info.Value != "abc" {
+		t.Errorf("configuredChecksum() = %+v, %v; want sha512 abc", info, ok)
+	}
+}
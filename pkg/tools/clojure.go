@@ -0,0 +1,354 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
+	mvxversion "github.com/gnodet/mvx/pkg/version"
+)
+
+// Compile-time interface validation
+var _ Tool = (*ClojureTool)(nil)
+var _ DependencyProvider = (*ClojureTool)(nil)
+var _ VersionResolver = (*ClojureTool)(nil)
+var _ PreferenceVersionResolver = (*ClojureTool)(nil)
+var _ PlatformSupportProvider = (*ClojureTool)(nil)
+
+// clojureArchiveName is the name of the Clojure CLI tools archive attached to each release
+const clojureArchiveName = "clojure-tools.zip"
+
+// clojureVerifyTimeout bounds "clojure --version", which may download dependencies on first run
+const clojureVerifyTimeout = 5 * time.Minute
+
+// ClojureTool implements Tool interface for the Clojure CLI tools (clojure and clj launchers)
+type ClojureTool struct {
+	*BaseTool
+}
+
+// NewClojureTool creates a new Clojure tool instance
+func NewClojureTool(manager *Manager) *ClojureTool {
+	return &ClojureTool{
+		BaseTool: NewBaseTool(manager, ToolClojure, BinaryClojure),
+	}
+}
+
+// Install downloads the Clojure CLI tools and lays them out like the official POSIX installer:
+// launchers in bin/, and deps.edn, tools.edn and the jars in lib/clojure.
+func (c *ClojureTool) Install(version string, cfg config.ToolConfig) error {
+	if UseSystemTool(c.toolName) {
+		return c.StandardInstall(version, cfg, c.getDownloadURL)
+	}
+
+	installDir, err := c.CreateInstallDir(version, "")
+	if err != nil {
+		return InstallError(c.toolName, version, fmt.Errorf("failed to create install directory: %w", err))
+	}
+
+	c.PrintDownloadMessage(version)
+
+	archivePath, err := c.Download(c.getDownloadURL(version), version, cfg)
+	if err != nil {
+		return InstallError(c.toolName, version, err)
+	}
+	defer os.Remove(archivePath) // Clean up downloaded file
+
+	if err := c.installFromArchive(archivePath, installDir, version); err != nil {
+		os.RemoveAll(installDir)
+		return InstallError(c.toolName, version, err)
+	}
+
+	if err := c.Verify(version, cfg); err != nil {
+		c.printf("  ❌ Clojure installation verification failed: %v\n", err)
+		c.printf("  🧹 Cleaning up failed installation directory...\n")
+		if removeErr := os.RemoveAll(installDir); removeErr != nil {
+			c.printf("  ⚠️  Warning: failed to clean up installation directory: %v\n", removeErr)
+		}
+		return InstallError(c.toolName, version, fmt.Errorf("installation verification failed: %w", err))
+	}
+	c.printf("  ✅ Clojure %s installation verification successful\n", version)
+
+	return nil
+}
+
+// installFromArchive extracts the CLI tools archive and installs its files into installDir
+func (c *ClojureTool) installFromArchive(archivePath, installDir, version string) error {
+	extractDir, err := os.MkdirTemp(installDir, ".extract-")
+	if err != nil {
+		return fmt.Errorf("failed to create extraction directory: %w", err)
+	}
+	defer os.RemoveAll(extractDir)
+
+	if err := c.Extract(archivePath, extractDir); err != nil {
+		return err
+	}
+
+	// The archive contains a clojure-tools directory, which may have been stripped on extraction
+	srcDir, err := c.manager.GetPathResolver().FindBinaryParentDir(extractDir, BinaryClojure)
+	if err != nil {
+		return fmt.Errorf("clojure launcher not found in archive: %w", err)
+	}
+
+	binDir := filepath.Join(installDir, "bin")
+	libDir := filepath.Join(installDir, "lib", "clojure")
+	libexecDir := filepath.Join(libDir, "libexec")
+	for _, dir := range []string{binDir, libexecDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %w", dir, err)
+		}
+	}
+
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return fmt.Errorf("failed to read archive contents: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		switch {
+		case strings.HasSuffix(name, ".edn"):
+			err = os.Rename(filepath.Join(srcDir, name), filepath.Join(libDir, name))
+		case strings.HasSuffix(name, ".jar"):
+			err = os.Rename(filepath.Join(srcDir, name), filepath.Join(libexecDir, name))
+		}
+		if err != nil {
+			return fmt.Errorf("failed to install %s: %w", name, err)
+		}
+	}
+
+	// The launchers contain placeholders for the library and bin directories
+	launchers := map[string][2]string{
+		"clojure": {"PREFIX", libDir},
+		"clj":     {"BINDIR", binDir},
+	}
+	for name, placeholder := range launchers {
+		content, err := os.ReadFile(filepath.Join(srcDir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read %s launcher: %w", name, err)
+		}
+		script := strings.ReplaceAll(string(content), placeholder[0], placeholder[1])
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(script), 0755); err != nil {
+			return fmt.Errorf("failed to write %s launcher: %w", name, err)
+		}
+	}
+
+	util.LogVerbose("Installed Clojure CLI %s into %s", version, installDir)
+	return nil
+}
+
+// IsInstalled checks if the specified version is installed. Clojure CLI versions have four
+// components, which StandardIsInstalled cannot parse, so the resolved version is checked directly.
+func (c *ClojureTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	if UseSystemTool(c.toolName) {
+		_, err := exec.LookPath(c.GetBinaryName())
+		return err == nil
+	}
+
+	binPath, err := c.GetPath(version, cfg)
+	if err != nil {
+		util.LogVerbose("Clojure %s not installed: %v", version, err)
+		return false
+	}
+	return c.BaseTool.IsInstalled(binPath)
+}
+
+// GetPath returns the binary path for the specified version (for PATH management)
+func (c *ClojureTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return c.StandardGetPath(version, cfg, c.getInstalledPath)
+}
+
+// getInstalledPath returns the bin directory of an installed Clojure CLI version
+func (c *ClojureTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
+	binDir := filepath.Join(c.manager.GetToolVersionDir(c.GetToolName(), version, ""), "bin")
+	if _, err := os.Stat(filepath.Join(binDir, c.GetBinaryName())); err != nil {
+		return "", err
+	}
+	return binDir, nil
+}
+
+// Verify runs "clojure --version", which needs Java and may download dependencies on first run
+func (c *ClojureTool) Verify(version string, cfg config.ToolConfig) error {
+	verifyConfig := VerificationConfig{
+		BinaryName:  c.GetBinaryName(),
+		VersionArgs: []string{"--version"},
+		Timeout:     clojureVerifyTimeout,
+		RequiredEnv: []string{EnvJavaHome},
+	}
+	return c.StandardVerifyWithConfig(version, cfg, verifyConfig)
+}
+
+// ListVersions returns available Clojure CLI versions, newest first
+func (c *ClojureTool) ListVersions() ([]string, error) {
+	versions, err := c.fetchClojureVersions()
+	if err != nil || len(versions) == 0 {
+		// Fallback to known versions if the releases feed is unavailable
+		return c.getFallbackClojureVersions(), nil
+	}
+	sortClojureVersions(versions)
+	return versions, nil
+}
+
+// SupportedPlatforms excludes Windows, where the Clojure CLI is a PowerShell module rather
+// than the POSIX launchers installed here (implements PlatformSupportProvider)
+func (c *ClojureTool) SupportedPlatforms(version string) []PlatformInfo {
+	return platformsExcept(PlatformInfo{OS: "windows", Arch: "amd64"})
+}
+
+// GetDisplayName returns the human-readable name for Clojure (implements ToolMetadataProvider)
+func (c *ClojureTool) GetDisplayName() string {
+	return "Clojure CLI"
+}
+
+// GetDependencies returns the list of tools that Clojure depends on (implements DependencyProvider)
+func (c *ClojureTool) GetDependencies() []string {
+	return []string{ToolJava}
+}
+
+// ResolveVersion resolves a Clojure version specification. Clojure CLI versions have four
+// components (e.g., 1.12.0.1488), so "1.12" resolves to the newest 1.12.x.y release.
+func (c *ClojureTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	return c.ResolveVersionWithPreference(versionSpec, distribution, mvxversion.PreferHighest)
+}
+
+// ResolveVersionWithPreference resolves a Clojure version specification to the newest or
+// oldest matching release (implements PreferenceVersionResolver)
+func (c *ClojureTool) ResolveVersionWithPreference(versionSpec, distribution string, preference mvxversion.Preference) (string, error) {
+	versions, err := c.ListVersions()
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", fmt.Errorf("no Clojure versions available")
+	}
+
+	// Versions are sorted newest first
+	var matching []string
+	for _, v := range versions {
+		if versionSpec == "latest" || versionSpec == "" || v == versionSpec || strings.HasPrefix(v, versionSpec+".") {
+			matching = append(matching, v)
+		}
+	}
+	if len(matching) == 0 {
+		return "", fmt.Errorf("no Clojure version matches %s", versionSpec)
+	}
+	if preference == mvxversion.PreferLowest {
+		return matching[len(matching)-1], nil
+	}
+	return matching[0], nil
+}
+
+// GetDownloadURL implements URLProvider interface for Clojure
+func (c *ClojureTool) GetDownloadURL(version string) string {
+	return c.getDownloadURL(version)
+}
+
+// getDownloadURL returns the download URL of the CLI tools archive for the specified version
+func (c *ClojureTool) getDownloadURL(version string) string {
+	return fmt.Sprintf("%s/releases/download/%s/%s", ClojureGithubBase, version, clojureArchiveName)
+}
+
+// GetChecksum implements ChecksumProvider interface for Clojure using the published .sha256 file
+func (c *ClojureTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
+	url := c.getDownloadURL(version) + ".sha256"
+	resp, err := c.manager.Get(url)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to fetch Clojure checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ChecksumInfo{}, fmt.Errorf("Clojure checksum returned status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to read Clojure checksum: %w", err)
+	}
+
+	// The checksum file contains the hash, optionally followed by the filename
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return ChecksumInfo{}, fmt.Errorf("empty Clojure checksum file at %s", url)
+	}
+
+	return ChecksumInfo{
+		Type:  SHA256,
+		Value: fields[0],
+	}, nil
+}
+
+// fetchClojureVersions fetches Clojure CLI versions from the GitHub releases feed
+func (c *ClojureTool) fetchClojureVersions() ([]string, error) {
+	resp, err := c.manager.Get(ClojureAPIBase + "/releases?per_page=100")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch Clojure versions: status %d", resp.StatusCode)
+	}
+
+	var releases []struct {
+		TagName    string `json:"tag_name"`
+		Prerelease bool   `json:"prerelease"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, release := range releases {
+		if !release.Prerelease && isClojureVersion(release.TagName) {
+			versions = append(versions, release.TagName)
+		}
+	}
+	return versions, nil
+}
+
+// getFallbackClojureVersions returns known Clojure CLI versions as fallback
+func (c *ClojureTool) getFallbackClojureVersions() []string {
+	return []string{
+		"1.12.0.1488", "1.12.0.1479",
+		"1.11.4.1474", "1.11.3.1463", "1.11.1.1435",
+	}
+}
+
+// isClojureVersion checks that a tag is a dotted numeric version
+func isClojureVersion(tag string) bool {
+	parts := strings.Split(tag, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// sortClojureVersions sorts dotted numeric versions, newest first
+func sortClojureVersions(versions []string) {
+	sort.SliceStable(versions, func(i, j int) bool {
+		a := strings.Split(versions[i], ".")
+		b := strings.Split(versions[j], ".")
+		for k := 0; k < len(a) && k < len(b); k++ {
+			x, _ := strconv.Atoi(a[k])
+			y, _ := strconv.Atoi(b[k])
+			if x != y {
+				return x > y
+			}
+		}
+		return len(a) > len(b)
+	})
+}
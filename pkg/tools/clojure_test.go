@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSortClojureVersions(t *testing.T) {
+	versions := []string{"1.11.1.1435", "1.12.0.1479", "1.9.0.397", "1.12.0.1488", "1.11.4.1474"}
+	sortClojureVersions(versions)
+
+	expected := []string{"1.12.0.1488", "1.12.0.1479", "1.11.4.1474", "1.11.1.1435", "1.9.0.397"}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("sortClojureVersions() = %v, want %v", versions, expected)
+	}
+}
+
+func TestIsClojureVersion(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected bool
+	}{
+		{"1.12.0.1488", true},
+		{"1.10.3.1087", true},
+		{"1", false},
+		{"v1.12.0.1488", false},
+		{"1.12.0.1488-rc1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			if got := isClojureVersion(tt.tag); got != tt.expected {
+				t.Errorf("isClojureVersion(%q) = %v, want %v", tt.tag, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestClojureInstallFromArchive(t *testing.T) {
+	manager := newTestManager(t)
+	clojureTool := NewClojureTool(manager)
+
+	// Build an archive mimicking clojure-tools.zip
+	archivePath := filepath.Join(t.TempDir(), clojureArchiveName)
+	file, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	zipWriter := zip.NewWriter(file)
+	files := map[string]string{
+		"clojure-tools/clojure":                "#!/bin/sh\ninstall_dir=PREFIX\n",
+		"clojure-tools/clj":                    "#!/bin/sh\nbin_dir=BINDIR\n",
+		"clojure-tools/deps.edn":               "{}",
+		"clojure-tools/clojure-tools-1.12.jar": "jar",
+	}
+	for name, content := range files {
+		w, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to add %s: %v", name, err)
+		}
+		w.Write([]byte(content))
+	}
+	zipWriter.Close()
+	file.Close()
+
+	installDir := t.TempDir()
+	if err := clojureTool.installFromArchive(archivePath, installDir, "1.12.0.1488"); err != nil {
+		t.Fatalf("installFromArchive() failed: %v", err)
+	}
+
+	libDir := filepath.Join(installDir, "lib", "clojure")
+	binDir := filepath.Join(installDir, "bin")
+	for _, path := range []string{
+		filepath.Join(libDir, "deps.edn"),
+		filepath.Join(libDir, "libexec", "clojure-tools-1.12.jar"),
+	} {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected %s to be installed: %v", path, err)
+		}
+	}
+
+	clojure, err := os.ReadFile(filepath.Join(binDir, "clojure"))
+	if err != nil {
+		t.Fatalf("Failed to read clojure launcher: %v", err)
+	}
+	if !strings.Contains(string(clojure), "install_dir="+libDir) {
+		t.Errorf("Expected clojure launcher to reference %s, got:\n%s", libDir, clojure)
+	}
+
+	clj, err := os.ReadFile(filepath.Join(binDir, "clj"))
+	if err != nil {
+		t.Fatalf("Failed to read clj launcher: %v", err)
+	}
+	if !strings.Contains(string(clj), "bin_dir="+binDir) {
+		t.Errorf("Expected clj launcher to reference %s, got:\n%s", binDir, clj)
+	}
+}
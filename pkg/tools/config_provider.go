@@ -65,9 +65,9 @@ func NewDownloadConfigProvider(configProvider ConfigProvider) *DownloadConfigPro
 	}
 }
 
-// GetDownloadTimeout returns the download timeout
+// GetDownloadTimeout returns the download timeout, which MVX_TIMEOUT overrides
 func (p *DownloadConfigProvider) GetDownloadTimeout() time.Duration {
-	return p.configProvider.GetTimeout(EnvDownloadTimeout, DefaultDownloadTimeout)
+	return p.configProvider.GetTimeout(EnvTimeout, p.configProvider.GetTimeout(EnvDownloadTimeout, DefaultDownloadTimeout))
 }
 
 // GetRegistryTimeout returns the registry timeout
@@ -100,11 +100,16 @@ func (p *DownloadConfigProvider) GetMaxRetries() int {
 	return p.configProvider.GetInt(EnvMaxRetries, DefaultMaxRetries)
 }
 
-// GetRetryDelay returns the retry delay
+// GetRetryDelay returns the base retry delay, doubled at each retry
 func (p *DownloadConfigProvider) GetRetryDelay() time.Duration {
 	return p.configProvider.GetTimeout(EnvRetryDelay, DefaultRetryDelay)
 }
 
+// GetMaxRetryDelay returns the maximum delay between retries
+func (p *DownloadConfigProvider) GetMaxRetryDelay() time.Duration {
+	return p.configProvider.GetTimeout(EnvMaxRetryDelay, DefaultMaxRetryDelay)
+}
+
 // GetMaxConcurrent returns the maximum concurrent downloads
 func (p *DownloadConfigProvider) GetMaxConcurrent() int {
 	return p.configProvider.GetInt(EnvParallelDownloads, DefaultMaxConcurrent)
@@ -17,8 +17,9 @@ const (
 	DefaultIdleTimeout     = 90 * time.Second  // 90 seconds
 
 	// Retry configuration
-	DefaultMaxRetries = 3
-	DefaultRetryDelay = 2 * time.Second
+	DefaultMaxRetries    = 3
+	DefaultRetryDelay    = 2 * time.Second
+	DefaultMaxRetryDelay = 60 * time.Second
 
 	// Concurrency limits
 	DefaultMaxConcurrent = 3
@@ -34,12 +35,27 @@ const (
 	GoGithubAPIBase    = "https://api.github.com/repos/golang/go"
 	ApacheMavenBase    = "https://archive.apache.org/dist/maven"
 	ApacheDistBase     = "https://dist.apache.org/repos/dist/release/maven"
+	ApacheMavenKeysURL = "https://downloads.apache.org/maven/KEYS"
+	MvndAPIBase        = "https://api.github.com/repos/apache/maven-mvnd"
+	ClojureGithubBase  = "https://github.com/clojure/brew-install"
+	ClojureAPIBase     = "https://api.github.com/repos/clojure/brew-install"
+	GradleServicesBase = "https://services.gradle.org"
+	RustDistBase       = "https://static.rust-lang.org/dist"
+	RustManifestsURL   = "https://static.rust-lang.org/manifests.txt"
+	KotlinGithubBase   = "https://github.com/JetBrains/kotlin"
+	KotlinAPIBase      = "https://api.github.com/repos/JetBrains/kotlin"
+	DenoGithubBase     = "https://github.com/denoland/deno"
+	DenoAPIBase        = "https://api.github.com/repos/denoland/deno"
+	BunGithubBase      = "https://github.com/oven-sh/bun"
+	BunAPIBase         = "https://api.github.com/repos/oven-sh/bun"
 )
 
 // Environment Variable Names
 const (
 	// MVX Configuration Environment Variables
 	EnvVerbose           = "MVX_VERBOSE"
+	EnvTimeout           = "MVX_TIMEOUT"
+	EnvHTTPTimeout       = "MVX_HTTP_TIMEOUT"
 	EnvDownloadTimeout   = "MVX_DOWNLOAD_TIMEOUT"
 	EnvRegistryTimeout   = "MVX_REGISTRY_TIMEOUT"
 	EnvChecksumTimeout   = "MVX_CHECKSUM_TIMEOUT"
@@ -48,22 +64,54 @@ const (
 	EnvIdleTimeout       = "MVX_IDLE_TIMEOUT"
 	EnvMaxRetries        = "MVX_MAX_RETRIES"
 	EnvRetryDelay        = "MVX_RETRY_DELAY"
+	EnvMaxRetryDelay     = "MVX_MAX_RETRY_DELAY"
 	EnvParallelDownloads = "MVX_PARALLEL_DOWNLOADS"
 	EnvNoColor           = "MVX_NO_COLOR"
+	EnvOffline           = "MVX_OFFLINE"
+	EnvNoAutoInstall     = "MVX_NO_AUTO_INSTALL"
+	EnvCACert            = "MVX_CA_CERT"
 
 	// Tool Home Directory Environment Variables
-	EnvJavaHome  = "JAVA_HOME"
-	EnvMavenHome = "MAVEN_HOME"
-	EnvMvndHome  = "MVND_HOME"
-	EnvNodeHome  = "NODE_HOME"
-	EnvGoRoot    = "GOROOT"
-	EnvGoPath    = "GOPATH"
+	EnvJavaHome    = "JAVA_HOME"
+	EnvGraalVMHome = "GRAALVM_HOME"
+	EnvMavenHome   = "MAVEN_HOME"
+	EnvMvndHome    = "MVND_HOME"
+	EnvGradleHome  = "GRADLE_HOME"
+	EnvNodeHome    = "NODE_HOME"
+	EnvGoRoot      = "GOROOT"
+	EnvGoPath      = "GOPATH"
+	EnvCargoHome   = "CARGO_HOME"
+
+	// Rust toolchain selection by rustup proxies
+	EnvRustupToolchain = "RUSTUP_TOOLCHAIN"
+
+	// Tool Options Environment Variables
+	EnvMavenOpts = "MAVEN_OPTS"
+)
+
+// Tool Configuration Option Keys
+const (
+	// OptionRepoLocal sets a shared Maven local repository (-Dmaven.repo.local)
+	OptionRepoLocal = "repo-local"
+	// OptionDistributionFallback controls whether Java may fall back to other distributions ("off" disables it)
+	OptionDistributionFallback = "distribution-fallback"
+	// OptionGraalNativeImage installs the native-image component of GraalVM distributions lacking it ("true")
+	OptionGraalNativeImage = "graalNativeImage"
+	// OptionMavenToolchains declares the JDK in the user's Maven toolchains file ("true")
+	OptionMavenToolchains = "mavenToolchains"
+	// OptionResolve selects whether version specifications resolve to the "highest" (default) or "lowest" match
+	OptionResolve = "resolve"
+	// OptionPackageID pins the package (build) to install, as chosen with 'mvx tools add --interactive'
+	OptionPackageID = "package-id"
+	// OptionPackagePlatform records the platform OptionPackageID was chosen for
+	OptionPackagePlatform = "package-platform"
 )
 
 // File Extensions
 const (
 	ExtExe   = ".exe"
 	ExtCmd   = ".cmd"
+	ExtBat   = ".bat"
 	ExtZip   = ".zip"
 	ExtTarGz = ".tar.gz"
 	ExtTarXz = ".tar.xz"
@@ -85,11 +133,17 @@ const (
 
 // Tool Names (for consistency)
 const (
-	ToolJava  = "java"
-	ToolMaven = "maven"
-	ToolMvnd  = "mvnd"
-	ToolNode  = "node"
-	ToolGo    = "go"
+	ToolJava    = "java"
+	ToolMaven   = "maven"
+	ToolMvnd    = "mvnd"
+	ToolNode    = "node"
+	ToolGo      = "go"
+	ToolClojure = "clojure"
+	ToolGradle  = "gradle"
+	ToolRust    = "rust"
+	ToolKotlin  = "kotlin"
+	ToolDeno    = "deno"
+	ToolBun     = "bun"
 )
 
 // Platform Strings
@@ -103,9 +157,15 @@ const (
 
 // Binary Names
 const (
-	BinaryJava  = "java"
-	BinaryMaven = "mvn"
-	BinaryMvnd  = "mvnd"
-	BinaryNode  = "node"
-	BinaryGo    = "go"
+	BinaryJava    = "java"
+	BinaryMaven   = "mvn"
+	BinaryMvnd    = "mvnd"
+	BinaryNode    = "node"
+	BinaryGo      = "go"
+	BinaryClojure = "clojure"
+	BinaryGradle  = "gradle"
+	BinaryRustc   = "rustc"
+	BinaryKotlinc = "kotlinc"
+	BinaryDeno    = "deno"
+	BinaryBun     = "bun"
 )
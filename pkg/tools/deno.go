@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// Compile-time interface validation
+var _ Tool = (*DenoTool)(nil)
+var _ VersionResolver = (*DenoTool)(nil)
+
+// denoArchitectures maps Go architectures to the ones of Deno's target triples
+var denoArchitectures = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+}
+
+// DenoTool implements Tool interface for the Deno JavaScript runtime
+type DenoTool struct {
+	*BaseTool
+}
+
+// NewDenoTool creates a new Deno tool instance
+func NewDenoTool(manager *Manager) *DenoTool {
+	return &DenoTool{
+		BaseTool: NewBaseTool(manager, ToolDeno, manager.GetPlatformMapper().BinaryName(BinaryDeno, ExtExe)),
+	}
+}
+
+// Install downloads and installs the specified Deno version
+func (d *DenoTool) Install(version string, cfg config.ToolConfig) error {
+	return d.StandardInstallWithExtractor(version, cfg, d.getDownloadURL, d.extractStandaloneBinary)
+}
+
+// IsInstalled checks if the specified version is installed
+func (d *DenoTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	return d.StandardIsInstalled(version, cfg, d.GetPath)
+}
+
+// GetPath returns the binary path for the specified version (for PATH management)
+func (d *DenoTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return d.StandardGetPath(version, cfg, d.getInstalledPath)
+}
+
+// getInstalledPath returns the directory containing the deno binary of an installed version
+func (d *DenoTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
+	installDir := d.manager.GetToolVersionDir(d.GetToolName(), version, "")
+	return d.manager.GetPathResolver().FindBinaryParentDir(installDir, d.GetBinaryName())
+}
+
+// Verify runs 'deno --version', which prints "deno <version> (stable, release, <target>)"
+func (d *DenoTool) Verify(version string, cfg config.ToolConfig) error {
+	verifyConfig := VerificationConfig{
+		BinaryName:      d.GetBinaryName(),
+		VersionArgs:     []string{"--version"},
+		ExpectedVersion: "deno " + version,
+	}
+	return d.StandardVerifyWithConfig(version, cfg, verifyConfig)
+}
+
+// ListVersions returns available Deno versions, newest first
+func (d *DenoTool) ListVersions() ([]string, error) {
+	versions, err := d.manager.fetchGitHubReleaseVersions(DenoAPIBase, denoReleaseVersion)
+	if err != nil || len(versions) == 0 {
+		// Fallback to known versions if the releases feed is unavailable
+		return d.getFallbackDenoVersions(), nil
+	}
+	return version.SortVersions(versions), nil
+}
+
+// GetDisplayName returns the human-readable name for Deno (implements ToolMetadataProvider)
+func (d *DenoTool) GetDisplayName() string {
+	return "Deno"
+}
+
+// ResolveVersion resolves a Deno version specification to a concrete version
+func (d *DenoTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	availableVersions, err := d.ListVersions()
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil {
+		return "", fmt.Errorf("invalid version specification %s: %w", versionSpec, err)
+	}
+
+	resolved, err := spec.Resolve(availableVersions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Deno version %s: %w", versionSpec, err)
+	}
+
+	return resolved, nil
+}
+
+// denoTarget returns the target triple of Deno's release assets for the platform
+func (d *DenoTool) denoTarget() string {
+	platformMapper := d.manager.GetPlatformMapper()
+	arch := platformMapper.MapArchitecture(denoArchitectures)
+	switch {
+	case platformMapper.IsWindows():
+		return arch + "-pc-windows-msvc"
+	case platformMapper.IsMacOS():
+		return arch + "-apple-darwin"
+	default:
+		return arch + "-unknown-linux-gnu"
+	}
+}
+
+// getDownloadURL returns the download URL of the zip attached to a release
+func (d *DenoTool) getDownloadURL(version string) string {
+	return fmt.Sprintf("%s/releases/download/v%s/deno-%s%s", DenoGithubBase, version, d.denoTarget(), ExtZip)
+}
+
+// GetDownloadURL implements URLProvider interface for Deno
+func (d *DenoTool) GetDownloadURL(version string) string {
+	return d.getDownloadURL(version)
+}
+
+// getChecksumURL returns the checksum URL for Deno, published next to the zip
+func (d *DenoTool) getChecksumURL(version string) string {
+	return d.getDownloadURL(version) + ".sha256sum"
+}
+
+// GetChecksum implements Tool interface for Deno using the published .sha256sum file, which
+// is in PowerShell Get-FileHash format for the Windows zip
+func (d *DenoTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
+	url := d.getChecksumURL(version)
+	resp, err := d.manager.Get(url)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to fetch Deno checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ChecksumInfo{}, fmt.Errorf("Deno checksum request returned status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to read Deno checksum: %w", err)
+	}
+
+	hash, ok := parseSingleSHA256(string(content))
+	if !ok {
+		return ChecksumInfo{}, fmt.Errorf("no SHA-256 hash in Deno checksum file at %s", url)
+	}
+	return ChecksumInfo{
+		Type:  SHA256,
+		Value: hash,
+	}, nil
+}
+
+// denoReleaseVersion returns the version of a release tag such as "v2.1.4"
+func denoReleaseVersion(tag string) (string, bool) {
+	return releaseTagVersion(tag, "v")
+}
+
+// getFallbackDenoVersions returns known Deno versions as fallback
+func (d *DenoTool) getFallbackDenoVersions() []string {
+	return []string{
+		// Deno 2.x
+		"2.1.4", "2.1.3", "2.1.2", "2.1.1", "2.1.0", "2.0.6", "2.0.5", "2.0.4", "2.0.3", "2.0.2", "2.0.1", "2.0.0",
+
+		// Deno 1.x
+		"1.46.3", "1.46.2", "1.46.1", "1.46.0", "1.45.5",
+	}
+}
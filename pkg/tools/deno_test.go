@@ -0,0 +1,54 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDenoURLs(t *testing.T) {
+	tests := []struct {
+		os       string
+		arch     string
+		expected string
+	}{
+		{"linux", "amd64", "https://github.com/denoland/deno/releases/download/v2.1.4/deno-x86_64-unknown-linux-gnu.zip"},
+		{"darwin", "arm64", "https://github.com/denoland/deno/releases/download/v2.1.4/deno-aarch64-apple-darwin.zip"},
+		{"windows", "amd64", "https://github.com/denoland/deno/releases/download/v2.1.4/deno-x86_64-pc-windows-msvc.zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.os+"-"+tt.arch, func(t *testing.T) {
+			manager := newTestManager(t)
+			manager.platform = &PlatformInfo{OS: tt.os, Arch: tt.arch}
+			denoTool := NewDenoTool(manager)
+			if got := denoTool.GetDownloadURL("2.1.4"); got != tt.expected {
+				t.Errorf("GetDownloadURL() = %s, want %s", got, tt.expected)
+			}
+			if got := denoTool.getChecksumURL("2.1.4"); got != tt.expected+".sha256sum" {
+				t.Errorf("getChecksumURL() = %s, want %s.sha256sum", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSingleSHA256(t *testing.T) {
+	hash := "0fd8c8ab0d1a3bd5b9a4bde9b8c0c6e8ac2d3b5fbd8a9e2c1f4b6d8e0a2c4e6f"
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"sha256sum", hash + "  deno-x86_64-unknown-linux-gnu.zip\n"},
+		{"powershell", "\nAlgorithm : SHA256\nHash      : " + strings.ToUpper(hash) + "\nPath      : D:\\a\\deno\\deno-x86_64-pc-windows-msvc.zip\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := parseSingleSHA256(tt.content); !ok || got != hash {
+				t.Errorf("parseSingleSHA256() = %q, %v, want %q", got, ok, hash)
+			}
+		})
+	}
+	if _, ok := parseSingleSHA256("Not Found"); ok {
+		t.Error("parseSingleSHA256() found a hash in a file without one")
+	}
+}
@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+// DiagnosticHost is a server mvx downloads tools or their metadata from
+type DiagnosticHost struct {
+	Name string
+	URL  string
+}
+
+// DiagnosticHosts are the servers whose reachability 'mvx doctor' checks
+var DiagnosticHosts = []DiagnosticHost{
+	{Name: "Foojay Disco API (Java)", URL: FoojayDiscoAPIBase + "/major_versions"},
+	{Name: "go.dev (Go)", URL: GoDevAPIBase + "/?mode=json"},
+	{Name: "nodejs.org (Node.js)", URL: NodeJSDistBase + "/index.json"},
+	{Name: "Apache dist (Maven, mvnd)", URL: ApacheDistBase + "/"},
+	{Name: "Apache archive (Maven, mvnd)", URL: ApacheMavenBase + "/"},
+	{Name: "GitHub API", URL: GitHubAPIBase},
+}
+
+// HostCheck is the result of checking the reachability of a host
+type HostCheck struct {
+	Host       DiagnosticHost
+	URL        string        // URL requested, with URL replacements applied
+	StatusCode int           // Status of the response, 0 if the request failed
+	Latency    time.Duration // Time until the response headers were received
+	Err        error
+}
+
+// Reachable returns whether the host answered, whatever the status of its response
+func (c HostCheck) Reachable() bool {
+	return c.Err == nil
+}
+
+// CheckHosts sends a HEAD request to each host, through the proxy and with the certificates
+// mvx downloads use and with URL replacements applied, bypassing the caches
+func (m *Manager) CheckHosts(hosts []DiagnosticHost, timeout time.Duration) []HostCheck {
+	urlReplacer, err := LoadURLReplacer()
+	if err != nil {
+		util.LogVerbose("Warning: failed to load URL replacements: %v", err)
+		urlReplacer = NewURLReplacer(nil)
+	}
+	client := &http.Client{Transport: m.httpClient.Transport, Timeout: timeout}
+
+	checks := make([]HostCheck, len(hosts))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		wg.Add(1)
+		go func(i int, host DiagnosticHost) {
+			defer wg.Done()
+			checks[i] = checkHost(client, host, urlReplacer.ApplyReplacements(host.URL))
+		}(i, host)
+	}
+	wg.Wait()
+	return checks
+}
+
+// checkHost sends a HEAD request to the URL of a host
+func checkHost(client *http.Client, host DiagnosticHost, url string) HostCheck {
+	check := HostCheck{Host: host, URL: url}
+	if IsOffline() {
+		check.Err = fmt.Errorf("%w: refusing HEAD %s", ErrOffline, url)
+		return check
+	}
+
+	req, err := http.NewRequest(http.MethodHead, url, nil)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	req.Header.Set("User-Agent", "mvx/1.0 (https://github.com/gnodet/mvx)")
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	check.Latency = time.Since(start)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	resp.Body.Close()
+	check.StatusCode = resp.StatusCode
+	return check
+}
@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckHosts(t *testing.T) {
+	t.Setenv("MVX_HOME", t.TempDir())
+	manager := newTestManager(t)
+
+	var methods []string
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+	}))
+	defer up.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	checks := manager.CheckHosts([]DiagnosticHost{
+		{Name: "up", URL: up.URL},
+		{Name: "failing", URL: failing.URL},
+		{Name: "down", URL: down.URL},
+	}, 5*time.Second)
+
+	tests := []struct {
+		name       string
+		reachable  bool
+		statusCode int
+	}{
+		{name: "up", reachable: true, statusCode: http.StatusOK},
+		{name: "failing", reachable: true, statusCode: http.StatusServiceUnavailable},
+		{name: "down", reachable: false},
+	}
+	for i, tt := range tests {
+		check := checks[i]
+		if check.Host.Name != tt.name || check.Reachable() != tt.reachable || check.StatusCode != tt.statusCode {
+			t.Errorf("CheckHosts()[%d] = %s reachable %v HTTP %d (%v), want %s reachable %v HTTP %d",
+				i, check.Host.Name, check.Reachable(), check.StatusCode, check.Err, tt.name, tt.reachable, tt.statusCode)
+		}
+	}
+	if len(methods) != 1 || methods[0] != http.MethodHead {
+		t.Errorf("requests to the host = %v, want a single HEAD", methods)
+	}
+
+	t.Setenv(EnvOffline, "true")
+	if check := manager.CheckHosts([]DiagnosticHost{{Name: "up", URL: up.URL}}, time.Second)[0]; check.Reachable() {
+		t.Errorf("CheckHosts() offline reached %s", check.URL)
+	}
+}
@@ -3,12 +3,15 @@ package tools
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,7 +24,8 @@ type DownloadConfig struct {
 	URL           string
 	DestPath      string
 	MaxRetries    int
-	RetryDelay    time.Duration
+	RetryDelay    time.Duration // Base delay, doubled at each retry
+	MaxRetryDelay time.Duration // Maximum delay between retries, 0 for no limit
 	Timeout       time.Duration
 	ExpectedType  string // Expected content type
 	MinSize       int64  // Minimum expected file size
@@ -30,7 +34,9 @@ type DownloadConfig struct {
 	ToolName      string // Name of the tool being downloaded (for progress reporting)
 	Version       string // Tool version for checksum verification
 	Config        config.ToolConfig
-	Tool          Tool // Tool instance for checksum verification
+	Tool          Tool      // Tool instance for checksum verification
+	Output        io.Writer // Where progress messages go, the status output if nil
+	FallbackURLs  []string  // URLs tried in order when the download from URL fails
 }
 
 // getTimeoutFromEnv returns a timeout from environment variable or default value
@@ -43,6 +49,23 @@ func getTimeoutFromEnv(envVar string, defaultTimeout time.Duration) time.Duratio
 	return defaultTimeout
 }
 
+// getNetworkTimeout returns the overall network timeout from MVX_TIMEOUT (set by --timeout) if
+// any, or else a timeout from environment variable or default value
+func getNetworkTimeout(envVar string, defaultTimeout time.Duration) time.Duration {
+	return getTimeoutFromEnv(EnvTimeout, getTimeoutFromEnv(envVar, defaultTimeout))
+}
+
+// output returns where the progress messages of the download go
+func (c *DownloadConfig) output() io.Writer {
+	if c.Output != nil {
+		return c.Output
+	}
+	if util.IsJSONLog() {
+		return util.NewLogWriter(c.ToolName)
+	}
+	return os.Stdout
+}
+
 // DefaultDownloadConfig returns a default download configuration
 func DefaultDownloadConfig(url, destPath string) *DownloadConfig {
 	configProvider := NewDownloadConfigProvider(NewEnvironmentConfigProvider())
@@ -52,6 +75,7 @@ func DefaultDownloadConfig(url, destPath string) *DownloadConfig {
 		DestPath:      destPath,
 		MaxRetries:    configProvider.GetMaxRetries(),
 		RetryDelay:    configProvider.GetRetryDelay(),
+		MaxRetryDelay: configProvider.GetMaxRetryDelay(),
 		Timeout:       configProvider.GetDownloadTimeout(),
 		MinSize:       configProvider.GetMinFileSize(),
 		MaxSize:       configProvider.GetMaxFileSize(),
@@ -69,69 +93,127 @@ type DownloadResult struct {
 
 // RobustDownload performs a robust download with validation and retries
 func RobustDownload(config *DownloadConfig) (*DownloadResult, error) {
-	// Apply URL replacements if configured
-	originalURL := config.URL
+	// A local archive replaces the download, and is available offline
+	if config.Config.LocalArchive() != "" {
+		return copyLocalArchive(config)
+	}
+	if IsOffline() {
+		return nil, fmt.Errorf("%w: refusing to download %s", ErrOffline, config.URL)
+	}
+
+	// Try the mirrors of the tool first, then the download URL and its fallbacks
 	urlReplacer, err := LoadURLReplacer()
 	if err != nil {
 		util.LogVerbose("Warning: failed to load URL replacements: %v", err)
-	} else {
-		config.URL = urlReplacer.ApplyReplacements(config.URL)
-		if config.URL != originalURL {
-			toolPrefix := ""
-			if config.ToolName != "" {
-				toolPrefix = fmt.Sprintf("[%s] ", config.ToolName)
-			}
-			fmt.Printf("  🔄 %sUsing URL replacement: %s\n", toolPrefix, getUserFriendlyURL(config.URL))
+		urlReplacer = nil
+	}
+	urls := downloadURLs(config, LoadMirrors(config.ToolName), urlReplacer)
+
+	// The partial download is kept between attempts, so that retries resume it
+	partialFile, err := os.CreateTemp("", "mvx-download-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	partialFile.Close()
+	defer os.Remove(partialFile.Name())
+
+	var lastErr error
+	for i, downloadURL := range urls {
+		if i > 0 {
+			fmt.Fprintf(config.output(), "  🔄 %sTrying %s...\n", config.toolPrefix(), getUserFriendlyURL(downloadURL))
+			// Another server may serve different bytes, start over
+			os.Truncate(partialFile.Name(), 0)
+		}
+		config.URL = downloadURL
+
+		result, err := downloadWithRetries(config, partialFile.Name())
+		if err == nil {
+			return result, nil
 		}
+		lastErr = err
 	}
 
+	if len(urls) > 1 {
+		lastErr = fmt.Errorf("download failed from all %d URLs, last error: %w", len(urls), lastErr)
+	}
+	config.notify(InstallEvent{Type: EventError, URL: config.URL, Err: lastErr})
+	return nil, lastErr
+}
+
+// downloadWithRetries downloads from the URL of the configuration, retrying failed attempts
+func downloadWithRetries(config *DownloadConfig, partialPath string) (*DownloadResult, error) {
+	toolPrefix := config.toolPrefix()
 	var lastErr error
+	attempts := 0
 
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			toolPrefix := ""
-			if config.ToolName != "" {
-				toolPrefix = fmt.Sprintf("[%s] ", config.ToolName)
-			}
-			fmt.Printf("  🔄 %sRetry attempt %d/%d after %v...\n", toolPrefix, attempt, config.MaxRetries, config.RetryDelay)
-			time.Sleep(config.RetryDelay * time.Duration(attempt)) // Exponential backoff
+			delay := retryDelay(config, attempt, lastErr)
+			fmt.Fprintf(config.output(), "  🔄 %sRetry attempt %d/%d after %v...\n", toolPrefix, attempt, config.MaxRetries, delay.Round(time.Millisecond))
+			time.Sleep(delay)
 		}
 
-		result, err := attemptDownload(config)
+		attempts++
+		result, err := attemptDownload(config, partialPath)
 		if err == nil {
 			return result, nil
 		}
 
 		lastErr = err
-		toolPrefix := ""
-		if config.ToolName != "" {
-			toolPrefix = fmt.Sprintf("[%s] ", config.ToolName)
+		fmt.Fprintf(config.output(), "  ⚠️  %sDownload attempt %d failed: %v\n", toolPrefix, attempt+1, err)
+		if !isRetryable(err) {
+			break
 		}
-		fmt.Printf("  ⚠️  %sDownload attempt %d failed: %v\n", toolPrefix, attempt+1, err)
 	}
 
-	return nil, fmt.Errorf("download failed after %d attempts: %w", config.MaxRetries+1, lastErr)
+	return nil, fmt.Errorf("download failed after %d attempts: %w", attempts, lastErr)
 }
 
-// attemptDownload performs a single download attempt
-func attemptDownload(config *DownloadConfig) (*DownloadResult, error) {
-	// Create temporary file
-	tempFile, err := os.CreateTemp("", "mvx-download-*.tmp")
-	if err != nil {
-		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+// toolPrefix returns the prefix of the messages of the download, naming the tool downloaded
+func (c *DownloadConfig) toolPrefix() string {
+	if c.ToolName == "" {
+		return ""
+	}
+	return fmt.Sprintf("[%s] ", c.ToolName)
+}
+
+// notify sends a download event to the observers of the manager of the tool downloaded, or
+// renders it on the console when the download is not made for a managed tool
+func (c *DownloadConfig) notify(event InstallEvent) {
+	event.Tool, event.Version = c.ToolName, c.Version
+	if c.Tool != nil {
+		if manager := c.Tool.GetManager(); manager != nil {
+			manager.notify(event)
+			return
+		}
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	defaultObserver().OnInstallEvent(event)
+}
+
+// attemptDownload performs a single download attempt into partialPath, resuming the bytes a
+// previous attempt left there with a Range request, and moves the complete file to its destination
+func attemptDownload(config *DownloadConfig, partialPath string) (*DownloadResult, error) {
+	var offset int64
+	if info, err := os.Stat(partialPath); err == nil {
+		offset = info.Size()
 	}
-	defer os.Remove(tempFile.Name())
-	defer tempFile.Close()
 
 	// Create HTTP client with granular timeouts for better handling of slow servers
 	configProvider := NewDownloadConfigProvider(NewEnvironmentConfigProvider())
 
+	transport, err := newHTTPTransport()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSHandshakeTimeout = configProvider.GetTLSTimeout()
+	transport.ResponseHeaderTimeout = configProvider.GetResponseTimeout()
+	transport.IdleConnTimeout = configProvider.GetIdleTimeout()
+
 	client := &http.Client{
-		Transport: &http.Transport{
-			TLSHandshakeTimeout:   configProvider.GetTLSTimeout(),
-			ResponseHeaderTimeout: configProvider.GetResponseTimeout(),
-			IdleConnTimeout:       configProvider.GetIdleTimeout(),
-		},
+		Transport: transport,
 		// Use context timeout instead of global client timeout for better control
 		CheckRedirect: func(req *http.Request, via []*http.Request) error {
 			if len(via) >= MaxRedirects {
@@ -152,13 +234,16 @@ func attemptDownload(config *DownloadConfig) (*DownloadResult, error) {
 
 	// Set user agent
 	req.Header.Set("User-Agent", "mvx/1.0 (https://github.com/gnodet/mvx)")
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
 
 	// Perform request with progress indication for slow servers
 	toolPrefix := ""
 	if config.ToolName != "" {
 		toolPrefix = fmt.Sprintf("[%s] ", config.ToolName)
 	}
-	fmt.Printf("  🌐 %sConnecting to server...\n", toolPrefix)
+	fmt.Fprintf(config.output(), "  🌐 %sConnecting to server...\n", toolPrefix)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -166,63 +251,83 @@ func attemptDownload(config *DownloadConfig) (*DownloadResult, error) {
 	}
 	defer resp.Body.Close()
 
-	fmt.Printf("  📡 %sServer responded, starting download...\n", toolPrefix)
-
-	// Check status code
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	fmt.Fprintf(config.output(), "  📡 %sServer responded, starting download...\n", toolPrefix)
+
+	// Check status code: servers ignoring the Range header send the whole file again
+	resumed := offset > 0 && resp.StatusCode == http.StatusPartialContent &&
+		strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset))
+	switch {
+	case resumed:
+		fmt.Fprintf(config.output(), "  ⏯️  %sResuming download after %d bytes...\n", toolPrefix, offset)
+	case resp.StatusCode == http.StatusOK:
+		offset = 0
+	case offset > 0 && resp.StatusCode == http.StatusPartialContent:
+		// The partial content doesn't continue the partial download, restart from zero
+		os.Truncate(partialPath, 0)
+		return nil, fmt.Errorf("server resumed the download with %q instead of at byte %d", resp.Header.Get("Content-Range"), offset)
+	default:
+		if resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+			// Restart from zero on the next attempt
+			os.Truncate(partialPath, 0)
+		}
+		return nil, &httpStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
 	// Validate content type if specified
 	if config.ExpectedType != "" {
 		contentType := resp.Header.Get("Content-Type")
 		if contentType != "" && !strings.Contains(contentType, config.ExpectedType) {
-			return nil, fmt.Errorf("unexpected content type: got %s, expected %s", contentType, config.ExpectedType)
+			return nil, &nonRetryableError{fmt.Errorf("unexpected content type: got %s, expected %s", contentType, config.ExpectedType)}
 		}
 	}
 
 	// Check content length if available
-	if contentLength := resp.ContentLength; contentLength > 0 {
+	if resp.ContentLength > 0 {
+		contentLength := offset + resp.ContentLength
 		if contentLength < config.MinSize {
-			return nil, fmt.Errorf("content too small: %d bytes (minimum %d)", contentLength, config.MinSize)
+			return nil, &nonRetryableError{fmt.Errorf("content too small: %d bytes (minimum %d)", contentLength, config.MinSize)}
 		}
 		if contentLength > config.MaxSize {
-			return nil, fmt.Errorf("content too large: %d bytes (maximum %d)", contentLength, config.MaxSize)
+			return nil, &nonRetryableError{fmt.Errorf("content too large: %d bytes (maximum %d)", contentLength, config.MaxSize)}
 		}
 	}
 
-	// Download with size tracking
-	written, err := io.Copy(tempFile, resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("download failed: %w", err)
-	}
-
-	// Validate downloaded size
-	if written < config.MinSize {
-		return nil, fmt.Errorf("downloaded file too small: %d bytes (minimum %d)", written, config.MinSize)
-	}
-	if written > config.MaxSize {
-		return nil, fmt.Errorf("downloaded file too large: %d bytes (maximum %d)", written, config.MaxSize)
+	// Download with size tracking, appending to the partial download when resuming it
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if resumed {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
 	}
-
-	// Close temp file before validation
+	tempFile, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open temporary file: %w", err)
+	}
+	var total int64
+	if resp.ContentLength > 0 {
+		total = offset + resp.ContentLength
+	}
+	activeDownloads.Add(1)
+	config.notify(InstallEvent{Type: EventDownloadStart, URL: config.URL, Downloaded: offset, Total: total})
+	progress := newProgressReader(resp.Body, func(event InstallEvent) {
+		event.URL = config.URL
+		config.notify(event)
+	}, offset, total)
+	copied, err := io.Copy(tempFile, progress)
+	progress.Finish()
+	activeDownloads.Add(-1)
 	tempFile.Close()
-
-	// Validate file content if requested
-	if config.ValidateMagic {
-		if err := validateFileFormat(tempFile.Name(), config.URL); err != nil {
-			return nil, fmt.Errorf("file validation failed: %w", err)
-		}
+	if err != nil {
+		return nil, fmt.Errorf("download failed after %d bytes: %w", offset+copied, err)
 	}
+	written := offset + copied
 
-	// Verify checksum if tool is available
-	if config.Tool != nil {
-		// Update config with final URL for better filename detection
-		finalConfig := *config
-		finalConfig.URL = resp.Request.URL.String()
-		if err := verifyChecksum(tempFile.Name(), &finalConfig); err != nil {
-			return nil, err
-		}
+	// Validate the assembled file, starting over on the next attempt if it is not right
+	if err := validateDownload(partialPath, written, config, resp.Request.URL.String()); err != nil {
+		os.Truncate(partialPath, 0)
+		return nil, err
 	}
 
 	// Create destination directory
@@ -231,7 +336,7 @@ func attemptDownload(config *DownloadConfig) (*DownloadResult, error) {
 	}
 
 	// Move to final destination with Windows-specific retry logic
-	if err := moveFileWithRetry(tempFile.Name(), config.DestPath); err != nil {
+	if err := moveFileWithRetry(partialPath, config.DestPath); err != nil {
 		return nil, fmt.Errorf("failed to move file to destination: %w", err)
 	}
 
@@ -243,6 +348,130 @@ func attemptDownload(config *DownloadConfig) (*DownloadResult, error) {
 	}, nil
 }
 
+// httpStatusError is the error of a download answered with an unexpected HTTP status
+type httpStatusError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration // Delay requested by the Retry-After header, 0 if none
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+// nonRetryableError is the error of a download rejected for its content, which the same URL
+// would serve again: a wrong content type or size, or a failed checksum or signature verification
+type nonRetryableError struct {
+	err error
+}
+
+func (e *nonRetryableError) Error() string {
+	return e.err.Error()
+}
+
+func (e *nonRetryableError) Unwrap() error {
+	return e.err
+}
+
+// isRetryable returns whether a failed download attempt may succeed when retried: rejected
+// content and client errors such as 404 or 403 fail the same way again, except timeouts,
+// rate limiting and a range the partial download no longer matches
+func isRetryable(err error) bool {
+	var rejected *nonRetryableError
+	if errors.As(err, &rejected) {
+		return false
+	}
+	var statusErr *httpStatusError
+	if !errors.As(err, &statusErr) {
+		return true
+	}
+	switch statusErr.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusRequestedRangeNotSatisfiable:
+		return true
+	}
+	return statusErr.StatusCode < 400 || statusErr.StatusCode >= 500
+}
+
+// retryDelay returns how long to wait before a retry: the delay the server asked for with
+// Retry-After if any, or else the base delay doubled at each retry with a random jitter so that
+// concurrent downloads do not retry in lockstep, both limited to the maximum delay
+func retryDelay(config *DownloadConfig, attempt int, lastErr error) time.Duration {
+	limit := func(delay time.Duration) time.Duration {
+		if config.MaxRetryDelay > 0 && delay > config.MaxRetryDelay {
+			return config.MaxRetryDelay
+		}
+		return delay
+	}
+
+	var statusErr *httpStatusError
+	if errors.As(lastErr, &statusErr) && statusErr.RetryAfter > 0 {
+		return limit(statusErr.RetryAfter)
+	}
+
+	delay := config.RetryDelay
+	for i := 1; i < attempt && (config.MaxRetryDelay <= 0 || delay < config.MaxRetryDelay); i++ {
+		delay *= 2
+	}
+	delay = limit(delay)
+	if delay <= 0 {
+		return 0
+	}
+	// Wait between half and all of the delay
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+// validateDownload checks the size, format, checksum and signature of a complete download
+func validateDownload(filePath string, size int64, config *DownloadConfig, finalURL string) error {
+	// Validate downloaded size
+	if size < config.MinSize {
+		return &nonRetryableError{fmt.Errorf("downloaded file too small: %d bytes (minimum %d)", size, config.MinSize)}
+	}
+	if size > config.MaxSize {
+		return &nonRetryableError{fmt.Errorf("downloaded file too large: %d bytes (maximum %d)", size, config.MaxSize)}
+	}
+
+	// Validate file content if requested
+	if config.ValidateMagic {
+		if err := validateFileFormat(filePath, config.URL); err != nil {
+			return fmt.Errorf("file validation failed: %w", err)
+		}
+	}
+
+	// Verify checksum if tool is available
+	if config.Tool != nil {
+		// Update config with final URL for better filename detection
+		finalConfig := *config
+		finalConfig.URL = finalURL
+		if err := verifyChecksum(filePath, &finalConfig); err != nil {
+			return &nonRetryableError{err}
+		}
+	}
+
+	// Verify the PGP signature if configured, against the URL the signature is published next to
+	if err := verifySignature(filePath, config); err != nil {
+		return &nonRetryableError{err}
+	}
+	return nil
+}
+
 // validateFileFormat validates the downloaded file format based on magic bytes
 func validateFileFormat(filePath, url string) error {
 	file, err := os.Open(filePath)
@@ -420,33 +649,20 @@ func verifyChecksum(filePath string, config *DownloadConfig) error {
 	var hasChecksum bool
 
 	// Check if checksum is provided in configuration
-	if config.Config.Checksum != nil {
-		checksumType := config.Config.Checksum.Type
-		if checksumType == "" {
-			checksumType = "sha256" // default
-		}
-
-		checksumInfo = ChecksumInfo{
-			Type:     ChecksumType(checksumType),
-			Value:    config.Config.Checksum.Value,
-			URL:      config.Config.Checksum.URL,
-			Filename: config.Config.Checksum.Filename,
-		}
-		hasChecksum = true
-	}
+	checksumInfo, hasChecksum = configuredChecksum(config.Config)
 
 	if !hasChecksum {
 		// Try to get checksum from tool using dynamic lookup
 		// Extract filename from URL, handling redirects and query parameters
 		filename := extractFilenameFromURL(config.URL)
-		fmt.Printf("  🔍 Attempting to find checksum for file: %s\n", filename)
+		fmt.Fprintf(config.output(), "  🔍 Attempting to find checksum for file: %s\n", filename)
 
 		// Use tool's GetChecksum method for dynamic checksum resolution
 		if dynamicChecksum, err := config.Tool.GetChecksum(config.Version, config.Config, filename); err == nil {
 			checksumInfo = dynamicChecksum
 			hasChecksum = true
 		} else {
-			fmt.Printf("  ⚠️  Tool checksum lookup failed: %v\n", err)
+			fmt.Fprintf(config.output(), "  ⚠️  Tool checksum lookup failed: %v\n", err)
 		}
 
 		// Tools should handle checksum fetching in their GetChecksum method
@@ -455,17 +671,19 @@ func verifyChecksum(filePath string, config *DownloadConfig) error {
 
 	if !hasChecksum {
 		if config.Tool.SupportsChecksumVerification() {
-			fmt.Printf("⚠️  No checksum available for %s %s\n", config.ToolName, config.Version)
-			fmt.Printf("   Consider adding checksum verification to your configuration for enhanced security.\n")
+			fmt.Fprintf(config.output(), "⚠️  No checksum available for %s %s\n", config.ToolName, config.Version)
+			fmt.Fprintf(config.output(), "   Consider adding checksum verification to your configuration for enhanced security.\n")
 		}
 		return nil
 	}
 
-	// Check if checksum verification is required
-	isRequired := config.Config.Checksum != nil && config.Config.Checksum.Required
+	// Check if checksum verification is required. A checksum value pinned in the
+	// configuration is always enforced, as it is meant for reproducible installs.
+	isRequired := config.Config.Checksum != nil && (config.Config.Checksum.Required || config.Config.Checksum.Value != "")
 
 	// Create checksum verifier
 	verifier := NewChecksumVerifier(config.Tool.GetManager())
+	verifier.out = config.output()
 
 	if isRequired {
 		// Strict verification - fail on error
@@ -475,7 +693,7 @@ func verifyChecksum(filePath string, config *DownloadConfig) error {
 			// Don't panic, return error instead for better error handling
 			return fmt.Errorf("checksum verification failed (required): %v", err)
 		}
-		fmt.Printf("  ✅ Checksum verified successfully (required)\n")
+		fmt.Fprintf(config.output(), "  ✅ Checksum verified successfully (required)\n")
 	} else {
 		// Optional verification - warn on error
 		verifier.VerifyFileWithWarning(filePath, checksumInfo)
@@ -484,6 +702,26 @@ func verifyChecksum(filePath string, config *DownloadConfig) error {
 	return nil
 }
 
+// configuredChecksum returns the checksum pinned in the tool configuration, if any.
+// A checksum block that only sets options such as required does not count.
+func configuredChecksum(cfg config.ToolConfig) (ChecksumInfo, bool) {
+	if cfg.Checksum == nil || (cfg.Checksum.Value == "" && cfg.Checksum.URL == "") {
+		return ChecksumInfo{}, false
+	}
+
+	checksumType := cfg.Checksum.Type
+	if checksumType == "" {
+		checksumType = "sha256" // default
+	}
+
+	return ChecksumInfo{
+		Type:     ChecksumType(checksumType),
+		Value:    cfg.Checksum.Value,
+		URL:      cfg.Checksum.URL,
+		Filename: cfg.Checksum.Filename,
+	}, true
+}
+
 // extractFilenameFromURL extracts the filename from a URL, handling redirects and query parameters
 func extractFilenameFromURL(urlStr string) string {
 	// Parse the URL
@@ -0,0 +1,217 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestRobustDownloadResumes(t *testing.T) {
+	// A gzip header followed by enough data to interrupt the transfer halfway
+	content := append([]byte{0x1f, 0x8b}, bytes.Repeat([]byte("mvx"), 4096)...)
+	half := len(content) / 2
+
+	tests := []struct {
+		name           string
+		supportsRange  bool
+		wrongOffset    bool     // The server answers the Range request with another range
+		expectedRanges []string // Range headers sent, none then a resume at half when nil
+	}{
+		{name: "server supports ranges", supportsRange: true},
+		{name: "server ignores ranges", supportsRange: false},
+		{name: "server resumes at the wrong offset", supportsRange: true, wrongOffset: true,
+			expectedRanges: []string{"", fmt.Sprintf("bytes=%d-", half), ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var ranges []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				ranges = append(ranges, r.Header.Get("Range"))
+				first := len(ranges) == 1
+				mutex.Unlock()
+
+				if first {
+					// Send half of the file, then drop the connection
+					w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+					w.Write(content[:half])
+					w.(http.Flusher).Flush()
+					panic(http.ErrAbortHandler)
+				}
+				if tt.supportsRange && r.Header.Get("Range") != "" {
+					if tt.wrongOffset {
+						w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", len(content)-1, len(content)))
+						w.WriteHeader(http.StatusPartialContent)
+						w.Write(content)
+						return
+					}
+					w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", half, len(content)-1, len(content)))
+					w.WriteHeader(http.StatusPartialContent)
+					w.Write(content[half:])
+					return
+				}
+				w.Write(content)
+			}))
+			defer server.Close()
+
+			t.Setenv("HOME", t.TempDir())
+			destPath := filepath.Join(t.TempDir(), "tool.tar.gz")
+			config := DefaultDownloadConfig(server.URL+"/tool.tar.gz", destPath)
+			config.MaxRetries = 2
+			config.RetryDelay = time.Millisecond
+			config.MinSize = 1
+
+			result, err := RobustDownload(config)
+			if err != nil {
+				t.Fatalf("RobustDownload() error = %v", err)
+			}
+			if result.Size != int64(len(content)) {
+				t.Errorf("RobustDownload() size = %d, want %d", result.Size, len(content))
+			}
+			downloaded, err := os.ReadFile(destPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(downloaded, content) {
+				t.Errorf("downloaded %d bytes differing from the %d bytes served", len(downloaded), len(content))
+			}
+
+			expectedRanges := tt.expectedRanges
+			if expectedRanges == nil {
+				expectedRanges = []string{"", fmt.Sprintf("bytes=%d-", half)}
+			}
+			if fmt.Sprint(ranges) != fmt.Sprint(expectedRanges) {
+				t.Errorf("Range headers = %q, want %q", ranges, expectedRanges)
+			}
+		})
+	}
+}
+
+func TestRobustDownloadRetries(t *testing.T) {
+	content := append([]byte{0x1f, 0x8b}, bytes.Repeat([]byte("mvx"), 100)...)
+
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "fake", nil)
+
+	tests := []struct {
+		name             string
+		failures         []int                 // Status of the responses before the file is served
+		configure        func(*DownloadConfig) // Rejects the content served, if set
+		expectedRequests int
+		expectError      bool
+	}{
+		{name: "rate limited", failures: []int{http.StatusTooManyRequests}, expectedRequests: 2},
+		{name: "unavailable", failures: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}, expectedRequests: 3},
+		{name: "not found", failures: []int{http.StatusNotFound}, expectedRequests: 1, expectError: true},
+		{name: "forbidden", failures: []int{http.StatusForbidden}, expectedRequests: 1, expectError: true},
+		{name: "content type rejected", configure: func(c *DownloadConfig) { c.ExpectedType = "application/zip" },
+			expectedRequests: 1, expectError: true},
+		{name: "content too large", configure: func(c *DownloadConfig) { c.MaxSize = 10 },
+			expectedRequests: 1, expectError: true},
+		{name: "checksum mismatch", configure: func(c *DownloadConfig) {
+			c.Tool = tool
+			c.Config = config.ToolConfig{Version: "1.0.0", Checksum: &config.ChecksumConfig{Value: "deadbeef"}}
+		}, expectedRequests: 1, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				requests++
+				request := requests
+				mutex.Unlock()
+
+				if request <= len(tt.failures) {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.failures[request-1])
+					return
+				}
+				w.Write(content)
+			}))
+			defer server.Close()
+
+			t.Setenv("HOME", t.TempDir())
+			config := DefaultDownloadConfig(server.URL+"/tool.tar.gz", filepath.Join(t.TempDir(), "tool.tar.gz"))
+			config.MaxRetries = 3
+			config.RetryDelay = time.Millisecond
+			config.MinSize = 1
+			config.Output = &bytes.Buffer{}
+			if tt.configure != nil {
+				tt.configure(config)
+			}
+
+			_, err := RobustDownload(config)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("RobustDownload() error = %v, expectError %v", err, tt.expectError)
+			}
+			if requests != tt.expectedRequests {
+				t.Errorf("RobustDownload() made %d requests, want %d", requests, tt.expectedRequests)
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	config := &DownloadConfig{RetryDelay: time.Second, MaxRetryDelay: 10 * time.Second}
+
+	tests := []struct {
+		name     string
+		attempt  int
+		lastErr  error
+		minDelay time.Duration
+		maxDelay time.Duration
+	}{
+		{name: "first retry", attempt: 1, lastErr: io.EOF, minDelay: 500 * time.Millisecond, maxDelay: time.Second},
+		{name: "third retry", attempt: 3, lastErr: io.EOF, minDelay: 2 * time.Second, maxDelay: 4 * time.Second},
+		{name: "limited", attempt: 10, lastErr: io.EOF, minDelay: 5 * time.Second, maxDelay: 10 * time.Second},
+		{name: "retry after", attempt: 1, lastErr: &httpStatusError{StatusCode: 429, RetryAfter: 7 * time.Second}, minDelay: 7 * time.Second, maxDelay: 7 * time.Second},
+		{name: "retry after limited", attempt: 1, lastErr: &httpStatusError{StatusCode: 503, RetryAfter: time.Hour}, minDelay: 10 * time.Second, maxDelay: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 20; i++ {
+				if delay := retryDelay(config, tt.attempt, tt.lastErr); delay < tt.minDelay || delay > tt.maxDelay {
+					t.Fatalf("retryDelay() = %v, want between %v and %v", delay, tt.minDelay, tt.maxDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "", expected: 0},
+		{value: "120", expected: 2 * time.Minute},
+		{value: "-1", expected: 0},
+		{value: "Wed, 01 Jan 2025 12:00:30 GMT", expected: 30 * time.Second},
+		{value: "Wed, 01 Jan 2025 11:00:00 GMT", expected: 0},
+		{value: "soon", expected: 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.expected {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.value, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package tools
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+const (
+	// envCacheFile stores computed environments across processes
+	envCacheFile = "env_cache.json"
+	// envCacheMaxEntries bounds the size of the on-disk environment cache
+	envCacheMaxEntries = 32
+	// envCacheTTL is how long a cached environment remains valid
+	envCacheTTL = 24 * time.Hour
+	// envCacheVersion identifies the format of the entries, older ones being ignored: those of
+	// version 1 held the values of env files and of the project environment
+	envCacheVersion = 2
+)
+
+// volatileEnvVars are system variables that change between invocations without affecting
+// tool environments. They are left out of the cache key and always taken from the current process.
+var volatileEnvVars = map[string]bool{
+	"_":      true,
+	"PWD":    true,
+	"OLDPWD": true,
+	"SHLVL":  true,
+}
+
+// EnvironmentCacheEntry holds the variables SetupEnvironment changed compared to the system environment.
+// The values of env files and of the project environment, often secrets, are not stored: Project
+// names the variables to take from them again.
+type EnvironmentCacheEntry struct {
+	Version   int               `json:"version"`
+	Delta     map[string]string `json:"delta"`
+	Project   []string          `json:"project,omitempty"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// environmentCacheKey hashes everything the computed environment depends on: the system
+// environment, the configured environment and env files and the resolved tool set, including the state
+// of each tool's installation directory so that installs and reinstalls invalidate the key.
+func (m *Manager) environmentCacheKey(cfg *config.Config) string {
+	hasher := sha256.New()
+
+	systemEnv := os.Environ()
+	sort.Strings(systemEnv)
+	for _, envVar := range systemEnv {
+		name, _, _ := strings.Cut(envVar, "=")
+		if !volatileEnvVars[name] {
+			fmt.Fprintf(hasher, "sys:%s\n", envVar)
+		}
+	}
+
+	for _, key := range sortedKeys(cfg.Environment) {
+		fmt.Fprintf(hasher, "env:%s=%s\n", key, cfg.Environment[key])
+	}
+
+	for _, path := range cfg.EnvFilePaths() {
+		fmt.Fprintf(hasher, "envfile:%s\n", path)
+		if content, err := os.ReadFile(path); err == nil {
+			hasher.Write(content)
+		}
+	}
+
+	for _, toolName := range sortedKeys(cfg.Tools) {
+		// Relative paths depend on the project the configuration was loaded from
+		toolConfig := resolveRepoLocal(cfg, cfg.Tools[toolName])
+		fmt.Fprintf(hasher, "tool:%s:%s:%s\n", toolName, toolConfig.Version, toolConfig.Distribution)
+		for _, key := range sortedKeys(toolConfig.Options) {
+			fmt.Fprintf(hasher, "option:%s=%s\n", key, toolConfig.Options[key])
+		}
+		for _, key := range sortedKeys(toolConfig.Env) {
+			fmt.Fprintf(hasher, "toolenv:%s=%s\n", key, toolConfig.Env[key])
+		}
+
+		resolvedVersion, err := m.resolveVersion(toolName, toolConfig)
+		if err != nil {
+			fmt.Fprintf(hasher, "unresolved:%v\n", err)
+			continue
+		}
+		fmt.Fprintf(hasher, "resolved:%s\n", resolvedVersion)
+
+		if tool, err := m.GetTool(toolName); err == nil {
+			resolvedConfig := toolConfig
+			resolvedConfig.Version = resolvedVersion
+			installDir := m.getInstallDir(tool, resolvedVersion, resolvedConfig)
+			if info, err := os.Stat(installDir); err == nil {
+				fmt.Fprintf(hasher, "installed:%s:%d\n", installDir, info.ModTime().UnixNano())
+			} else {
+				fmt.Fprintf(hasher, "missing:%s\n", installDir)
+			}
+		}
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// getCachedEnvironment returns the cached environment entry for a key, looking in memory then on disk
+func (m *Manager) getCachedEnvironment(key string) (EnvironmentCacheEntry, bool) {
+	m.envCacheMutex.Lock()
+	defer m.envCacheMutex.Unlock()
+
+	if m.envCache == nil {
+		m.envCache = m.loadEnvironmentCache()
+	}
+
+	entry, exists := m.envCache[key]
+	if !exists || time.Since(entry.Timestamp) > envCacheTTL {
+		return EnvironmentCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// storeCachedEnvironment records an environment entry in memory and on disk, only readable by the user
+func (m *Manager) storeCachedEnvironment(key string, entry EnvironmentCacheEntry) {
+	m.envCacheMutex.Lock()
+	defer m.envCacheMutex.Unlock()
+
+	if m.envCache == nil {
+		m.envCache = m.loadEnvironmentCache()
+	}
+	entry.Version = envCacheVersion
+	entry.Timestamp = time.Now()
+	m.envCache[key] = entry
+
+	// Evict the oldest entries to keep the cache file small
+	for len(m.envCache) > envCacheMaxEntries {
+		oldestKey := ""
+		for k, entry := range m.envCache {
+			if oldestKey == "" || entry.Timestamp.Before(m.envCache[oldestKey].Timestamp) {
+				oldestKey = k
+			}
+		}
+		delete(m.envCache, oldestKey)
+	}
+
+	data, err := json.MarshalIndent(m.envCache, "", "  ")
+	if err != nil {
+		return // Silently fail on cache save errors
+	}
+	path := filepath.Join(m.cacheDir, envCacheFile)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		util.LogVerbose("Failed to save environment cache: %v", err)
+		return
+	}
+	// Files written by earlier versions were readable by everyone
+	if err := os.Chmod(path, 0600); err != nil {
+		util.LogVerbose("Failed to restrict access to environment cache: %v", err)
+	}
+}
+
+// invalidateEnvironmentCache drops in-memory environments after tools were installed.
+// On-disk entries are invalidated by their key, which covers installation directories.
+func (m *Manager) invalidateEnvironmentCache() {
+	m.envCacheMutex.Lock()
+	defer m.envCacheMutex.Unlock()
+	m.envCache = nil
+}
+
+// loadEnvironmentCache reads the on-disk environment cache, dropping expired entries
+func (m *Manager) loadEnvironmentCache() map[string]EnvironmentCacheEntry {
+	cache := make(map[string]EnvironmentCacheEntry)
+
+	data, err := os.ReadFile(filepath.Join(m.cacheDir, envCacheFile))
+	if err != nil {
+		return cache
+	}
+
+	var stored map[string]EnvironmentCacheEntry
+	if err := json.Unmarshal(data, &stored); err != nil {
+		// Invalid cache file, start with empty cache
+		return cache
+	}
+
+	for key, entry := range stored {
+		if entry.Version == envCacheVersion && time.Since(entry.Timestamp) < envCacheTTL {
+			cache[key] = entry
+		}
+	}
+	return cache
+}
+
+// environmentDelta returns the variables of env that are missing from or differ in the system environment
+func environmentDelta(systemEnv []string, env map[string]string) map[string]string {
+	system := make(map[string]string, len(systemEnv))
+	for _, envVar := range systemEnv {
+		if name, value, ok := strings.Cut(envVar, "="); ok {
+			system[name] = value
+		}
+	}
+
+	delta := make(map[string]string)
+	for key, value := range env {
+		if current, exists := system[key]; !exists || current != value {
+			delta[key] = value
+		}
+	}
+	return delta
+}
+
+// projectEnvironment returns the variables set from the project's env files and its environment,
+// except those referencing other variables, which are expanded once the tools have set theirs
+func projectEnvironment(cfg *config.Config) (map[string]string, error) {
+	env, err := readEnvFiles(cfg.EnvFilePaths(), func(error) {})
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range cfg.Environment {
+		if !strings.Contains(value, "$") {
+			env[key] = value
+		}
+	}
+	return env, nil
+}
+
+// newEnvironmentCacheEntry returns the cache entry of a computed environment, leaving out the
+// values coming from env files or from the project environment
+func newEnvironmentCacheEntry(cfg *config.Config, systemEnv []string, env map[string]string) (EnvironmentCacheEntry, error) {
+	projectEnv, err := projectEnvironment(cfg)
+	if err != nil {
+		return EnvironmentCacheEntry{}, err
+	}
+
+	entry := EnvironmentCacheEntry{Delta: environmentDelta(systemEnv, env)}
+	for _, key := range sortedKeys(projectEnv) {
+		// Values replaced by a tool are kept, as they don't come from the project
+		if env[key] == projectEnv[key] {
+			delete(entry.Delta, key)
+			entry.Project = append(entry.Project, key)
+		}
+	}
+	// Variables referencing others are always expanded again
+	for key := range cfg.Environment {
+		delete(entry.Delta, key)
+	}
+	return entry, nil
+}
+
+// restoreEnvironment returns the environment of a cache entry, setting again the variables of
+// the env files and of the project environment on top of the current system environment
+func restoreEnvironment(cfg *config.Config, systemEnv []string, entry EnvironmentCacheEntry) (map[string]string, error) {
+	env := applyEnvironmentDelta(systemEnv, entry.Delta)
+	projectEnv, err := projectEnvironment(cfg)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range entry.Project {
+		if value, ok := projectEnv[key]; ok {
+			env[key] = value
+		}
+	}
+
+	envManager := NewEnvironmentManagerFromMap(env)
+	if err := setProjectEnv(cfg.Environment, envManager); err != nil {
+		return nil, err
+	}
+	return envManager.ToMap(), nil
+}
+
+// applyEnvironmentDelta overlays a cached delta onto the current system environment
+func applyEnvironmentDelta(systemEnv []string, delta map[string]string) map[string]string {
+	env := make(map[string]string, len(systemEnv)+len(delta))
+	for _, envVar := range systemEnv {
+		if name, value, ok := strings.Cut(envVar, "="); ok {
+			env[name] = value
+		}
+	}
+	for key, value := range delta {
+		env[key] = value
+	}
+	return env
+}
+
+// sortedKeys returns the keys of a string-keyed map in sorted order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/util"
@@ -164,3 +165,147 @@ func (em *EnvironmentManager) ToSlice() []string {
 
 	return result
 }
+
+// toolEnvPlaceholders returns the variables available to the env values of a tool configuration:
+// TOOL_HOME (the installation, parent of its bin directory), TOOL_BIN and TOOL_VERSION
+func toolEnvPlaceholders(version, binDir string) map[string]string {
+	toolHome := binDir
+	if filepath.Base(binDir) == "bin" {
+		toolHome = filepath.Dir(binDir)
+	}
+	return map[string]string{
+		"TOOL_HOME":    toolHome,
+		"TOOL_BIN":     binDir,
+		"TOOL_VERSION": version,
+	}
+}
+
+// setToolEnv sets the env variables of a tool configuration, expanding ${TOOL_HOME}-like
+// placeholders and references to the variables already set, in the order of their names
+func setToolEnv(env map[string]string, placeholders map[string]string, envManager *EnvironmentManager) {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := os.Expand(env[key], func(name string) string {
+			if placeholder, ok := placeholders[name]; ok {
+				return placeholder
+			}
+			value, _ := envManager.GetEnv(name)
+			return value
+		})
+		envManager.SetEnv(key, value)
+		util.LogVerbose("Set %s=%s from tool configuration", key, value)
+	}
+}
+
+// setProjectEnv sets the variables of the project environment that reference other variables,
+// once the tools have set theirs. References may name other entries of the environment, which
+// are expanded first, tool variables such as JAVA_HOME, or system variables; an entry
+// referencing itself, e.g. MAVEN_OPTS: "$MAVEN_OPTS -Xmx2g", extends the value it replaces.
+func setProjectEnv(env map[string]string, envManager *EnvironmentManager) error {
+	expanded := make(map[string]string, len(env))
+	visiting := make(map[string]bool)
+
+	var expand func(key string, chain []string) (string, error)
+	expand = func(key string, chain []string) (string, error) {
+		if value, ok := expanded[key]; ok {
+			return value, nil
+		}
+		chain = append(chain, key)
+		if visiting[key] {
+			return "", fmt.Errorf("environment variables form a reference cycle: %s", strings.Join(chain, " -> "))
+		}
+		visiting[key] = true
+
+		var err error
+		value := os.Expand(env[key], func(reference string) string {
+			name, defaultValue, hasDefault := strings.Cut(reference, ":-")
+			var value string
+			if raw, ok := env[name]; ok && name != key {
+				if !strings.Contains(raw, "$") {
+					value = raw
+				} else if expandedValue, expandErr := expand(name, chain); expandErr != nil {
+					if err == nil {
+						err = expandErr
+					}
+				} else {
+					value = expandedValue
+				}
+			} else {
+				value, _ = envManager.GetEnv(name)
+			}
+			if value == "" && hasDefault {
+				return defaultValue
+			}
+			return value
+		})
+		if err != nil {
+			return "", err
+		}
+		expanded[key] = value
+		return value, nil
+	}
+
+	keys := make([]string, 0, len(env))
+	for key, value := range env {
+		if strings.Contains(value, "$") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if _, err := expand(key, nil); err != nil {
+			return err
+		}
+	}
+	for _, key := range keys {
+		envManager.SetEnv(key, expanded[key])
+		util.LogVerbose("Set %s=%s from project environment", key, expanded[key])
+	}
+	return nil
+}
+
+// setEnvFiles sets the variables of the project's env files, later files overriding earlier
+// ones. Missing files are skipped, and malformed lines are skipped with a warning.
+func setEnvFiles(paths []string, envManager *EnvironmentManager) error {
+	env, err := readEnvFiles(paths, func(err error) {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: skipping env file line: %v\n", err)
+	})
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(env) {
+		envManager.SetEnv(key, env[key])
+	}
+	return nil
+}
+
+// readEnvFiles returns the variables of env files, later files overriding earlier ones. Missing
+// files are skipped, and malformed lines and PATH are skipped after calling warn.
+func readEnvFiles(paths []string, warn func(error)) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, path := range paths {
+		fileEnv, err := util.ReadDotenvFileLenient(path, warn)
+		if os.IsNotExist(err) {
+			util.LogVerbose("Skipping missing env file %s", path)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read env file: %w", err)
+		}
+		for key, value := range fileEnv {
+			if key == "PATH" {
+				warn(fmt.Errorf("%s: PATH cannot be set from an env file", path))
+				continue
+			}
+			env[key] = value
+		}
+		util.LogVerbose("Loaded %d variable(s) from env file %s", len(fileEnv), path)
+	}
+	return env, nil
+}
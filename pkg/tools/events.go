@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"time"
+)
+
+// InstallEventType identifies a step of the installation lifecycle of a tool
+type InstallEventType string
+
+// Install lifecycle events, in the order they occur
+const (
+	EventResolveStart     InstallEventType = "resolve-start"     // Resolving the version specification in Version
+	EventResolveDone      InstallEventType = "resolve-done"      // Version resolved to Version
+	EventInstallStart     InstallEventType = "install-start"     // Installing Version, which is missing
+	EventDownloadStart    InstallEventType = "download-start"    // Downloading URL, of Total bytes if known
+	EventDownloadProgress InstallEventType = "download-progress" // Downloaded bytes of Total, at Rate
+	EventDownloadDone     InstallEventType = "download-done"     // Downloaded bytes of URL received
+	EventExtractStart     InstallEventType = "extract-start"     // Extracting the archive at Path
+	EventVerifyStart      InstallEventType = "verify-start"      // Verifying the installation of Version
+	EventVerifyDone       InstallEventType = "verify-done"       // Installation of Version verified
+	EventInstallDone      InstallEventType = "install-done"      // Version installed
+	EventError            InstallEventType = "error"             // Err failed the installation
+)
+
+// InstallEvent describes a step of the installation of a tool
+type InstallEvent struct {
+	Type       InstallEventType
+	Time       time.Time // When the event occurred
+	Tool       string    // Name of the tool
+	Version    string    // Version, or version specification before it is resolved
+	URL        string    // Download URL
+	Path       string    // Archive being extracted
+	Downloaded int64     // Bytes downloaded so far, including those of a resumed download
+	Total      int64     // Size of the download, 0 if unknown
+	Rate       float64   // Transfer rate in bytes per second
+	Err        error     // Error that failed the installation
+}
+
+// InstallObserver receives the install lifecycle events of a Manager, e.g. to present them
+// in a TUI rather than parsing the output. Events may be sent concurrently when several tools
+// are installed in parallel, and must be handled quickly as they are sent synchronously.
+type InstallObserver interface {
+	OnInstallEvent(event InstallEvent)
+}
+
+// InstallObserverFunc adapts a function to the InstallObserver interface
+type InstallObserverFunc func(event InstallEvent)
+
+// OnInstallEvent calls the function (implements InstallObserver)
+func (f InstallObserverFunc) OnInstallEvent(event InstallEvent) {
+	f(event)
+}
+
+// AddInstallObserver registers an observer of the install lifecycle events
+func (m *Manager) AddInstallObserver(observer InstallObserver) {
+	m.observersMutex.Lock()
+	defer m.observersMutex.Unlock()
+	m.observers = append(m.observers, observer)
+}
+
+// SetInstallObservers replaces the observers of the install lifecycle events, including the
+// default one rendering the download progress on the console
+func (m *Manager) SetInstallObservers(observers ...InstallObserver) {
+	m.observersMutex.Lock()
+	defer m.observersMutex.Unlock()
+	m.observers = observers
+}
+
+// notify sends an event to the registered observers
+func (m *Manager) notify(event InstallEvent) {
+	if m == nil {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	m.observersMutex.RLock()
+	observers := m.observers
+	m.observersMutex.RUnlock()
+	for _, observer := range observers {
+		observer.OnInstallEvent(event)
+	}
+}
+
+// notifyError sends an error event for a tool, and returns the error
+func (m *Manager) notifyError(toolName, version string, err error) error {
+	m.notify(InstallEvent{Type: EventError, Tool: toolName, Version: version, Err: err})
+	return err
+}
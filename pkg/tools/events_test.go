@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestInstallObserver(t *testing.T) {
+	tests := []struct {
+		name       string
+		installErr error
+		expected   []InstallEventType
+	}{
+		{
+			name:     "install",
+			expected: []InstallEventType{EventResolveStart, EventResolveDone, EventInstallStart, EventVerifyStart, EventVerifyDone, EventInstallDone},
+		},
+		{
+			name:       "failed install",
+			installErr: errors.New("download failed"),
+			expected:   []InstallEventType{EventResolveStart, EventResolveDone, EventInstallStart, EventError},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestManager(t)
+			manager.RegisterTool(newFakeTool(manager, "alpha", tt.installErr))
+
+			var mutex sync.Mutex
+			var events []InstallEvent
+			manager.AddInstallObserver(InstallObserverFunc(func(event InstallEvent) {
+				mutex.Lock()
+				events = append(events, event)
+				mutex.Unlock()
+			}))
+
+			_, err := manager.EnsureTool("alpha", config.ToolConfig{Version: "1.0.0"})
+			if (err != nil) != (tt.installErr != nil) {
+				t.Fatalf("EnsureTool() error = %v, want %v", err, tt.installErr)
+			}
+
+			var types []InstallEventType
+			for _, event := range events {
+				types = append(types, event.Type)
+				if event.Tool != "alpha" || event.Version != "1.0.0" || event.Time.IsZero() {
+					t.Errorf("event %+v, want one for alpha 1.0.0 with a time", event)
+				}
+			}
+			if !slices.Equal(types, tt.expected) {
+				t.Errorf("events = %v, want %v", types, tt.expected)
+			}
+			if last := events[len(events)-1]; tt.installErr != nil && !errors.Is(last.Err, tt.installErr) {
+				t.Errorf("error event = %v, want %v", last.Err, tt.installErr)
+			}
+
+			// Installed tools are only resolved
+			events = nil
+			manager.SetInstallObservers(InstallObserverFunc(func(event InstallEvent) {
+				events = append(events, event)
+			}))
+			if tt.installErr == nil {
+				manager.pathCache = make(map[string]string)
+				manager.EnsureTool("alpha", config.ToolConfig{Version: "1.0.0"})
+				if len(events) != 2 || events[1].Type != EventResolveDone {
+					t.Errorf("events for an installed tool = %+v, want resolve events only", events)
+				}
+			}
+		})
+	}
+}
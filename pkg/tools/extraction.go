@@ -3,12 +3,17 @@ package tools
 import (
 	"archive/tar"
 	"archive/zip"
+	"bytes"
 	"compress/gzip"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/util"
@@ -119,9 +124,13 @@ func extractZipFile(src, dest string) error {
 	defer reader.Close()
 
 	// Create destination directory
-	if err := os.MkdirAll(dest, 0755); err != nil {
+	if err := os.MkdirAll(extendedLengthPath(dest), 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
+	realDest, err := filepath.EvalSymlinks(dest)
+	if err != nil {
+		return fmt.Errorf("failed to resolve destination directory: %w", err)
+	}
 
 	// Check if archive contains a single top-level directory
 	stripPrefix := detectSingleTopLevelDirectory(reader.File)
@@ -140,21 +149,30 @@ func extractZipFile(src, dest string) error {
 			}
 		}
 
-		targetPath := filepath.Join(dest, relativePath)
-
-		// Security check: ensure the file path is within destDir
-		if !strings.HasPrefix(targetPath, filepath.Clean(dest)+string(os.PathSeparator)) {
-			return fmt.Errorf("invalid file path in ZIP: %s", file.Name)
+		// Security check: ensure the file path is within destDir (Zip Slip), including
+		// through the symlinks already extracted
+		targetPath, err := resolveArchiveEntryPath(dest, relativePath)
+		if err == nil {
+			targetPath, err = resolveExtractedPath(realDest, targetPath)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid file path in ZIP: %w", err)
 		}
 
-		if file.FileInfo().IsDir() {
+		switch {
+		case file.FileInfo().IsDir():
 			// Create directory
-			if err := os.MkdirAll(targetPath, file.FileInfo().Mode()); err != nil {
+			if err := os.MkdirAll(extendedLengthPath(targetPath), directoryMode(file.Mode())); err != nil {
 				return fmt.Errorf("failed to create directory %s: %w", targetPath, err)
 			}
-		} else {
+		case file.Mode()&os.ModeSymlink != 0:
+			// Symlinks made on Unix store their target as content
+			if err := extractZipSymlink(file, realDest, targetPath); err != nil {
+				return err
+			}
+		default:
 			// Extract file
-			if err := extractSingleZipFile(file, targetPath); err != nil {
+			if err := extractSingleZipFile(file, targetPath, zipEntryMode(file, relativePath)); err != nil {
 				return fmt.Errorf("failed to extract file %s: %w", targetPath, err)
 			}
 		}
@@ -164,9 +182,9 @@ func extractZipFile(src, dest string) error {
 }
 
 // extractSingleZipFile extracts a single file from ZIP archive
-func extractSingleZipFile(file *zip.File, targetPath string) error {
+func extractSingleZipFile(file *zip.File, targetPath string, mode os.FileMode) error {
 	// Create parent directory
-	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+	if err := os.MkdirAll(extendedLengthPath(filepath.Dir(targetPath)), 0755); err != nil {
 		return err
 	}
 
@@ -177,14 +195,11 @@ func extractSingleZipFile(file *zip.File, targetPath string) error {
 	}
 	defer reader.Close()
 
-	// Ensure we have write permissions for the file
-	mode := file.FileInfo().Mode()
-	if mode&0200 == 0 {
-		mode |= 0200 // Add write permission for owner
-	}
-
 	// Create target file
-	targetFile, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err := removeSymlink(targetPath); err != nil {
+		return err
+	}
+	targetFile, err := os.OpenFile(extendedLengthPath(targetPath), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileMode(mode))
 	if err != nil {
 		return err
 	}
@@ -195,62 +210,140 @@ func extractSingleZipFile(file *zip.File, targetPath string) error {
 	return err
 }
 
+// extractZipSymlink creates the symlink stored in a zip entry, rejecting targets outside dest
+func extractZipSymlink(file *zip.File, dest, targetPath string) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	linkname, err := io.ReadAll(io.LimitReader(reader, 4096))
+	if err != nil {
+		return fmt.Errorf("failed to read symlink %s: %w", file.Name, err)
+	}
+
+	if !isSymlinkWithinDir(dest, targetPath, string(linkname)) {
+		return fmt.Errorf("invalid symlink in ZIP: %s -> %s", file.Name, linkname)
+	}
+	if err := os.MkdirAll(extendedLengthPath(filepath.Dir(targetPath)), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for symlink %s: %w", targetPath, err)
+	}
+	if err := createSymlinkSafely(string(linkname), targetPath); err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", targetPath, err)
+	}
+	return nil
+}
+
+// zipCreatorUnix is the "version made by" of the zip entries made on Unix, which record permissions
+const zipCreatorUnix = 3
+
+// zipEntryMode returns the permissions of a file of a zip archive. Archives made on other systems
+// than Unix don't record them, so their files in a bin directory are made executable.
+func zipEntryMode(file *zip.File, name string) os.FileMode {
+	mode := file.Mode().Perm()
+	if file.CreatorVersion>>8 != zipCreatorUnix && slices.Contains(strings.Split(path.Dir(name), "/"), "bin") {
+		mode |= 0755
+	}
+	return mode
+}
+
+// fileMode returns the permissions an extracted file is created with: those of its archive
+// entry, writable by its owner so that it can be replaced or removed later
+func fileMode(mode os.FileMode) os.FileMode {
+	return mode.Perm() | 0200
+}
+
+// directoryMode returns the permissions an extracted directory is created with: those of its
+// archive entry, which the owner can always list and write to, to extract the entries it contains
+func directoryMode(mode os.FileMode) os.FileMode {
+	return mode.Perm() | 0700
+}
+
 // extractTarGzFile extracts a tar.gz file to the destination directory
 func extractTarGzFile(src, dest string) error {
+	return extractTar(func() (io.ReadCloser, error) { return openTarGz(src) }, dest)
+}
+
+// openTarGz opens a tar.gz file, returning the uncompressed tar stream
+func openTarGz(src string) (io.ReadCloser, error) {
 	file, err := os.Open(src)
 	if err != nil {
-		return fmt.Errorf("failed to open archive: %w", err)
+		return nil, fmt.Errorf("failed to open archive: %w", err)
 	}
-	defer file.Close()
-
-	// Create gzip reader
 	gzReader, err := gzip.NewReader(file)
 	if err != nil {
-		return fmt.Errorf("failed to create gzip reader: %w", err)
+		file.Close()
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
 	}
-	defer gzReader.Close()
+	return &streamReader{Reader: gzReader, close: func() error {
+		gzReader.Close()
+		return file.Close()
+	}}, nil
+}
+
+// streamReader is an uncompressed archive stream, releasing its resources when closed
+type streamReader struct {
+	io.Reader
+	close func() error
+}
 
-	// Create tar reader
-	tarReader := tar.NewReader(gzReader)
+// Close releases the resources of the stream (implements io.Closer)
+func (r *streamReader) Close() error {
+	return r.close()
+}
 
+// extractTar extracts a tar stream to the destination directory. The stream is opened twice:
+// once to detect a single top-level directory to strip, and once to extract the entries.
+func extractTar(open func() (io.ReadCloser, error), dest string) error {
 	// Create destination directory
-	if err := os.MkdirAll(dest, 0755); err != nil {
+	if err := os.MkdirAll(extendedLengthPath(dest), 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
 	// First pass: collect all headers to detect single top-level directory
+	stream, err := open()
+	if err != nil {
+		return err
+	}
 	var headers []*tar.Header
+	tarReader := tar.NewReader(stream)
 	for {
 		header, err := tarReader.Next()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
+			stream.Close()
 			return fmt.Errorf("failed to read tar header: %w", err)
 		}
 		headers = append(headers, header)
 	}
+	if err := stream.Close(); err != nil {
+		return err
+	}
 
 	// Detect if we should strip a single top-level directory
 	stripPrefix := detectSingleTopLevelDirectoryTar(headers)
 
-	// Reopen the file for second pass
-	file.Close()
-	file, err = os.Open(src)
+	// Second pass: extract files
+	realDest, err := filepath.EvalSymlinks(dest)
 	if err != nil {
-		return fmt.Errorf("failed to reopen archive: %w", err)
+		return fmt.Errorf("failed to resolve destination directory: %w", err)
 	}
-	defer file.Close()
-
-	gzReader, err = gzip.NewReader(file)
+	stream, err = open()
 	if err != nil {
-		return fmt.Errorf("failed to create gzip reader: %w", err)
+		return err
 	}
-	defer gzReader.Close()
-
-	tarReader = tar.NewReader(gzReader)
+	if err := extractTarEntries(tar.NewReader(stream), realDest, stripPrefix); err != nil {
+		stream.Close()
+		return err
+	}
+	return stream.Close()
+}
 
-	// Second pass: extract files
+// extractTarEntries extracts the entries of a tar stream, stripping stripPrefix from their names.
+// dest must not contain symlinks, so that the entries can be checked against it.
+func extractTarEntries(tarReader *tar.Reader, dest, stripPrefix string) error {
 	for {
 		header, err := tarReader.Next()
 		if err == io.EOF {
@@ -272,17 +365,20 @@ func extractTarGzFile(src, dest string) error {
 			}
 		}
 
-		targetPath := filepath.Join(dest, relativePath)
-
-		// Security check: ensure the file path is within destDir
-		if !strings.HasPrefix(targetPath, filepath.Clean(dest)+string(os.PathSeparator)) {
-			return fmt.Errorf("invalid file path in tar: %s", header.Name)
+		// Security check: ensure the file path is within destDir (Zip Slip), including
+		// through the symlinks already extracted
+		targetPath, err := resolveArchiveEntryPath(dest, relativePath)
+		if err == nil {
+			targetPath, err = resolveExtractedPath(dest, targetPath)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid file path in tar: %w", err)
 		}
 
 		switch header.Typeflag {
 		case tar.TypeDir:
 			// Create directory
-			if err := os.MkdirAll(targetPath, os.FileMode(header.Mode)); err != nil {
+			if err := os.MkdirAll(extendedLengthPath(targetPath), directoryMode(os.FileMode(header.Mode))); err != nil {
 				return fmt.Errorf("failed to create directory %s: %w", targetPath, err)
 			}
 		case tar.TypeReg:
@@ -291,10 +387,30 @@ func extractTarGzFile(src, dest string) error {
 				return fmt.Errorf("failed to extract file %s: %w", targetPath, err)
 			}
 		case tar.TypeSymlink:
+			// Reject symlinks pointing outside the destination directory
+			if !isSymlinkWithinDir(dest, targetPath, header.Linkname) {
+				return fmt.Errorf("invalid symlink in tar: %s -> %s", header.Name, header.Linkname)
+			}
 			// Create symlink, handling existing symlinks
+			if err := os.MkdirAll(extendedLengthPath(filepath.Dir(targetPath)), 0755); err != nil {
+				return fmt.Errorf("failed to create directory for symlink %s: %w", targetPath, err)
+			}
 			if err := createSymlinkSafely(header.Linkname, targetPath); err != nil {
 				return fmt.Errorf("failed to create symlink %s: %w", targetPath, err)
 			}
+		case tar.TypeLink:
+			// Hard link names are relative to the archive root, so apply the same prefix stripping
+			linkPath := header.Linkname
+			if stripPrefix != "" {
+				linkPath = strings.TrimPrefix(linkPath, stripPrefix)
+			}
+			linkTarget, err := resolveExistingPath(filepath.Join(dest, linkPath))
+			if err != nil || !isPathWithinDir(dest, linkTarget) {
+				return fmt.Errorf("invalid hard link in tar: %s -> %s", header.Name, header.Linkname)
+			}
+			if err := createHardLinkSafely(linkTarget, targetPath); err != nil {
+				return fmt.Errorf("failed to create hard link %s: %w", targetPath, err)
+			}
 		default:
 			// Skip other file types (char devices, block devices, etc.)
 			util.LogVerbose("Skipping unsupported file type %d for %s", header.Typeflag, header.Name)
@@ -307,17 +423,15 @@ func extractTarGzFile(src, dest string) error {
 // extractSingleTarFile extracts a single file from tar reader
 func extractSingleTarFile(tarReader *tar.Reader, targetPath string, mode os.FileMode) error {
 	// Create parent directory
-	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+	if err := os.MkdirAll(extendedLengthPath(filepath.Dir(targetPath)), 0755); err != nil {
 		return err
 	}
 
-	// Ensure we have write permissions for the file
-	if mode&0200 == 0 {
-		mode |= 0200 // Add write permission for owner
+	// Create file, replacing rather than following an existing symlink
+	if err := removeSymlink(targetPath); err != nil {
+		return err
 	}
-
-	// Create file
-	file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	file, err := os.OpenFile(extendedLengthPath(targetPath), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileMode(mode))
 	if err != nil {
 		return err
 	}
@@ -330,6 +444,7 @@ func extractSingleTarFile(tarReader *tar.Reader, targetPath string, mode os.File
 
 // createSymlinkSafely creates a symlink, handling existing files/symlinks
 func createSymlinkSafely(linkname, targetPath string) error {
+	targetPath = extendedLengthPath(targetPath)
 	// Check if target already exists
 	if _, err := os.Lstat(targetPath); err == nil {
 		// Target exists, check if it's already the correct symlink
@@ -356,15 +471,182 @@ func createSymlinkSafely(linkname, targetPath string) error {
 	return os.Symlink(linkname, targetPath)
 }
 
-// extractTarXzFile extracts a tar.xz file using system tar command
+// createHardLinkSafely creates a hard link to an already extracted file, replacing any existing file
+func createHardLinkSafely(linkTarget, targetPath string) error {
+	linkTarget = extendedLengthPath(linkTarget)
+	targetPath = extendedLengthPath(targetPath)
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return err
+	}
+
+	if _, err := os.Lstat(targetPath); err == nil {
+		util.LogVerbose("Removing existing file %s to create hard link (target: %s)", targetPath, linkTarget)
+		if err := os.RemoveAll(targetPath); err != nil {
+			return fmt.Errorf("failed to remove existing file %s: %w", targetPath, err)
+		}
+	}
+
+	return os.Link(linkTarget, targetPath)
+}
+
+// resolveArchiveEntryPath joins an archive entry name to the destination directory,
+// rejecting absolute names and names that would escape the destination (Zip Slip)
+func resolveArchiveEntryPath(dest, name string) (string, error) {
+	if filepath.IsAbs(name) || strings.HasPrefix(name, "/") || strings.HasPrefix(name, "\\") || filepath.VolumeName(name) != "" {
+		return "", fmt.Errorf("absolute path not allowed: %s", name)
+	}
+
+	targetPath := filepath.Join(dest, name)
+	if !isPathWithinDir(dest, targetPath) {
+		return "", fmt.Errorf("path escapes destination directory: %s", name)
+	}
+
+	return targetPath, nil
+}
+
+// isPathWithinDir checks that path (once cleaned) is located inside dir
+func isPathWithinDir(dir, path string) bool {
+	cleanDir := filepath.Clean(dir)
+	cleanPath := filepath.Clean(path)
+	return cleanPath == cleanDir || strings.HasPrefix(cleanPath, cleanDir+string(os.PathSeparator))
+}
+
+// isSymlinkWithinDir checks that a symlink created at linkPath with the given target
+// resolves to a location inside dir, following the symlinks already extracted. Absolute
+// targets are always rejected.
+func isSymlinkWithinDir(dir, linkPath, linkTarget string) bool {
+	if filepath.IsAbs(linkTarget) || strings.HasPrefix(linkTarget, "/") {
+		return false
+	}
+	resolved, err := resolveExistingPath(filepath.Join(filepath.Dir(linkPath), linkTarget))
+	return err == nil && isPathWithinDir(dir, resolved)
+}
+
+// resolveExtractedPath returns the path an archive entry is extracted to, with the symlinks
+// of its parent directories resolved, rejecting entries that these symlinks would place outside
+// dest (e.g. "d/e/file" after "d -> ." and "d/e -> .."). dest must not contain symlinks.
+func resolveExtractedPath(dest, targetPath string) (string, error) {
+	parent, err := resolveExistingPath(filepath.Dir(targetPath))
+	if err != nil {
+		return "", err
+	}
+	if !isPathWithinDir(dest, parent) {
+		return "", fmt.Errorf("path escapes destination directory through a symlink: %s", targetPath)
+	}
+	return filepath.Join(parent, filepath.Base(targetPath)), nil
+}
+
+// maxSymlinkHops bounds the symlinks followed to resolve a path, as loops never resolve
+const maxSymlinkHops = 255
+
+// resolveExistingPath resolves the symlinks of the longest existing part of a path, the rest
+// of which doesn't exist yet and is kept as is. Broken symlinks are followed too, since what
+// they point to may be extracted later.
+func resolveExistingPath(path string) (string, error) {
+	existing, rest := filepath.Clean(path), ""
+	for hops := 0; ; {
+		resolved, err := filepath.EvalSymlinks(existing)
+		if err == nil {
+			return filepath.Join(resolved, rest), nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if linkTarget, err := os.Readlink(existing); err == nil {
+			if hops++; hops > maxSymlinkHops {
+				return "", fmt.Errorf("too many levels of symlinks: %s", path)
+			}
+			if !filepath.IsAbs(linkTarget) {
+				linkTarget = filepath.Join(filepath.Dir(existing), linkTarget)
+			}
+			existing, rest = filepath.Clean(filepath.Join(linkTarget, rest)), ""
+			continue
+		}
+		parent := filepath.Dir(existing)
+		if parent == existing {
+			return "", err
+		}
+		existing, rest = parent, filepath.Join(filepath.Base(existing), rest)
+	}
+}
+
+// removeSymlink removes a symlink extracted earlier at path, so that a file replacing it
+// isn't written through it
+func removeSymlink(path string) error {
+	info, err := os.Lstat(extendedLengthPath(path))
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+	return os.Remove(extendedLengthPath(path))
+}
+
+// extractTarXzFile extracts a tar.xz file, decompressed by the system xz and then extracted like
+// a tar.gz file. Without xz, e.g. on Windows, the system tar extracts it.
 func extractTarXzFile(src, dest string) error {
+	if _, err := exec.LookPath("xz"); err != nil {
+		return extractTarXzWithTar(src, dest)
+	}
+	return extractTar(func() (io.ReadCloser, error) { return openTarXz(src) }, dest)
+}
+
+// openTarXz decompresses a tar.xz file with the system xz, returning the tar stream
+func openTarXz(src string) (io.ReadCloser, error) {
+	cmd := exec.Command("xz", "-dc", src)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run xz: %w", err)
+	}
+	return &streamReader{Reader: stdout, close: func() error {
+		// Consume the padding following the end of the archive, so that xz completes
+		io.Copy(io.Discard, stdout)
+		if err := cmd.Wait(); err != nil {
+			return fmt.Errorf("failed to decompress %s: %w: %s", src, err, strings.TrimSpace(stderr.String()))
+		}
+		return nil
+	}}, nil
+}
+
+// extractTarXzWithTar extracts a tar.xz file with the system tar command, after checking its
+// entry names, and strips a single top-level directory as the other formats do
+func extractTarXzWithTar(src, dest string) error {
 	// Create destination directory
 	if err := os.MkdirAll(dest, 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
+	// Validate entry names before extracting, since the system tar does the actual work
+	listCmd := exec.Command("tar", "-tJf", src)
+	output, err := listCmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to list tar.xz file: %w", err)
+	}
+	var headers []*tar.Header
+	for _, name := range strings.Split(string(output), "\n") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, err := resolveArchiveEntryPath(dest, name); err != nil {
+			return fmt.Errorf("invalid file path in tar.xz: %w", err)
+		}
+		header := &tar.Header{Name: name, Typeflag: tar.TypeReg}
+		if strings.HasSuffix(name, "/") {
+			header.Typeflag = tar.TypeDir
+		}
+		headers = append(headers, header)
+	}
+
 	// Use system tar command for tar.xz files
-	cmd := exec.Command("tar", "-xJf", src, "-C", dest)
+	args := []string{"-xJf", src, "-C", dest}
+	if detectSingleTopLevelDirectoryTar(headers) != "" {
+		args = append(args, "--strip-components=1")
+	}
+	cmd := exec.Command("tar", args...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -372,7 +654,58 @@ func extractTarXzFile(src, dest string) error {
 		return fmt.Errorf("failed to extract tar.xz file: %w", err)
 	}
 
-	return nil
+	// The system tar refuses to write through symlinks leaving the destination, check the
+	// symlinks it created don't lead out of it either
+	return checkExtractedSymlinks(dest)
+}
+
+// checkExtractedSymlinks fails if a symlink of an extracted directory resolves outside of it
+func checkExtractedSymlinks(dest string) error {
+	realDest, err := filepath.EvalSymlinks(dest)
+	if err != nil {
+		return fmt.Errorf("failed to resolve destination directory: %w", err)
+	}
+	return filepath.WalkDir(realDest, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.Type()&os.ModeSymlink == 0 {
+			return err
+		}
+		linkTarget, err := os.Readlink(path)
+		if err != nil {
+			return err
+		}
+		if !isSymlinkWithinDir(realDest, path, linkTarget) {
+			return fmt.Errorf("invalid symlink in tar.xz: %s -> %s", path, linkTarget)
+		}
+		return nil
+	})
+}
+
+// windowsMaxPath is the length from which Windows APIs need absolute paths to have the \\?\
+// prefix: MAX_PATH (260), less the 12 characters Windows reserves in directory names
+const windowsMaxPath = 248
+
+// extendedLengthPath returns a path usable whatever its length: on Windows, a long absolute
+// path gets the \\?\ prefix lifting the MAX_PATH limit. Other paths are returned unchanged.
+func extendedLengthPath(path string) string {
+	return extendedLengthPathFor(runtime.GOOS, path)
+}
+
+// extendedLengthPathFor returns the extended-length form of a path on the given OS
+func extendedLengthPathFor(goos, path string) string {
+	if goos != "windows" || len(path) < windowsMaxPath || strings.HasPrefix(path, `\\?\`) {
+		return path
+	}
+	path = strings.ReplaceAll(path, "/", `\`)
+	switch {
+	case strings.HasPrefix(path, `\\`):
+		// UNC path: \\server\share\... becomes \\?\UNC\server\share\...
+		return `\\?\UNC\` + path[2:]
+	case len(path) >= 3 && path[1] == ':' && path[2] == '\\':
+		return `\\?\` + path
+	default:
+		// Relative paths can't have the prefix
+		return path
+	}
 }
 
 // detectArchiveType detects the archive type from file extension
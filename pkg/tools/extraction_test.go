@@ -0,0 +1,558 @@
+package tools
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// tarEntry describes an entry to write into a test tar.gz archive
+type tarEntry struct {
+	name     string
+	typeflag byte
+	content  string
+	linkname string
+	mode     int64
+}
+
+// writeTestTarGz creates a tar.gz archive containing the given entries
+func writeTestTarGz(t *testing.T, path string, entries []tarEntry) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	gzWriter := gzip.NewWriter(file)
+	defer gzWriter.Close()
+	writeTestTar(t, gzWriter, entries)
+}
+
+// writeTestTarXz creates a tar.xz archive containing the given entries, skipping the test
+// when xz isn't available to compress it
+func writeTestTarXz(t *testing.T, path string, entries []tarEntry) {
+	t.Helper()
+	if _, err := exec.LookPath("xz"); err != nil {
+		t.Skip("xz is not available")
+	}
+
+	tarPath := strings.TrimSuffix(path, ".xz")
+	file, err := os.Create(tarPath)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	writeTestTar(t, file, entries)
+	file.Close()
+
+	if output, err := exec.Command("xz", "-z", tarPath).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to compress archive: %v\n%s", err, output)
+	}
+}
+
+// writeTestTar writes a tar stream containing the given entries
+func writeTestTar(t *testing.T, w io.Writer, entries []tarEntry) {
+	t.Helper()
+
+	tarWriter := tar.NewWriter(w)
+	defer tarWriter.Close()
+
+	for _, entry := range entries {
+		header := &tar.Header{
+			Name:     entry.name,
+			Typeflag: entry.typeflag,
+			Linkname: entry.linkname,
+			Mode:     0755,
+		}
+		if entry.typeflag == tar.TypeReg {
+			header.Mode = 0644
+			header.Size = int64(len(entry.content))
+		}
+		if entry.mode != 0 {
+			header.Mode = entry.mode
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatalf("Failed to write tar header for %s: %v", entry.name, err)
+		}
+		if entry.typeflag == tar.TypeReg {
+			if _, err := tarWriter.Write([]byte(entry.content)); err != nil {
+				t.Fatalf("Failed to write tar content for %s: %v", entry.name, err)
+			}
+		}
+	}
+}
+
+func TestExtractTarGzWithLinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping symlink test on Windows")
+	}
+
+	tempDir := t.TempDir()
+	archive := filepath.Join(tempDir, "jdk.tar.gz")
+	writeTestTarGz(t, archive, []tarEntry{
+		{name: "jdk/", typeflag: tar.TypeDir},
+		{name: "jdk/lib/", typeflag: tar.TypeDir},
+		{name: "jdk/lib/libjvm.so", typeflag: tar.TypeReg, content: "jvm"},
+		{name: "jdk/jre/", typeflag: tar.TypeDir},
+		{name: "jdk/jre/lib", typeflag: tar.TypeSymlink, linkname: "../lib"},
+		{name: "jdk/lib/libjvm-copy.so", typeflag: tar.TypeLink, linkname: "jdk/lib/libjvm.so"},
+	})
+
+	dest := filepath.Join(tempDir, "out")
+	if err := ExtractArchive(archive, dest); err != nil {
+		t.Fatalf("ExtractArchive() error = %v", err)
+	}
+
+	linkTarget, err := os.Readlink(filepath.Join(dest, "jre", "lib"))
+	if err != nil {
+		t.Fatalf("Expected jre/lib to be a symlink: %v", err)
+	}
+	if linkTarget != "../lib" {
+		t.Errorf("Expected symlink target ../lib, got %s", linkTarget)
+	}
+
+	// The symlink must resolve to the extracted file
+	content, err := os.ReadFile(filepath.Join(dest, "jre", "lib", "libjvm.so"))
+	if err != nil || string(content) != "jvm" {
+		t.Errorf("Expected to read libjvm.so through symlink, got %q (err: %v)", content, err)
+	}
+
+	// The hard link must point to the same content
+	content, err = os.ReadFile(filepath.Join(dest, "lib", "libjvm-copy.so"))
+	if err != nil || string(content) != "jvm" {
+		t.Errorf("Expected hard link content 'jvm', got %q (err: %v)", content, err)
+	}
+}
+
+func TestExtractTarGzRejectsEscapingLinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping symlink test on Windows")
+	}
+
+	tests := []struct {
+		name  string
+		entry tarEntry
+	}{
+		{
+			name:  "relative symlink escaping destination",
+			entry: tarEntry{name: "pkg/evil", typeflag: tar.TypeSymlink, linkname: "../../etc"},
+		},
+		{
+			name:  "absolute symlink",
+			entry: tarEntry{name: "pkg/evil", typeflag: tar.TypeSymlink, linkname: "/etc/passwd"},
+		},
+		{
+			name:  "hard link escaping destination",
+			entry: tarEntry{name: "pkg/evil", typeflag: tar.TypeLink, linkname: "../../etc/passwd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			archive := filepath.Join(tempDir, "evil.tar.gz")
+			writeTestTarGz(t, archive, []tarEntry{
+				{name: "pkg/", typeflag: tar.TypeDir},
+				{name: "pkg/file.txt", typeflag: tar.TypeReg, content: "ok"},
+				tt.entry,
+			})
+
+			dest := filepath.Join(tempDir, "out")
+			if err := ExtractArchive(archive, dest); err == nil {
+				t.Error("Expected extraction to fail for link escaping the destination")
+			}
+
+			if _, err := os.Lstat(filepath.Join(dest, "evil")); err == nil {
+				t.Error("Escaping link should not have been created")
+			}
+		})
+	}
+}
+
+// writeTestZip creates a zip archive containing the given files
+func writeTestZip(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer file.Close()
+
+	zipWriter := zip.NewWriter(file)
+	defer zipWriter.Close()
+
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry %s: %v", name, err)
+		}
+		if _, err := writer.Write([]byte(content)); err != nil {
+			t.Fatalf("Failed to write zip entry %s: %v", name, err)
+		}
+	}
+}
+
+func TestExtractZipRejectsPathTraversal(t *testing.T) {
+	tests := []struct {
+		name      string
+		entryName string
+	}{
+		{name: "parent directory traversal", entryName: "../../evil.txt"},
+		{name: "nested traversal", entryName: "pkg/../../evil.txt"},
+		{name: "absolute path", entryName: "/tmp/evil.txt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			archive := filepath.Join(tempDir, "evil.zip")
+			writeTestZip(t, archive, map[string]string{
+				"pkg/ok.txt":   "ok",
+				"other/ok.txt": "ok",
+				tt.entryName:   "evil",
+			})
+
+			dest := filepath.Join(tempDir, "nested", "out")
+			if err := ExtractArchive(archive, dest); err == nil {
+				t.Errorf("Expected extraction to fail for entry %s", tt.entryName)
+			}
+
+			if _, err := os.Stat(filepath.Join(tempDir, "evil.txt")); err == nil {
+				t.Error("Malicious entry was written outside the destination")
+			}
+		})
+	}
+}
+
+func TestExtractTarGzRejectsPathTraversal(t *testing.T) {
+	tempDir := t.TempDir()
+	archive := filepath.Join(tempDir, "evil.tar.gz")
+	writeTestTarGz(t, archive, []tarEntry{
+		{name: "pkg/ok.txt", typeflag: tar.TypeReg, content: "ok"},
+		{name: "other/ok.txt", typeflag: tar.TypeReg, content: "ok"},
+		{name: "../../evil.txt", typeflag: tar.TypeReg, content: "evil"},
+	})
+
+	dest := filepath.Join(tempDir, "nested", "out")
+	if err := ExtractArchive(archive, dest); err == nil {
+		t.Error("Expected extraction to fail for entry escaping the destination")
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, "evil.txt")); err == nil {
+		t.Error("Malicious entry was written outside the destination")
+	}
+}
+
+func TestResolveArchiveEntryPath(t *testing.T) {
+	dest := filepath.Join(t.TempDir(), "out")
+
+	tests := []struct {
+		name      string
+		entryName string
+		wantErr   bool
+	}{
+		{name: "plain file", entryName: "bin/tool", wantErr: false},
+		{name: "current directory prefix", entryName: "./bin/tool", wantErr: false},
+		{name: "inner traversal staying inside", entryName: "bin/../lib/tool", wantErr: false},
+		{name: "parent traversal", entryName: "../tool", wantErr: true},
+		{name: "sibling directory with same prefix", entryName: "../out-evil/tool", wantErr: true},
+		{name: "absolute path", entryName: "/etc/passwd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveArchiveEntryPath(dest, tt.entryName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveArchiveEntryPath(%q) error = %v, wantErr %v", tt.entryName, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExtractTarXz(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping symlink test on Windows")
+	}
+
+	// A path nested deeper than the 260 characters Windows paths are limited to
+	deepDir := "jdk/" + strings.Repeat("nested-directory/", 20)
+	tempDir := t.TempDir()
+	archive := filepath.Join(tempDir, "jdk.tar.xz")
+	writeTestTarXz(t, archive, []tarEntry{
+		{name: "jdk/", typeflag: tar.TypeDir},
+		{name: "jdk/bin/", typeflag: tar.TypeDir},
+		{name: "jdk/bin/java", typeflag: tar.TypeReg, content: "#!/bin/sh\n", mode: 0755},
+		{name: "jdk/bin/javac", typeflag: tar.TypeSymlink, linkname: "java"},
+		{name: "jdk/lib/", typeflag: tar.TypeDir, mode: 0555},
+		{name: "jdk/lib/modules", typeflag: tar.TypeReg, content: "modules", mode: 0444},
+		{name: deepDir + "deep.txt", typeflag: tar.TypeReg, content: "deep"},
+	})
+
+	dest := filepath.Join(tempDir, "out")
+	if err := ExtractArchive(archive, dest); err != nil {
+		t.Fatalf("ExtractArchive() error = %v", err)
+	}
+
+	if info, err := os.Stat(filepath.Join(dest, "bin", "java")); err != nil || info.Mode().Perm()&0111 == 0 {
+		t.Errorf("Expected bin/java to be extracted as executable, got %v (err: %v)", info, err)
+	}
+	if linkTarget, err := os.Readlink(filepath.Join(dest, "bin", "javac")); err != nil || linkTarget != "java" {
+		t.Errorf("Expected bin/javac to be a symlink to java, got %q (err: %v)", linkTarget, err)
+	}
+	if info, err := os.Stat(filepath.Join(dest, "lib", "modules")); err != nil || info.Mode().Perm()&0200 == 0 {
+		t.Errorf("Expected lib/modules to be writable by its owner, got %v (err: %v)", info, err)
+	}
+	deepFile := filepath.Join(dest, filepath.FromSlash(strings.TrimPrefix(deepDir, "jdk/")), "deep.txt")
+	if content, err := os.ReadFile(deepFile); err != nil || string(content) != "deep" {
+		t.Errorf("Expected the deeply nested file to be extracted, got %q (err: %v)", content, err)
+	}
+
+	for name, entry := range map[string]tarEntry{
+		"traversal": {name: "../../evil.txt", typeflag: tar.TypeReg, content: "evil"},
+		"symlink":   {name: "pkg/evil", typeflag: tar.TypeSymlink, linkname: "../../etc"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "evil.tar.xz")
+			writeTestTarXz(t, archive, []tarEntry{{name: "pkg/ok.txt", typeflag: tar.TypeReg, content: "ok"}, entry})
+			dest := filepath.Join(t.TempDir(), "nested", "out")
+			if err := ExtractArchive(archive, dest); err == nil {
+				t.Errorf("Expected extraction to fail for entry %s", entry.name)
+			}
+			if _, err := os.Lstat(filepath.Join(dest, "pkg", "evil")); err == nil {
+				t.Error("Escaping symlink should not have been created")
+			}
+		})
+	}
+}
+
+// zipEntry describes an entry to write into a test zip archive
+type zipEntry struct {
+	name    string
+	content string
+	mode    os.FileMode // Recorded as made on Unix when set
+}
+
+// writeTestZipEntries creates a zip archive containing the given entries
+func writeTestZipEntries(t *testing.T, path string, entries []zipEntry) {
+	t.Helper()
+
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	defer file.Close()
+	zipWriter := zip.NewWriter(file)
+	defer zipWriter.Close()
+
+	for _, entry := range entries {
+		header := &zip.FileHeader{Name: entry.name, Method: zip.Deflate}
+		if entry.mode != 0 {
+			header.SetMode(entry.mode)
+		}
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			t.Fatalf("Failed to create zip entry %s: %v", entry.name, err)
+		}
+		if _, err := writer.Write([]byte(entry.content)); err != nil {
+			t.Fatalf("Failed to write zip entry %s: %v", entry.name, err)
+		}
+	}
+}
+
+func TestExtractZipSymlinksAndModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping symlink and permission test on Windows")
+	}
+
+	tempDir := t.TempDir()
+	archive := filepath.Join(tempDir, "tool.zip")
+	writeTestZipEntries(t, archive, []zipEntry{
+		{name: "tool/bin/tool", content: "#!/bin/sh\n", mode: 0755},
+		{name: "tool/bin/alias", content: "tool", mode: os.ModeSymlink | 0777},
+		{name: "tool/conf/settings.xml", content: "<settings/>", mode: 0644},
+		// Made on Windows: no permissions recorded
+		{name: "tool/bin/launcher", content: "#!/bin/sh\n"},
+		{name: "tool/lib/library.jar", content: "jar"},
+	})
+
+	dest := filepath.Join(tempDir, "out")
+	if err := ExtractArchive(archive, dest); err != nil {
+		t.Fatalf("ExtractArchive() error = %v", err)
+	}
+
+	if linkTarget, err := os.Readlink(filepath.Join(dest, "bin", "alias")); err != nil || linkTarget != "tool" {
+		t.Errorf("Expected bin/alias to be a symlink to tool, got %q (err: %v)", linkTarget, err)
+	}
+	tests := []struct {
+		name       string
+		executable bool
+	}{
+		{"bin/tool", true},
+		{"bin/launcher", true},
+		{"conf/settings.xml", false},
+		{"lib/library.jar", false},
+	}
+	for _, tt := range tests {
+		info, err := os.Stat(filepath.Join(dest, filepath.FromSlash(tt.name)))
+		if err != nil {
+			t.Errorf("Expected %s to be extracted: %v", tt.name, err)
+			continue
+		}
+		if executable := info.Mode().Perm()&0100 != 0; executable != tt.executable {
+			t.Errorf("%s mode = %v, want executable %v", tt.name, info.Mode(), tt.executable)
+		}
+	}
+
+	evil := filepath.Join(tempDir, "evil.zip")
+	writeTestZipEntries(t, evil, []zipEntry{
+		{name: "tool/ok.txt", content: "ok", mode: 0644},
+		{name: "tool/evil", content: "../../etc", mode: os.ModeSymlink | 0777},
+	})
+	evilDest := filepath.Join(tempDir, "evil-out")
+	if err := ExtractArchive(evil, evilDest); err == nil {
+		t.Error("Expected extraction to fail for a symlink escaping the destination")
+	}
+	if _, err := os.Lstat(filepath.Join(evilDest, "evil")); err == nil {
+		t.Error("Escaping symlink should not have been created")
+	}
+}
+
+func TestExtendedLengthPath(t *testing.T) {
+	long := strings.Repeat(`\directory`, 30)
+	tests := []struct {
+		name     string
+		goos     string
+		path     string
+		expected string
+	}{
+		{"short path", "windows", `C:\tools\java`, `C:\tools\java`},
+		{"long drive path", "windows", `C:` + long, `\\?\C:` + long},
+		{"long drive path with slashes", "windows", `C:` + strings.ReplaceAll(long, `\`, "/"), `\\?\C:` + long},
+		{"long UNC path", "windows", `\\server\share` + long, `\\?\UNC\server\share` + long},
+		{"already extended", "windows", `\\?\C:` + long, `\\?\C:` + long},
+		{"long relative path", "windows", `tools` + long, `tools` + long},
+		{"long Unix path", "linux", "/tmp" + strings.ReplaceAll(long, `\`, "/"), "/tmp" + strings.ReplaceAll(long, `\`, "/")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extendedLengthPathFor(tt.goos, tt.path); got != tt.expected {
+				t.Errorf("extendedLengthPathFor(%q, %q) = %q, want %q", tt.goos, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractArchiveRejectsEvilEntry(t *testing.T) {
+	entries := []tarEntry{
+		{name: "pkg/ok.txt", typeflag: tar.TypeReg, content: "ok"},
+		{name: "../../evil", typeflag: tar.TypeReg, content: "evil"},
+	}
+	tests := []struct {
+		archive string
+		write   func(t *testing.T, path string)
+	}{
+		{"evil.zip", func(t *testing.T, path string) {
+			writeTestZip(t, path, map[string]string{"pkg/ok.txt": "ok", "../../evil": "evil"})
+		}},
+		{"evil.tar.gz", func(t *testing.T, path string) { writeTestTarGz(t, path, entries) }},
+		{"evil.tar.xz", func(t *testing.T, path string) { writeTestTarXz(t, path, entries) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.archive, func(t *testing.T) {
+			tempDir := t.TempDir()
+			archive := filepath.Join(tempDir, tt.archive)
+			tt.write(t, archive)
+
+			dest := filepath.Join(tempDir, "tools", "install")
+			if err := ExtractArchive(archive, dest); err == nil || !strings.Contains(err.Error(), "escapes destination") {
+				t.Errorf("ExtractArchive() error = %v, want an error for the entry escaping the destination", err)
+			}
+			if _, err := os.Stat(filepath.Join(tempDir, "evil")); err == nil {
+				t.Error("Malicious entry was written outside the destination")
+			}
+		})
+	}
+}
+
+func TestResolveExistingPath(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, target := range map[string]string{"self": ".", "up": "..", "lib.so": "lib.so.1", "lib.so.1": "lib.so.1.2"} {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"missing/file", filepath.Join(dir, "missing", "file")},
+		{"self/up/file", filepath.Join(filepath.Dir(dir), "file")},
+		{"lib.so", filepath.Join(dir, "lib.so.1.2")},
+	}
+	for _, tt := range tests {
+		if got, err := resolveExistingPath(filepath.Join(dir, tt.path)); err != nil || got != tt.expected {
+			t.Errorf("resolveExistingPath(%s) = %q, %v, want %q", tt.path, got, err, tt.expected)
+		}
+	}
+}
+
+func TestExtractArchiveRejectsSymlinkChain(t *testing.T) {
+	entries := []tarEntry{
+		{name: "d", typeflag: tar.TypeSymlink, linkname: "."},
+		{name: "d/e", typeflag: tar.TypeSymlink, linkname: ".."},
+		{name: "e/pwned.txt", typeflag: tar.TypeReg, content: "pwned"},
+	}
+	tests := []struct {
+		archive string
+		write   func(t *testing.T, path string)
+		extract func(src, dest string) error
+	}{
+		{"chain.zip", func(t *testing.T, path string) {
+			writeTestZipEntries(t, path, []zipEntry{
+				{name: "d", content: ".", mode: os.ModeSymlink | 0777},
+				{name: "d/e", content: "..", mode: os.ModeSymlink | 0777},
+				{name: "e/pwned.txt", content: "pwned"},
+			})
+		}, ExtractArchive},
+		{"chain.tar.gz", func(t *testing.T, path string) { writeTestTarGz(t, path, entries) }, ExtractArchive},
+		{"chain.tar.xz", func(t *testing.T, path string) { writeTestTarXz(t, path, entries) }, ExtractArchive},
+		{"system-tar.tar.xz", func(t *testing.T, path string) { writeTestTarXz(t, path, entries) }, extractTarXzWithTar},
+		{"system-tar-link.tar.xz", func(t *testing.T, path string) {
+			writeTestTarXz(t, path, []tarEntry{
+				{name: "ok.txt", typeflag: tar.TypeReg, content: "ok"},
+				{name: "x", typeflag: tar.TypeSymlink, linkname: "../.."},
+			})
+		}, extractTarXzWithTar},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.archive, func(t *testing.T) {
+			tempDir := t.TempDir()
+			archive := filepath.Join(tempDir, tt.archive)
+			tt.write(t, archive)
+
+			dest := filepath.Join(tempDir, "tools", "install")
+			if err := tt.extract(archive, dest); err == nil {
+				t.Error("extraction succeeded, want an error for the symlink leading out of the destination")
+			}
+			if _, err := os.Stat(filepath.Join(tempDir, "tools", "pwned.txt")); err == nil {
+				t.Error("File was written outside the destination through symlinks")
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// githubRelease is an entry of the GitHub releases API
+type githubRelease struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// fetchGitHubReleases fetches the latest releases of a GitHub repository, given its API base URL
+func (m *Manager) fetchGitHubReleases(repoAPIBase string) ([]githubRelease, error) {
+	resp, err := m.Get(repoAPIBase + "/releases?per_page=100")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch releases from %s: status %d", repoAPIBase, resp.StatusCode)
+	}
+
+	var releases []githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases from %s: %w", repoAPIBase, err)
+	}
+	return releases, nil
+}
+
+// fetchGitHubReleaseVersions fetches the versions of the final releases of a GitHub repository,
+// given its API base URL. releaseVersion returns the version of a release tag, or false for the
+// tags of other releases (betas, canaries, ...).
+func (m *Manager) fetchGitHubReleaseVersions(repoAPIBase string, releaseVersion func(tag string) (string, bool)) ([]string, error) {
+	releases, err := m.fetchGitHubReleases(repoAPIBase)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, release := range releases {
+		if v, ok := releaseVersion(release.TagName); ok && !release.Prerelease {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// releaseTagVersion returns the version of a release tag made of prefix and a numeric version
+// such as "2.0.0", rejecting pre-releases ("2.1.0-Beta1") and the other tags of a repository
+func releaseTagVersion(tag, prefix string) (string, bool) {
+	v, ok := strings.CutPrefix(tag, prefix)
+	if !ok {
+		return "", false
+	}
+	parts := strings.Split(v, ".")
+	if len(parts) < 2 {
+		return "", false
+	}
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return "", false
+		}
+	}
+	return v, true
+}
+
+// sha256Pattern matches a hexadecimal SHA-256 hash
+var sha256Pattern = regexp.MustCompile(`\b[0-9a-fA-F]{64}\b`)
+
+// parseSingleSHA256 returns the hash of a checksum file published for a single asset, whether
+// in sha256sum format ("<hash>  <file>") or in PowerShell Get-FileHash format ("Hash : <HASH>")
+func parseSingleSHA256(content string) (string, bool) {
+	hash := sha256Pattern.FindString(content)
+	return strings.ToLower(hash), hash != ""
+}
+
+// extractStandaloneBinary extracts an archive containing a single executable, wherever the
+// archive places it, making sure it can be executed
+func (b *BaseTool) extractStandaloneBinary(archivePath, installDir string) error {
+	if err := b.Extract(archivePath, installDir); err != nil {
+		return err
+	}
+	binDir, err := b.manager.GetPathResolver().FindBinaryParentDir(installDir, b.GetBinaryName())
+	if err != nil {
+		return fmt.Errorf("%s not found in the archive: %w", b.GetBinaryName(), err)
+	}
+	if b.manager.GetPlatformMapper().IsWindows() {
+		return nil
+	}
+	binary, _ := b.manager.GetPlatformMapper().FindBinary(binDir, b.GetBinaryName())
+	return os.Chmod(binary, 0755)
+}
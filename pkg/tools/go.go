@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -16,6 +17,8 @@ import (
 // Compile-time interface validation
 var _ Tool = (*GoTool)(nil)
 var _ EnvironmentProvider = (*GoTool)(nil)
+var _ PlatformSupportProvider = (*GoTool)(nil)
+var _ VersionCanonicalizer = (*GoTool)(nil)
 
 // GoTool implements Tool interface for Go toolchain management
 type GoTool struct {
@@ -23,11 +26,7 @@ type GoTool struct {
 }
 
 func getGoBinaryName() string {
-	platformMapper := NewPlatformMapper()
-	if platformMapper.IsWindows() {
-		return "go.exe"
-	}
-	return "go"
+	return NewPlatformMapper().BinaryName(BinaryGo, ExtExe)
 }
 
 // NewGoTool creates a new Go tool instance
@@ -59,7 +58,7 @@ func (g *GoTool) GetBinaryName() string {
 // getInstalledPath returns the path for an installed Go version
 func (g *GoTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
 	installDir := g.manager.GetToolVersionDir(g.GetToolName(), version, "")
-	pathResolver := NewPathResolver(g.manager.GetToolsDir())
+	pathResolver := g.manager.GetPathResolver()
 	binDir, err := pathResolver.FindBinaryParentDir(installDir, g.GetBinaryName())
 	if err != nil {
 		return "", err
@@ -94,11 +93,29 @@ func (g *GoTool) ListVersions() ([]string, error) {
 	return version.SortVersions(versions), nil
 }
 
+// goDarwinArm64Since is the first Go release published for Apple Silicon
+var goDarwinArm64Since = version.Version{Major: 1, Minor: 16}
+
+// SupportedPlatforms returns the platforms a Go version is published for (implements PlatformSupportProvider)
+func (g *GoTool) SupportedPlatforms(v string) []PlatformInfo {
+	parsed, err := version.ParseVersion(v)
+	if err != nil || parsed.Compare(&goDarwinArm64Since) >= 0 {
+		return AllPlatforms()
+	}
+	return platformsExcept(PlatformInfo{OS: "darwin", Arch: "arm64"})
+}
+
 // GetDisplayName returns the human-readable name for Go (implements ToolMetadataProvider)
 func (g *GoTool) GetDisplayName() string {
 	return "Go Programming Language"
 }
 
+// CanonicalVersion strips the "go" prefix of Go versions, e.g. "go1.21.0" becomes "1.21.0"
+// (implements VersionCanonicalizer)
+func (g *GoTool) CanonicalVersion(raw string) string {
+	return strings.TrimPrefix(raw, "go")
+}
+
 // SetupEnvironment sets up Go-specific environment variables (implements EnvironmentProvider)
 func (g *GoTool) SetupEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error {
 	util.LogVerbose("Go SetupEnvironment called for version %s", version)
@@ -192,7 +209,7 @@ func (g *GoTool) getFallbackGoVersions() []string {
 
 // getDownloadURL returns the download URL for the specified version
 func (g *GoTool) getDownloadURL(version string) string {
-	platformMapper := NewPlatformMapper()
+	platformMapper := g.manager.GetPlatformMapper()
 	osName := platformMapper.GetOS()
 	arch := platformMapper.GetArch()
 
@@ -230,20 +247,26 @@ func (g *GoTool) ResolveVersion(versionSpec, distribution string) (string, error
 	return resolved, nil
 }
 
-// GetChecksum implements ChecksumProvider interface for Go
+// GetChecksum implements ChecksumProvider interface for Go, looking the downloaded
+// archive up in the go.dev release manifest, which lists the SHA-256 of every file
 func (g *GoTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
-	fmt.Printf("  🔍 Fetching Go checksum from go.dev API...\n")
+	if filename == "" {
+		filename = path.Base(g.getDownloadURL(version))
+	}
+
+	g.printf("  🔍 Fetching Go checksum from go.dev API...\n")
 
 	checksum, err := g.fetchGoChecksum(version, filename)
 	if err != nil {
-		fmt.Printf("  ⚠️  Failed to get Go checksum from go.dev API: %v\n", err)
+		g.printf("  ⚠️  Failed to get Go checksum from go.dev API: %v\n", err)
 		return ChecksumInfo{}, err
 	}
 
-	fmt.Printf("  ✅ Found Go checksum from go.dev API\n")
+	g.printf("  ✅ Found Go checksum from go.dev API\n")
 	return ChecksumInfo{
-		Type:  SHA256,
-		Value: checksum,
+		Type:     SHA256,
+		Value:    checksum,
+		Filename: filename,
 	}, nil
 }
 
@@ -285,18 +308,28 @@ func (g *GoTool) fetchGoChecksum(version, filename string) (string, error) {
 		return "", fmt.Errorf("failed to decode Go API response: %w", err)
 	}
 
-	// Find the matching version and file
+	return findGoChecksum(releases, version, filename)
+}
+
+// findGoChecksum returns the SHA-256 the go.dev manifest lists for a file of a release
+func findGoChecksum(releases []GoRelease, version, filename string) (string, error) {
 	for _, release := range releases {
-		if release.Version == "go"+version {
-			for _, file := range release.Files {
-				if file.Filename == filename && file.Kind == "archive" {
-					return file.SHA256, nil
-				}
+		if release.Version != "go"+version {
+			continue
+		}
+		for _, file := range release.Files {
+			if file.Filename != filename {
+				continue
+			}
+			if !sha256Pattern.MatchString(file.SHA256) {
+				return "", fmt.Errorf("invalid SHA-256 %q listed for Go file %s", file.SHA256, filename)
 			}
+			return strings.ToLower(file.SHA256), nil
 		}
+		return "", fmt.Errorf("Go %s has no file %s", version, filename)
 	}
 
-	return "", fmt.Errorf("no matching Go file found for version %s, filename %s", version, filename)
+	return "", fmt.Errorf("Go version %s not found in the go.dev release manifest", version)
 }
 
 // GetDownloadURL implements URLProvider interface for Go
@@ -1,8 +1,18 @@
 package tools
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
 )
 
 func TestGoToolBasicFunctionality(t *testing.T) {
@@ -63,3 +73,77 @@ func TestGoToolAutomaticArchiveDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestGoCanonicalVersion(t *testing.T) {
+	goTool := NewGoTool(newTestManager(t))
+
+	tests := map[string]string{
+		"go1.21.0": "1.21.0",
+		"1.21.0":   "1.21.0",
+	}
+	for raw, expected := range tests {
+		if got := goTool.CanonicalVersion(raw); got != expected {
+			t.Errorf("CanonicalVersion(%q) = %q, expected %q", raw, got, expected)
+		}
+	}
+}
+
+// staticTransport stubs the network, serving the given bodies by URL and 404 otherwise
+type staticTransport map[string]string
+
+func (s staticTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, ok := s[req.URL.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestGoGetChecksum(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "go1.21.5.linux-amd64.tar.gz")
+	if err := os.WriteFile(archive, []byte("go archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("go archive"))
+	hash := hex.EncodeToString(sum[:])
+
+	manager := newTestManager(t)
+	manager.platform = &PlatformInfo{OS: "linux", Arch: "amd64"}
+	manager.httpClient = &http.Client{Transport: staticTransport{
+		GoDevAPIBase + "/?mode=json&include=all": `[
+			{"version": "go1.21.6", "files": [{"filename": "go1.21.6.linux-amd64.tar.gz", "sha256": "` + strings.Repeat("0", 64) + `"}]},
+			{"version": "go1.21.5", "files": [
+				{"filename": "go1.21.5.src.tar.gz", "sha256": "` + strings.Repeat("1", 64) + `"},
+				{"filename": "go1.21.5.linux-amd64.tar.gz", "sha256": "` + strings.ToUpper(hash) + `"}
+			]}
+		]`,
+	}}
+	goTool := NewGoTool(manager)
+
+	if !goTool.SupportsChecksumVerification() {
+		t.Fatal("SupportsChecksumVerification() = false, want true")
+	}
+	checksum, err := goTool.GetChecksum("1.21.5", config.ToolConfig{}, path.Base(goTool.GetDownloadURL("1.21.5")))
+	if err != nil || checksum.Type != SHA256 || checksum.Value != hash {
+		t.Fatalf("GetChecksum() = %+v, %v, want SHA-256 %s", checksum, err, hash)
+	}
+	if _, err := goTool.GetChecksum("1.21.5", config.ToolConfig{}, "go1.21.5.linux-riscv64.tar.gz"); err == nil {
+		t.Error("GetChecksum() found a checksum for a file missing from the manifest")
+	}
+	if _, err := goTool.GetChecksum("1.21.4", config.ToolConfig{}, "go1.21.4.linux-amd64.tar.gz"); err == nil {
+		t.Error("GetChecksum() found a checksum for a version missing from the manifest")
+	}
+
+	// Downloads are verified against the manifest without any configured checksum
+	cfg := config.ToolConfig{Checksum: &config.ChecksumConfig{Required: true}}
+	download := &DownloadConfig{Tool: goTool, ToolName: "go", Version: "1.21.5", Config: cfg, URL: goTool.GetDownloadURL("1.21.5")}
+	if err := verifyChecksum(archive, download); err != nil {
+		t.Errorf("verifyChecksum() error = %v", err)
+	}
+	if err := os.WriteFile(archive, []byte("tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := verifyChecksum(archive, download); err == nil {
+		t.Error("verifyChecksum() accepted an archive not matching the manifest")
+	}
+}
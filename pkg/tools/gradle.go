@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// Compile-time interface validation
+var _ Tool = (*GradleTool)(nil)
+var _ DependencyProvider = (*GradleTool)(nil)
+var _ EnvironmentProvider = (*GradleTool)(nil)
+var _ VersionResolver = (*GradleTool)(nil)
+
+// gradleVerifyTimeout bounds 'gradle --version', which starts a JVM
+const gradleVerifyTimeout = 2 * time.Minute
+
+// GradleTool implements Tool interface for Gradle management
+type GradleTool struct {
+	*BaseTool
+}
+
+// getGradleBinaryName returns the Gradle launcher name for the target platform
+func getGradleBinaryName(manager *Manager) string {
+	return manager.GetPlatformMapper().BinaryName(BinaryGradle, ExtBat)
+}
+
+// NewGradleTool creates a new Gradle tool instance
+func NewGradleTool(manager *Manager) *GradleTool {
+	return &GradleTool{
+		BaseTool: NewBaseTool(manager, ToolGradle, getGradleBinaryName(manager)),
+	}
+}
+
+// Install downloads and installs the specified Gradle version
+func (g *GradleTool) Install(version string, cfg config.ToolConfig) error {
+	return g.StandardInstall(version, cfg, g.getDownloadURL)
+}
+
+// IsInstalled checks if the specified version is installed
+func (g *GradleTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	return g.StandardIsInstalled(version, cfg, g.GetPath)
+}
+
+// GetPath returns the binary path for the specified version (for PATH management)
+func (g *GradleTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return g.StandardGetPath(version, cfg, g.getInstalledPath)
+}
+
+// getInstalledPath returns the path for an installed Gradle version
+func (g *GradleTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
+	installDir := g.manager.GetToolVersionDir(g.GetToolName(), version, "")
+	pathResolver := g.manager.GetPathResolver()
+	return pathResolver.FindBinaryParentDir(installDir, g.GetBinaryName())
+}
+
+// Verify checks if the installation is working correctly
+func (g *GradleTool) Verify(version string, cfg config.ToolConfig) error {
+	verifyConfig := VerificationConfig{
+		BinaryName:      g.GetBinaryName(),
+		VersionArgs:     []string{"--version"},
+		ExpectedVersion: "Gradle " + version,
+		Timeout:         gradleVerifyTimeout,
+		RequiredEnv:     []string{EnvJavaHome},
+	}
+	return g.StandardVerifyWithConfig(version, cfg, verifyConfig)
+}
+
+// ListVersions returns available Gradle versions, newest first
+func (g *GradleTool) ListVersions() ([]string, error) {
+	versions, err := g.fetchGradleVersions()
+	if err != nil || len(versions) == 0 {
+		// Fallback to known versions if the releases API is unavailable
+		return g.getFallbackGradleVersions(), nil
+	}
+	return version.SortVersions(versions), nil
+}
+
+// GetDisplayName returns the human-readable name for Gradle (implements ToolMetadataProvider)
+func (g *GradleTool) GetDisplayName() string {
+	return "Gradle"
+}
+
+// GetDependencies returns the list of tools that Gradle depends on (implements DependencyProvider)
+func (g *GradleTool) GetDependencies() []string {
+	return []string{ToolJava}
+}
+
+// SetupEnvironment sets GRADLE_HOME (implements EnvironmentProvider)
+func (g *GradleTool) SetupEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error {
+	// Convert EnvironmentManager to map for the existing helper
+	envVars := envManager.ToMap()
+	err := g.SetupHomeEnvironment(version, cfg, envVars, EnvGradleHome, g.GetPath)
+	// Update the environment manager with any changes
+	for key, value := range envVars {
+		if key != "PATH" { // PATH is handled separately by EnvironmentManager
+			envManager.SetEnv(key, value)
+		}
+	}
+	return err
+}
+
+// gradleRelease is an entry of the Gradle versions API
+type gradleRelease struct {
+	Version      string `json:"version"`
+	Snapshot     bool   `json:"snapshot"`
+	Nightly      bool   `json:"nightly"`
+	Broken       bool   `json:"broken"`
+	RCFor        string `json:"rcFor"`
+	MilestoneFor string `json:"milestoneFor"`
+}
+
+// isFinal reports whether a release is a final, usable release
+func (r gradleRelease) isFinal() bool {
+	return !r.Snapshot && !r.Nightly && !r.Broken && r.RCFor == "" && r.MilestoneFor == ""
+}
+
+// fetchGradleVersions fetches final release versions from the Gradle versions API
+func (g *GradleTool) fetchGradleVersions() ([]string, error) {
+	resp, err := g.manager.Get(GradleServicesBase + "/versions/all")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch Gradle versions: status %d", resp.StatusCode)
+	}
+
+	var releases []gradleRelease
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse Gradle versions: %w", err)
+	}
+
+	var versions []string
+	for _, release := range releases {
+		if release.isFinal() {
+			versions = append(versions, release.Version)
+		}
+	}
+	return versions, nil
+}
+
+// getFallbackGradleVersions returns known Gradle versions as fallback
+func (g *GradleTool) getFallbackGradleVersions() []string {
+	return []string{
+		// Gradle 8.x
+		"8.10.2", "8.10.1", "8.10", "8.9", "8.8", "8.7", "8.6", "8.5", "8.4", "8.3", "8.2.1", "8.1.1", "8.0.2",
+
+		// Gradle 7.x
+		"7.6.4", "7.6.3", "7.5.1", "7.4.2", "7.3.3",
+	}
+}
+
+// getDownloadURL returns the download URL of the binary distribution
+func (g *GradleTool) getDownloadURL(version string) string {
+	return fmt.Sprintf("%s/distributions/gradle-%s-bin.zip", GradleServicesBase, version)
+}
+
+// GetDownloadURL implements URLProvider interface for Gradle
+func (g *GradleTool) GetDownloadURL(version string) string {
+	return g.getDownloadURL(version)
+}
+
+// getChecksumURL returns the checksum URL for Gradle, published next to the distribution
+func (g *GradleTool) getChecksumURL(version string) string {
+	return g.getDownloadURL(version) + ".sha256"
+}
+
+// ResolveVersion resolves a Gradle version specification to a concrete version
+func (g *GradleTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	availableVersions, err := g.ListVersions()
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil {
+		return "", fmt.Errorf("invalid version specification %s: %w", versionSpec, err)
+	}
+
+	resolved, err := spec.Resolve(availableVersions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Gradle version %s: %w", versionSpec, err)
+	}
+
+	return resolved, nil
+}
+
+// GetChecksum implements Tool interface for Gradle
+func (g *GradleTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
+	url := g.getChecksumURL(version)
+	resp, err := g.manager.Get(url)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to fetch Gradle checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ChecksumInfo{}, fmt.Errorf("Gradle checksum request returned status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to read Gradle checksum: %w", err)
+	}
+
+	// The checksum file contains just the hash
+	checksum := strings.TrimSpace(string(content))
+	if checksum == "" {
+		return ChecksumInfo{}, fmt.Errorf("empty Gradle checksum file at %s", url)
+	}
+
+	return ChecksumInfo{
+		Type:  SHA256,
+		Value: checksum,
+	}, nil
+}
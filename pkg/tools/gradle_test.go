@@ -0,0 +1,39 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestGradleReleaseIsFinal(t *testing.T) {
+	tests := []struct {
+		name     string
+		release  gradleRelease
+		expected bool
+	}{
+		{"final", gradleRelease{Version: "8.7"}, true},
+		{"snapshot", gradleRelease{Version: "8.8-20240301000000+0000", Snapshot: true}, false},
+		{"nightly", gradleRelease{Version: "8.9-20240401000000+0000", Nightly: true}, false},
+		{"release candidate", gradleRelease{Version: "8.8-rc-1", RCFor: "8.8"}, false},
+		{"milestone", gradleRelease{Version: "9.0-milestone-1", MilestoneFor: "9.0"}, false},
+		{"broken", gradleRelease{Version: "7.6.2", Broken: true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.release.isFinal(); got != tt.expected {
+				t.Errorf("isFinal() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGradleURLs(t *testing.T) {
+	gradleTool := NewGradleTool(newTestManager(t))
+
+	if got, want := gradleTool.GetDownloadURL("8.7"), "https://services.gradle.org/distributions/gradle-8.7-bin.zip"; got != want {
+		t.Errorf("GetDownloadURL() = %s, want %s", got, want)
+	}
+	if got, want := gradleTool.getChecksumURL("8.7"), "https://services.gradle.org/distributions/gradle-8.7-bin.zip.sha256"; got != want {
+		t.Errorf("getChecksumURL() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// httpProxy selects the proxy of HTTP requests, from HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+var httpProxy = http.ProxyFromEnvironment
+
+// newHTTPTransport creates the transport of mvx HTTP clients, which goes through the proxy
+// configured in the environment and trusts the certificates of MVX_CA_CERT, if set
+func newHTTPTransport() (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return httpProxy(req)
+	}
+
+	caCertPath := os.Getenv(EnvCACert)
+	if caCertPath == "" {
+		return transport, nil
+	}
+	rootCAs, err := loadCACertificates(caCertPath)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	return transport, nil
+}
+
+// loadCACertificates returns the system certificate pool with the certificates of a PEM bundle
+// added, e.g. for a corporate proxy re-signing TLS traffic
+func loadCACertificates(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", EnvCACert, err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in %s (%s)", path, EnvCACert)
+	}
+	return pool, nil
+}
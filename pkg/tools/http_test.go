@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHTTPProxy(t *testing.T) {
+	var mutex sync.Mutex
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests through a proxy carry the absolute URL of the target
+		mutex.Lock()
+		proxied = append(proxied, r.URL.String())
+		mutex.Unlock()
+		io.WriteString(w, `[{"major_version":21}]`)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(previous func(*http.Request) (*url.URL, error)) { httpProxy = previous }(httpProxy)
+	httpProxy = http.ProxyURL(proxyURL)
+
+	t.Setenv("HOME", t.TempDir())
+	manager, err := newManager(&PlatformInfo{OS: "linux", Arch: "amd64"})
+	if err != nil {
+		t.Fatalf("newManager() error = %v", err)
+	}
+	t.Cleanup(manager.pendingSaves.Wait)
+
+	apiURL := "http://api.example.test/disco/v3.0/major_versions"
+	resp, err := manager.Get(apiURL)
+	if err != nil {
+		t.Fatalf("Get(%s) error = %v", apiURL, err)
+	}
+	resp.Body.Close()
+
+	downloadURL := "http://downloads.example.test/tool.tar.gz"
+	destPath := filepath.Join(t.TempDir(), "tool.tar.gz")
+	if _, err := attemptDownload(&DownloadConfig{
+		URL:      downloadURL,
+		DestPath: destPath,
+		Timeout:  10 * time.Second,
+		MinSize:  1,
+		MaxSize:  DefaultMaxFileSize,
+	}, filepath.Join(t.TempDir(), "tool.tar.gz.tmp")); err != nil {
+		t.Fatalf("attemptDownload(%s) error = %v", downloadURL, err)
+	}
+
+	expected := []string{apiURL, downloadURL}
+	if strings.Join(proxied, " ") != strings.Join(expected, " ") {
+		t.Errorf("proxied requests = %v, want %v", proxied, expected)
+	}
+}
+
+func TestHTTPTransportCACert(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(bundle, certificate, 0644); err != nil {
+		t.Fatal(err)
+	}
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		caCert       string
+		wantErr      bool
+		wantAccepted bool
+	}{
+		{name: "system certificates", caCert: "", wantAccepted: false},
+		{name: "custom bundle", caCert: bundle, wantAccepted: true},
+		{name: "invalid bundle", caCert: invalid, wantErr: true},
+		{name: "missing bundle", caCert: filepath.Join(t.TempDir(), "missing.pem"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(EnvCACert, tt.caCert)
+			transport, err := newHTTPTransport()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newHTTPTransport() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			defer transport.CloseIdleConnections()
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+			if accepted := err == nil; accepted != tt.wantAccepted {
+				t.Errorf("GET %s accepted = %v (error: %v), want %v", server.URL, accepted, err, tt.wantAccepted)
+			}
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"slices"
 	"sort"
 	"strings"
 
@@ -18,10 +20,16 @@ import (
 
 // Compile-time interface validation
 var _ Tool = (*JavaTool)(nil)
+var _ PreferenceVersionResolver = (*JavaTool)(nil)
 var _ DistributionProvider = (*JavaTool)(nil)
 var _ DistributionVersionProvider = (*JavaTool)(nil)
 var _ VersionValidator = (*JavaTool)(nil)
 var _ EnvironmentProvider = (*JavaTool)(nil)
+var _ SecondaryEnvironmentProvider = (*JavaTool)(nil)
+var _ InstallDirProvider = (*JavaTool)(nil)
+var _ PackageLister = (*JavaTool)(nil)
+var _ VersionCanonicalizer = (*JavaTool)(nil)
+var _ DistributionURLProvider = (*JavaTool)(nil)
 
 // DiscoDistribution represents a Java distribution from Disco API
 type DiscoDistribution struct {
@@ -108,10 +116,7 @@ type JavaTool struct {
 }
 
 func getJavaBinaryName() string {
-	if NewPlatformMapper().IsWindows() {
-		return BinaryJava + ExtExe
-	}
-	return BinaryJava
+	return NewPlatformMapper().BinaryName(BinaryJava, ExtExe)
 }
 
 // NewJavaTool creates a new Java tool instance
@@ -130,7 +135,7 @@ func (j *JavaTool) Install(version string, cfg config.ToolConfig) error {
 
 	// Create a wrapper function that matches the expected signature
 	getDownloadURLWrapper := func(v string) string {
-		url, err := j.getDownloadURL(v, distribution)
+		url, err := j.getDownloadURL(v, distribution, distributionFallbackEnabled(cfg))
 		if err != nil {
 			util.LogVerbose("Failed to get download URL for Java %s (%s): %v", v, distribution, err)
 			return ""
@@ -142,6 +147,15 @@ func (j *JavaTool) Install(version string, cfg config.ToolConfig) error {
 	return j.installWithDistribution(version, cfg, distribution, getDownloadURLWrapper)
 }
 
+// GetInstallDir returns the installation directory for the specified version (implements InstallDirProvider)
+func (j *JavaTool) GetInstallDir(version string, cfg config.ToolConfig) string {
+	distribution := cfg.Distribution
+	if distribution == "" {
+		distribution = "temurin"
+	}
+	return j.manager.GetToolVersionDir(ToolJava, version, distribution)
+}
+
 // installWithDistribution provides Java-specific installation flow with distribution support
 func (j *JavaTool) installWithDistribution(version string, cfg config.ToolConfig, distribution string, getDownloadURL func(string) string) error {
 	// Check if we should use system tool instead of downloading
@@ -156,10 +170,27 @@ func (j *JavaTool) installWithDistribution(version string, cfg config.ToolConfig
 		return InstallError(j.toolName, version, fmt.Errorf("failed to create install directory: %w", err))
 	}
 
-	// Get download URL and package ID for checksum
-	downloadURL, packageID, err := j.getDownloadURLWithChecksum(version, distribution)
-	if err != nil {
-		return InstallError(j.toolName, version, fmt.Errorf("failed to get download URL: %w", err))
+	// Get download URL and package ID for checksum, honoring a package chosen explicitly
+	var downloadURL string
+	packageID := j.pinnedPackageID(cfg)
+	if archive := cfg.LocalArchive(); archive != "" {
+		// A local archive is installed without looking up any package
+		downloadURL, packageID = archive, ""
+	} else if packageID != "" {
+		info, err := j.fetchDiscoPackageInfo(packageID)
+		if err != nil {
+			return InstallError(j.toolName, version, fmt.Errorf("failed to get package %s: %w", packageID, err))
+		}
+		downloadURL = info.DirectDownloadURI
+		util.LogVerbose("Using chosen package %s: %s", packageID, info.Filename)
+	} else {
+		downloadURL, packageID, err = j.getDownloadURLWithChecksum(version, distribution, distributionFallbackEnabled(cfg))
+		if err != nil {
+			return InstallError(j.toolName, version, fmt.Errorf("failed to get download URL: %w", err))
+		}
+	}
+	if downloadURL == "" {
+		return InstallError(j.toolName, version, fmt.Errorf("no download URL found for package %s", packageID))
 	}
 
 	// Print download message
@@ -202,37 +233,177 @@ func (j *JavaTool) installWithDistribution(version string, cfg config.ToolConfig
 		if removeErr := os.RemoveAll(installDir); removeErr != nil {
 			util.LogVerbose("Failed to clean up installation directory %s: %v", installDir, removeErr)
 		}
-		fmt.Printf("  🧹 Cleaning up failed installation directory...\n")
+		j.printf("  🧹 Cleaning up failed installation directory...\n")
 		return InstallError(j.toolName, version, fmt.Errorf("installation verification failed: %w", err))
 	}
 
-	fmt.Printf("  ✅ %s %s installation verification successful\n", j.toolName, version)
+	j.printf("  ✅ %s %s installation verification successful\n", j.toolName, version)
+	if isGraalVMDistribution(distribution) {
+		j.ensureNativeImage(version, verifyConfig)
+	}
 	return nil
 }
 
-// getDownloadURLWithChecksum returns download URL and package ID for checksum verification
-func (j *JavaTool) getDownloadURLWithChecksum(version, distribution string) (string, string, error) {
-	platformMapper := NewPlatformMapper()
+// isGraalVMDistribution reports whether a distribution is a GraalVM one, e.g. graalvm_ce17 or
+// graalvm_community
+func isGraalVMDistribution(distribution string) bool {
+	return strings.HasPrefix(strings.ToLower(distribution), "graalvm")
+}
+
+// ensureNativeImage checks that a GraalVM installation provides native-image. Older GraalVM
+// releases ship it as a separate component, which is installed with 'gu install native-image'
+// when the "graalNativeImage: true" option is set. Failing to install it is only reported, as
+// the JDK itself is usable.
+func (j *JavaTool) ensureNativeImage(version string, cfg config.ToolConfig) {
+	javaHome, err := j.getJavaHomeUncached(version, cfg)
+	if err != nil {
+		util.LogVerbose("Could not determine GraalVM home: %v", err)
+		return
+	}
+	binDir := filepath.Join(javaHome, "bin")
+	if findGraalVMBinary(binDir, "native-image") != "" {
+		util.LogVerbose("GraalVM native-image found in %s", binDir)
+		return
+	}
+	if !strings.EqualFold(cfg.Options[OptionGraalNativeImage], "true") {
+		j.printf("  💡 native-image is not installed, set the %q option to \"true\" to install it\n", OptionGraalNativeImage)
+		return
+	}
+
+	gu := findGraalVMBinary(binDir, "gu")
+	if gu == "" {
+		j.printf("  ⚠️  Cannot install native-image: this GraalVM release has no 'gu' updater\n")
+		return
+	}
+	if IsOffline() {
+		j.printf("  ⚠️  Cannot install native-image in offline mode\n")
+		return
+	}
+
+	j.printf("  📦 Installing GraalVM native-image...\n")
+	cmd := exec.Command(gu, "install", "native-image")
+	cmd.Env = append(os.Environ(), EnvJavaHome+"="+javaHome, EnvGraalVMHome+"="+javaHome)
+	output := j.manager.output(j.toolName)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	if err := cmd.Run(); err != nil {
+		j.printf("  ⚠️  Failed to install native-image: %v\n", err)
+		return
+	}
+	if findGraalVMBinary(binDir, "native-image") == "" {
+		j.printf("  ⚠️  native-image is still missing after 'gu install native-image'\n")
+		return
+	}
+	j.printf("  ✅ GraalVM native-image installed\n")
+}
+
+// findGraalVMBinary returns the path of a GraalVM launcher in binDir, or "" if missing. On
+// Windows, the launchers are executables or batch scripts.
+func findGraalVMBinary(binDir, name string) string {
+	candidates := []string{name}
+	if runtime.GOOS == "windows" {
+		candidates = []string{name + ".exe", name + ".cmd"}
+	}
+	for _, candidate := range candidates {
+		path := filepath.Join(binDir, candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// javaFallbackDistributions are tried in order when the requested distribution lacks a version
+var javaFallbackDistributions = []string{"temurin", "zulu", "microsoft", "corretto"}
+
+// distributionFallbackEnabled reports whether other distributions may be substituted when the
+// configured one lacks the requested version. Users bound to a specific distribution (e.g. for
+// licensing reasons) disable it with the "distribution-fallback: off" option.
+func distributionFallbackEnabled(cfg config.ToolConfig) bool {
+	return !strings.EqualFold(cfg.Options[OptionDistributionFallback], "off")
+}
+
+// discoPlatform returns the target operating system and architecture in Disco API format
+func (j *JavaTool) discoPlatform() (osName, arch string) {
+	platformMapper := j.manager.GetPlatformMapper()
 
 	// Map Go arch to Disco API arch
-	archMapping := map[string]string{
+	arch = platformMapper.MapArchitecture(map[string]string{
 		"amd64": "x64",
 		"arm64": "aarch64",
-	}
-	arch := platformMapper.MapArchitecture(archMapping)
+	})
 
 	// Map OS names to Disco API format
-	osMapping := map[string]string{
+	osName = platformMapper.MapOS(map[string]string{
 		"darwin": "macos",
+	})
+	return osName, arch
+}
+
+// earlyAccessSuffix marks Java early-access version specifications, e.g. "24-ea"
+const earlyAccessSuffix = "-ea"
+
+// IsEarlyAccessVersion reports whether a Java version specification selects an early-access build
+func IsEarlyAccessVersion(version string) bool {
+	return strings.HasSuffix(version, earlyAccessSuffix)
+}
+
+// splitReleaseStatus strips the early-access suffix of a version and returns the matching
+// Disco API release status: "ea" (Early Access) or "ga" (General Availability)
+func splitReleaseStatus(version string) (string, string) {
+	if IsEarlyAccessVersion(version) {
+		return strings.TrimSuffix(version, earlyAccessSuffix), "ea"
+	}
+	return version, "ga"
+}
+
+// ListPackages lists the JDK and JRE builds of a version for the target platform (implements PackageLister)
+func (j *JavaTool) ListPackages(version, distribution string) ([]PackageInfo, error) {
+	if distribution == "" {
+		distribution = "temurin"
+	}
+	osName, arch := j.discoPlatform()
+
+	version, releaseStatus := splitReleaseStatus(version)
+
+	packages, err := j.queryDiscoPackages(version, distribution, osName, arch, releaseStatus, "")
+	if err != nil {
+		return nil, err
 	}
-	osName := platformMapper.MapOS(osMapping)
 
-	// Handle early access versions
-	releaseStatus := "ga" // General Availability
-	if strings.HasSuffix(version, "-ea") {
-		releaseStatus = "ea" // Early Access
-		version = strings.TrimSuffix(version, "-ea")
+	result := make([]PackageInfo, 0, len(packages))
+	for _, pkg := range packages {
+		result = append(result, PackageInfo{
+			ID:          pkg.ID,
+			Filename:    pkg.Filename,
+			Type:        pkg.PackageType,
+			LibC:        pkg.LibCType,
+			ArchiveType: pkg.ArchiveType,
+			Size:        pkg.Size,
+		})
+	}
+	return result, nil
+}
+
+// pinnedPackageID returns the package chosen with 'mvx tools add --interactive', if it was
+// chosen for the target platform. Other platforms use the automatic selection.
+func (j *JavaTool) pinnedPackageID(cfg config.ToolConfig) string {
+	packageID := cfg.Options[OptionPackageID]
+	if packageID == "" {
+		return ""
 	}
+	if platform := cfg.Options[OptionPackagePlatform]; platform != "" && platform != j.manager.GetPlatform().String() {
+		util.LogVerbose("Ignoring package %s chosen for %s, selecting a package for %s", packageID, platform, j.manager.GetPlatform())
+		return ""
+	}
+	return packageID
+}
+
+// getDownloadURLWithChecksum returns download URL and package ID for checksum verification
+func (j *JavaTool) getDownloadURLWithChecksum(version, distribution string, allowFallback bool) (string, string, error) {
+	osName, arch := j.discoPlatform()
+
+	version, releaseStatus := splitReleaseStatus(version)
 
 	// Try primary distribution first
 	result, err := j.tryDiscoDistributionWithChecksum(version, distribution, osName, arch, releaseStatus)
@@ -240,17 +411,20 @@ func (j *JavaTool) getDownloadURLWithChecksum(version, distribution string) (str
 		return result.DownloadURL, result.PackageID, nil
 	}
 
+	if !allowFallback {
+		return "", "", URLGenerationError(ToolJava, version, fmt.Errorf("Java %s not available in %s distribution for %s/%s (distribution fallback is off)", version, distribution, osName, arch))
+	}
+
 	// If primary distribution fails, try fallback distributions
-	fallbackDistributions := []string{"temurin", "zulu", "microsoft", "corretto"}
-	for _, fallback := range fallbackDistributions {
+	for _, fallback := range javaFallbackDistributions {
 		if fallback == distribution {
 			continue // Already tried this one
 		}
 
-		fmt.Printf("  🔄 Trying fallback distribution: %s\n", fallback)
+		j.printf("  🔄 Trying fallback distribution: %s\n", fallback)
 		result, err := j.tryDiscoDistributionWithChecksum(version, fallback, osName, arch, releaseStatus)
 		if err == nil && result.DownloadURL != "" {
-			fmt.Printf("  ✅ Found Java %s in %s distribution\n", version, fallback)
+			j.printf("  ✅ Found Java %s in %s distribution\n", version, fallback)
 			return result.DownloadURL, result.PackageID, nil
 		}
 	}
@@ -394,7 +568,7 @@ func (j *JavaTool) getInstalledPath(version string, cfg config.ToolConfig) (stri
 		distribution = "temurin" // Default distribution
 	}
 	installDir := j.manager.GetToolVersionDir(ToolJava, version, distribution)
-	pathResolver := NewPathResolver(j.manager.GetToolsDir())
+	pathResolver := j.manager.GetPathResolver()
 	binDir, err := pathResolver.FindBinaryParentDir(installDir, j.GetBinaryName())
 	if err != nil {
 		return "", err
@@ -494,11 +668,25 @@ func (j *JavaTool) GetDisplayName() string {
 	return "Java Development Kit"
 }
 
+// CanonicalVersion strips the build number from Java versions, e.g. "17.0.16+8" becomes "17.0.16"
+// (implements VersionCanonicalizer)
+func (j *JavaTool) CanonicalVersion(raw string) string {
+	canonical, _, _ := strings.Cut(strings.TrimPrefix(raw, "v"), "+")
+	return canonical
+}
+
 // SetupEnvironment sets up Java-specific environment variables (implements EnvironmentProvider)
 func (j *JavaTool) SetupEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error {
 	// Convert EnvironmentManager to map for the existing helper
 	envVars := envManager.ToMap()
 	err := j.SetupHomeEnvironment(version, cfg, envVars, EnvJavaHome, j.GetPath)
+	if javaHome, ok := envVars[EnvJavaHome]; ok && !UseSystemTool(ToolJava) {
+		envVars[javaMajorHomeVar(version)] = javaHome
+		// GraalVM tools, such as the native-image Maven and Gradle plugins, look for GRAALVM_HOME
+		if isGraalVMDistribution(cfg.Distribution) {
+			envVars[EnvGraalVMHome] = javaHome
+		}
+	}
 	// Update the environment manager with any changes
 	for key, value := range envVars {
 		if key != "PATH" { // PATH is handled separately by EnvironmentManager
@@ -508,6 +696,32 @@ func (j *JavaTool) SetupEnvironment(version string, cfg config.ToolConfig, envMa
 	return err
 }
 
+// SetupSecondaryEnvironment sets JAVA<major>_HOME for a JDK configured in addition to the primary
+// one, e.g. under "java8" for Maven toolchains (implements SecondaryEnvironmentProvider)
+func (j *JavaTool) SetupSecondaryEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error {
+	javaHome, err := j.GetJavaHome(version, cfg)
+	if err != nil {
+		return err
+	}
+	envManager.SetEnv(javaMajorHomeVar(version), javaHome)
+	return nil
+}
+
+// javaMajorHomeVar returns the variable set to the home of a JDK besides JAVA_HOME, e.g.
+// JAVA21_HOME for 21.0.5 and JAVA8_HOME for 8.0.392 or 1.8.0_392
+func javaMajorHomeVar(version string) string {
+	return "JAVA" + javaMajorVersion(version) + "_HOME"
+}
+
+// javaMajorVersion returns the feature release of a Java version, e.g. "8" for 1.8.0_392
+func javaMajorVersion(version string) string {
+	major, rest, _ := strings.Cut(version, ".")
+	if major == "1" {
+		major, _, _ = strings.Cut(rest, ".")
+	}
+	return strings.TrimSuffix(major, "-ea")
+}
+
 // DiscoMajorVersion represents a major version entry from Disco API
 type DiscoMajorVersion struct {
 	MajorVersion int      `json:"major_version"`
@@ -563,20 +777,7 @@ func (j *JavaTool) getDetailedVersionsForMajor(majorVersion, distribution string
 		distribution = "temurin"
 	}
 
-	platformMapper := NewPlatformMapper()
-
-	// Map Go arch to Disco API arch
-	archMapping := map[string]string{
-		"amd64": "x64",
-		"arm64": "aarch64",
-	}
-	arch := platformMapper.MapArchitecture(archMapping)
-
-	// Map OS names to Disco API format
-	osMapping := map[string]string{
-		"darwin": "macos",
-	}
-	osName := platformMapper.MapOS(osMapping)
+	osName, arch := j.discoPlatform()
 
 	// Query packages for this major version and distribution
 	url := fmt.Sprintf("%s/packages?version=%s&distribution=%s&operating_system=%s&architecture=%s&package_type=jdk&release_status=ga&latest=available",
@@ -626,38 +827,29 @@ func (j *JavaTool) getDetailedVersionsForMajor(majorVersion, distribution string
 	return versions, nil
 }
 
+// GetDistributionDownloadURL returns the download URL of a version of a distribution
+// (implements DistributionURLProvider)
+func (j *JavaTool) GetDistributionDownloadURL(version, distribution string) (string, error) {
+	if distribution == "" {
+		distribution = "temurin"
+	}
+	return j.getDownloadURL(version, distribution, false)
+}
+
 // getDownloadURL returns the download URL for the specified version and distribution using Disco API
-func (j *JavaTool) getDownloadURL(version, distribution string) (string, error) {
-	return j.getDiscoURL(version, distribution)
+func (j *JavaTool) getDownloadURL(version, distribution string, allowFallback bool) (string, error) {
+	return j.getDiscoURL(version, distribution, allowFallback)
 }
 
 // getDiscoURL returns the download URL using Foojay Disco API
-func (j *JavaTool) getDiscoURL(version, distribution string) (string, error) {
+func (j *JavaTool) getDiscoURL(version, distribution string, allowFallback bool) (string, error) {
 	if distribution == "" {
 		distribution = "temurin" // Default to Temurin
 	}
 
-	platformMapper := NewPlatformMapper()
-
-	// Map Go arch to Disco API arch
-	archMapping := map[string]string{
-		"amd64": "x64",
-		"arm64": "aarch64",
-	}
-	arch := platformMapper.MapArchitecture(archMapping)
-
-	// Map OS names to Disco API format
-	osMapping := map[string]string{
-		"darwin": "macos",
-	}
-	osName := platformMapper.MapOS(osMapping)
+	osName, arch := j.discoPlatform()
 
-	// Handle early access versions
-	releaseStatus := "ga" // General Availability
-	if strings.HasSuffix(version, "-ea") {
-		releaseStatus = "ea" // Early Access
-		version = strings.TrimSuffix(version, "-ea")
-	}
+	version, releaseStatus := splitReleaseStatus(version)
 
 	// Try primary distribution first
 	downloadURL, err := j.tryDiscoDistribution(version, distribution, osName, arch, releaseStatus)
@@ -665,17 +857,20 @@ func (j *JavaTool) getDiscoURL(version, distribution string) (string, error) {
 		return downloadURL, nil
 	}
 
+	if !allowFallback {
+		return "", URLGenerationError("java", version, fmt.Errorf("Java %s not available in %s distribution for %s/%s (distribution fallback is off)", version, distribution, osName, arch))
+	}
+
 	// If primary distribution fails, try fallback distributions
-	fallbackDistributions := []string{"temurin", "zulu", "microsoft", "corretto"}
-	for _, fallback := range fallbackDistributions {
+	for _, fallback := range javaFallbackDistributions {
 		if fallback == distribution {
 			continue // Already tried this one
 		}
 
-		fmt.Printf("  🔄 Trying fallback distribution: %s\n", fallback)
+		j.printf("  🔄 Trying fallback distribution: %s\n", fallback)
 		downloadURL, err := j.tryDiscoDistribution(version, fallback, osName, arch, releaseStatus)
 		if err == nil && downloadURL != "" {
-			fmt.Printf("  ✅ Found Java %s in %s distribution\n", version, fallback)
+			j.printf("  ✅ Found Java %s in %s distribution\n", version, fallback)
 			return downloadURL, nil
 		}
 	}
@@ -698,11 +893,43 @@ func (j *JavaTool) tryDiscoDistribution(version, distribution, osName, arch, rel
 	return result.DownloadURL, nil
 }
 
-// tryDiscoDistributionWithChecksum attempts to get download URL and package ID from a specific distribution
-func (j *JavaTool) tryDiscoDistributionWithChecksum(version, distribution, osName, arch, releaseStatus string) (DiscoveryResult, error) {
+// DiscoPackage is a downloadable build listed by the Disco API
+type DiscoPackage struct {
+	ID                string `json:"id"`
+	DirectDownloadURI string `json:"direct_download_uri"`
+	Filename          string `json:"filename"`
+	VersionNumber     string `json:"version_number"`
+	JavaVersion       string `json:"java_version"`
+	Distribution      string `json:"distribution"`
+	PackageType       string `json:"package_type"`
+	LibCType          string `json:"lib_c_type"`
+	Architecture      string `json:"architecture"`
+	OperatingSystem   string `json:"operating_system"`
+	ArchiveType       string `json:"archive_type"`
+	Size              int64  `json:"size"`
+	Links             struct {
+		PkgInfoURI          string `json:"pkg_info_uri"`
+		PkgDownloadRedirect string `json:"pkg_download_redirect"`
+	} `json:"links"`
+}
+
+// downloadURL returns the direct download URI of the package, or its redirect link
+func (p DiscoPackage) downloadURL() string {
+	if p.DirectDownloadURI != "" {
+		return p.DirectDownloadURI
+	}
+	return p.Links.PkgDownloadRedirect
+}
+
+// queryDiscoPackages lists the packages of a distribution matching a version and platform.
+// An empty packageType lists all package types (JDK and JRE).
+func (j *JavaTool) queryDiscoPackages(version, distribution, osName, arch, releaseStatus, packageType string) ([]DiscoPackage, error) {
 	// Build Disco API URL for package search
-	url := fmt.Sprintf(FoojayDiscoAPIBase+"/packages?version=%s&distribution=%s&operating_system=%s&architecture=%s&package_type=jdk&release_status=%s&latest=available",
+	url := fmt.Sprintf(FoojayDiscoAPIBase+"/packages?version=%s&distribution=%s&operating_system=%s&architecture=%s&release_status=%s&latest=available",
 		version, distribution, osName, arch, releaseStatus)
+	if packageType != "" {
+		url += "&package_type=" + packageType
+	}
 
 	// Add verbose logging for debugging
 	util.LogVerbose("Disco API URL: %s", url)
@@ -713,29 +940,14 @@ func (j *JavaTool) tryDiscoDistributionWithChecksum(version, distribution, osNam
 	resp, err := j.manager.Get(url)
 	if err != nil {
 		util.LogVerbose("HTTP request failed: %v", err)
-		return DiscoveryResult{}, fmt.Errorf("failed to query Disco API: %w", err)
+		return nil, fmt.Errorf("failed to query Disco API: %w", err)
 	}
 	defer resp.Body.Close()
 
 	util.LogVerbose("HTTP response status: %s", resp.Status)
 
 	if resp.StatusCode != http.StatusOK {
-		return DiscoveryResult{}, fmt.Errorf("Disco API request failed with status: %s", resp.Status)
-	}
-
-	type DiscoPackage struct {
-		ID                string `json:"id"`
-		DirectDownloadURI string `json:"direct_download_uri"`
-		Filename          string `json:"filename"`
-		VersionNumber     string `json:"version_number"`
-		LibCType          string `json:"lib_c_type"`
-		Architecture      string `json:"architecture"`
-		OperatingSystem   string `json:"operating_system"`
-		ArchiveType       string `json:"archive_type"`
-		Links             struct {
-			PkgInfoURI          string `json:"pkg_info_uri"`
-			PkgDownloadRedirect string `json:"pkg_download_redirect"`
-		} `json:"links"`
+		return nil, fmt.Errorf("Disco API request failed with status: %s", resp.Status)
 	}
 
 	var packages struct {
@@ -745,27 +957,33 @@ func (j *JavaTool) tryDiscoDistributionWithChecksum(version, distribution, osNam
 	// Read response body for debugging
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return DiscoveryResult{}, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
 	util.LogVerbose("Raw API response: %s", string(body))
 
 	if err := json.Unmarshal(body, &packages); err != nil {
 		util.LogVerbose("JSON parsing failed: %v", err)
-		return DiscoveryResult{}, fmt.Errorf("failed to parse Disco API response: %w", err)
+		return nil, fmt.Errorf("failed to parse Disco API response: %w", err)
 	}
 
 	util.LogVerbose("Found %d packages in response", len(packages.Result))
 	for i, pkg := range packages.Result {
-		downloadURI := pkg.DirectDownloadURI
-		if downloadURI == "" {
-			downloadURI = pkg.Links.PkgDownloadRedirect
-		}
 		util.LogVerbose("Package %d: filename=%s, version=%s, download_uri=%s",
-			i+1, pkg.Filename, pkg.VersionNumber, downloadURI)
+			i+1, pkg.Filename, pkg.VersionNumber, pkg.downloadURL())
+	}
+
+	return packages.Result, nil
+}
+
+// tryDiscoDistributionWithChecksum attempts to get download URL and package ID from a specific distribution
+func (j *JavaTool) tryDiscoDistributionWithChecksum(version, distribution, osName, arch, releaseStatus string) (DiscoveryResult, error) {
+	packages, err := j.queryDiscoPackages(version, distribution, osName, arch, releaseStatus, "jdk")
+	if err != nil {
+		return DiscoveryResult{}, err
 	}
 
-	if len(packages.Result) == 0 {
+	if len(packages) == 0 {
 		return DiscoveryResult{}, fmt.Errorf("no packages found for Java %s (%s)", version, distribution)
 	}
 
@@ -774,7 +992,7 @@ func (j *JavaTool) tryDiscoDistributionWithChecksum(version, distribution, osNam
 	// Smart selection: prefer glibc over musl on glibc systems, and tar.gz over other formats
 	var glibcPkg, muslPkg, zipPkg, tarGzPkg, otherPkg *DiscoPackage
 
-	for _, pkg := range packages.Result {
+	for _, pkg := range packages {
 		// Check architecture compatibility
 		archMatch := false
 		if pkg.Architecture == "x64" || pkg.Architecture == "amd64" {
@@ -843,10 +1061,7 @@ func (j *JavaTool) tryDiscoDistributionWithChecksum(version, distribution, osNam
 	}
 
 	util.LogVerbose("Selected package: %s", selectedPkg.Filename)
-	downloadURL := selectedPkg.DirectDownloadURI
-	if downloadURL == "" {
-		downloadURL = selectedPkg.Links.PkgDownloadRedirect
-	}
+	downloadURL := selectedPkg.downloadURL()
 
 	if downloadURL == "" {
 		return DiscoveryResult{}, fmt.Errorf("no download URL found for Java %s (%s)", version, distribution)
@@ -861,46 +1076,56 @@ func (j *JavaTool) tryDiscoDistributionWithChecksum(version, distribution, osNam
 	}, nil
 }
 
-// getChecksumFromDiscoAPI fetches checksum information from Foojay Disco API
-func (j *JavaTool) getChecksumFromDiscoAPI(packageID string) (ChecksumInfo, error) {
+// discoPackageInfo holds the details of a single Disco API package
+type discoPackageInfo struct {
+	Filename          string `json:"filename"`
+	Checksum          string `json:"checksum"`
+	ChecksumType      string `json:"checksum_type"`
+	ChecksumURI       string `json:"checksum_uri"`
+	DirectDownloadURI string `json:"direct_download_uri"`
+}
+
+// fetchDiscoPackageInfo fetches the details of a package from Foojay Disco API
+func (j *JavaTool) fetchDiscoPackageInfo(packageID string) (discoPackageInfo, error) {
 	if packageID == "" {
-		return ChecksumInfo{}, fmt.Errorf("package ID is required")
+		return discoPackageInfo{}, fmt.Errorf("package ID is required")
 	}
 
 	// Build package info URL
 	url := fmt.Sprintf(FoojayDiscoAPIBase+"/ids/%s", packageID)
 
-	util.LogVerbose("Fetching checksum from Disco API: %s", url)
+	util.LogVerbose("Fetching package info from Disco API: %s", url)
 
 	resp, err := j.manager.Get(url)
 	if err != nil {
-		return ChecksumInfo{}, fmt.Errorf("failed to fetch package info: %w", err)
+		return discoPackageInfo{}, fmt.Errorf("failed to fetch package info: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return ChecksumInfo{}, fmt.Errorf("Disco API returned status %d", resp.StatusCode)
+		return discoPackageInfo{}, fmt.Errorf("Disco API returned status %d", resp.StatusCode)
 	}
 
 	var packageInfo struct {
-		Result []struct {
-			Filename          string `json:"filename"`
-			Checksum          string `json:"checksum"`
-			ChecksumType      string `json:"checksum_type"`
-			ChecksumURI       string `json:"checksum_uri"`
-			DirectDownloadURI string `json:"direct_download_uri"`
-		} `json:"result"`
-		Message string `json:"message"`
+		Result  []discoPackageInfo `json:"result"`
+		Message string             `json:"message"`
 	}
 	if err := json.NewDecoder(resp.Body).Decode(&packageInfo); err != nil {
-		return ChecksumInfo{}, fmt.Errorf("failed to decode package info: %w", err)
+		return discoPackageInfo{}, fmt.Errorf("failed to decode package info: %w", err)
 	}
 
 	if len(packageInfo.Result) == 0 {
-		return ChecksumInfo{}, fmt.Errorf("no package info found")
+		return discoPackageInfo{}, fmt.Errorf("no package info found")
 	}
+	return packageInfo.Result[0], nil
+}
 
-	pkg := packageInfo.Result[0]
+// getChecksumFromDiscoAPI fetches checksum information from Foojay Disco API
+func (j *JavaTool) getChecksumFromDiscoAPI(packageID string) (ChecksumInfo, error) {
+	pkg, err := j.fetchDiscoPackageInfo(packageID)
+	if err != nil {
+		return ChecksumInfo{}, err
+	}
 
 	// Convert checksum type to our enum
 	var checksumType ChecksumType
@@ -934,7 +1159,7 @@ func (j *JavaTool) GetChecksum(version string, cfg config.ToolConfig, filename s
 	// Java checksums are handled during the installation process via getDownloadURLWithChecksum
 	// and getChecksumFromDiscoAPI, which adds checksums to the configuration automatically.
 	// This method is not used for Java since checksums are fetched during URL resolution.
-	fmt.Printf("  ℹ️  Java checksums are handled during installation via Disco API\n")
+	j.printf("  ℹ️  Java checksums are handled during installation via Disco API\n")
 	return ChecksumInfo{}, fmt.Errorf("Java checksums are provided via configuration during installation")
 }
 
@@ -955,12 +1180,18 @@ func (j *JavaTool) ValidateVersion(versionSpec, distribution string) error {
 
 // ResolveVersion resolves a Java version specification to a concrete version
 func (j *JavaTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	return j.ResolveVersionWithPreference(versionSpec, distribution, version.PreferHighest)
+}
+
+// ResolveVersionWithPreference resolves a Java version specification to the newest or oldest
+// matching release (implements PreferenceVersionResolver)
+func (j *JavaTool) ResolveVersionWithPreference(versionSpec, distribution string, preference version.Preference) (string, error) {
 	if distribution == "" {
 		distribution = "temurin" // Default distribution
 	}
 
 	// If the version is already a concrete version (e.g., "17.0.16"), return it as-is
-	if strings.Contains(versionSpec, ".") {
+	if strings.Contains(versionSpec, ".") && !version.IsRange(versionSpec) {
 		return versionSpec, nil
 	}
 
@@ -976,13 +1207,17 @@ func (j *JavaTool) ResolveVersion(versionSpec, distribution string) (string, err
 		return "", err
 	}
 
-	majorResolved, err := spec.Resolve(majorVersions)
+	if spec.Constraint == "range" {
+		return j.resolveVersionRange(spec, majorVersions, distribution, preference)
+	}
+
+	majorResolved, err := spec.ResolveWithPreference(majorVersions, preference)
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve Java %s version %s: %w", distribution, versionSpec, err)
 	}
 
 	// If the resolved version is a major version (e.g., "17"), fetch detailed versions
-	// and resolve to the latest patch version (e.g., "17.0.16")
+	// and resolve to the latest (or, preferring the lowest, earliest) patch version (e.g., "17.0.16")
 	if !strings.Contains(majorResolved, ".") {
 		detailedVersions, err := j.getDetailedVersionsForMajor(majorResolved, distribution)
 		if err != nil {
@@ -991,6 +1226,11 @@ func (j *JavaTool) ResolveVersion(versionSpec, distribution string) (string, err
 		}
 
 		if len(detailedVersions) > 0 {
+			if preference == version.PreferLowest {
+				if sorted := version.SortVersions(detailedVersions); len(sorted) > 0 {
+					return sorted[len(sorted)-1], nil
+				}
+			}
 			// Return the latest (first) detailed version
 			return detailedVersions[0], nil
 		}
@@ -999,10 +1239,36 @@ func (j *JavaTool) ResolveVersion(versionSpec, distribution string) (string, err
 	return majorResolved, nil
 }
 
+// resolveVersionRange resolves a version range against the releases of the major versions it
+// allows, in order of preference, as a range may exclude the major versions themselves
+func (j *JavaTool) resolveVersionRange(spec *version.Spec, majorVersions []string, distribution string, preference version.Preference) (string, error) {
+	majors := version.SortVersions(majorVersions)
+	if preference == version.PreferLowest {
+		slices.Reverse(majors)
+	}
+
+	for _, major := range majors {
+		parsed, err := version.ParseVersion(major)
+		if err != nil || !spec.AllowsMajor(parsed.Major) {
+			continue
+		}
+		detailedVersions, err := j.getDetailedVersionsForMajor(major, distribution)
+		if err != nil {
+			util.LogVerbose("Failed to fetch detailed versions for Java %s: %v", major, err)
+			continue
+		}
+		if resolved, err := spec.ResolveWithPreference(detailedVersions, preference); err == nil {
+			return resolved, nil
+		}
+	}
+
+	return "", fmt.Errorf("no Java %s version matches %s", distribution, spec.Raw)
+}
+
 // GetDownloadURL implements Tool interface for Java
 func (j *JavaTool) GetDownloadURL(version string) string {
 	// Use default distribution (temurin) for URL generation
-	url, err := j.getDownloadURL(version, "temurin")
+	url, err := j.getDownloadURL(version, "temurin", true)
 	if err != nil {
 		util.LogVerbose("Failed to get download URL for Java %s: %v", version, err)
 		return ""
@@ -202,3 +202,134 @@ func TestJavaToolWithSystemJava(t *testing.T) {
 	// The old behavior was more strict and would fail if JAVA_HOME was invalid
 	t.Logf("Note: Standardized approach is more permissive than old Java-specific logic")
 }
+
+func TestDistributionFallbackEnabled(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]string
+		expected bool
+	}{
+		{name: "no options", options: nil, expected: true},
+		{name: "on", options: map[string]string{OptionDistributionFallback: "on"}, expected: true},
+		{name: "off", options: map[string]string{OptionDistributionFallback: "off"}, expected: false},
+		{name: "OFF", options: map[string]string{OptionDistributionFallback: "OFF"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.ToolConfig{Version: "21", Distribution: "zulu", Options: tt.options}
+			if got := distributionFallbackEnabled(cfg); got != tt.expected {
+				t.Errorf("distributionFallbackEnabled() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGraalVMNativeImage(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as GraalVM launchers")
+	}
+	manager := newTestManager(t)
+	java := NewJavaTool(manager)
+	cfg := config.ToolConfig{Version: "17.0.9", Distribution: "graalvm_ce17"}
+
+	// A GraalVM release whose gu updater installs native-image
+	binDir := filepath.Join(manager.GetToolVersionDir(ToolJava, "17.0.9", "graalvm_ce17"), "graalvm-ce-java17-22.3.3", "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	scripts := map[string]string{
+		"java": "#!/bin/sh\necho 'openjdk version \"17.0.9\"' >&2\n",
+		"gu":   "#!/bin/sh\n[ \"$*\" = \"install native-image\" ] && touch \"$GRAALVM_HOME/bin/native-image\"\n",
+	}
+	for name, script := range scripts {
+		if err := os.WriteFile(filepath.Join(binDir, name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nativeImage := filepath.Join(binDir, "native-image")
+
+	java.ensureNativeImage("17.0.9", cfg)
+	if _, err := os.Stat(nativeImage); err == nil {
+		t.Fatal("native-image was installed without the graalNativeImage option")
+	}
+
+	cfg.Options = map[string]string{OptionGraalNativeImage: "true"}
+	java.ensureNativeImage("17.0.9", cfg)
+	if _, err := os.Stat(nativeImage); err != nil {
+		t.Fatalf("native-image was not installed: %v", err)
+	}
+
+	envManager := NewEnvironmentManager()
+	if err := java.SetupEnvironment("17.0.9", cfg, envManager); err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	env := envManager.ToMap()
+	if home := filepath.Dir(binDir); env[EnvGraalVMHome] != home || env[EnvJavaHome] != home {
+		t.Errorf("GRAALVM_HOME = %q, JAVA_HOME = %q, want both %q", env[EnvGraalVMHome], env[EnvJavaHome], home)
+	}
+}
+
+func TestJavaPinnedPackageID(t *testing.T) {
+	manager := newTestManager(t)
+	manager.platform = &PlatformInfo{OS: "linux", Arch: "arm64"}
+	javaTool := NewJavaTool(manager)
+
+	tests := []struct {
+		name     string
+		options  map[string]string
+		expected string
+	}{
+		{name: "no package", options: nil, expected: ""},
+		{name: "same platform", options: map[string]string{OptionPackageID: "abc", OptionPackagePlatform: "linux-arm64"}, expected: "abc"},
+		{name: "other platform", options: map[string]string{OptionPackageID: "abc", OptionPackagePlatform: "darwin-arm64"}, expected: ""},
+		{name: "no recorded platform", options: map[string]string{OptionPackageID: "abc"}, expected: "abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.ToolConfig{Version: "21", Options: tt.options}
+			if got := javaTool.pinnedPackageID(cfg); got != tt.expected {
+				t.Errorf("pinnedPackageID() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSplitReleaseStatus(t *testing.T) {
+	tests := []struct {
+		version       string
+		expected      string
+		releaseStatus string
+	}{
+		{"21", "21", "ga"},
+		{"21.0.1", "21.0.1", "ga"},
+		{"25-ea", "25", "ea"},
+	}
+
+	for _, tt := range tests {
+		version, releaseStatus := splitReleaseStatus(tt.version)
+		if version != tt.expected || releaseStatus != tt.releaseStatus {
+			t.Errorf("splitReleaseStatus(%q) = %q, %q, expected %q, %q", tt.version, version, releaseStatus, tt.expected, tt.releaseStatus)
+		}
+		if IsEarlyAccessVersion(tt.version) != (tt.releaseStatus == "ea") {
+			t.Errorf("IsEarlyAccessVersion(%q) = %v", tt.version, IsEarlyAccessVersion(tt.version))
+		}
+	}
+}
+
+func TestJavaCanonicalVersion(t *testing.T) {
+	javaTool := NewJavaTool(newTestManager(t))
+
+	tests := map[string]string{
+		"17.0.16+8":     "17.0.16",
+		"21.0.1+12-LTS": "21.0.1",
+		"21":            "21",
+		"25-ea":         "25-ea",
+	}
+	for raw, expected := range tests {
+		if got := javaTool.CanonicalVersion(raw); got != expected {
+			t.Errorf("CanonicalVersion(%q) = %q, expected %q", raw, got, expected)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// Compile-time interface validation
+var _ Tool = (*KotlinTool)(nil)
+var _ DependencyProvider = (*KotlinTool)(nil)
+var _ VersionResolver = (*KotlinTool)(nil)
+
+// kotlinVerifyTimeout bounds 'kotlinc -version', which starts a JVM
+const kotlinVerifyTimeout = 2 * time.Minute
+
+// KotlinTool implements Tool interface for the Kotlin command-line compiler
+type KotlinTool struct {
+	*BaseTool
+}
+
+// getKotlinBinaryName returns the kotlinc launcher name for the target platform
+func getKotlinBinaryName(manager *Manager) string {
+	return manager.GetPlatformMapper().BinaryName(BinaryKotlinc, ExtBat)
+}
+
+// NewKotlinTool creates a new Kotlin tool instance
+func NewKotlinTool(manager *Manager) *KotlinTool {
+	return &KotlinTool{
+		BaseTool: NewBaseTool(manager, ToolKotlin, getKotlinBinaryName(manager)),
+	}
+}
+
+// Install downloads and installs the specified Kotlin compiler version
+func (k *KotlinTool) Install(version string, cfg config.ToolConfig) error {
+	return k.StandardInstall(version, cfg, k.getDownloadURL)
+}
+
+// IsInstalled checks if the specified version is installed
+func (k *KotlinTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	return k.StandardIsInstalled(version, cfg, k.GetPath)
+}
+
+// GetPath returns the binary path for the specified version (for PATH management)
+func (k *KotlinTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return k.StandardGetPath(version, cfg, k.getInstalledPath)
+}
+
+// getInstalledPath returns the bin directory of the kotlinc layout of an installed version
+func (k *KotlinTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
+	installDir := k.manager.GetToolVersionDir(k.GetToolName(), version, "")
+	pathResolver := k.manager.GetPathResolver()
+	return pathResolver.FindBinaryParentDir(installDir, k.GetBinaryName())
+}
+
+// Verify runs 'kotlinc -version', which prints "info: kotlinc-jvm <version> (JRE ...)" on
+// stderr; the verification reads the combined output
+func (k *KotlinTool) Verify(version string, cfg config.ToolConfig) error {
+	verifyConfig := VerificationConfig{
+		BinaryName:      k.GetBinaryName(),
+		VersionArgs:     []string{"-version"},
+		ExpectedVersion: "kotlinc-jvm " + version,
+		Timeout:         kotlinVerifyTimeout,
+		RequiredEnv:     []string{EnvJavaHome},
+	}
+	return k.StandardVerifyWithConfig(version, cfg, verifyConfig)
+}
+
+// ListVersions returns available Kotlin versions, newest first
+func (k *KotlinTool) ListVersions() ([]string, error) {
+	versions, err := k.fetchKotlinVersions()
+	if err != nil || len(versions) == 0 {
+		// Fallback to known versions if the releases feed is unavailable
+		return k.getFallbackKotlinVersions(), nil
+	}
+	return version.SortVersions(versions), nil
+}
+
+// GetDisplayName returns the human-readable name for Kotlin (implements ToolMetadataProvider)
+func (k *KotlinTool) GetDisplayName() string {
+	return "Kotlin"
+}
+
+// GetDependencies returns the list of tools that Kotlin depends on (implements DependencyProvider)
+func (k *KotlinTool) GetDependencies() []string {
+	return []string{ToolJava}
+}
+
+// ResolveVersion resolves a Kotlin version specification to a concrete version
+func (k *KotlinTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	availableVersions, err := k.ListVersions()
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil {
+		return "", fmt.Errorf("invalid version specification %s: %w", versionSpec, err)
+	}
+
+	resolved, err := spec.Resolve(availableVersions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Kotlin version %s: %w", versionSpec, err)
+	}
+
+	return resolved, nil
+}
+
+// getDownloadURL returns the download URL of the compiler archive attached to a release
+func (k *KotlinTool) getDownloadURL(version string) string {
+	return fmt.Sprintf("%s/releases/download/v%s/kotlin-compiler-%s.zip", KotlinGithubBase, version, version)
+}
+
+// GetDownloadURL implements URLProvider interface for Kotlin
+func (k *KotlinTool) GetDownloadURL(version string) string {
+	return k.getDownloadURL(version)
+}
+
+// getChecksumURL returns the checksum URL for Kotlin, published next to the compiler archive
+func (k *KotlinTool) getChecksumURL(version string) string {
+	return k.getDownloadURL(version) + ".sha256"
+}
+
+// GetChecksum implements Tool interface for Kotlin using the published .sha256 file
+func (k *KotlinTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
+	url := k.getChecksumURL(version)
+	resp, err := k.manager.Get(url)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to fetch Kotlin checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ChecksumInfo{}, fmt.Errorf("Kotlin checksum request returned status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to read Kotlin checksum: %w", err)
+	}
+
+	// The checksum file contains the hash, optionally followed by the filename
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return ChecksumInfo{}, fmt.Errorf("empty Kotlin checksum file at %s", url)
+	}
+
+	return ChecksumInfo{
+		Type:  SHA256,
+		Value: fields[0],
+	}, nil
+}
+
+// fetchKotlinVersions fetches final Kotlin versions from the GitHub releases feed
+func (k *KotlinTool) fetchKotlinVersions() ([]string, error) {
+	return k.manager.fetchGitHubReleaseVersions(KotlinAPIBase, kotlinReleaseVersion)
+}
+
+// kotlinReleaseVersion returns the version of a final release tag such as "v2.0.0", rejecting
+// betas, release candidates and the other tags of the repository
+func kotlinReleaseVersion(tag string) (string, bool) {
+	return releaseTagVersion(tag, "v")
+}
+
+// getFallbackKotlinVersions returns known Kotlin versions as fallback
+func (k *KotlinTool) getFallbackKotlinVersions() []string {
+	return []string{
+		// Kotlin 2.x
+		"2.0.21", "2.0.20", "2.0.10", "2.0.0",
+
+		// Kotlin 1.9.x
+		"1.9.25", "1.9.24", "1.9.23", "1.9.22", "1.9.21", "1.9.20", "1.9.10", "1.9.0",
+	}
+}
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestKotlinReleaseVersion(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+		ok       bool
+	}{
+		{"v2.0.0", "2.0.0", true},
+		{"v1.9.25", "1.9.25", true},
+		{"v2.1.0-Beta1", "", false},
+		{"v2.0.20-RC2", "", false},
+		{"build-2.1.0-dev-1234", "", false},
+		{"2.0.0", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := kotlinReleaseVersion(tt.tag)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("kotlinReleaseVersion(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestKotlinURLs(t *testing.T) {
+	kotlinTool := NewKotlinTool(newTestManager(t))
+
+	if got, want := kotlinTool.GetDownloadURL("2.0.0"), "https://github.com/JetBrains/kotlin/releases/download/v2.0.0/kotlin-compiler-2.0.0.zip"; got != want {
+		t.Errorf("GetDownloadURL() = %s, want %s", got, want)
+	}
+	if got, want := kotlinTool.getChecksumURL("2.0.0"), "https://github.com/JetBrains/kotlin/releases/download/v2.0.0/kotlin-compiler-2.0.0.zip.sha256"; got != want {
+		t.Errorf("getChecksumURL() = %s, want %s", got, want)
+	}
+	if deps := kotlinTool.GetDependencies(); len(deps) != 1 || deps[0] != ToolJava {
+		t.Errorf("GetDependencies() = %v, want [java]", deps)
+	}
+}
@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+// localArchiveExtensions are the archive formats a tool can be installed from
+var localArchiveExtensions = []string{ExtZip, ExtTarGz, ExtTgz, ExtTarXz}
+
+// ValidateArchive checks that a local file is an archive mvx can extract, by its extension
+// and its magic bytes, so that a wrong file is rejected before anything is installed
+func ValidateArchive(archivePath string) error {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return fmt.Errorf("archive not found: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("archive %s is not a regular file", archivePath)
+	}
+
+	supported := false
+	for _, ext := range localArchiveExtensions {
+		if strings.HasSuffix(strings.ToLower(archivePath), ext) {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("unsupported archive %s: expected a %s file", archivePath, strings.Join(localArchiveExtensions, ", "))
+	}
+
+	if err := validateFileFormat(archivePath, strings.ToLower(archivePath)); err != nil {
+		return fmt.Errorf("invalid archive %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+// copyLocalArchive stands in for a download when the tool configuration names a local
+// archive: the archive is validated, copied to the destination and checked against the
+// configured checksum, without any network access
+func copyLocalArchive(config *DownloadConfig) (*DownloadResult, error) {
+	archivePath := config.Config.LocalArchive()
+	if err := ValidateArchive(archivePath); err != nil {
+		return nil, err
+	}
+
+	src, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(config.DestPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", config.DestPath, err)
+	}
+	size, err := io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(config.DestPath)
+		return nil, fmt.Errorf("failed to copy archive %s: %w", archivePath, err)
+	}
+
+	// Only a checksum value pinned in the configuration can be verified offline
+	if checksum, ok := configuredChecksum(config.Config); ok && checksum.Value != "" {
+		if err := NewChecksumVerifier(nil).VerifyFile(config.DestPath, checksum); err != nil {
+			os.Remove(config.DestPath)
+			return nil, fmt.Errorf("checksum verification of %s failed: %w", archivePath, err)
+		}
+		fmt.Fprintf(config.output(), "  ✅ Checksum verified successfully\n")
+	} else {
+		util.LogVerbose("No checksum configured for %s, installing it unverified", archivePath)
+	}
+
+	return &DownloadResult{
+		Size:     size,
+		FinalURL: archivePath,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestCopyLocalArchive(t *testing.T) {
+	dir := t.TempDir()
+	content := append([]byte{0x1f, 0x8b}, bytes.Repeat([]byte("mvx"), 100)...)
+	archive := filepath.Join(dir, "jdk-21.tar.gz")
+	if err := os.WriteFile(archive, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	notArchive := filepath.Join(dir, "jdk-21.zip")
+	if err := os.WriteFile(notArchive, []byte("<html>not found</html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	unsupported := filepath.Join(dir, "jdk-21.rar")
+	if err := os.WriteFile(unsupported, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(content)
+
+	tests := []struct {
+		name     string
+		archive  string
+		checksum string
+		errMsg   string
+	}{
+		{name: "archive", archive: archive},
+		{name: "matching checksum", archive: archive, checksum: hex.EncodeToString(sum[:])},
+		{name: "checksum mismatch", archive: archive, checksum: strings.Repeat("0", 64), errMsg: "checksum mismatch"},
+		{name: "wrong magic bytes", archive: notArchive, errMsg: "invalid ZIP header"},
+		{name: "unsupported format", archive: unsupported, errMsg: "unsupported archive"},
+		{name: "missing file falls back to the download", archive: filepath.Join(dir, "missing.zip"), errMsg: "offline"},
+	}
+
+	// Local archives are available offline
+	t.Setenv(EnvOffline, "true")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destPath := filepath.Join(t.TempDir(), "download")
+			downloadConfig := DefaultDownloadConfig("https://example.com/jdk-21.tar.gz", destPath)
+			downloadConfig.Config = config.ToolConfig{Version: "21", Archive: tt.archive}
+			if tt.checksum != "" {
+				downloadConfig.Config.Checksum = &config.ChecksumConfig{Value: tt.checksum}
+			}
+
+			result, err := RobustDownload(downloadConfig)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Fatalf("RobustDownload() error = %v, want %q", err, tt.errMsg)
+				}
+				if _, statErr := os.Stat(destPath); statErr == nil && tt.checksum != "" {
+					t.Errorf("archive failing its checksum was left at %s", destPath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RobustDownload() error = %v", err)
+			}
+			if result.Size != int64(len(content)) {
+				t.Errorf("RobustDownload() size = %d, want %d", result.Size, len(content))
+			}
+			if copied, _ := os.ReadFile(destPath); !bytes.Equal(copied, content) {
+				t.Errorf("copied archive differs from %s", tt.archive)
+			}
+		})
+	}
+}
+
+func TestResolveVersionLocalArchive(t *testing.T) {
+	manager := newTestManager(t)
+	transport := &failingTransport{}
+	manager.httpClient = &http.Client{Transport: transport}
+	manager.RegisterTool(NewJavaTool(manager))
+
+	archive := filepath.Join(t.TempDir(), "jdk-21.tar.gz")
+	if err := os.WriteFile(archive, []byte{0x1f, 0x8b}, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	resolved, err := manager.ResolveVersion("java", config.ToolConfig{Version: "21", Archive: archive})
+	if err != nil || resolved != "21" {
+		t.Errorf("ResolveVersion(java 21 from archive) = %q, %v, want 21", resolved, err)
+	}
+	if requests := transport.requests.Load(); requests != 0 {
+		t.Errorf("resolution made %d requests, want none", requests)
+	}
+}
@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+// LockfileName is the name of the lockfile, in the project's .mvx directory
+const LockfileName = "mvx.lock"
+
+// Lockfile pins the versions the tool specifications of a project resolved to, so that
+// specifications such as "21", "lts" or ranges resolve alike for everyone
+type Lockfile struct {
+	Tools map[string]LockedTool `json:"tools"`
+}
+
+// LockedTool records the resolution of a tool specification
+type LockedTool struct {
+	Spec         string        `json:"spec"`                   // Version specification from the configuration
+	Version      string        `json:"version"`                // Concrete version it resolved to
+	Distribution string        `json:"distribution,omitempty"` // Distribution from the configuration
+	URL          string        `json:"url,omitempty"`          // Download URL of the resolved version
+	Checksum     *ChecksumInfo `json:"checksum,omitempty"`     // Checksum of the download
+}
+
+// DistributionURLProvider is an optional interface for tools whose download URL depends on
+// the distribution
+type DistributionURLProvider interface {
+	// GetDistributionDownloadURL returns the download URL of a version of a distribution
+	GetDistributionDownloadURL(version, distribution string) (string, error)
+}
+
+// GetLockfilePath returns the path of the lockfile of a project
+func GetLockfilePath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".mvx", LockfileName)
+}
+
+// ReadLockfile reads the lockfile of a project, returning nil if the project has none
+func ReadLockfile(projectRoot string) (*Lockfile, error) {
+	lockfilePath := GetLockfilePath(projectRoot)
+	data, err := os.ReadFile(lockfilePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", lockfilePath, err)
+	}
+
+	var lockfile Lockfile
+	if err := json.Unmarshal(data, &lockfile); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", lockfilePath, err)
+	}
+	return &lockfile, nil
+}
+
+// UseLockfile makes version resolution prefer the versions pinned in the project's lockfile,
+// if it has one
+func (m *Manager) UseLockfile(projectRoot string) error {
+	lockfile, err := ReadLockfile(projectRoot)
+	if err != nil {
+		return err
+	}
+	m.lockfile = lockfile
+	return nil
+}
+
+// IgnoreLockfile makes version resolution ignore the lockfile, e.g. to update it
+func (m *Manager) IgnoreLockfile() {
+	m.lockfile = nil
+}
+
+// lockedVersion returns the version pinned in the lockfile for a tool, provided the
+// configuration still has the specification that was locked
+func (m *Manager) lockedVersion(toolName string, toolConfig config.ToolConfig) (string, bool) {
+	if m.lockfile == nil {
+		return "", false
+	}
+	locked, ok := m.lockfile.Tools[toolName]
+	if !ok || locked.Version == "" || locked.Spec != toolConfig.Version || locked.Distribution != toolConfig.Distribution {
+		return "", false
+	}
+	return locked.Version, true
+}
+
+// WriteLockfile resolves the configured tools, ignoring any existing lockfile and version
+// overrides from the environment, and writes their resolutions to the project's lockfile
+func (m *Manager) WriteLockfile(cfg *config.Config, projectRoot string) (*Lockfile, error) {
+	toolNames := make([]string, 0, len(cfg.Tools))
+	for toolName := range cfg.Tools {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	lockfile := &Lockfile{Tools: make(map[string]LockedTool, len(toolNames))}
+	for _, toolName := range toolNames {
+		toolConfig := cfg.Tools[toolName]
+		resolved, err := m.resolveConfiguredVersion(toolName, toolConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve version for %s: %w", toolName, err)
+		}
+		lockfile.Tools[toolName] = m.lockTool(toolName, toolConfig, resolved)
+	}
+
+	if err := m.saveLockfile(lockfile, projectRoot); err != nil {
+		return nil, err
+	}
+	return lockfile, nil
+}
+
+// saveLockfile writes a lockfile to the project's .mvx directory
+func (m *Manager) saveLockfile(lockfile *Lockfile, projectRoot string) error {
+	data, err := json.MarshalIndent(lockfile, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lockfile: %w", err)
+	}
+	lockfilePath := GetLockfilePath(projectRoot)
+	if err := os.WriteFile(lockfilePath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", lockfilePath, err)
+	}
+	return nil
+}
+
+// HasChecksum reports whether downloads of a tool configuration can be checksum-verified,
+// either with a checksum pinned in the configuration or one the tool publishes
+func (m *Manager) HasChecksum(toolName string, toolConfig config.ToolConfig) bool {
+	if _, ok := configuredChecksum(toolConfig); ok {
+		return true
+	}
+	resolved, err := m.ResolveVersion(toolName, toolConfig)
+	if err != nil {
+		util.LogVerbose("Cannot look up the checksum of %s %s: %v", toolName, toolConfig.Version, err)
+		return false
+	}
+	return m.lockTool(toolName, toolConfig, resolved).Checksum != nil
+}
+
+// lockTool records a resolved tool, with its download URL and checksum when available
+func (m *Manager) lockTool(toolName string, toolConfig config.ToolConfig, resolved string) LockedTool {
+	locked := LockedTool{
+		Spec:         toolConfig.Version,
+		Version:      resolved,
+		Distribution: toolConfig.Distribution,
+	}
+
+	tool, err := m.GetTool(toolName)
+	if err != nil {
+		return locked
+	}
+	if provider, ok := tool.(DistributionURLProvider); ok {
+		if locked.URL, err = provider.GetDistributionDownloadURL(resolved, toolConfig.Distribution); err != nil {
+			util.LogVerbose("No download URL locked for %s %s: %v", toolName, resolved, err)
+		}
+	} else {
+		locked.URL = tool.GetDownloadURL(resolved)
+	}
+
+	// A checksum pinned in the configuration avoids any network lookup
+	if checksum, ok := configuredChecksum(toolConfig); ok {
+		locked.Checksum = &checksum
+	} else if tool.SupportsChecksumVerification() && locked.URL != "" {
+		resolvedConfig := toolConfig
+		resolvedConfig.Version = resolved
+		if checksum, err := tool.GetChecksum(resolved, resolvedConfig, path.Base(locked.URL)); err == nil && checksum.Value != "" {
+			locked.Checksum = &checksum
+		} else if err != nil {
+			util.LogVerbose("No checksum locked for %s %s: %v", toolName, resolved, err)
+		}
+	}
+	return locked
+}
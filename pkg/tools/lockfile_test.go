@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestLockfile(t *testing.T) {
+	manager := newTestManager(t)
+	alpha := newFakeTool(manager, "alpha", nil)
+	alpha.versions = []string{"1.0.0", "1.1.0", "2.0.0"}
+	manager.RegisterTool(&resolvingFakeTool{alpha})
+
+	projectRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".mvx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{"alpha": {Version: "1"}}}
+
+	lockfile, err := manager.WriteLockfile(cfg, projectRoot)
+	if err != nil {
+		t.Fatalf("WriteLockfile() error = %v", err)
+	}
+	if locked := lockfile.Tools["alpha"]; locked.Spec != "1" || locked.Version != "1.1.0" {
+		t.Errorf("locked alpha = %+v, want 1 -> 1.1.0", locked)
+	}
+
+	// A newer release of the line is ignored while the lockfile pins the resolution
+	alpha.versions = []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0"}
+	manager.versionCache = make(map[string]VersionCacheEntry)
+	if err := manager.UseLockfile(projectRoot); err != nil {
+		t.Fatalf("UseLockfile() error = %v", err)
+	}
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{spec: "1", expected: "1.1.0"},
+		{spec: "2", expected: "2.0.0"}, // The locked specification changed
+	}
+	for _, tt := range tests {
+		resolved, err := manager.ResolveVersion("alpha", config.ToolConfig{Version: tt.spec})
+		if err != nil || resolved != tt.expected {
+			t.Errorf("ResolveVersion(alpha %s) = %q, %v, want %q", tt.spec, resolved, err, tt.expected)
+		}
+	}
+
+	manager.IgnoreLockfile()
+	if resolved, _ := manager.ResolveVersion("alpha", config.ToolConfig{Version: "1"}); resolved != "1.2.0" {
+		t.Errorf("ResolveVersion(alpha 1) ignoring the lockfile = %q, want 1.2.0", resolved)
+	}
+}
+
+func TestLockfileAvoidsNetwork(t *testing.T) {
+	manager := newTestManager(t)
+	transport := &failingTransport{}
+	manager.httpClient = &http.Client{Transport: transport}
+	manager.RegisterTool(NewJavaTool(manager))
+
+	projectRoot := t.TempDir()
+	lockfile := `{"tools": {"java": {"spec": "21", "version": "21.0.5", "distribution": "zulu"}}}`
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".mvx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetLockfilePath(projectRoot), []byte(lockfile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.UseLockfile(projectRoot); err != nil {
+		t.Fatalf("UseLockfile() error = %v", err)
+	}
+
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{"java": {Version: "21", Distribution: "zulu"}}}
+	versions, err := manager.ResolveVersions(cfg)
+	if err != nil {
+		t.Fatalf("ResolveVersions() error = %v", err)
+	}
+	if versions["java"] != "21.0.5" {
+		t.Errorf("ResolveVersions()[java] = %q, want the locked 21.0.5", versions["java"])
+	}
+	if requests := transport.requests.Load(); requests != 0 {
+		t.Errorf("resolution made %d requests, want none", requests)
+	}
+}
+
+func TestLockToolPinnedChecksum(t *testing.T) {
+	manager := newTestManager(t)
+	transport := &failingTransport{}
+	manager.httpClient = &http.Client{Transport: transport}
+	manager.RegisterTool(NewGoTool(manager))
+
+	toolConfig := config.ToolConfig{
+		Version:  "1.22.0",
+		Checksum: &config.ChecksumConfig{Value: "0123456789abcdef"},
+	}
+	locked := manager.lockTool("go", toolConfig, "1.22.0")
+	if locked.Checksum == nil || locked.Checksum.Value != "0123456789abcdef" || locked.Checksum.Type != SHA256 {
+		t.Errorf("locked checksum = %+v, want the pinned sha256", locked.Checksum)
+	}
+	if requests := transport.requests.Load(); requests != 0 {
+		t.Errorf("locking a pinned checksum made %d requests, want none", requests)
+	}
+}
@@ -8,10 +8,13 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gnodet/mvx/pkg/config"
@@ -53,6 +56,49 @@ type Manager struct {
 	httpCache      map[string]HTTPCacheEntry // In-memory HTTP response cache
 	cacheMutex     sync.RWMutex
 	httpClient     *http.Client
+
+	// Per-URL locks, coalescing concurrent requests for the same metadata
+	urlLocks      map[string]*sync.Mutex
+	urlLocksMutex sync.Mutex
+
+	// Version cache saves running in the background
+	pendingSaves sync.WaitGroup
+
+	// Versions pinned by the project's lockfile (nil without lockfile)
+	lockfile *Lockfile
+
+	// Per-tool install semaphores, limiting concurrent installs of the same tool
+	installSlots map[string]chan struct{}
+	slotsMutex   sync.Mutex
+
+	// Target platform when installing tools for another os/arch (nil for the host platform)
+	platform *PlatformInfo
+
+	// Computed environments keyed by a hash of their inputs (loaded lazily from disk)
+	envCache      map[string]EnvironmentCacheEntry
+	envCacheMutex sync.Mutex
+
+	// Installs were explicitly requested, and are performed even when auto-install is disabled
+	explicitInstall bool
+
+	// Expired cached metadata may replace requests that are refused offline, fail or are rate-limited
+	staleMetadata bool
+	// Some metadata was not fetched live: it came from an expired cache entry, or was unavailable
+	metadataUnavailable atomic.Bool
+	// Metadata is fetched live rather than from the disk cache (see ForceRefresh)
+	forceRefresh atomic.Bool
+
+	// Messages of the tools installed in parallel, printed together when each install completes
+	outputs      map[string]*toolOutput
+	outputsMutex sync.Mutex
+
+	// Observers of the install lifecycle events
+	observers      []InstallObserver
+	observersMutex sync.RWMutex
+
+	// Whether tools can be installed in the mvx home directory, checked before the first install
+	writableOnce sync.Once
+	writableErr  error
 }
 
 var (
@@ -118,6 +164,13 @@ type VersionResolver interface {
 	ResolveVersion(version, distribution string) (string, error)
 }
 
+// PreferenceVersionResolver is an optional interface for tools with custom version resolution
+// that can also resolve to the lowest matching version. Other tools are resolved against ListVersions.
+type PreferenceVersionResolver interface {
+	// ResolveVersionWithPreference resolves a version specification to its highest or lowest match
+	ResolveVersionWithPreference(version, distribution string, preference version.Preference) (string, error)
+}
+
 // DistributionProvider is an optional interface for tools that support multiple distributions
 type DistributionProvider interface {
 	// GetDistributions returns available distributions for this tool
@@ -136,6 +189,12 @@ type DependencyProvider interface {
 	GetDependencies() []string
 }
 
+// InstallDirProvider is an optional interface for tools that can report their installation directory
+type InstallDirProvider interface {
+	// GetInstallDir returns the directory where the specified version is installed
+	GetInstallDir(version string, cfg config.ToolConfig) string
+}
+
 // EnvironmentProvider is an optional interface for tools that need custom environment setup
 type EnvironmentProvider interface {
 	// SetupEnvironment sets up tool-specific environment variables
@@ -143,6 +202,62 @@ type EnvironmentProvider interface {
 	SetupEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error
 }
 
+// SecondaryEnvironmentProvider is an optional interface for tools of which a project may configure
+// several versions, under keys made of the tool name and a number (e.g. "java8" next to "java").
+// Only the primary entry is added to PATH and sets up the environment with SetupEnvironment.
+type SecondaryEnvironmentProvider interface {
+	// SetupSecondaryEnvironment sets the variables exposing a version that is not the primary one
+	SetupSecondaryEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error
+}
+
+// PlatformSupportProvider is an optional interface for tools whose versions are not
+// published for every platform. Tools that don't implement it are assumed to support all of them.
+type PlatformSupportProvider interface {
+	// SupportedPlatforms returns the os/arch combinations the specified version is available for
+	SupportedPlatforms(version string) []PlatformInfo
+}
+
+// PackageLister is an optional interface for tools publishing several builds of the same
+// version for a platform (e.g. JDK and JRE, or glibc and musl builds)
+type PackageLister interface {
+	// ListPackages returns the builds of the specified version for the target platform
+	ListPackages(version, distribution string) ([]PackageInfo, error)
+}
+
+// SignatureProvider is an optional interface for tools whose distributions are signed with PGP
+type SignatureProvider interface {
+	// GetSignatureURL returns the URL of the detached signature of a downloaded distribution
+	GetSignatureURL(downloadURL string) string
+
+	// GetSignatureKeyURL returns the URL of the public keys the distributions are signed with
+	GetSignatureKeyURL() string
+}
+
+// FallbackURLProvider is an optional interface for tools whose distributions are published at
+// several locations (e.g. the Apache dist site for current releases and its archive for all)
+type FallbackURLProvider interface {
+	// GetFallbackURLs returns the URLs tried in order when downloading the specified version
+	// from its download URL fails
+	GetFallbackURLs(version string) []string
+}
+
+// VersionCanonicalizer is an optional interface for tools reporting versions in several forms
+// (e.g. Node.js "v18.17.0", or Java "17.0.16+8" with build metadata)
+type VersionCanonicalizer interface {
+	// CanonicalVersion returns the canonical form of a version, used for display and comparisons
+	CanonicalVersion(raw string) string
+}
+
+// PackageInfo describes a build of a tool version
+type PackageInfo struct {
+	ID          string `json:"id"`
+	Filename    string `json:"filename"`
+	Type        string `json:"type,omitempty"`         // e.g. jdk or jre
+	LibC        string `json:"libc,omitempty"`         // e.g. glibc or musl
+	ArchiveType string `json:"archive_type,omitempty"` // e.g. tar.gz or zip
+	Size        int64  `json:"size,omitempty"`         // In bytes, 0 if unknown
+}
+
 // Distribution represents a tool distribution (e.g., Java distributions like Temurin, Zulu)
 type Distribution struct {
 	Name        string
@@ -159,19 +274,48 @@ func NewManager() (*Manager, error) {
 		return globalManager, nil
 	}
 
-	homeDir, err := os.UserHomeDir()
+	manager, err := newManager(nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user home directory: %w", err)
+		return nil, err
 	}
 
-	cacheDir := filepath.Join(homeDir, ".mvx")
+	// Store as global singleton
+	globalManager = manager
+	return manager, nil
+}
+
+// NewManagerForPlatform creates a tool manager installing tools for another platform.
+// Such tools are installed into a platform-tagged directory and are never executed,
+// which allows warming caches for foreign platforms (e.g., multi-arch Docker builds).
+func NewManagerForPlatform(platform PlatformInfo) (*Manager, error) {
+	if err := ValidatePlatform(platform); err != nil {
+		return nil, err
+	}
+	if platform == GetPlatformInfo() {
+		return NewManager()
+	}
+	return newManager(&platform)
+}
+
+// newManager creates and initializes a tool manager for the given target platform
+func newManager(platform *PlatformInfo) (*Manager, error) {
+	cacheDir, err := config.GetMvxHome()
+	if err != nil {
+		return nil, err
+	}
 
 	// Create cache directory if it doesn't exist
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create cache directory %s: %w", cacheDir, err)
+		return nil, fmt.Errorf("failed to create mvx home directory %s (set %s to a writable directory): %w", cacheDir, config.EnvMvxHome, err)
+	}
+
+	transport, err := newHTTPTransport()
+	if err != nil {
+		return nil, err
 	}
 
 	manager := &Manager{
+		platform:       platform,
 		cacheDir:       cacheDir,
 		tools:          make(map[string]Tool),
 		versionCache:   make(map[string]VersionCacheEntry),
@@ -179,8 +323,10 @@ func NewManager() (*Manager, error) {
 		pathCache:      make(map[string]string),
 		httpCache:      make(map[string]HTTPCacheEntry),
 		httpClient: &http.Client{
-			Timeout: getTimeoutFromEnv("MVX_HTTP_TIMEOUT", 120*time.Second), // Default: 2 minutes for slow servers
+			Transport: transport,
+			Timeout:   getNetworkTimeout(EnvHTTPTimeout, 120*time.Second), // Default: 2 minutes for slow servers
 		},
+		observers: []InstallObserver{defaultObserver()},
 	}
 
 	// Create registry after manager is initialized (to avoid circular dependency)
@@ -194,11 +340,30 @@ func NewManager() (*Manager, error) {
 		return nil, fmt.Errorf("failed to register tools: %w", err)
 	}
 
-	// Store as global singleton
-	globalManager = manager
 	return manager, nil
 }
 
+// checkWritableHome fails if tools can't be installed in the mvx home directory. It is only
+// checked before installing, so that a read-only shared tool cache can still be used.
+func (m *Manager) checkWritableHome() error {
+	m.writableOnce.Do(func() {
+		if err := checkWritable(m.cacheDir); err != nil {
+			m.writableErr = fmt.Errorf("mvx home directory %s is not writable (set %s to a writable directory): %w", m.cacheDir, config.EnvMvxHome, err)
+		}
+	})
+	return m.writableErr
+}
+
+// checkWritable fails if files can't be created in a directory
+func checkWritable(dir string) error {
+	probe, err := os.CreateTemp(dir, ".write-test-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
 // ResetManager resets the global manager instance (for testing purposes)
 func ResetManager() {
 	managerMutex.Lock()
@@ -206,39 +371,54 @@ func ResetManager() {
 	globalManager = nil
 }
 
+// SetTimeout sets the timeout of the HTTP requests made with Get
+func (m *Manager) SetTimeout(timeout time.Duration) {
+	m.httpClient.Timeout = timeout
+}
+
+// ApplySettings applies the settings of a project configuration to the manager, unless the
+// environment overrides them (MVX_TIMEOUT or MVX_HTTP_TIMEOUT for the HTTP timeout)
+func (m *Manager) ApplySettings(cfg *config.Config) error {
+	timeout, err := cfg.Settings.GetHTTPTimeout()
+	if err != nil {
+		return err
+	}
+	if timeout > 0 && os.Getenv(EnvTimeout) == "" && os.Getenv(EnvHTTPTimeout) == "" {
+		util.LogVerbose("Using the HTTP timeout of the project settings: %s", timeout)
+		m.SetTimeout(timeout)
+	}
+	return nil
+}
+
 // Get performs an HTTP GET request with verbose logging and multi-level caching
 // This centralizes all HTTP requests and provides visibility into API calls
 // Caching strategy:
 // 1. In-memory cache (5 minutes) - for same execution
 // 2. Disk cache (24 hours) - for all metadata APIs, persists across executions
 // 3. Network request - if not cached
-// Set MVX_FORCE_REFRESH=true to bypass disk cache and force fresh requests
+// Set MVX_FORCE_REFRESH=true (or call ForceRefresh) to bypass disk cache and force fresh requests
+// In offline mode (MVX_OFFLINE=true) it fails immediately with ErrOffline, unless stale
+// metadata is allowed (see AllowStaleMetadata) and the response is cached on disk
 func (m *Manager) Get(url string) (*http.Response, error) {
-	// Check in-memory cache first (fastest)
-	m.cacheMutex.RLock()
-	if cached, ok := m.httpCache[url]; ok {
-		// Cache valid for 5 minutes
-		if time.Since(cached.Timestamp) < 5*time.Minute {
-			m.cacheMutex.RUnlock()
-			if os.Getenv("MVX_VERBOSE") == "true" {
-				fmt.Printf("💾 HTTP GET (memory cache): %s\n", url)
-			}
-			// Return a fake response with cached body
-			return &http.Response{
-				StatusCode: 200,
-				Body:       io.NopCloser(bytes.NewReader(cached.Body)),
-				Header:     make(http.Header),
-			}, nil
+	if IsOffline() {
+		if body, found := m.getStaleMetadata(url); found {
+			return cachedResponse(body), nil
 		}
+		m.metadataUnavailable.Store(true)
+		return nil, fmt.Errorf("%w: refusing GET %s", ErrOffline, url)
+	}
+
+	// Check in-memory cache first (fastest)
+	if resp, found := m.getMemoryCachedResponse(url); found {
+		return resp, nil
 	}
-	m.cacheMutex.RUnlock()
 
 	// Check disk cache (24 hours, unless MVX_FORCE_REFRESH is set)
 	// Cache all metadata API responses (Foojay, GitHub, Node.js, Apache)
-	if os.Getenv("MVX_FORCE_REFRESH") != "true" {
+	if os.Getenv("MVX_FORCE_REFRESH") != "true" && !m.forceRefresh.Load() {
 		if body, found := m.getDiskCachedResponse(url); found {
 			if os.Getenv("MVX_VERBOSE") == "true" {
-				fmt.Printf("💾 HTTP GET (disk cache): %s\n", url)
+				statusf("", "💾 HTTP GET (disk cache): %s\n", url)
 			}
 			// Also store in memory cache for faster subsequent access
 			m.cacheMutex.Lock()
@@ -256,21 +436,41 @@ func (m *Manager) Get(url string) (*http.Response, error) {
 		}
 	}
 
+	// Concurrent requests for the same URL wait for the first one, then use its response
+	unlock := m.lockURL(url)
+	defer unlock()
+	if resp, found := m.getMemoryCachedResponse(url); found {
+		return resp, nil
+	}
+
 	// Log the request if verbose mode is enabled
 	if os.Getenv("MVX_VERBOSE") == "true" {
-		fmt.Printf("🌐 HTTP GET: %s\n", url)
+		statusf("", "🌐 HTTP GET: %s\n", url)
 	}
 
 	resp, err := m.httpClient.Get(url)
 	if err != nil {
 		if os.Getenv("MVX_VERBOSE") == "true" {
-			fmt.Printf("❌ HTTP GET failed: %s - %v\n", url, err)
+			statusf("", "❌ HTTP GET failed: %s - %v\n", url, err)
+		}
+		if body, found := m.getStaleMetadata(url); found {
+			return cachedResponse(body), nil
 		}
+		m.metadataUnavailable.Store(true)
 		return nil, err
 	}
 
+	// Rate limits and server errors
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		if body, found := m.getStaleMetadata(url); found {
+			resp.Body.Close()
+			return cachedResponse(body), nil
+		}
+		m.metadataUnavailable.Store(true)
+	}
+
 	if os.Getenv("MVX_VERBOSE") == "true" {
-		fmt.Printf("✅ HTTP GET %d: %s\n", resp.StatusCode, url)
+		statusf("", "✅ HTTP GET %d: %s\n", resp.StatusCode, url)
 	}
 
 	// Cache successful responses (200 OK)
@@ -306,20 +506,86 @@ func (m *Manager) Get(url string) (*http.Response, error) {
 	return resp, nil
 }
 
+// getMemoryCachedResponse returns a response fetched less than 5 minutes ago
+func (m *Manager) getMemoryCachedResponse(url string) (*http.Response, bool) {
+	m.cacheMutex.RLock()
+	cached, ok := m.httpCache[url]
+	m.cacheMutex.RUnlock()
+	if !ok || time.Since(cached.Timestamp) >= 5*time.Minute {
+		return nil, false
+	}
+
+	if os.Getenv("MVX_VERBOSE") == "true" {
+		statusf("", "💾 HTTP GET (memory cache): %s\n", url)
+	}
+	// Return a fake response with cached body
+	return &http.Response{
+		StatusCode: 200,
+		Body:       io.NopCloser(bytes.NewReader(cached.Body)),
+		Header:     make(http.Header),
+	}, true
+}
+
+// lockURL serializes the requests made for a URL, returning the function releasing it
+func (m *Manager) lockURL(url string) func() {
+	m.urlLocksMutex.Lock()
+	if m.urlLocks == nil {
+		m.urlLocks = make(map[string]*sync.Mutex)
+	}
+	lock, ok := m.urlLocks[url]
+	if !ok {
+		lock = &sync.Mutex{}
+		m.urlLocks[url] = lock
+	}
+	m.urlLocksMutex.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
 // RegisterTool registers a tool with the manager
 func (m *Manager) RegisterTool(tool Tool) {
 	m.tools[tool.GetToolName()] = tool
 }
 
-// GetTool returns a tool by name
+// GetTool returns a tool by name, or by the key of an additional version of it (e.g. "java8")
 func (m *Manager) GetTool(name string) (Tool, error) {
-	tool, exists := m.tools[name]
+	tool, exists := m.tools[m.toolNameForKey(name)]
 	if !exists {
 		return nil, fmt.Errorf("unknown tool: %s", name)
 	}
 	return tool, nil
 }
 
+// toolNameForKey returns the name of the tool configured under a key: the key itself, or for the
+// additional versions of tools supporting several ones, the key without its numeric suffix
+func (m *Manager) toolNameForKey(key string) string {
+	if _, exists := m.tools[key]; exists {
+		return key
+	}
+	name := strings.TrimRight(key, "0123456789")
+	if name == key {
+		return key
+	}
+	if _, ok := m.tools[name].(SecondaryEnvironmentProvider); ok {
+		return name
+	}
+	return key
+}
+
+// primaryToolKeys returns, for each tool configured under several keys, the key of its primary
+// version: the tool name itself if configured, otherwise the first key in alphabetical order
+func (m *Manager) primaryToolKeys(cfg *config.Config) map[string]string {
+	primary := make(map[string]string)
+	for key := range cfg.Tools {
+		name := m.toolNameForKey(key)
+		if current, exists := primary[name]; !exists || key == name || (current != name && key < current) {
+			primary[name] = key
+		}
+	}
+	return primary
+}
+
 // GetAllTools returns all registered tools
 func (m *Manager) GetAllTools() map[string]Tool {
 	result := make(map[string]Tool)
@@ -345,11 +611,17 @@ type ToolFactory func(*Manager) Tool
 // toolFactories contains all available tool factories for auto-discovery
 // This registry allows tools to be registered dynamically, following the Open/Closed Principle
 var toolFactories = map[string]ToolFactory{
-	ToolJava:  func(m *Manager) Tool { return NewJavaTool(m) },
-	ToolMaven: func(m *Manager) Tool { return NewMavenTool(m) },
-	ToolMvnd:  func(m *Manager) Tool { return NewMvndTool(m) },
-	ToolNode:  func(m *Manager) Tool { return NewNodeTool(m) },
-	ToolGo:    func(m *Manager) Tool { return NewGoTool(m) },
+	ToolJava:    func(m *Manager) Tool { return NewJavaTool(m) },
+	ToolMaven:   func(m *Manager) Tool { return NewMavenTool(m) },
+	ToolMvnd:    func(m *Manager) Tool { return NewMvndTool(m) },
+	ToolNode:    func(m *Manager) Tool { return NewNodeTool(m) },
+	ToolGo:      func(m *Manager) Tool { return NewGoTool(m) },
+	ToolClojure: func(m *Manager) Tool { return NewClojureTool(m) },
+	ToolGradle:  func(m *Manager) Tool { return NewGradleTool(m) },
+	ToolRust:    func(m *Manager) Tool { return NewRustTool(m) },
+	ToolKotlin:  func(m *Manager) Tool { return NewKotlinTool(m) },
+	ToolDeno:    func(m *Manager) Tool { return NewDenoTool(m) },
+	ToolBun:     func(m *Manager) Tool { return NewBunTool(m) },
 }
 
 // discoverAndRegisterTools automatically discovers and registers all available tools
@@ -395,6 +667,26 @@ func (m *Manager) SearchToolVersions(toolName string, filters []string) ([]strin
 		return nil, fmt.Errorf("failed to get versions for %s: %w", toolName, err)
 	}
 
+	// Without live metadata, versions resolved earlier complement the tool's fallback list
+	if m.UsedStaleMetadata() {
+		versions = m.addCachedResolutions(toolName, versions)
+	}
+
+	// Hide versions that aren't published for the target platform
+	if _, ok := tool.(PlatformSupportProvider); ok {
+		platform := m.GetPlatform()
+		available := make([]string, 0, len(versions))
+		for _, version := range versions {
+			if m.CheckPlatformSupport(tool, version, platform) == nil {
+				available = append(available, version)
+			}
+		}
+		if hidden := len(versions) - len(available); hidden > 0 {
+			util.LogVerbose("Hiding %d %s versions not available for %s", hidden, toolName, platform)
+		}
+		versions = available
+	}
+
 	// Apply filters if provided
 	if len(filters) > 0 {
 		filtered := make([]string, 0)
@@ -412,6 +704,87 @@ func (m *Manager) SearchToolVersions(toolName string, filters []string) ([]strin
 	return versions, nil
 }
 
+// ListToolPackages lists the builds of the configured tool version for the target platform
+func (m *Manager) ListToolPackages(toolName string, cfg config.ToolConfig) ([]PackageInfo, error) {
+	tool, err := m.GetTool(toolName)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := tool.(PackageLister)
+	if !ok {
+		return nil, fmt.Errorf("%s does not publish multiple packages per version", toolName)
+	}
+
+	resolvedVersion, err := m.ResolveVersion(toolName, cfg)
+	if err != nil {
+		return nil, err
+	}
+	packages, err := lister.ListPackages(resolvedVersion, cfg.Distribution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages for %s %s: %w", toolName, resolvedVersion, err)
+	}
+	return packages, nil
+}
+
+// GetVersionCompletions returns version specifications suitable for shell completion.
+// It never hits the network: it combines common specs ("latest", "lts") with installed
+// versions, versions from the resolution cache, and the major versions derived from them.
+func (m *Manager) GetVersionCompletions(toolName string) []string {
+	completions := []string{"latest"}
+	if toolName == ToolJava || toolName == ToolNode {
+		completions = append(completions, "lts")
+	}
+
+	var known []string
+
+	// Installed versions (directory names may carry a @distribution suffix)
+	if entries, err := os.ReadDir(m.GetToolDir(toolName)); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				versionPart, _, _ := strings.Cut(entry.Name(), "@")
+				known = append(known, versionPart)
+			}
+		}
+	}
+
+	// Versions from the resolution cache
+	m.cacheMutex.RLock()
+	for key, entry := range m.versionCache {
+		if strings.HasPrefix(key, toolName+":") {
+			known = append(known, entry.ResolvedVersion)
+		}
+	}
+	m.cacheMutex.RUnlock()
+
+	seen := make(map[string]bool)
+	for _, c := range completions {
+		seen[c] = true
+	}
+
+	// Recent major versions first, then concrete versions, newest first
+	var majors []string
+	sorted := version.SortVersions(known)
+	for _, v := range sorted {
+		if parsed, err := version.ParseVersion(v); err == nil {
+			major := strconv.Itoa(parsed.Major)
+			if !seen[major] {
+				seen[major] = true
+				majors = append(majors, major)
+			}
+		}
+	}
+	completions = append(completions, majors...)
+
+	for _, v := range sorted {
+		if !seen[v] {
+			seen[v] = true
+			completions = append(completions, v)
+		}
+	}
+
+	return completions
+}
+
 // GetToolInfo returns detailed information about a tool
 func (m *Manager) GetToolInfo(toolName string) (map[string]interface{}, error) {
 	tool, err := m.GetTool(toolName)
@@ -466,23 +839,90 @@ func (m *Manager) ValidateToolVersion(toolName, version, distribution string) er
 	// Check if resolved version exists
 	for _, v := range versions {
 		if v == resolvedVersion {
-			return nil
+			return m.CheckPlatformSupport(tool, resolvedVersion, m.GetPlatform())
 		}
 	}
 
 	return fmt.Errorf("version %s (resolved to %s) not found for tool %s", version, resolvedVersion, toolName)
 }
 
-// GetDefaultConcurrency returns the default concurrency level from environment or default
-func GetDefaultConcurrency() int {
-	if concStr := os.Getenv("MVX_PARALLEL_DOWNLOADS"); concStr != "" {
+// CheckPlatformSupport returns an error if a tool version isn't available for the platform,
+// so that users get a precise message instead of a failed download
+func (m *Manager) CheckPlatformSupport(tool Tool, version string, platform PlatformInfo) error {
+	provider, ok := tool.(PlatformSupportProvider)
+	if !ok {
+		return nil
+	}
+
+	supported := provider.SupportedPlatforms(version)
+	names := make([]string, 0, len(supported))
+	for _, p := range supported {
+		if p == platform {
+			return nil
+		}
+		names = append(names, p.OS+"/"+p.Arch)
+	}
+
+	if len(names) == 0 {
+		return fmt.Errorf("%s %s isn't available for %s/%s", tool.GetToolName(), version, platform.OS, platform.Arch)
+	}
+	return fmt.Errorf("%s %s isn't available for %s/%s (available for: %s)", tool.GetToolName(), version, platform.OS, platform.Arch, strings.Join(names, ", "))
+}
+
+// GetDefaultConcurrency returns the default concurrency level from MVX_PARALLEL_DOWNLOADS, or
+// else from the settings of cfg (which may be nil), or else the default
+func GetDefaultConcurrency(cfg *config.Config) int {
+	if concStr := os.Getenv(EnvParallelDownloads); concStr != "" {
 		if conc, err := strconv.Atoi(concStr); err == nil && conc > 0 {
 			return conc
 		}
 	}
+	if cfg != nil && cfg.Settings != nil && cfg.Settings.ParallelDownloads > 0 {
+		return cfg.Settings.ParallelDownloads
+	}
 	return 3 // Default to 3 concurrent downloads
 }
 
+// getToolConcurrencyEnvVar returns the environment variable limiting concurrent installs of a tool
+func getToolConcurrencyEnvVar(toolName string) string {
+	return fmt.Sprintf("MVX_TOOL_CONCURRENCY_%s", strings.ToUpper(toolName))
+}
+
+// GetToolConcurrency returns the maximum number of concurrent installs allowed for a tool,
+// as configured by MVX_TOOL_CONCURRENCY_<TOOL>. Zero means no per-tool limit.
+func GetToolConcurrency(toolName string) int {
+	if concStr := os.Getenv(getToolConcurrencyEnvVar(toolName)); concStr != "" {
+		if conc, err := strconv.Atoi(concStr); err == nil && conc > 0 {
+			return conc
+		}
+		util.LogVerbose("Ignoring invalid %s value: %s", getToolConcurrencyEnvVar(toolName), concStr)
+	}
+	return 0
+}
+
+// acquireInstallSlot blocks until an install slot is available for the tool and
+// returns a function releasing it. Tools without a per-tool limit never block.
+func (m *Manager) acquireInstallSlot(toolName string) func() {
+	limit := GetToolConcurrency(toolName)
+	if limit <= 0 {
+		return func() {}
+	}
+
+	m.slotsMutex.Lock()
+	if m.installSlots == nil {
+		m.installSlots = make(map[string]chan struct{})
+	}
+	slots, exists := m.installSlots[toolName]
+	if !exists || cap(slots) != limit {
+		slots = make(chan struct{}, limit)
+		m.installSlots[toolName] = slots
+	}
+	m.slotsMutex.Unlock()
+
+	slots <- struct{}{}
+	return func() { <-slots }
+}
+
 // EnsureTools ensures all tools from configuration are installed (with parallel downloads)
 // This replaces InstallTools and uses EnsureTool for automatic installation
 func (m *Manager) EnsureTools(cfg *config.Config, maxConcurrent int) error {
@@ -491,69 +931,191 @@ func (m *Manager) EnsureTools(cfg *config.Config, maxConcurrent int) error {
 	}
 
 	if maxConcurrent <= 0 {
-		maxConcurrent = GetDefaultConcurrency()
+		maxConcurrent = GetDefaultConcurrency(cfg)
 	}
 
-	// Resolve dependency order
-	orderedTools, err := m.resolveDependencyOrder(cfg)
+	// Group tools into dependency tiers
+	tiers, err := m.resolveDependencyTiers(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to resolve tool dependencies: %w", err)
 	}
 
 	// If only one tool, use sequential
-	if len(orderedTools) == 1 {
-		toolName := orderedTools[0]
+	if len(cfg.Tools) == 1 {
+		toolName := tiers[0][0]
 		toolConfig := cfg.Tools[toolName]
 		_, err := m.EnsureTool(toolName, toolConfig)
 		if err != nil {
 			return fmt.Errorf("failed to ensure %s is installed: %w", toolName, err)
 		}
-		fmt.Printf("✅ %s is ready\n", toolName)
+		statusf(toolName, "✅ %s is ready\n", toolName)
+		m.updateMavenToolchains(cfg)
 		return nil
 	}
 
-	fmt.Printf("📦 Ensuring %d tools are installed (max %d concurrent)...\n", len(cfg.Tools), maxConcurrent)
+	statusf("", "📦 Ensuring %d tools are installed (max %d concurrent)...\n", len(cfg.Tools), maxConcurrent)
+	if _, err := m.ensureToolTiers(cfg, tiers, maxConcurrent, false); err != nil {
+		return err
+	}
 
-	// Install tools in dependency order
-	// Tools with dependencies must be installed sequentially, but independent tools can be parallel
+	statusf("", "✅ All %d tools are ready\n", len(cfg.Tools))
+	m.updateMavenToolchains(cfg)
+	return nil
+}
+
+// ensureToolTiers installs the tiers of tools in dependency order, so that tools such as
+// Maven find Java installed when they are verified. Only the tools of a tier are installed
+// concurrently. Without keepGoing it stops at the first failure, otherwise it records every
+// failure and skips the tools depending on a failed one.
+func (m *Manager) ensureToolTiers(cfg *config.Config, tiers [][]string, maxConcurrent int, keepGoing bool) ([]ToolInstallResult, error) {
+	results := make([]ToolInstallResult, 0, len(cfg.Tools))
+	failed := make(map[string]bool)
+	var completedMutex sync.Mutex
 	completed := 0
-	for _, toolName := range orderedTools {
-		toolConfig := cfg.Tools[toolName]
+	for _, tier := range tiers {
+		// Tools depending on a failed tool are not attempted
+		var runnable []string
+		for _, toolName := range tier {
+			var failedDeps []string
+			for _, dep := range m.getToolDependencies(toolName, cfg) {
+				if failed[dep] {
+					failedDeps = append(failedDeps, dep)
+				}
+			}
+			if len(failedDeps) == 0 {
+				runnable = append(runnable, toolName)
+				continue
+			}
+			err := fmt.Errorf("skipped because %s failed", strings.Join(failedDeps, ", "))
+			statusf(toolName, "  ⏭️  %s %v\n", toolName, err)
+			failed[toolName] = true
+			results = append(results, ToolInstallResult{ToolName: toolName, Error: err})
+		}
 
-		if _, err := m.EnsureTool(toolName, toolConfig); err != nil {
-			return fmt.Errorf("failed to ensure %s is installed: %w", toolName, err)
+		// The messages of concurrent installs are grouped per tool, and printed with its status
+		grouped := maxConcurrent > 1 && len(runnable) > 1 && !util.IsJSONLog()
+		errs := make([]error, len(runnable))
+		slots := make(chan struct{}, maxConcurrent)
+		var wg sync.WaitGroup
+		for i, toolName := range runnable {
+			wg.Add(1)
+			go func(i int, toolName string) {
+				defer wg.Done()
+				slots <- struct{}{}
+				defer func() { <-slots }()
+				if !grouped {
+					_, errs[i] = m.EnsureTool(toolName, cfg.Tools[toolName])
+					return
+				}
+
+				m.groupOutput(toolName)
+				_, errs[i] = m.EnsureTool(toolName, cfg.Tools[toolName])
+				lines := m.releaseOutput(toolName)
+				completedMutex.Lock()
+				defer completedMutex.Unlock()
+				if errs[i] == nil {
+					completed++
+					lines += fmt.Sprintf("  ✅ %s is ready (%d/%d tools)\n", toolName, completed, len(cfg.Tools))
+				}
+				consoleOutput.printLines(lines)
+			}(i, toolName)
 		}
+		wg.Wait()
 
-		completed++
-		fmt.Printf("  ✅ %s is ready (%d/%d tools)\n", toolName, completed, len(cfg.Tools))
+		for i, toolName := range runnable {
+			if errs[i] != nil {
+				if !keepGoing {
+					return nil, fmt.Errorf("failed to ensure %s is installed: %w", toolName, errs[i])
+				}
+				statusf(toolName, "  ❌ %s failed: %v\n", toolName, errs[i])
+				failed[toolName] = true
+			} else if !grouped {
+				completed++
+				statusf(toolName, "  ✅ %s is ready (%d/%d tools)\n", toolName, completed, len(cfg.Tools))
+			}
+			results = append(results, ToolInstallResult{ToolName: toolName, Error: errs[i]})
+		}
 	}
+	return results, nil
+}
 
-	fmt.Printf("✅ All %d tools are ready\n", len(cfg.Tools))
-	return nil
+// CheckToolCompatibility returns warnings about known-incompatible tool combinations in the configuration,
+// such as an mvnd version embedding a different Maven major version than the configured maven tool
+func (m *Manager) CheckToolCompatibility(cfg *config.Config) []string {
+	var warnings []string
+
+	mavenConfig, hasMaven := cfg.Tools[ToolMaven]
+	mvndConfig, hasMvnd := cfg.Tools[ToolMvnd]
+	if hasMaven && hasMvnd {
+		if tool, err := m.GetTool(ToolMvnd); err == nil {
+			if mvndTool, ok := tool.(*MvndTool); ok {
+				if warning := mvndTool.CheckMavenCompatibility(mavenConfig.Version, mvndConfig.Version); warning != "" {
+					warnings = append(warnings, warning)
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// ToolInstallResult records the outcome of ensuring a single tool is installed
+type ToolInstallResult struct {
+	ToolName string
+	Error    error
+}
+
+// EnsureToolsKeepGoing ensures all tools from configuration are installed like EnsureTools,
+// attempting every tool even when others fail; tools depending on a failed tool are skipped.
+// It returns one result per tool, in installation order, so that callers can report all
+// failures at once instead of stopping at the first one.
+func (m *Manager) EnsureToolsKeepGoing(cfg *config.Config, maxConcurrent int) ([]ToolInstallResult, error) {
+	if len(cfg.Tools) == 0 {
+		return nil, nil
+	}
+
+	if maxConcurrent <= 0 {
+		maxConcurrent = GetDefaultConcurrency(cfg)
+	}
+
+	// Group tools into dependency tiers
+	tiers, err := m.resolveDependencyTiers(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve tool dependencies: %w", err)
+	}
+
+	statusf("", "📦 Ensuring %d tools are installed (max %d concurrent, keep going on failures)...\n", len(cfg.Tools), maxConcurrent)
+	results, err := m.ensureToolTiers(cfg, tiers, maxConcurrent, true)
+	if err != nil {
+		return nil, err
+	}
+
+	m.updateMavenToolchains(cfg)
+	return results, nil
 }
 
-// resolveDependencyOrder resolves the installation order for tools based on their dependencies
-// Uses a simple topological sort with Kahn's algorithm
-func (m *Manager) resolveDependencyOrder(cfg *config.Config) ([]string, error) {
+// resolveDependencyTiers groups tools into tiers to install one after the other: the
+// configured dependencies of a tool are all in earlier tiers, so that only the tools of a
+// tier can be installed concurrently. Uses Kahn's algorithm, taking all the tools whose
+// dependencies are processed at each round; tools are sorted by name within a tier.
+func (m *Manager) resolveDependencyTiers(cfg *config.Config) ([][]string, error) {
 	// Build dependency map: tool -> list of dependencies
 	deps := make(map[string][]string)
 	for toolName := range cfg.Tools {
 		deps[toolName] = m.getToolDependencies(toolName, cfg)
 	}
 
-	// Topological sort: process tools with no remaining dependencies first
-	var result []string
+	var tiers [][]string
 	processed := make(map[string]bool)
 
-	for len(result) < len(cfg.Tools) {
-		// Find a tool with all dependencies already processed
-		found := false
+	for len(processed) < len(cfg.Tools) {
+		// Find the tools with all dependencies already processed
+		var tier []string
 		for toolName := range cfg.Tools {
 			if processed[toolName] {
 				continue
 			}
 
-			// Check if all dependencies are processed
 			allDepsProcessed := true
 			for _, dep := range deps[toolName] {
 				if !processed[dep] {
@@ -561,22 +1123,55 @@ func (m *Manager) resolveDependencyOrder(cfg *config.Config) ([]string, error) {
 					break
 				}
 			}
-
 			if allDepsProcessed {
-				result = append(result, toolName)
-				processed[toolName] = true
-				found = true
-				break
+				tier = append(tier, toolName)
 			}
 		}
 
 		// If no tool can be processed, we have a circular dependency
-		if !found {
-			return nil, fmt.Errorf("circular dependency detected among tools")
+		if len(tier) == 0 {
+			var remaining []string
+			for toolName := range cfg.Tools {
+				if !processed[toolName] {
+					remaining = append(remaining, toolName)
+				}
+			}
+			sort.Strings(remaining)
+			return nil, fmt.Errorf("circular dependency detected among tools: %s", strings.Join(remaining, ", "))
 		}
+
+		sort.Strings(tier)
+		for _, toolName := range tier {
+			processed[toolName] = true
+		}
+		tiers = append(tiers, tier)
 	}
 
-	return result, nil
+	return tiers, nil
+}
+
+// ToolsRequiredFor returns the configuration with only the tools needed to run a command,
+// according to their required_for lists, along with the configured tools they depend on
+func (m *Manager) ToolsRequiredFor(cfg *config.Config, command string) *config.Config {
+	required := *cfg
+	required.Tools = make(map[string]config.ToolConfig, len(cfg.Tools))
+
+	var add func(toolName string)
+	add = func(toolName string) {
+		if _, added := required.Tools[toolName]; added {
+			return
+		}
+		required.Tools[toolName] = cfg.Tools[toolName]
+		for _, dep := range m.getToolDependencies(toolName, cfg) {
+			add(dep)
+		}
+	}
+	for toolName, toolConfig := range cfg.Tools {
+		if toolConfig.IsRequiredFor(command) {
+			add(toolName)
+		}
+	}
+	return &required
 }
 
 // getToolDependencies returns the list of dependencies for a tool that are configured in this project
@@ -594,10 +1189,13 @@ func (m *Manager) getToolDependencies(toolName string, cfg *config.Config) []str
 	// Filter dependencies to only include those configured in this project
 	var configuredDeps []string
 	for _, dep := range depProvider.GetDependencies() {
-		if _, exists := cfg.Tools[dep]; exists {
-			configuredDeps = append(configuredDeps, dep)
+		for key := range cfg.Tools {
+			if m.toolNameForKey(key) == dep {
+				configuredDeps = append(configuredDeps, key)
+			}
 		}
 	}
+	sort.Strings(configuredDeps)
 
 	return configuredDeps
 }
@@ -606,17 +1204,21 @@ func (m *Manager) getToolDependencies(toolName string, cfg *config.Config) []str
 func (m *Manager) GetToolsNeedingInstallation(cfg *config.Config) (map[string]config.ToolConfig, error) {
 	needInstallation := make(map[string]config.ToolConfig)
 
-	for toolName, toolConfig := range cfg.Tools {
-		tool, err := m.GetTool(toolName)
-		if err != nil {
+	for toolName := range cfg.Tools {
+		if _, err := m.GetTool(toolName); err != nil {
 			return nil, fmt.Errorf("unknown tool %s: %w", toolName, err)
 		}
+	}
 
-		// Resolve version specification to concrete version
-		resolvedVersion, err := m.resolveVersion(toolName, toolConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to resolve version for %s: %w", toolName, err)
-		}
+	// Resolve version specifications to concrete versions, sharing API requests
+	resolvedVersions, err := m.ResolveVersions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	for toolName, toolConfig := range cfg.Tools {
+		tool, _ := m.GetTool(toolName)
+		resolvedVersion := resolvedVersions[toolName]
 
 		// Update config with resolved version for checking
 		resolvedConfig := toolConfig
@@ -630,6 +1232,12 @@ func (m *Manager) GetToolsNeedingInstallation(cfg *config.Config) (map[string]co
 	return needInstallation, nil
 }
 
+// HomeEntries returns the names of the files and directories the tool manager creates in the
+// mvx home directory: installed tools and caches
+func HomeEntries() []string {
+	return []string{"tools", "platforms", "http_cache", "version_cache.json", envCacheFile}
+}
+
 // GetCacheDir returns the cache directory path
 func (m *Manager) GetCacheDir() string {
 	return m.cacheDir
@@ -637,9 +1245,35 @@ func (m *Manager) GetCacheDir() string {
 
 // GetToolsDir returns the tools directory path
 func (m *Manager) GetToolsDir() string {
+	if m.IsForeignPlatform() {
+		return filepath.Join(m.cacheDir, "platforms", m.platform.String(), "tools")
+	}
 	return filepath.Join(m.cacheDir, "tools")
 }
 
+// GetPlatform returns the platform tools are installed for
+func (m *Manager) GetPlatform() PlatformInfo {
+	if m.platform != nil {
+		return *m.platform
+	}
+	return GetPlatformInfo()
+}
+
+// IsForeignPlatform returns true if tools are installed for a platform other than the host
+func (m *Manager) IsForeignPlatform() bool {
+	return m.platform != nil && *m.platform != GetPlatformInfo()
+}
+
+// GetPlatformMapper returns a platform mapper for the platform tools are installed for
+func (m *Manager) GetPlatformMapper() *PlatformMapper {
+	return NewPlatformMapperFor(m.GetPlatform())
+}
+
+// GetPathResolver returns a path resolver for the manager's tools directory and target platform
+func (m *Manager) GetPathResolver() *PathResolver {
+	return NewPathResolverFor(m.GetToolsDir(), m.GetPlatformMapper())
+}
+
 // GetToolDir returns the directory for a specific tool
 func (m *Manager) GetToolDir(toolName string) string {
 	return filepath.Join(m.GetToolsDir(), toolName)
@@ -693,10 +1327,12 @@ func (m *Manager) isToolInstalled(toolName, version string, cfg config.ToolConfi
 // All in one atomic, cached operation.
 func (m *Manager) EnsureTool(toolName string, cfg config.ToolConfig) (string, error) {
 	// Resolve version
+	m.notify(InstallEvent{Type: EventResolveStart, Tool: toolName, Version: cfg.Version})
 	resolvedVersion, err := m.resolveVersion(toolName, cfg)
 	if err != nil {
-		return "", fmt.Errorf("failed to resolve version for %s: %w", toolName, err)
+		return "", m.notifyError(toolName, cfg.Version, fmt.Errorf("failed to resolve version for %s: %w", toolName, err))
 	}
+	m.notify(InstallEvent{Type: EventResolveDone, Tool: toolName, Version: resolvedVersion})
 
 	resolvedConfig := cfg
 	resolvedConfig.Version = resolvedVersion
@@ -719,22 +1355,18 @@ func (m *Manager) EnsureTool(toolName string, cfg config.ToolConfig) (string, er
 
 	// Check if installed
 	if !tool.IsInstalled(resolvedVersion, resolvedConfig) {
-		// Auto-install
-		util.LogVerbose("Auto-installing %s %s...", toolName, resolvedVersion)
-		if err := tool.Install(resolvedVersion, resolvedConfig); err != nil {
-			return "", fmt.Errorf("failed to install %s %s: %w", toolName, resolvedVersion, err)
+		if IsAutoInstallDisabled() && !m.explicitInstall && !UseSystemTool(toolName) {
+			return "", m.notifyError(toolName, resolvedVersion, NotInstalledError(toolName, resolvedVersion))
 		}
-
-		// Verify installation
-		if err := tool.Verify(resolvedVersion, resolvedConfig); err != nil {
-			return "", fmt.Errorf("failed to verify %s %s: %w", toolName, resolvedVersion, err)
+		if err := m.installAndVerify(tool, resolvedVersion, resolvedConfig); err != nil {
+			return "", err
 		}
 	}
 
 	// Get path
 	path, err := tool.GetPath(resolvedVersion, resolvedConfig)
 	if err != nil {
-		return "", fmt.Errorf("failed to get path for %s %s: %w", toolName, resolvedVersion, err)
+		return "", m.notifyError(toolName, resolvedVersion, fmt.Errorf("failed to get path for %s %s: %w", toolName, resolvedVersion, err))
 	}
 
 	// Cache the result
@@ -746,8 +1378,314 @@ func (m *Manager) EnsureTool(toolName string, cfg config.ToolConfig) (string, er
 	return path, nil
 }
 
+// FindToolBinary returns the absolute path of the binary mvx runs for a tool, installing the
+// tool first when install is set. A system tool (MVX_USE_SYSTEM_<TOOL>) is looked up in PATH.
+func (m *Manager) FindToolBinary(toolName string, cfg config.ToolConfig, install bool) (string, error) {
+	tool, err := m.GetTool(toolName)
+	if err != nil {
+		return "", err
+	}
+
+	var binDir string
+	if install {
+		if binDir, err = m.EnsureTool(toolName, cfg); err != nil {
+			return "", err
+		}
+	} else {
+		resolvedVersion, err := m.resolveVersion(toolName, cfg)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve version for %s: %w", toolName, err)
+		}
+		resolvedConfig := cfg
+		resolvedConfig.Version = resolvedVersion
+		if !UseSystemTool(toolName) && !tool.IsInstalled(resolvedVersion, resolvedConfig) {
+			return "", fmt.Errorf("%s %s is not installed; run 'mvx setup' to install it", toolName, resolvedVersion)
+		}
+		if binDir, err = tool.GetPath(resolvedVersion, resolvedConfig); err != nil {
+			return "", fmt.Errorf("failed to get path for %s %s: %w", toolName, resolvedVersion, err)
+		}
+	}
+
+	// System tools have no bin directory: they are found in PATH
+	if binDir == "" {
+		path, err := exec.LookPath(tool.GetBinaryName())
+		if err != nil {
+			return "", fmt.Errorf("system %s not found in PATH: %w", toolName, err)
+		}
+		return filepath.Abs(path)
+	}
+	path, found := m.GetPlatformMapper().FindBinary(binDir, tool.GetBinaryName())
+	if !found {
+		return "", fmt.Errorf("%s binary not found in %s", tool.GetBinaryName(), binDir)
+	}
+	return filepath.Abs(path)
+}
+
+// CanonicalVersion returns the canonical form of a version of a tool, so that versions are
+// displayed consistently and e.g. "v18.17.0" and "18.17.0" compare equal
+func (m *Manager) CanonicalVersion(toolName, raw string) string {
+	raw = strings.TrimSpace(raw)
+	tool, err := m.GetTool(toolName)
+	if err != nil {
+		return raw
+	}
+	if canonicalizer, ok := tool.(VersionCanonicalizer); ok {
+		return canonicalizer.CanonicalVersion(raw)
+	}
+	return raw
+}
+
+// ReinstallTool removes an existing installation of a tool and installs it again,
+// bypassing the installed and path caches. It returns the binary path of the fresh install.
+func (m *Manager) ReinstallTool(toolName string, cfg config.ToolConfig) (string, error) {
+	if UseSystemTool(toolName) {
+		return "", fmt.Errorf("cannot reinstall %s: %s=true (using system tool)", toolName, getSystemToolEnvVar(toolName))
+	}
+
+	resolvedVersion, err := m.resolveVersion(toolName, cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve version for %s: %w", toolName, err)
+	}
+
+	tool, err := m.GetTool(toolName)
+	if err != nil {
+		return "", err
+	}
+
+	resolvedConfig := cfg
+	resolvedConfig.Version = resolvedVersion
+
+	installDir := m.getInstallDir(tool, resolvedVersion, resolvedConfig)
+
+	util.LogVerbose("Removing existing installation of %s %s: %s", toolName, resolvedVersion, installDir)
+	if err := os.RemoveAll(installDir); err != nil {
+		return "", fmt.Errorf("failed to remove existing installation of %s %s: %w", toolName, resolvedVersion, err)
+	}
+
+	m.clearToolCaches(tool, resolvedVersion, cfg.Distribution)
+
+	path, err := m.EnsureTool(toolName, cfg)
+	if err != nil {
+		return "", err
+	}
+
+	// Clear caches again so that later lookups observe the fresh installation
+	m.clearToolCaches(tool, resolvedVersion, cfg.Distribution)
+	return path, nil
+}
+
+// UninstallTool removes the installation of a tool version, or every installed version
+// of the tool when version is empty, and clears the matching cache entries.
+func (m *Manager) UninstallTool(toolName, version, distribution string) error {
+	tool, err := m.GetTool(toolName)
+	if err != nil {
+		return err
+	}
+
+	if version == "" {
+		toolDir := m.GetToolDir(toolName)
+		util.LogVerbose("Removing all installations of %s: %s", toolName, toolDir)
+		if err := os.RemoveAll(toolDir); err != nil {
+			return fmt.Errorf("failed to remove installations of %s: %w", toolName, err)
+		}
+
+		prefix := toolName + ":"
+		m.cacheMutex.Lock()
+		for key := range m.installedCache {
+			if strings.HasPrefix(key, prefix) {
+				delete(m.installedCache, key)
+			}
+		}
+		for key := range m.pathCache {
+			if strings.HasPrefix(key, prefix) {
+				delete(m.pathCache, key)
+			}
+		}
+		m.cacheMutex.Unlock()
+
+		if cacheable, ok := tool.(interface{ clearPathCache() }); ok {
+			cacheable.clearPathCache()
+		}
+		return nil
+	}
+
+	installDir := m.getInstallDir(tool, version, config.ToolConfig{Version: version, Distribution: distribution})
+	util.LogVerbose("Removing installation of %s %s: %s", toolName, version, installDir)
+	if err := os.RemoveAll(installDir); err != nil {
+		return fmt.Errorf("failed to remove installation of %s %s: %w", toolName, version, err)
+	}
+
+	m.clearToolCaches(tool, version, distribution)
+	return nil
+}
+
+// PlatformMetadataFile is written into installations made for a foreign platform
+const PlatformMetadataFile = ".mvx-platform.json"
+
+// PlatformMetadata records the platform a foreign installation was made for
+type PlatformMetadata struct {
+	Tool         string `json:"tool"`
+	Version      string `json:"version"`
+	Distribution string `json:"distribution,omitempty"`
+	OS           string `json:"os"`
+	Arch         string `json:"arch"`
+}
+
+// InstallForPlatform installs a tool for the manager's target platform without executing it.
+// It is meant for managers created with NewManagerForPlatform and returns the installation directory.
+func (m *Manager) InstallForPlatform(toolName string, cfg config.ToolConfig) (string, error) {
+	resolvedVersion, err := m.resolveVersion(toolName, cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve version for %s: %w", toolName, err)
+	}
+
+	tool, err := m.GetTool(toolName)
+	if err != nil {
+		return "", err
+	}
+
+	resolvedConfig := cfg
+	resolvedConfig.Version = resolvedVersion
+	installDir := m.getInstallDir(tool, resolvedVersion, resolvedConfig)
+	metadataFile := filepath.Join(installDir, PlatformMetadataFile)
+
+	// A metadata file is only written once the installation completed
+	if _, err := os.Stat(metadataFile); err == nil {
+		util.LogVerbose("%s %s for %s already installed in %s", toolName, resolvedVersion, m.GetPlatform(), installDir)
+		return installDir, nil
+	}
+
+	if err := m.CheckPlatformSupport(tool, resolvedVersion, m.GetPlatform()); err != nil {
+		return "", err
+	}
+
+	if err := m.checkWritableHome(); err != nil {
+		return "", err
+	}
+	release := m.acquireInstallSlot(toolName)
+	defer release()
+
+	// Verification is skipped by the tools, as foreign binaries cannot be executed
+	if err := tool.Install(resolvedVersion, resolvedConfig); err != nil {
+		return "", fmt.Errorf("failed to install %s %s for %s: %w", toolName, resolvedVersion, m.GetPlatform(), err)
+	}
+
+	platform := m.GetPlatform()
+	metadata := PlatformMetadata{
+		Tool:         toolName,
+		Version:      resolvedVersion,
+		Distribution: cfg.Distribution,
+		OS:           platform.OS,
+		Arch:         platform.Arch,
+	}
+	data, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode platform metadata: %w", err)
+	}
+	if err := os.WriteFile(metadataFile, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write platform metadata: %w", err)
+	}
+
+	return installDir, nil
+}
+
+// getInstallDir returns the installation directory of a tool version
+func (m *Manager) getInstallDir(tool Tool, version string, cfg config.ToolConfig) string {
+	if provider, ok := tool.(InstallDirProvider); ok {
+		return provider.GetInstallDir(version, cfg)
+	}
+	return m.GetToolVersionDir(tool.GetToolName(), version, cfg.Distribution)
+}
+
+// clearToolCaches removes cached installation and path results for a tool version
+func (m *Manager) clearToolCaches(tool Tool, version, distribution string) {
+	cacheKey := m.getCacheKey(tool.GetToolName(), version, distribution)
+
+	m.cacheMutex.Lock()
+	delete(m.installedCache, cacheKey)
+	delete(m.pathCache, cacheKey)
+	m.cacheMutex.Unlock()
+
+	if cacheable, ok := tool.(interface{ clearPathCache() }); ok {
+		cacheable.clearPathCache()
+	}
+}
+
+// installAndVerify installs and verifies a tool version, honoring the per-tool concurrency limit
+func (m *Manager) installAndVerify(tool Tool, version string, cfg config.ToolConfig) error {
+	toolName := tool.GetToolName()
+
+	// Fail precisely rather than with a download error for unpublished platforms
+	if !UseSystemTool(toolName) {
+		if err := m.CheckPlatformSupport(tool, version, m.GetPlatform()); err != nil {
+			return m.notifyError(toolName, version, err)
+		}
+	}
+
+	if IsOffline() && !UseSystemTool(toolName) && cfg.LocalArchive() == "" {
+		return m.notifyError(toolName, version, OfflineInstallError(toolName, version))
+	}
+
+	if err := m.checkWritableHome(); err != nil {
+		return m.notifyError(toolName, version, err)
+	}
+	release := m.acquireInstallSlot(toolName)
+	defer release()
+
+	// Auto-install
+	util.LogVerbose("Auto-installing %s %s...", toolName, version)
+	m.notify(InstallEvent{Type: EventInstallStart, Tool: toolName, Version: version})
+	if err := tool.Install(version, cfg); err != nil {
+		return m.notifyError(toolName, version, fmt.Errorf("failed to install %s %s: %w", toolName, version, err))
+	}
+
+	// Verify installation
+	m.notify(InstallEvent{Type: EventVerifyStart, Tool: toolName, Version: version})
+	if err := tool.Verify(version, cfg); err != nil {
+		return m.notifyError(toolName, version, fmt.Errorf("failed to verify %s %s: %w", toolName, version, err))
+	}
+	m.notify(InstallEvent{Type: EventVerifyDone, Tool: toolName, Version: version})
+
+	m.invalidateEnvironmentCache()
+	m.notify(InstallEvent{Type: EventInstallDone, Tool: toolName, Version: version})
+	return nil
+}
+
 // SetupEnvironment sets up environment variables for installed tools
+// The result is cached, in memory and on disk, keyed by a hash of the system environment,
+// the configuration and the resolved tool installations.
 func (m *Manager) SetupEnvironment(cfg *config.Config) (map[string]string, error) {
+	cacheKey := m.environmentCacheKey(cfg)
+	if entry, ok := m.getCachedEnvironment(cacheKey); ok {
+		util.LogVerbose("Using cached environment (%s)", cacheKey[:12])
+		return restoreEnvironment(cfg, os.Environ(), entry)
+	}
+
+	env, err := m.computeEnvironment(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := newEnvironmentCacheEntry(cfg, os.Environ(), env)
+	if err != nil {
+		return nil, err
+	}
+	m.storeCachedEnvironment(cacheKey, entry)
+	return env, nil
+}
+
+// EnvironmentChanges returns the variables SetupEnvironment adds to or changes in the current
+// environment, i.e. the tool and project environment without inherited system variables
+func (m *Manager) EnvironmentChanges(cfg *config.Config) (map[string]string, error) {
+	env, err := m.SetupEnvironment(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return environmentDelta(os.Environ(), env), nil
+}
+
+// computeEnvironment computes the environment variables for installed tools
+func (m *Manager) computeEnvironment(cfg *config.Config) (map[string]string, error) {
 	// Create environment manager
 	envManager := NewEnvironmentManager()
 
@@ -765,12 +1703,21 @@ func (m *Manager) SetupEnvironment(cfg *config.Config) (map[string]string, error
 		}
 	}
 
-	// Override with config environment
+	// Add variables from the project's env files, below the config environment
+	if err := setEnvFiles(cfg.EnvFilePaths(), envManager); err != nil {
+		return nil, err
+	}
+
+	// Override with config environment, values referencing other variables being expanded
+	// once the tools have set theirs
 	for key, value := range cfg.Environment {
-		envManager.SetEnv(key, value)
+		if !strings.Contains(value, "$") {
+			envManager.SetEnv(key, value)
+		}
 	}
 
 	// Add tool-specific environment variables and PATH entries
+	primaryKeys := m.primaryToolKeys(cfg)
 	for toolName, toolConfig := range cfg.Tools {
 		// Check if user wants to use system tool instead
 		systemEnvVar := fmt.Sprintf("MVX_USE_SYSTEM_%s", strings.ToUpper(toolName))
@@ -786,7 +1733,7 @@ func (m *Manager) SetupEnvironment(cfg *config.Config) (map[string]string, error
 			continue // Skip tools with resolution errors
 		}
 
-		resolvedConfig := toolConfig
+		resolvedConfig := resolveRepoLocal(cfg, toolConfig)
 		resolvedConfig.Version = resolvedVersion
 
 		// Check if installed (using cache)
@@ -802,6 +1749,14 @@ func (m *Manager) SetupEnvironment(cfg *config.Config) (map[string]string, error
 			continue
 		}
 
+		// Additional versions are not put on PATH, only exposed through their own variables
+		if secondaryProvider, ok := tool.(SecondaryEnvironmentProvider); ok && primaryKeys[tool.GetToolName()] != toolName {
+			if err := secondaryProvider.SetupSecondaryEnvironment(resolvedVersion, resolvedConfig, envManager); err != nil {
+				util.LogVerbose("Failed to setup environment for %s %s: %v", toolName, resolvedVersion, err)
+			}
+			continue
+		}
+
 		// Get tool path and add to PATH
 		toolPath, err := tool.GetPath(resolvedVersion, resolvedConfig)
 		if err != nil {
@@ -819,6 +1774,13 @@ func (m *Manager) SetupEnvironment(cfg *config.Config) (map[string]string, error
 				util.LogVerbose("Failed to setup environment for %s %s: %v", toolName, resolvedVersion, err)
 			}
 		}
+
+		// Variables configured for the tool come last, so they may use or replace the tool's own
+		setToolEnv(toolConfig.Env, toolEnvPlaceholders(resolvedVersion, toolPath), envManager)
+	}
+
+	if err := setProjectEnv(cfg.Environment, envManager); err != nil {
+		return nil, err
 	}
 
 	// Add system PATH directories after tool directories (lower priority)
@@ -870,6 +1832,22 @@ func (m *Manager) resolveVersion(toolName string, toolConfig config.ToolConfig)
 		return m.resolveVersionInternal(toolName, overrideConfig)
 	}
 
+	// The lockfile pins the version of a specification that was locked
+	if locked, ok := m.lockedVersion(toolName, toolConfig); ok {
+		util.LogVerbose("Using locked version: %s %s -> %s", toolName, toolConfig.Version, locked)
+		return locked, nil
+	}
+
+	return m.resolveConfiguredVersion(toolName, toolConfig)
+}
+
+// resolveConfiguredVersion resolves the version specification of a tool configuration
+func (m *Manager) resolveConfiguredVersion(toolName string, toolConfig config.ToolConfig) (string, error) {
+	// A local archive provides exactly the configured version
+	if toolConfig.LocalArchive() != "" {
+		return toolConfig.Version, nil
+	}
+
 	// Fast path: Check if version is already concrete (no resolution needed)
 	if m.isConcreteVersion(toolName, toolConfig.Version) {
 		return toolConfig.Version, nil
@@ -882,12 +1860,23 @@ func (m *Manager) resolveVersion(toolName string, toolConfig config.ToolConfig)
 func (m *Manager) resolveVersionInternal(toolName string, toolConfig config.ToolConfig) (string, error) {
 	distribution := toolConfig.Distribution
 
+	preference, err := version.ParsePreference(toolConfig.Options[OptionResolve])
+	if err != nil {
+		return "", fmt.Errorf("invalid %s option for %s: %w", OptionResolve, toolName, err)
+	}
+
+	cacheSpec := versionCacheSpec(toolConfig.Version, preference)
+
 	// Check cache first
-	if cached, found := m.getCachedVersion(toolName, toolConfig.Version, distribution); found {
+	if cached, found := m.getCachedVersion(toolName, cacheSpec, distribution); found {
 		util.LogVerbose("Using cached version resolution: %s %s (%s) -> %s", toolName, toolConfig.Version, distribution, cached)
 		return cached, nil
 	}
 
+	if IsOffline() {
+		return "", fmt.Errorf("%w: cannot resolve %s %s, no cached resolution (resolve it once while online)", ErrOffline, toolName, toolConfig.Version)
+	}
+
 	util.LogVerbose("Resolving version online: %s %s (%s)", toolName, toolConfig.Version, distribution)
 
 	// Get the tool instance
@@ -896,23 +1885,61 @@ func (m *Manager) resolveVersionInternal(toolName string, toolConfig config.Tool
 		return "", fmt.Errorf("unknown tool: %s", toolName)
 	}
 
-	// Check if tool implements VersionResolver interface
-	var resolved string
-	if resolver, ok := tool.(VersionResolver); ok {
-		resolved, err = resolver.ResolveVersion(toolConfig.Version, distribution)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		// Fallback: return version as-is for tools that don't implement VersionResolver
-		resolved = toolConfig.Version
+	resolved, err := m.resolveOnline(tool, toolConfig.Version, distribution, preference)
+	if err != nil {
+		return "", err
 	}
 
 	util.LogVerbose("Resolved %s %s (%s) -> %s (caching for 24h)", toolName, toolConfig.Version, distribution, resolved)
 
 	// Cache the resolved version
-	m.setCachedVersion(toolName, toolConfig.Version, distribution, resolved)
+	m.setCachedVersion(toolName, cacheSpec, distribution, resolved)
+
+	return resolved, nil
+}
+
+// versionCacheSpec returns the key of a version specification in the version cache, where
+// resolutions to the lowest match are cached separately from the default ones
+func versionCacheSpec(versionSpec string, preference version.Preference) string {
+	if preference != version.PreferHighest {
+		return versionSpec + "@" + string(preference)
+	}
+	return versionSpec
+}
+
+// resolveOnline resolves a version specification against the available versions of a tool,
+// without looking at the version cache
+func (m *Manager) resolveOnline(tool Tool, versionSpec, distribution string, preference version.Preference) (string, error) {
+	if preference != version.PreferHighest {
+		return m.resolveVersionWithPreference(tool, versionSpec, distribution, preference)
+	}
+	if resolver, ok := tool.(VersionResolver); ok {
+		return resolver.ResolveVersion(versionSpec, distribution)
+	}
+	// Fallback: return version as-is for tools that don't implement VersionResolver
+	return versionSpec, nil
+}
+
+// resolveVersionWithPreference resolves a version specification to its highest or lowest match
+func (m *Manager) resolveVersionWithPreference(tool Tool, versionSpec, distribution string, preference version.Preference) (string, error) {
+	if resolver, ok := tool.(PreferenceVersionResolver); ok {
+		return resolver.ResolveVersionWithPreference(versionSpec, distribution, preference)
+	}
+
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil {
+		return "", fmt.Errorf("invalid version specification %s: %w", versionSpec, err)
+	}
+
+	availableVersions, err := tool.ListVersions()
+	if err != nil {
+		return "", fmt.Errorf("failed to get versions for %s: %w", tool.GetToolName(), err)
+	}
 
+	resolved, err := spec.ResolveWithPreference(availableVersions, preference)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s version %s: %w", tool.GetToolName(), versionSpec, err)
+	}
 	return resolved, nil
 }
 
@@ -997,14 +2024,34 @@ func (m *Manager) getCachedVersion(toolName, versionSpec, distribution string) (
 		return "", false
 	}
 
-	// Check if cache entry is still valid (less than 24 hours old)
-	if time.Since(entry.Timestamp) > 24*time.Hour {
+	// Check if cache entry is still valid (less than 24 hours old).
+	// Offline, a stale resolution is better than none.
+	if time.Since(entry.Timestamp) > 24*time.Hour && !IsOffline() {
 		return "", false
 	}
 
 	return entry.ResolvedVersion, true
 }
 
+// addCachedResolutions adds the versions of a tool found in the version resolution cache
+func (m *Manager) addCachedResolutions(toolName string, versions []string) []string {
+	m.cacheMutex.RLock()
+	defer m.cacheMutex.RUnlock()
+
+	known := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		known[v] = true
+	}
+	prefix := toolName + ":"
+	for key, entry := range m.versionCache {
+		if strings.HasPrefix(key, prefix) && !known[entry.ResolvedVersion] {
+			known[entry.ResolvedVersion] = true
+			versions = append(versions, entry.ResolvedVersion)
+		}
+	}
+	return versions
+}
+
 // setCachedVersion stores a version resolution in cache
 func (m *Manager) setCachedVersion(toolName, versionSpec, distribution, resolvedVersion string) {
 	m.cacheMutex.Lock()
@@ -1018,7 +2065,11 @@ func (m *Manager) setCachedVersion(toolName, versionSpec, distribution, resolved
 	}
 
 	// Save cache to disk asynchronously
-	go m.saveVersionCache()
+	m.pendingSaves.Add(1)
+	go func() {
+		defer m.pendingSaves.Done()
+		m.saveVersionCache()
+	}()
 }
 
 // InstallSpecificTools installs only the specified tools from configuration
@@ -1094,22 +2145,14 @@ func (m *Manager) EnsureToolInstalled(cfg *config.Config, toolName string) error
 // getDiskCachedResponse retrieves a cached HTTP response from disk
 // Returns the response body and true if found and valid (less than 24 hours old)
 func (m *Manager) getDiskCachedResponse(url string) ([]byte, bool) {
-	cacheFile := m.getDiskCacheFilePath(url)
-
-	data, err := os.ReadFile(cacheFile)
-	if err != nil {
-		return nil, false
-	}
-
-	var entry DiskCacheEntry
-	if err := json.Unmarshal(data, &entry); err != nil {
+	entry, found := m.readDiskCacheEntry(url)
+	if !found {
 		return nil, false
 	}
 
-	// Check if cache is still valid (less than 24 hours old)
+	// Check if cache is still valid (less than 24 hours old). Expired entries are kept
+	// as stale metadata, and replaced when the response is fetched again.
 	if time.Since(entry.Timestamp) > 24*time.Hour {
-		// Clean up expired cache file
-		os.Remove(cacheFile)
 		return nil, false
 	}
 
@@ -1118,6 +2161,20 @@ func (m *Manager) getDiskCachedResponse(url string) ([]byte, bool) {
 	return body, true
 }
 
+// readDiskCacheEntry reads the disk cache entry of a URL, whatever its age
+func (m *Manager) readDiskCacheEntry(url string) (DiskCacheEntry, bool) {
+	data, err := os.ReadFile(m.getDiskCacheFilePath(url))
+	if err != nil {
+		return DiskCacheEntry{}, false
+	}
+
+	var entry DiskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return DiskCacheEntry{}, false
+	}
+	return entry, true
+}
+
 // setDiskCachedResponse stores an HTTP response in disk cache
 func (m *Manager) setDiskCachedResponse(url string, body []byte) {
 	cacheFile := m.getDiskCacheFilePath(url)
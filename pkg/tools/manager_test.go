@@ -0,0 +1,1082 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+// fakeTool is a minimal Tool implementation used to exercise Manager logic without network access
+type fakeTool struct {
+	*BaseTool
+	installErr   error
+	installCount int
+	pathCount    int
+	onInstall    func()
+	versions     []string
+	mutex        sync.Mutex
+}
+
+func newFakeTool(manager *Manager, name string, installErr error) *fakeTool {
+	return &fakeTool{
+		BaseTool:   NewBaseTool(manager, name, name),
+		installErr: installErr,
+	}
+}
+
+func (f *fakeTool) Install(version string, cfg config.ToolConfig) error {
+	if f.installErr != nil {
+		return f.installErr
+	}
+	if f.onInstall != nil {
+		f.onInstall()
+	}
+	f.mutex.Lock()
+	f.installCount++
+	f.mutex.Unlock()
+	return os.MkdirAll(f.GetInstallDir(version, cfg), 0755)
+}
+
+func (f *fakeTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	_, err := os.Stat(f.GetInstallDir(version, cfg))
+	return err == nil
+}
+
+func (f *fakeTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	f.mutex.Lock()
+	f.pathCount++
+	f.mutex.Unlock()
+	return "/fake/" + f.GetToolName() + "/" + version + "/bin", nil
+}
+
+func (f *fakeTool) Verify(version string, cfg config.ToolConfig) error {
+	return nil
+}
+
+func (f *fakeTool) ListVersions() ([]string, error) {
+	if f.versions != nil {
+		return f.versions, nil
+	}
+	return []string{"1.0.0"}, nil
+}
+
+func (f *fakeTool) GetDownloadURL(version string) string {
+	return ""
+}
+
+func (f *fakeTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
+	return ChecksumInfo{}, nil
+}
+
+// newTestManager creates an isolated manager that does not touch the user's ~/.mvx directory
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	manager := &Manager{
+		cacheDir:       t.TempDir(),
+		tools:          make(map[string]Tool),
+		versionCache:   make(map[string]VersionCacheEntry),
+		installedCache: make(map[string]bool),
+		pathCache:      make(map[string]string),
+		httpCache:      make(map[string]HTTPCacheEntry),
+		httpClient:     &http.Client{},
+	}
+	manager.registry = NewToolRegistry(manager)
+	// Let background cache saves finish before the temporary directory is removed
+	t.Cleanup(manager.pendingSaves.Wait)
+	return manager
+}
+
+// dependentFakeTool is a fakeTool declaring dependencies on other tools
+type dependentFakeTool struct {
+	*fakeTool
+	dependencies []string
+}
+
+func (d *dependentFakeTool) GetDependencies() []string {
+	return d.dependencies
+}
+
+func TestEnsureToolsDependencyOrder(t *testing.T) {
+	manager := newTestManager(t)
+
+	var mutex sync.Mutex
+	var events []string
+	record := func(event string) {
+		mutex.Lock()
+		events = append(events, event)
+		mutex.Unlock()
+	}
+
+	base := newFakeTool(manager, "base", nil)
+	base.onInstall = func() {
+		record("base started")
+		// Leave a concurrent install of the dependent tool time to start too early
+		time.Sleep(50 * time.Millisecond)
+		record("base installed")
+	}
+	other := newFakeTool(manager, "other", nil)
+	dependent := newFakeTool(manager, "dependent", nil)
+	dependent.onInstall = func() { record("dependent started") }
+	manager.RegisterTool(base)
+	manager.RegisterTool(other)
+	manager.RegisterTool(&dependentFakeTool{fakeTool: dependent, dependencies: []string{"base", "missing"}})
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"base":      {Version: "1.0.0"},
+			"other":     {Version: "1.0.0"},
+			"dependent": {Version: "1.0.0"},
+		},
+	}
+
+	tiers, err := manager.resolveDependencyTiers(cfg)
+	if err != nil {
+		t.Fatalf("resolveDependencyTiers() error = %v", err)
+	}
+	expected := [][]string{{"base", "other"}, {"dependent"}}
+	if !slices.EqualFunc(tiers, expected, slices.Equal[[]string]) {
+		t.Errorf("resolveDependencyTiers() = %v, want %v", tiers, expected)
+	}
+
+	if err := manager.EnsureTools(cfg, 3); err != nil {
+		t.Fatalf("EnsureTools() error = %v", err)
+	}
+	if index := slices.Index(events, "dependent started"); index < 0 || index < slices.Index(events, "base installed") {
+		t.Errorf("dependent installed before its dependency: %v", events)
+	}
+}
+
+func TestEnsureToolsGroupsOutput(t *testing.T) {
+	var out bytes.Buffer
+	previous := consoleOutput
+	consoleOutput = &consoleObserver{out: &out, lastLine: make(map[string]time.Time)}
+	t.Cleanup(func() { consoleOutput = previous })
+
+	manager := newTestManager(t)
+	alpha := newFakeTool(manager, "alpha", nil)
+	beta := newFakeTool(manager, "beta", nil)
+	// Interleave the messages of both installs
+	alphaStarted, betaStarted := make(chan struct{}), make(chan struct{})
+	alpha.onInstall = func() {
+		alpha.printf("  alpha downloading\n")
+		close(alphaStarted)
+		<-betaStarted
+		alpha.printf("  alpha extracting\n")
+	}
+	beta.onInstall = func() {
+		<-alphaStarted
+		beta.printf("  beta downloading\n")
+		close(betaStarted)
+		beta.printf("  beta extracting")
+	}
+	manager.RegisterTool(alpha)
+	manager.RegisterTool(beta)
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"alpha": {Version: "1.0.0"},
+			"beta":  {Version: "1.0.0"},
+		},
+	}
+	if err := manager.EnsureTools(cfg, 2); err != nil {
+		t.Fatalf("EnsureTools() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	for _, toolName := range []string{"alpha", "beta"} {
+		start := slices.Index(lines, "  "+toolName+" downloading")
+		if start < 0 || start+2 >= len(lines) ||
+			lines[start+1] != "  "+toolName+" extracting" || !strings.HasPrefix(lines[start+2], "  ✅ "+toolName+" is ready") {
+			t.Errorf("messages of %s not grouped with its status:\n%s", toolName, out.String())
+		}
+	}
+	if len(manager.outputs) != 0 {
+		t.Errorf("outputs still grouped after the installs: %v", manager.outputs)
+	}
+}
+
+func TestResolveDependencyTiersCycle(t *testing.T) {
+	manager := newTestManager(t)
+	manager.RegisterTool(&dependentFakeTool{fakeTool: newFakeTool(manager, "alpha", nil), dependencies: []string{"beta"}})
+	manager.RegisterTool(&dependentFakeTool{fakeTool: newFakeTool(manager, "beta", nil), dependencies: []string{"alpha"}})
+	manager.RegisterTool(newFakeTool(manager, "gamma", nil))
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"alpha": {Version: "1.0.0"},
+			"beta":  {Version: "1.0.0"},
+			"gamma": {Version: "1.0.0"},
+		},
+	}
+	if _, err := manager.resolveDependencyTiers(cfg); err == nil || !strings.Contains(err.Error(), "alpha, beta") {
+		t.Errorf("resolveDependencyTiers() error = %v, want a cycle between alpha and beta", err)
+	}
+}
+
+func TestToolsRequiredFor(t *testing.T) {
+	manager := newTestManager(t)
+	manager.RegisterTool(newFakeTool(manager, "base", nil))
+	manager.RegisterTool(&dependentFakeTool{fakeTool: newFakeTool(manager, "dependent", nil), dependencies: []string{"base"}})
+	manager.RegisterTool(newFakeTool(manager, "common", nil))
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"base":      {Version: "1.0.0", RequiredFor: []string{"other"}},
+			"dependent": {Version: "1.0.0", RequiredFor: []string{"website"}},
+			"common":    {Version: "1.0.0"},
+		},
+	}
+	tests := []struct {
+		command  string
+		expected []string
+	}{
+		{command: "setup", expected: []string{"common"}},
+		{command: "other", expected: []string{"base", "common"}},
+		{command: "website", expected: []string{"base", "common", "dependent"}}, // base is a dependency
+	}
+	for _, tt := range tests {
+		var toolNames []string
+		for toolName := range manager.ToolsRequiredFor(cfg, tt.command).Tools {
+			toolNames = append(toolNames, toolName)
+		}
+		sort.Strings(toolNames)
+		if !slices.Equal(toolNames, tt.expected) {
+			t.Errorf("ToolsRequiredFor(%q) = %v, want %v", tt.command, toolNames, tt.expected)
+		}
+	}
+	if len(cfg.Tools) != 3 {
+		t.Errorf("ToolsRequiredFor() modified the configuration: %v", cfg.Tools)
+	}
+}
+
+func TestEnsureToolsKeepGoing(t *testing.T) {
+	manager := newTestManager(t)
+	manager.RegisterTool(newFakeTool(manager, "alpha", nil))
+	manager.RegisterTool(newFakeTool(manager, "beta", errors.New("download failed")))
+	manager.RegisterTool(newFakeTool(manager, "gamma", nil))
+	delta := &dependentFakeTool{fakeTool: newFakeTool(manager, "delta", nil), dependencies: []string{"beta"}}
+	manager.RegisterTool(delta)
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"alpha": {Version: "1.0.0"},
+			"beta":  {Version: "1.0.0"},
+			"gamma": {Version: "1.0.0"},
+			"delta": {Version: "1.0.0"},
+		},
+	}
+
+	results, err := manager.EnsureToolsKeepGoing(cfg, 2)
+	if err != nil {
+		t.Fatalf("EnsureToolsKeepGoing() error = %v", err)
+	}
+
+	if len(results) != 4 {
+		t.Fatalf("Expected 4 results, got %d", len(results))
+	}
+
+	failures := make(map[string]error)
+	for _, result := range results {
+		if result.Error != nil {
+			failures[result.ToolName] = result.Error
+		}
+	}
+
+	if len(failures) != 2 || failures["beta"] == nil || failures["delta"] == nil {
+		t.Errorf("Expected beta and its dependent delta to fail, got failures: %v", failures)
+	}
+	if delta.installCount != 0 {
+		t.Errorf("delta was installed %d times, want it skipped as its dependency beta failed", delta.installCount)
+	}
+
+	// Tools after the failing one must still have been installed
+	for _, name := range []string{"alpha", "gamma"} {
+		tool, _ := manager.GetTool(name)
+		if !tool.IsInstalled("1.0.0", cfg.Tools[name]) {
+			t.Errorf("Expected %s to be installed despite beta failing", name)
+		}
+	}
+}
+
+func TestGetVersionCompletions(t *testing.T) {
+	manager := newTestManager(t)
+
+	// Simulate installed versions, one of them with a distribution suffix
+	for _, dir := range []string{"21.0.1@zulu", "17.0.9"} {
+		if err := os.MkdirAll(filepath.Join(manager.GetToolDir(ToolJava), dir), 0755); err != nil {
+			t.Fatalf("Failed to create install dir: %v", err)
+		}
+	}
+	manager.versionCache["java:11:temurin"] = VersionCacheEntry{ResolvedVersion: "11.0.21"}
+	manager.versionCache["maven:3:"] = VersionCacheEntry{ResolvedVersion: "3.9.6"}
+
+	completions := manager.GetVersionCompletions(ToolJava)
+	expected := []string{"latest", "lts", "21", "17", "11", "21.0.1", "17.0.9", "11.0.21"}
+	if strings.Join(completions, ",") != strings.Join(expected, ",") {
+		t.Errorf("GetVersionCompletions(java) = %v, want %v", completions, expected)
+	}
+
+	completions = manager.GetVersionCompletions(ToolMaven)
+	expected = []string{"latest", "3", "3.9.6"}
+	if strings.Join(completions, ",") != strings.Join(expected, ",") {
+		t.Errorf("GetVersionCompletions(maven) = %v, want %v", completions, expected)
+	}
+}
+
+func TestReinstallTool(t *testing.T) {
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "alpha", nil)
+	manager.RegisterTool(tool)
+
+	cfg := config.ToolConfig{Version: "1.0.0"}
+	if _, err := manager.EnsureTool("alpha", cfg); err != nil {
+		t.Fatalf("EnsureTool() error = %v", err)
+	}
+
+	// Simulate a broken installation with a leftover file
+	installDir := tool.GetInstallDir("1.0.0", cfg)
+	staleFile := filepath.Join(installDir, "stale")
+	if err := os.WriteFile(staleFile, []byte("broken"), 0644); err != nil {
+		t.Fatalf("Failed to write stale file: %v", err)
+	}
+
+	if _, err := manager.ReinstallTool("alpha", cfg); err != nil {
+		t.Fatalf("ReinstallTool() error = %v", err)
+	}
+
+	if tool.installCount != 2 {
+		t.Errorf("Expected tool to be installed twice, got %d", tool.installCount)
+	}
+	if _, err := os.Stat(staleFile); err == nil {
+		t.Error("Expected previous installation contents to be removed")
+	}
+	if !tool.IsInstalled("1.0.0", cfg) {
+		t.Error("Expected tool to be installed after reinstall")
+	}
+}
+
+func TestUninstallTool(t *testing.T) {
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "alpha", nil)
+	manager.RegisterTool(tool)
+
+	v1 := config.ToolConfig{Version: "1.0.0"}
+	v2 := config.ToolConfig{Version: "2.0.0"}
+	for _, cfg := range []config.ToolConfig{v1, v2} {
+		if _, err := manager.EnsureTool("alpha", cfg); err != nil {
+			t.Fatalf("EnsureTool() error = %v", err)
+		}
+	}
+
+	if err := manager.UninstallTool("alpha", "1.0.0", ""); err != nil {
+		t.Fatalf("UninstallTool() error = %v", err)
+	}
+	if manager.isToolInstalled("alpha", "1.0.0", v1) {
+		t.Error("Expected 1.0.0 to be uninstalled")
+	}
+	if !manager.isToolInstalled("alpha", "2.0.0", v2) {
+		t.Error("Expected 2.0.0 to remain installed")
+	}
+
+	if err := manager.UninstallTool("alpha", "", ""); err != nil {
+		t.Fatalf("UninstallTool() error = %v", err)
+	}
+	if manager.isToolInstalled("alpha", "2.0.0", v2) {
+		t.Error("Expected 2.0.0 to be uninstalled")
+	}
+	if _, err := os.Stat(manager.GetToolDir("alpha")); !os.IsNotExist(err) {
+		t.Errorf("Expected tool directory to be removed, got %v", err)
+	}
+}
+
+func TestPerToolInstallConcurrency(t *testing.T) {
+	t.Setenv("MVX_TOOL_CONCURRENCY_ALPHA", "1")
+
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "alpha", nil)
+
+	var running, maxRunning int32
+	tool.onInstall = func() {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			observed := atomic.LoadInt32(&maxRunning)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxRunning, observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+	manager.RegisterTool(tool)
+
+	var wg sync.WaitGroup
+	for _, v := range []string{"1.0.0", "2.0.0", "3.0.0"} {
+		wg.Add(1)
+		go func(v string) {
+			defer wg.Done()
+			if _, err := manager.EnsureTool("alpha", config.ToolConfig{Version: v}); err != nil {
+				t.Errorf("EnsureTool(%s) error = %v", v, err)
+			}
+		}(v)
+	}
+	wg.Wait()
+
+	if maxRunning != 1 {
+		t.Errorf("Expected at most 1 concurrent install, observed %d", maxRunning)
+	}
+	if tool.installCount != 3 {
+		t.Errorf("Expected 3 installs, got %d", tool.installCount)
+	}
+}
+
+func TestGetToolConcurrency(t *testing.T) {
+	t.Setenv("MVX_TOOL_CONCURRENCY_JAVA", "2")
+	t.Setenv("MVX_TOOL_CONCURRENCY_NODE", "invalid")
+
+	if got := GetToolConcurrency("java"); got != 2 {
+		t.Errorf("GetToolConcurrency(java) = %d, want 2", got)
+	}
+	if got := GetToolConcurrency("node"); got != 0 {
+		t.Errorf("GetToolConcurrency(node) = %d, want 0 for invalid value", got)
+	}
+	if got := GetToolConcurrency("go"); got != 0 {
+		t.Errorf("GetToolConcurrency(go) = %d, want 0 when unset", got)
+	}
+}
+
+func TestGetDefaultConcurrency(t *testing.T) {
+	cfg := &config.Config{Settings: &config.SettingsConfig{ParallelDownloads: 5}}
+
+	t.Setenv(EnvParallelDownloads, "")
+	if got := GetDefaultConcurrency(nil); got != 3 {
+		t.Errorf("GetDefaultConcurrency(nil) = %d, want 3", got)
+	}
+	if got := GetDefaultConcurrency(cfg); got != 5 {
+		t.Errorf("GetDefaultConcurrency() = %d, want 5 from the settings", got)
+	}
+	t.Setenv(EnvParallelDownloads, "2")
+	if got := GetDefaultConcurrency(cfg); got != 2 {
+		t.Errorf("GetDefaultConcurrency() = %d, want 2 from %s", got, EnvParallelDownloads)
+	}
+}
+
+func TestApplySettings(t *testing.T) {
+	cfg := &config.Config{Settings: &config.SettingsConfig{HTTPTimeout: "3m"}}
+
+	t.Setenv(EnvTimeout, "")
+	t.Setenv(EnvHTTPTimeout, "")
+	manager := newTestManager(t)
+	if err := manager.ApplySettings(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if manager.httpClient.Timeout != 3*time.Minute {
+		t.Errorf("timeout = %s, want 3m from the settings", manager.httpClient.Timeout)
+	}
+
+	t.Setenv(EnvHTTPTimeout, "30s")
+	manager = newTestManager(t)
+	if err := manager.ApplySettings(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if manager.httpClient.Timeout != 0 {
+		t.Errorf("timeout = %s, want the settings ignored when %s is set", manager.httpClient.Timeout, EnvHTTPTimeout)
+	}
+}
+
+func TestInstallForPlatform(t *testing.T) {
+	manager := newTestManager(t)
+	foreign := PlatformInfo{OS: "windows", Arch: "amd64"}
+	if GetPlatformInfo() == foreign {
+		foreign = PlatformInfo{OS: "linux", Arch: "arm64"}
+	}
+	manager.platform = &foreign
+
+	tool := newFakeTool(manager, "alpha", nil)
+	manager.RegisterTool(tool)
+
+	cfg := config.ToolConfig{Version: "1.0.0"}
+	installDir, err := manager.InstallForPlatform("alpha", cfg)
+	if err != nil {
+		t.Fatalf("InstallForPlatform() error = %v", err)
+	}
+
+	expectedDir := filepath.Join(manager.cacheDir, "platforms", foreign.String(), "tools", "alpha", "1.0.0")
+	if installDir != expectedDir {
+		t.Errorf("Expected install dir %s, got %s", expectedDir, installDir)
+	}
+
+	data, err := os.ReadFile(filepath.Join(installDir, PlatformMetadataFile))
+	if err != nil {
+		t.Fatalf("Expected platform metadata to be written: %v", err)
+	}
+	if !strings.Contains(string(data), `"os": "`+foreign.OS+`"`) || !strings.Contains(string(data), `"arch": "`+foreign.Arch+`"`) {
+		t.Errorf("Unexpected platform metadata: %s", data)
+	}
+
+	// A second install is a no-op
+	if _, err := manager.InstallForPlatform("alpha", cfg); err != nil {
+		t.Fatalf("InstallForPlatform() second call error = %v", err)
+	}
+	if tool.installCount != 1 {
+		t.Errorf("Expected a single install, got %d", tool.installCount)
+	}
+}
+
+func TestSetupEnvironmentCache(t *testing.T) {
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "alpha", nil)
+	manager.RegisterTool(tool)
+
+	cfg := &config.Config{
+		Tools:       map[string]config.ToolConfig{"alpha": {Version: "1.0.0"}},
+		Environment: map[string]string{"GREETING": "hello"},
+	}
+	if _, err := manager.EnsureTool("alpha", cfg.Tools["alpha"]); err != nil {
+		t.Fatalf("EnsureTool() error = %v", err)
+	}
+
+	first, err := manager.SetupEnvironment(cfg)
+	if err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	pathCalls := tool.pathCount
+
+	// An identical config hits the in-memory cache
+	identical := &config.Config{
+		Tools:       map[string]config.ToolConfig{"alpha": {Version: "1.0.0"}},
+		Environment: map[string]string{"GREETING": "hello"},
+	}
+	second, err := manager.SetupEnvironment(identical)
+	if err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	if tool.pathCount != pathCalls {
+		t.Errorf("Expected identical config to hit the cache, GetPath called %d more time(s)", tool.pathCount-pathCalls)
+	}
+	if second["PATH"] != first["PATH"] || second["GREETING"] != "hello" {
+		t.Errorf("Cached environment differs: PATH %q vs %q, GREETING %q", second["PATH"], first["PATH"], second["GREETING"])
+	}
+
+	// A new process (manager) with the same cache directory hits the on-disk cache
+	other := newTestManager(t)
+	other.cacheDir = manager.cacheDir
+	otherTool := newFakeTool(other, "alpha", nil)
+	other.RegisterTool(otherTool)
+	if _, err := other.SetupEnvironment(identical); err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	if otherTool.pathCount != 0 {
+		t.Errorf("Expected on-disk cache hit, GetPath called %d time(s)", otherTool.pathCount)
+	}
+
+	// A different config misses the cache
+	changed := &config.Config{
+		Tools:       map[string]config.ToolConfig{"alpha": {Version: "1.0.0"}},
+		Environment: map[string]string{"GREETING": "bye"},
+	}
+	env, err := manager.SetupEnvironment(changed)
+	if err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	if tool.pathCount == pathCalls || env["GREETING"] != "bye" {
+		t.Errorf("Expected changed config to recompute the environment")
+	}
+}
+
+func TestSetupEnvironmentToolEnv(t *testing.T) {
+	t.Setenv("MVX_TEST_SHARED", "/shared")
+	manager := newTestManager(t)
+	manager.RegisterTool(newFakeTool(manager, "alpha", nil))
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"alpha": {Version: "1.0.0", Env: map[string]string{
+				"ALPHA_CONF":    "${TOOL_HOME}/conf",
+				"ALPHA_LAUNCH":  "$TOOL_BIN/alpha-$TOOL_VERSION",
+				"ALPHA_SHARED":  "${MVX_TEST_SHARED}/alpha",
+				"ALPHA_LITERAL": "plain",
+			}},
+		},
+	}
+	if _, err := manager.EnsureTool("alpha", cfg.Tools["alpha"]); err != nil {
+		t.Fatalf("EnsureTool() error = %v", err)
+	}
+
+	env, err := manager.SetupEnvironment(cfg)
+	if err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	expected := map[string]string{
+		"ALPHA_CONF":    filepath.Dir("/fake/alpha/1.0.0/bin") + "/conf",
+		"ALPHA_LAUNCH":  "/fake/alpha/1.0.0/bin/alpha-1.0.0",
+		"ALPHA_SHARED":  "/shared/alpha",
+		"ALPHA_LITERAL": "plain",
+	}
+	for key, value := range expected {
+		if env[key] != value {
+			t.Errorf("%s = %q, want %q", key, env[key], value)
+		}
+	}
+}
+
+func TestSetupEnvironmentInterpolation(t *testing.T) {
+	t.Setenv("MVX_TEST_SHARED", "/shared")
+	t.Setenv("MVX_TEST_OPTS", "-Xmx1g")
+	manager := newTestManager(t)
+	manager.RegisterTool(newFakeTool(manager, "alpha", nil))
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"alpha": {Version: "1.0.0", Env: map[string]string{"ALPHA_HOME": "${TOOL_HOME}"}},
+		},
+		Environment: map[string]string{
+			"A_CONF":        "${B_BASE}/conf", // References an entry expanded after it alphabetically
+			"B_BASE":        "$ALPHA_HOME/base",
+			"C_REPO":        "${MVX_TEST_SHARED}/repo",
+			"MVX_TEST_OPTS": "$MVX_TEST_OPTS -Dfoo=bar",
+			"D_DEFAULT":     "${MVX_TEST_UNSET:-fallback}",
+			"E_LITERAL":     "plain",
+			"F_LITERAL_REF": "$E_LITERAL-value",
+		},
+	}
+	if _, err := manager.EnsureTool("alpha", cfg.Tools["alpha"]); err != nil {
+		t.Fatalf("EnsureTool() error = %v", err)
+	}
+
+	env, err := manager.SetupEnvironment(cfg)
+	if err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	alphaHome := filepath.Dir("/fake/alpha/1.0.0/bin")
+	expected := map[string]string{
+		"A_CONF":        alphaHome + "/base/conf",
+		"B_BASE":        alphaHome + "/base",
+		"C_REPO":        "/shared/repo",
+		"MVX_TEST_OPTS": "-Xmx1g -Dfoo=bar",
+		"D_DEFAULT":     "fallback",
+		"F_LITERAL_REF": "plain-value",
+	}
+	for key, value := range expected {
+		if env[key] != value {
+			t.Errorf("%s = %q, want %q", key, env[key], value)
+		}
+	}
+
+	cfg.Environment = map[string]string{"A": "${B}", "B": "x${C}", "C": "$A"}
+	if _, err := manager.SetupEnvironment(cfg); err == nil || !strings.Contains(err.Error(), "A -> B -> C -> A") {
+		t.Errorf("SetupEnvironment() error = %v, want a reference cycle", err)
+	}
+}
+
+func TestSetupEnvironmentEnvFiles(t *testing.T) {
+	t.Setenv("MVX_TEST_SHARED", "/shared")
+	manager := newTestManager(t)
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	localEnvFile := filepath.Join(dir, ".env.local")
+	content := "# Defaults\nDB_USER=app\nDB_PASSWORD='s3cr3t #1'\nDB_URL=\"jdbc:${DB_HOST}\"\nthis line is malformed\nGREETING=hello\n"
+	if err := os.WriteFile(envFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(localEnvFile, []byte("DB_USER=me\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{
+		Environment: map[string]string{"GREETING": "bye", "DB_HOME": "${MVX_TEST_SHARED}/${DB_USER}"},
+		EnvFiles:    []string{envFile, filepath.Join(dir, "missing.env"), localEnvFile},
+	}
+	env, err := manager.SetupEnvironment(cfg)
+	if err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	expected := map[string]string{
+		"DB_USER":     "me",        // .env.local overrides .env
+		"DB_PASSWORD": "s3cr3t #1", // Quoted values keep their #
+		"DB_URL":      "jdbc:${DB_HOST}",
+		"GREETING":    "bye", // The environment block overrides the env files
+		"DB_HOME":     "/shared/me",
+	}
+	for key, value := range expected {
+		if env[key] != value {
+			t.Errorf("%s = %q, want %q", key, env[key], value)
+		}
+	}
+
+	// Changing an env file invalidates the cached environment
+	if err := os.WriteFile(localEnvFile, []byte("DB_USER=you\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if env, err = manager.SetupEnvironment(cfg); err != nil || env["DB_USER"] != "you" {
+		t.Errorf("SetupEnvironment() after changing .env.local: DB_USER = %q, %v, want you", env["DB_USER"], err)
+	}
+}
+
+func TestEnvironmentCacheLeavesOutProjectValues(t *testing.T) {
+	manager := newTestManager(t)
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envFile, []byte("DB_PASSWORD=s3cr3t\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{
+		Environment: map[string]string{"API_TOKEN": "t0k3n", "DB_URL": "db://app:${DB_PASSWORD}@host"},
+		EnvFiles:    []string{envFile},
+	}
+	expected := map[string]string{"DB_PASSWORD": "s3cr3t", "API_TOKEN": "t0k3n", "DB_URL": "db://app:s3cr3t@host"}
+
+	if _, err := manager.SetupEnvironment(cfg); err != nil {
+		t.Fatalf("SetupEnvironment() error = %v", err)
+	}
+	cachePath := filepath.Join(manager.cacheDir, envCacheFile)
+	info, err := os.Stat(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("environment cache mode = %v, want 0600", info.Mode().Perm())
+	}
+	content, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"s3cr3t", "t0k3n"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("environment cache contains %q:\n%s", secret, content)
+		}
+	}
+
+	// Another process finds the cached environment, completed with the project values
+	other := newTestManager(t)
+	other.cacheDir = manager.cacheDir
+	env, err := other.SetupEnvironment(cfg)
+	if err != nil {
+		t.Fatalf("SetupEnvironment() from cache error = %v", err)
+	}
+	for key, value := range expected {
+		if env[key] != value {
+			t.Errorf("%s = %q from cache, want %q", key, env[key], value)
+		}
+	}
+}
+
+func TestResolveVersionPreference(t *testing.T) {
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "fake", nil)
+	tool.versions = []string{"17.0.9", "21.0.2", "17.0.1", "17.0.16"}
+	manager.RegisterTool(tool)
+
+	tests := []struct {
+		name     string
+		spec     string
+		resolve  string
+		expected string
+		wantErr  bool
+	}{
+		{name: "lowest major", spec: "17", resolve: "lowest", expected: "17.0.1"},
+		{name: "lowest latest", spec: "latest", resolve: "lowest", expected: "17.0.1"},
+		{name: "invalid preference", spec: "17", resolve: "oldest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.ToolConfig{Version: tt.spec, Options: map[string]string{OptionResolve: tt.resolve}}
+			resolved, err := manager.ResolveVersion("fake", cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveVersion() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resolved != tt.expected {
+				t.Errorf("ResolveVersion() = %q, want %q", resolved, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckPathShadowing(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Skipping PATH lookup test on Windows")
+	}
+
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "faketool", nil)
+	manager.RegisterTool(tool)
+
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{"faketool": {Version: "1.0.0"}}}
+	if err := tool.Install("1.0.0", cfg.Tools["faketool"]); err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	// No binary on PATH: nothing shadows the managed tool
+	t.Setenv("PATH", t.TempDir())
+	if shadowed := manager.CheckPathShadowing(cfg); len(shadowed) != 0 {
+		t.Errorf("Expected no shadowing, got %+v", shadowed)
+	}
+
+	// A system binary on PATH shadows the managed one
+	systemDir := t.TempDir()
+	systemBinary := filepath.Join(systemDir, "faketool")
+	if err := os.WriteFile(systemBinary, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", systemDir)
+	shadowed := manager.CheckPathShadowing(cfg)
+	if len(shadowed) != 1 || shadowed[0].SystemPath != systemBinary || shadowed[0].ManagedDir != "/fake/faketool/1.0.0/bin" {
+		t.Errorf("Expected faketool to be shadowed by %s, got %+v", systemBinary, shadowed)
+	}
+
+	// Using the system tool on purpose is not reported
+	t.Setenv("MVX_USE_SYSTEM_FAKETOOL", "true")
+	if shadowed := manager.CheckPathShadowing(cfg); len(shadowed) != 0 {
+		t.Errorf("Expected no shadowing with MVX_USE_SYSTEM_FAKETOOL, got %+v", shadowed)
+	}
+}
+
+func TestOfflineMode(t *testing.T) {
+	t.Setenv(EnvOffline, "true")
+
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "alpha", nil)
+	manager.RegisterTool(tool)
+
+	if _, err := manager.Get("https://example.com/versions.json"); !errors.Is(err, ErrOffline) {
+		t.Errorf("Get() error = %v, expected ErrOffline", err)
+	}
+
+	_, err := manager.EnsureTool("alpha", config.ToolConfig{Version: "1.0.0"})
+	if !errors.Is(err, ErrOffline) || !strings.Contains(err.Error(), "alpha 1.0.0") {
+		t.Errorf("EnsureTool() error = %v, expected an offline error naming alpha 1.0.0", err)
+	}
+	if tool.installCount != 0 {
+		t.Errorf("Expected no install attempt, got %d", tool.installCount)
+	}
+
+	// Stale resolutions are still used offline
+	manager.versionCache["alpha:1:"] = VersionCacheEntry{
+		ResolvedVersion: "1.0.0",
+		Timestamp:       time.Now().Add(-48 * time.Hour),
+		CacheVersion:    versionCacheVersion,
+	}
+	if resolved, err := manager.ResolveVersion("alpha", config.ToolConfig{Version: "1"}); err != nil || resolved != "1.0.0" {
+		t.Errorf("ResolveVersion() = %q, %v, expected the cached 1.0.0", resolved, err)
+	}
+	if _, err := manager.ResolveVersion("alpha", config.ToolConfig{Version: "2"}); !errors.Is(err, ErrOffline) {
+		t.Errorf("ResolveVersion() error = %v, expected ErrOffline", err)
+	}
+}
+
+// failingTransport stubs the network, failing every request and counting them
+type failingTransport struct {
+	requests atomic.Int32
+}
+
+func (f *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f.requests.Add(1)
+	return nil, errors.New("network unreachable")
+}
+
+func TestSearchToolVersionsWithoutNetwork(t *testing.T) {
+	versionsURL := GradleServicesBase + "/versions/all"
+
+	tests := []struct {
+		name     string
+		offline  bool
+		cached   string
+		expected []string
+	}{
+		{name: "fallback list and resolutions", expected: []string{"8.10.2", "7.0.2"}},
+		{name: "expired cache", cached: `[{"version":"9.9.9"}]`, expected: []string{"9.9.9", "7.0.2"}},
+		{name: "offline expired cache", offline: true, cached: `[{"version":"9.9.9"}]`, expected: []string{"9.9.9", "7.0.2"}},
+		{name: "offline fallback list", offline: true, expected: []string{"8.10.2", "7.0.2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.offline {
+				t.Setenv(EnvOffline, "true")
+			}
+			manager := newTestManager(t)
+			transport := &failingTransport{}
+			manager.httpClient = &http.Client{Transport: transport}
+			manager.RegisterTool(NewGradleTool(manager))
+			manager.AllowStaleMetadata()
+			manager.versionCache["gradle:7:"] = VersionCacheEntry{ResolvedVersion: "7.0.2", Timestamp: time.Now()}
+			if tt.cached != "" {
+				data, err := json.Marshal(DiskCacheEntry{URL: versionsURL, Body: tt.cached, Timestamp: time.Now().Add(-48 * time.Hour)})
+				if err != nil {
+					t.Fatal(err)
+				}
+				cacheFile := manager.getDiskCacheFilePath(versionsURL)
+				if err := os.MkdirAll(filepath.Dir(cacheFile), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(cacheFile, data, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			versions, err := manager.SearchToolVersions("gradle", nil)
+			if err != nil {
+				t.Fatalf("SearchToolVersions() error = %v", err)
+			}
+			for _, v := range tt.expected {
+				if !slices.Contains(versions, v) {
+					t.Errorf("SearchToolVersions() = %v, expected it to contain %s", versions, v)
+				}
+			}
+			if !manager.UsedStaleMetadata() {
+				t.Error("UsedStaleMetadata() = false, expected results labeled as cached/offline")
+			}
+			if tt.offline && transport.requests.Load() != 0 {
+				t.Errorf("Expected no network request offline, got %d", transport.requests.Load())
+			}
+		})
+	}
+}
+
+func TestNoAutoInstall(t *testing.T) {
+	t.Setenv(EnvNoAutoInstall, "true")
+
+	manager := newTestManager(t)
+	tool := newFakeTool(manager, "alpha", nil)
+	manager.RegisterTool(tool)
+
+	_, err := manager.EnsureTool("alpha", config.ToolConfig{Version: "1.0.0"})
+	if !errors.Is(err, ErrAutoInstallDisabled) || !strings.Contains(err.Error(), "run 'mvx setup'") {
+		t.Errorf("EnsureTool() error = %v, expected a not installed error suggesting mvx setup", err)
+	}
+	if tool.installCount != 0 {
+		t.Errorf("Expected no install attempt, got %d", tool.installCount)
+	}
+
+	// Explicit installs are still performed
+	manager.EnableExplicitInstall()
+	if _, err := manager.EnsureTool("alpha", config.ToolConfig{Version: "1.0.0"}); err != nil {
+		t.Fatalf("EnsureTool() after EnableExplicitInstall() error = %v", err)
+	}
+	if tool.installCount != 1 {
+		t.Errorf("Expected one install, got %d", tool.installCount)
+	}
+}
+
+func TestManagerCanonicalVersion(t *testing.T) {
+	manager := newTestManager(t)
+	manager.RegisterTool(NewNodeTool(manager))
+	manager.RegisterTool(NewJavaTool(manager))
+	manager.RegisterTool(newFakeTool(manager, "alpha", nil))
+
+	tests := []struct {
+		tool     string
+		raw      string
+		expected string
+	}{
+		{"node", "v18.17.0", "18.17.0"},
+		{"java", " 17.0.16+8\n", "17.0.16"},
+		{"alpha", "v1.0.0", "v1.0.0"},
+		{"unknown", "v1.0.0", "v1.0.0"},
+	}
+	for _, tt := range tests {
+		if got := manager.CanonicalVersion(tt.tool, tt.raw); got != tt.expected {
+			t.Errorf("CanonicalVersion(%q, %q) = %q, expected %q", tt.tool, tt.raw, got, tt.expected)
+		}
+	}
+
+	if got := manager.CanonicalInstalledVersion("java", "17.0.16+8@temurin"); got != "17.0.16@temurin" {
+		t.Errorf("CanonicalInstalledVersion() = %q, expected 17.0.16@temurin", got)
+	}
+}
+
+func TestNewManagerMvxHome(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	mvxHome := filepath.Join(t.TempDir(), "cache", "mvx")
+	t.Setenv(config.EnvMvxHome, mvxHome)
+
+	manager, err := newManager(&PlatformInfo{OS: "linux", Arch: "amd64"})
+	if err != nil {
+		t.Fatalf("newManager() error = %v", err)
+	}
+	t.Cleanup(manager.pendingSaves.Wait)
+	if info, err := os.Stat(mvxHome); err != nil || !info.IsDir() {
+		t.Errorf("MVX_HOME %s was not created: %v", mvxHome, err)
+	}
+	if toolsDir := manager.GetToolsDir(); toolsDir != filepath.Join(mvxHome, "tools") {
+		t.Errorf("GetToolsDir() = %q, want it in MVX_HOME", toolsDir)
+	}
+
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced")
+	}
+	readOnly := t.TempDir()
+	if err := os.Chmod(readOnly, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvMvxHome, readOnly)
+	// A read-only home holding tools installed beforehand can be used, installing fails
+	readOnlyManager, err := newManager(&PlatformInfo{OS: "linux", Arch: "amd64"})
+	if err != nil {
+		t.Fatalf("newManager() with a read-only MVX_HOME error = %v", err)
+	}
+	tool := newFakeTool(readOnlyManager, "fake", nil)
+	if err := readOnlyManager.installAndVerify(tool, "1.0.0", config.ToolConfig{Version: "1.0.0"}); err == nil || !strings.Contains(err.Error(), config.EnvMvxHome) {
+		t.Errorf("installAndVerify() with a read-only MVX_HOME error = %v, want one mentioning %s", err, config.EnvMvxHome)
+	}
+}
+
+// binaryFakeTool is a fakeTool whose installs contain its binary
+type binaryFakeTool struct {
+	*fakeTool
+}
+
+func (b *binaryFakeTool) Install(version string, cfg config.ToolConfig) error {
+	if err := b.fakeTool.Install(version, cfg); err != nil {
+		return err
+	}
+	binDir := filepath.Join(b.GetInstallDir(version, cfg), "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(binDir, b.GetBinaryName()), []byte("#!/bin/sh\n"), 0755)
+}
+
+func (b *binaryFakeTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return filepath.Join(b.GetInstallDir(version, cfg), "bin"), nil
+}
+
+func TestFindToolBinary(t *testing.T) {
+	manager := newTestManager(t)
+	tool := &binaryFakeTool{newFakeTool(manager, "alpha", nil)}
+	manager.RegisterTool(tool)
+	cfg := config.ToolConfig{Version: "1.0.0"}
+
+	if _, err := manager.FindToolBinary("alpha", cfg, false); err == nil || !strings.Contains(err.Error(), "not installed") {
+		t.Errorf("FindToolBinary() without install error = %v, want a not installed error", err)
+	}
+	if tool.installCount != 0 {
+		t.Errorf("FindToolBinary() without install installed the tool")
+	}
+
+	expected := filepath.Join(tool.GetInstallDir("1.0.0", cfg), "bin", "alpha")
+	for _, install := range []bool{true, false} {
+		path, err := manager.FindToolBinary("alpha", cfg, install)
+		if err != nil {
+			t.Fatalf("FindToolBinary(install=%v) error = %v", install, err)
+		}
+		if path != expected {
+			t.Errorf("FindToolBinary(install=%v) = %q, want %q", install, path, expected)
+		}
+	}
+	if tool.installCount != 1 {
+		t.Errorf("Expected one install, got %d", tool.installCount)
+	}
+}
@@ -3,11 +3,13 @@ package tools
 import (
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
 	"github.com/gnodet/mvx/pkg/version"
 )
 
@@ -15,6 +17,8 @@ import (
 var _ Tool = (*MavenTool)(nil)
 var _ DependencyProvider = (*MavenTool)(nil)
 var _ EnvironmentProvider = (*MavenTool)(nil)
+var _ SignatureProvider = (*MavenTool)(nil)
+var _ FallbackURLProvider = (*MavenTool)(nil)
 
 // MavenTool implements Tool interface for Maven management
 type MavenTool struct {
@@ -23,22 +27,13 @@ type MavenTool struct {
 
 // NewMavenTool creates a new Maven tool instance
 func NewMavenTool(manager *Manager) Tool {
-	var binaryName = BinaryMaven
-	if NewPlatformMapper().IsWindows() {
-		binaryName = BinaryMaven + ExtCmd
-	}
 	return &MavenTool{
-		BaseTool: NewBaseTool(manager, ToolMaven, binaryName),
+		BaseTool: NewBaseTool(manager, ToolMaven, NewPlatformMapper().BinaryName(BinaryMaven, ExtCmd)),
 	}
 }
 
 // Install downloads and installs the specified Maven version
 func (m *MavenTool) Install(version string, cfg config.ToolConfig) error {
-	return m.installWithFallback(version, cfg)
-}
-
-// installWithFallback tries primary URL first, then fallback archive URL
-func (m *MavenTool) installWithFallback(version string, cfg config.ToolConfig) error {
 	// Check if we should use system tool instead of downloading (use standardized approach)
 	if UseSystemTool(m.GetToolName()) {
 		// Use standardized system tool detection
@@ -51,15 +46,11 @@ func (m *MavenTool) installWithFallback(version string, cfg config.ToolConfig) e
 		return InstallError(m.GetToolName(), version, fmt.Errorf("failed to create install directory: %w", err))
 	}
 
-	// Try both URLs with reduced retries instead of exhausting retries on first URL
-	primaryURL := m.getDownloadURL(version)
-	archiveURL := m.getArchiveDownloadURL(version)
+	// Download from the dist site, falling back to the archive for older versions
 	m.PrintDownloadMessage(version)
-
-	// Try to download with alternating URLs and reduced retries per URL
-	archivePath, err := m.downloadWithAlternatingURLs(primaryURL, archiveURL, version, cfg)
+	archivePath, err := m.Download(m.getDownloadURL(version), version, cfg)
 	if err != nil {
-		return InstallError(m.GetToolName(), version, fmt.Errorf("download failed from both primary and archive URLs: %w", err))
+		return InstallError(m.GetToolName(), version, err)
 	}
 	defer os.Remove(archivePath) // Clean up downloaded file
 
@@ -71,14 +62,14 @@ func (m *MavenTool) installWithFallback(version string, cfg config.ToolConfig) e
 	// Verify installation
 	if err := m.Verify(version, cfg); err != nil {
 		// Installation verification failed, clean up the installation directory
-		fmt.Printf("  ❌ Maven installation verification failed: %v\n", err)
-		fmt.Printf("  🧹 Cleaning up failed installation directory...\n")
+		m.printf("  ❌ Maven installation verification failed: %v\n", err)
+		m.printf("  🧹 Cleaning up failed installation directory...\n")
 		if removeErr := os.RemoveAll(installDir); removeErr != nil {
-			fmt.Printf("  ⚠️  Warning: failed to clean up installation directory: %v\n", removeErr)
+			m.printf("  ⚠️  Warning: failed to clean up installation directory: %v\n", removeErr)
 		}
 		return InstallError("maven", version, fmt.Errorf("installation verification failed: %w", err))
 	}
-	fmt.Printf("  ✅ Maven %s installation verification successful\n", version)
+	m.printf("  ✅ Maven %s installation verification successful\n", version)
 
 	return nil
 }
@@ -96,7 +87,7 @@ func (m *MavenTool) GetPath(version string, cfg config.ToolConfig) (string, erro
 // getInstalledPath returns the path for an installed Maven version
 func (m *MavenTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
 	installDir := m.manager.GetToolVersionDir(m.GetToolName(), version, "")
-	pathResolver := NewPathResolver(m.manager.GetToolsDir())
+	pathResolver := m.manager.GetPathResolver()
 	binDir, err := pathResolver.FindBinaryParentDir(installDir, m.GetBinaryName())
 	if err != nil {
 		return "", err
@@ -146,9 +137,49 @@ func (m *MavenTool) SetupEnvironment(version string, cfg config.ToolConfig, envM
 			envManager.SetEnv(key, value)
 		}
 	}
+	applyMavenRepoLocal(cfg, envManager)
 	return err
 }
 
+// applyMavenRepoLocal adds -Dmaven.repo.local to MAVEN_OPTS when the repo-local option is configured.
+// An existing MAVEN_OPTS is preserved, and left untouched if it already sets a local repository.
+func applyMavenRepoLocal(cfg config.ToolConfig, envManager *EnvironmentManager) {
+	repoLocal := cfg.Options[OptionRepoLocal]
+	if repoLocal == "" {
+		return
+	}
+
+	mavenOpts, _ := envManager.GetEnv(EnvMavenOpts)
+	if strings.Contains(mavenOpts, "-Dmaven.repo.local=") {
+		util.LogVerbose("MAVEN_OPTS already sets maven.repo.local, ignoring %s option", OptionRepoLocal)
+		return
+	}
+
+	// mvn expands MAVEN_OPTS unquoted, a path with whitespace would be split into several arguments
+	if strings.ContainsAny(repoLocal, " \t\n") {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: ignoring %s option %q: MAVEN_OPTS cannot hold a path with whitespace\n", OptionRepoLocal, repoLocal)
+		return
+	}
+
+	repoLocalOpt := "-Dmaven.repo.local=" + repoLocal
+	if mavenOpts != "" {
+		repoLocalOpt = mavenOpts + " " + repoLocalOpt
+	}
+	envManager.SetEnv(EnvMavenOpts, repoLocalOpt)
+}
+
+// resolveRepoLocal returns the tool configuration with a relative repo-local option resolved
+// against the project root, so that Maven uses the same repository from any directory
+func resolveRepoLocal(cfg *config.Config, toolConfig config.ToolConfig) config.ToolConfig {
+	repoLocal := toolConfig.Options[OptionRepoLocal]
+	if repoLocal == "" || cfg.ResolvePath(repoLocal) == repoLocal {
+		return toolConfig
+	}
+	toolConfig.Options = maps.Clone(toolConfig.Options)
+	toolConfig.Options[OptionRepoLocal] = cfg.ResolvePath(repoLocal)
+	return toolConfig
+}
+
 // fetchMavenVersionsFromApache fetches Maven versions from Apache archive repositories
 func (m *MavenTool) fetchMavenVersionsFromApache() ([]string, error) {
 	var allVersions []string
@@ -209,6 +240,12 @@ func (m *MavenTool) getDownloadURL(version string) string {
 	return fmt.Sprintf(ApacheDistBase+"/maven-3/%s/binaries/apache-maven-%s-bin.zip", version, version)
 }
 
+// GetFallbackURLs returns the archive URL of the specified version, which is no longer on the
+// dist site once a newer version is released
+func (m *MavenTool) GetFallbackURLs(version string) []string {
+	return []string{m.getArchiveDownloadURL(version)}
+}
+
 // getArchiveDownloadURL returns the fallback archive URL for the specified version
 func (m *MavenTool) getArchiveDownloadURL(version string) string {
 	if strings.HasPrefix(version, "4.") {
@@ -245,6 +282,16 @@ func (m *MavenTool) GetDownloadURL(version string) string {
 	return m.getDownloadURL(version)
 }
 
+// GetSignatureURL implements SignatureProvider interface for Maven
+func (m *MavenTool) GetSignatureURL(downloadURL string) string {
+	return downloadURL + ".asc"
+}
+
+// GetSignatureKeyURL implements SignatureProvider interface for Maven
+func (m *MavenTool) GetSignatureKeyURL() string {
+	return ApacheMavenKeysURL
+}
+
 // getChecksumURL returns the checksum URL for Maven (internal method)
 func (m *MavenTool) getChecksumURL(version, filename string) string {
 	if strings.HasPrefix(version, "4.") {
@@ -257,7 +304,7 @@ func (m *MavenTool) getChecksumURL(version, filename string) string {
 
 // GetChecksum implements Tool interface for Maven
 func (m *MavenTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
-	fmt.Printf("  🔍 Fetching Maven checksum from Apache archive...\n")
+	m.printf("  🔍 Fetching Maven checksum from Apache archive...\n")
 
 	checksumURL := m.getChecksumURL(version, filename)
 	if checksumURL == "" {
@@ -267,11 +314,11 @@ func (m *MavenTool) GetChecksum(version string, cfg config.ToolConfig, filename
 	// Fetch checksum from Apache archive
 	checksum, err := m.fetchChecksumFromURL(checksumURL)
 	if err != nil {
-		fmt.Printf("  ⚠️  Failed to get Maven checksum: %v\n", err)
+		m.printf("  ⚠️  Failed to get Maven checksum: %v\n", err)
 		return ChecksumInfo{}, err
 	}
 
-	fmt.Printf("  ✅ Found Maven checksum from Apache archive\n")
+	m.printf("  ✅ Found Maven checksum from Apache archive\n")
 	return ChecksumInfo{
 		Type:  SHA512,
 		Value: checksum,
@@ -280,7 +327,10 @@ func (m *MavenTool) GetChecksum(version string, cfg config.ToolConfig, filename
 
 // fetchChecksumFromURL fetches checksum from a URL
 func (m *MavenTool) fetchChecksumFromURL(url string) (string, error) {
-	resp, err := http.Get(url)
+	if IsOffline() {
+		return "", fmt.Errorf("%w: refusing GET %s", ErrOffline, url)
+	}
+	resp, err := m.manager.Get(url)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch checksum: %w", err)
 	}
@@ -303,62 +353,3 @@ func (m *MavenTool) fetchChecksumFromURL(url string) (string, error) {
 
 	return checksum, nil
 }
-
-// downloadWithAlternatingURLs tries downloading from both URLs with reduced retries per URL
-// instead of exhausting all retries on the first URL before trying the second
-func (m *MavenTool) downloadWithAlternatingURLs(primaryURL, archiveURL, version string, cfg config.ToolConfig) (string, error) {
-	urls := []struct {
-		url  string
-		name string
-	}{
-		{primaryURL, "primary"},
-		{archiveURL, "archive"},
-	}
-
-	maxRetries := 3    // Total retries across both URLs
-	retriesPerURL := 1 // Reduced retries per URL to allow trying both
-
-	var lastErr error
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		urlIndex := attempt % len(urls)
-		currentURL := urls[urlIndex]
-
-		if attempt > 0 && urlIndex == 0 {
-			fmt.Printf("  🔄 Trying %s URL again (attempt %d)...\n", currentURL.name, (attempt/len(urls))+1)
-		} else if urlIndex == 1 {
-			fmt.Printf("  🔄 Switching to %s URL...\n", currentURL.name)
-		}
-
-		// Create download config with reduced retries per URL
-		downloadConfig := DefaultDownloadConfig(currentURL.url, "")
-		downloadConfig.MaxRetries = retriesPerURL
-		// Note: MinSize/MaxSize/ExpectedType removed - we rely on checksums for validation
-		downloadConfig.ToolName = m.toolName
-		downloadConfig.Version = version
-		downloadConfig.Config = cfg
-		downloadConfig.Tool = m
-
-		// Create temporary file for download (Maven always uses ZIP)
-		tmpFile, err := os.CreateTemp("", "maven-*.zip")
-		if err != nil {
-			lastErr = fmt.Errorf("failed to create temporary file: %w", err)
-			continue
-		}
-		downloadConfig.DestPath = tmpFile.Name()
-		tmpFile.Close()
-
-		_, err = RobustDownload(downloadConfig)
-		if err == nil {
-			fmt.Printf("  ✅ Successfully downloaded from %s URL\n", currentURL.name)
-			return downloadConfig.DestPath, nil
-		}
-
-		lastErr = err
-		fmt.Printf("  ⚠️  Download from %s URL failed: %v\n", currentURL.name, err)
-		// Clean up failed download
-		os.Remove(downloadConfig.DestPath)
-	}
-
-	return "", fmt.Errorf("all download attempts failed, last error: %w", lastErr)
-}
@@ -3,6 +3,7 @@ package tools
 import (
 	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/gnodet/mvx/pkg/config"
@@ -98,3 +99,97 @@ func TestMavenToolWithSystemMaven(t *testing.T) {
 		t.Error("IsInstalled should return false when MVX_USE_SYSTEM_MAVEN=true but no Maven is available")
 	}
 }
+
+func TestApplyMavenRepoLocal(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   map[string]string
+		mavenOpts string
+		expected  string
+	}{
+		{
+			name:     "no option leaves MAVEN_OPTS unset",
+			options:  nil,
+			expected: "",
+		},
+		{
+			name:     "option sets MAVEN_OPTS",
+			options:  map[string]string{OptionRepoLocal: "/shared/repo"},
+			expected: "-Dmaven.repo.local=/shared/repo",
+		},
+		{
+			name:      "option is appended to existing MAVEN_OPTS",
+			options:   map[string]string{OptionRepoLocal: "/shared/repo"},
+			mavenOpts: "-Xmx1g",
+			expected:  "-Xmx1g -Dmaven.repo.local=/shared/repo",
+		},
+		{
+			name:      "path with spaces is ignored",
+			options:   map[string]string{OptionRepoLocal: "/shared/my repo"},
+			mavenOpts: "-Xmx1g",
+			expected:  "-Xmx1g",
+		},
+		{
+			name:      "existing repo.local in MAVEN_OPTS wins",
+			options:   map[string]string{OptionRepoLocal: "/shared/repo"},
+			mavenOpts: "-Dmaven.repo.local=/my/repo",
+			expected:  "-Dmaven.repo.local=/my/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			envManager := NewEnvironmentManager()
+			if tt.mavenOpts != "" {
+				envManager.SetEnv(EnvMavenOpts, tt.mavenOpts)
+			}
+
+			applyMavenRepoLocal(config.ToolConfig{Version: "3.9.6", Options: tt.options}, envManager)
+
+			mavenOpts, _ := envManager.GetEnv(EnvMavenOpts)
+			if mavenOpts != tt.expected {
+				t.Errorf("MAVEN_OPTS = %q, want %q", mavenOpts, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveRepoLocal(t *testing.T) {
+	projectRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".mvx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectRoot, ".mvx", "config.json5"), []byte(`{project: {name: "test"}, tools: {maven: {version: "3.9.6"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.LoadConfig(projectRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	absolute := filepath.Join(t.TempDir(), "repo")
+
+	tests := []struct {
+		repoLocal string
+		expected  string
+	}{
+		{repoLocal: "", expected: ""},
+		{repoLocal: ".m2/repository", expected: filepath.Join(projectRoot, ".m2", "repository")},
+		{repoLocal: absolute, expected: absolute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoLocal, func(t *testing.T) {
+			toolConfig := config.ToolConfig{Version: "3.9.6"}
+			if tt.repoLocal != "" {
+				toolConfig.Options = map[string]string{OptionRepoLocal: tt.repoLocal}
+			}
+			resolved := resolveRepoLocal(cfg, toolConfig)
+			if resolved.Options[OptionRepoLocal] != tt.expected {
+				t.Errorf("resolveRepoLocal(%q) = %q, want %q", tt.repoLocal, resolved.Options[OptionRepoLocal], tt.expected)
+			}
+			if toolConfig.Options[OptionRepoLocal] != tt.repoLocal {
+				t.Errorf("resolveRepoLocal(%q) modified the configured option", tt.repoLocal)
+			}
+		})
+	}
+}
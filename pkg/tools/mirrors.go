@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+// LoadMirrors loads the mirrors of a tool from the global configuration, in the order they are tried
+func LoadMirrors(toolName string) []string {
+	if toolName == "" {
+		return nil
+	}
+	globalConfig, err := config.LoadGlobalConfig()
+	if err != nil {
+		util.LogVerbose("Warning: failed to load global config: %v", err)
+		return nil
+	}
+	return globalConfig.Mirrors[toolName]
+}
+
+// mirrorURL returns the URL of a download on a mirror: the mirror base URL replaces the scheme
+// and host of the download URL, whose path is kept
+func mirrorURL(mirror, downloadURL string) (string, error) {
+	parsed, err := url.Parse(downloadURL)
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("invalid download URL %s", downloadURL)
+	}
+	if base, err := url.Parse(mirror); err != nil || base.Scheme == "" || base.Host == "" {
+		return "", fmt.Errorf("invalid mirror URL %s", mirror)
+	}
+	mirrored := strings.TrimSuffix(mirror, "/") + parsed.EscapedPath()
+	if parsed.RawQuery != "" {
+		mirrored += "?" + parsed.RawQuery
+	}
+	return mirrored, nil
+}
+
+// downloadURLs returns the URLs a download is tried from, in order: the mirrors of the tool, the
+// download URL and the fallback URLs of the tool, the latter two with URL replacements applied
+func downloadURLs(cfg *DownloadConfig, mirrors []string, replacer *URLReplacer) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(candidate string) {
+		if !seen[candidate] {
+			seen[candidate] = true
+			urls = append(urls, candidate)
+		}
+	}
+
+	for _, mirror := range mirrors {
+		mirrored, err := mirrorURL(mirror, cfg.URL)
+		if err != nil {
+			util.LogVerbose("Warning: ignoring mirror of %s: %v", cfg.ToolName, err)
+			continue
+		}
+		add(mirrored)
+	}
+	for i, candidate := range append([]string{cfg.URL}, cfg.FallbackURLs...) {
+		if replacer != nil {
+			replaced := replacer.ApplyReplacements(candidate)
+			if i == 0 && replaced != candidate {
+				fmt.Fprintf(cfg.output(), "  🔄 %sUsing URL replacement: %s\n", cfg.toolPrefix(), getUserFriendlyURL(replaced))
+			}
+			candidate = replaced
+		}
+		add(candidate)
+	}
+	return urls
+}
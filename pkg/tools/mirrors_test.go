@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMirrorURL(t *testing.T) {
+	tests := []struct {
+		mirror      string
+		downloadURL string
+		expected    string
+		expectError bool
+	}{
+		{
+			mirror:      "https://nexus.corp.com/repository/apache",
+			downloadURL: "https://dist.apache.org/repos/dist/release/maven/maven-3/3.9.9/binaries/apache-maven-3.9.9-bin.zip",
+			expected:    "https://nexus.corp.com/repository/apache/repos/dist/release/maven/maven-3/3.9.9/binaries/apache-maven-3.9.9-bin.zip",
+		},
+		{
+			mirror:      "https://mirror.corp.com/",
+			downloadURL: "https://nodejs.org/dist/v22.1.0/node-v22.1.0-linux-x64.tar.gz?download=1",
+			expected:    "https://mirror.corp.com/dist/v22.1.0/node-v22.1.0-linux-x64.tar.gz?download=1",
+		},
+		{mirror: "mirror.corp.com", downloadURL: "https://nodejs.org/dist/node.tar.gz", expectError: true},
+		{mirror: "https://mirror.corp.com", downloadURL: "/dist/node.tar.gz", expectError: true},
+	}
+
+	for _, tt := range tests {
+		got, err := mirrorURL(tt.mirror, tt.downloadURL)
+		if (err != nil) != tt.expectError {
+			t.Errorf("mirrorURL(%q, %q) error = %v, expectError %v", tt.mirror, tt.downloadURL, err, tt.expectError)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("mirrorURL(%q, %q) = %q, want %q", tt.mirror, tt.downloadURL, got, tt.expected)
+		}
+	}
+}
+
+func TestDownloadURLs(t *testing.T) {
+	cfg := &DownloadConfig{
+		URL:          "https://dist.apache.org/maven/apache-maven-3.9.9-bin.zip",
+		FallbackURLs: []string{"https://archive.apache.org/maven/apache-maven-3.9.9-bin.zip"},
+		Output:       &bytes.Buffer{},
+	}
+	mirrors := []string{"https://nexus.corp.com/apache", "https://archive.apache.org"}
+	replacer := NewURLReplacer(map[string]string{"dist.apache.org": "dist.corp.com"})
+
+	expected := []string{
+		"https://nexus.corp.com/apache/maven/apache-maven-3.9.9-bin.zip",
+		"https://archive.apache.org/maven/apache-maven-3.9.9-bin.zip",
+		"https://dist.corp.com/maven/apache-maven-3.9.9-bin.zip",
+	}
+	if got := downloadURLs(cfg, mirrors, replacer); !reflect.DeepEqual(got, expected) {
+		t.Errorf("downloadURLs() = %q, want %q", got, expected)
+	}
+}
+
+func TestRobustDownloadMirrors(t *testing.T) {
+	content := append([]byte{0x1f, 0x8b}, bytes.Repeat([]byte("mvx"), 100)...)
+
+	var mutex sync.Mutex
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requested = append(requested, r.URL.Path)
+		mutex.Unlock()
+
+		switch r.URL.Path {
+		case "/mirror/dist/tool.tar.gz":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/archive/tool.tar.gz":
+			w.Write(content)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	mvxHome := t.TempDir()
+	t.Setenv("MVX_HOME", mvxHome)
+	t.Setenv("HOME", t.TempDir())
+	globalConfig := `{mirrors: {"alpha": ["` + server.URL + `/mirror"]}}`
+	if err := os.WriteFile(filepath.Join(mvxHome, "config.json5"), []byte(globalConfig), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config := DefaultDownloadConfig(server.URL+"/dist/tool.tar.gz", filepath.Join(t.TempDir(), "tool.tar.gz"))
+	config.ToolName = "alpha"
+	config.FallbackURLs = []string{server.URL + "/archive/tool.tar.gz"}
+	config.MaxRetries = 1
+	config.RetryDelay = time.Millisecond
+	config.MinSize = 1
+	config.Output = &bytes.Buffer{}
+
+	result, err := RobustDownload(config)
+	if err != nil {
+		t.Fatalf("RobustDownload() error = %v", err)
+	}
+	if result.FinalURL != server.URL+"/archive/tool.tar.gz" {
+		t.Errorf("RobustDownload() final URL = %s, want the fallback URL", result.FinalURL)
+	}
+
+	// The mirror is retried, the upstream URL answering 404 is not
+	expected := []string{"/mirror/dist/tool.tar.gz", "/mirror/dist/tool.tar.gz", "/dist/tool.tar.gz", "/archive/tool.tar.gz"}
+	if !reflect.DeepEqual(requested, expected) {
+		t.Errorf("requested %q, want %q", requested, expected)
+	}
+}
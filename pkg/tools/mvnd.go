@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
 	"github.com/gnodet/mvx/pkg/version"
 )
 
@@ -15,6 +17,21 @@ import (
 var _ Tool = (*MvndTool)(nil)
 var _ DependencyProvider = (*MvndTool)(nil)
 var _ EnvironmentProvider = (*MvndTool)(nil)
+var _ FallbackURLProvider = (*MvndTool)(nil)
+var _ PlatformSupportProvider = (*MvndTool)(nil)
+
+// mvndVerifyTimeout bounds 'mvnd --version', which may start a daemon JVM
+const mvndVerifyTimeout = 2 * time.Minute
+
+// mvndPlatforms maps platforms to the suffix of the mvnd archives published for them,
+// e.g. maven-mvnd-1.0.2-darwin-aarch64.zip
+var mvndPlatforms = map[PlatformInfo]string{
+	{OS: "linux", Arch: "amd64"}:   "linux-amd64",
+	{OS: "linux", Arch: "arm64"}:   "linux-aarch64",
+	{OS: "darwin", Arch: "amd64"}:  "darwin-amd64",
+	{OS: "darwin", Arch: "arm64"}:  "darwin-aarch64",
+	{OS: "windows", Arch: "amd64"}: "windows-amd64",
+}
 
 // MvndTool implements Tool interface for Maven Daemon management
 type MvndTool struct {
@@ -22,10 +39,7 @@ type MvndTool struct {
 }
 
 func getMvndBinaryName() string {
-	if NewPlatformMapper().IsWindows() {
-		return "mvnd.exe"
-	}
-	return "mvnd"
+	return NewPlatformMapper().BinaryName(BinaryMvnd, ExtExe)
 }
 
 // NewMvndTool creates a new Mvnd tool instance
@@ -37,7 +51,7 @@ func NewMvndTool(manager *Manager) *MvndTool {
 
 // Install downloads and installs the specified mvnd version
 func (m *MvndTool) Install(version string, cfg config.ToolConfig) error {
-	return m.installWithFallback(version, cfg)
+	return m.install(version, cfg)
 }
 
 // IsInstalled checks if the specified version is installed
@@ -57,7 +71,7 @@ func (m *MvndTool) GetBinaryName() string {
 // getInstalledPath returns the path for an installed Mvnd version
 func (m *MvndTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
 	installDir := m.manager.GetToolVersionDir(m.GetToolName(), version, "")
-	pathResolver := NewPathResolver(m.manager.GetToolsDir())
+	pathResolver := m.manager.GetPathResolver()
 	binDir, err := pathResolver.FindBinaryParentDir(installDir, m.GetBinaryName())
 	if err != nil {
 		return "", err
@@ -65,20 +79,27 @@ func (m *MvndTool) getInstalledPath(version string, cfg config.ToolConfig) (stri
 	return binDir, nil
 }
 
-// Verify checks if the installation is working correctly
+// Verify runs 'mvnd --version', which prints "Apache Maven Daemon (mvnd) <version> <platform> ...",
+// with the JAVA_HOME of the Java it depends on
 func (m *MvndTool) Verify(version string, cfg config.ToolConfig) error {
 	verifyConfig := VerificationConfig{
-		BinaryName:  m.GetBinaryName(),
-		VersionArgs: []string{"--version"},
-		DebugInfo:   false,
+		BinaryName:      m.GetBinaryName(),
+		VersionArgs:     []string{"--version"},
+		ExpectedVersion: version,
+		Timeout:         mvndVerifyTimeout,
+		RequiredEnv:     []string{EnvJavaHome},
 	}
 	return m.StandardVerifyWithConfig(version, cfg, verifyConfig)
 }
 
-// ListVersions returns available mvnd versions
+// ListVersions returns available mvnd versions, from the GitHub releases, or else from the
+// Apache archive
 func (m *MvndTool) ListVersions() ([]string, error) {
-	// Try to fetch versions from Apache archive
-	versions, err := m.fetchMvndVersionsFromApache()
+	versions, err := m.fetchMvndVersionsFromGitHub()
+	if err != nil || len(versions) == 0 {
+		util.LogVerbose("Listing Maven Daemon versions from the Apache archive: %v", err)
+		versions, err = m.fetchMvndVersionsFromApache()
+	}
 	if err != nil {
 		// Fallback to known versions if API is unavailable
 		return m.getFallbackMvndVersions(), nil
@@ -86,6 +107,17 @@ func (m *MvndTool) ListVersions() ([]string, error) {
 	return version.SortVersions(versions), nil
 }
 
+// SupportedPlatforms returns the platforms mvnd is published for (implements PlatformSupportProvider)
+func (m *MvndTool) SupportedPlatforms(version string) []PlatformInfo {
+	var platforms []PlatformInfo
+	for _, platform := range AllPlatforms() {
+		if _, published := mvndPlatforms[platform]; published {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
 // GetDisplayName returns the human-readable name for Mvnd (implements ToolMetadataProvider)
 func (m *MvndTool) GetDisplayName() string {
 	return "Maven Daemon (mvnd)"
@@ -110,6 +142,34 @@ func (m *MvndTool) SetupEnvironment(version string, cfg config.ToolConfig, envMa
 	return err
 }
 
+// fetchMvndVersionsFromGitHub fetches mvnd versions from the GitHub releases, including the
+// milestones and release candidates mvnd 2 was long published as
+func (m *MvndTool) fetchMvndVersionsFromGitHub() ([]string, error) {
+	releases, err := m.manager.fetchGitHubReleases(MvndAPIBase)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, release := range releases {
+		if v, ok := mvndReleaseVersion(release.TagName); ok {
+			versions = append(versions, v)
+		}
+	}
+	return versions, nil
+}
+
+// mvndReleaseVersion returns the version of a release tag such as "1.0.2" or "2.0.0-rc-3"
+func mvndReleaseVersion(tag string) (string, bool) {
+	if tag == "" || tag[0] < '0' || tag[0] > '9' {
+		return "", false
+	}
+	if _, err := version.ParseVersion(tag); err != nil {
+		return "", false
+	}
+	return tag, true
+}
+
 // fetchMvndVersionsFromApache fetches mvnd versions from Apache archive
 func (m *MvndTool) fetchMvndVersionsFromApache() ([]string, error) {
 	registry := m.manager.GetRegistry()
@@ -122,6 +182,52 @@ func (m *MvndTool) fetchMvndVersionsFromApache() ([]string, error) {
 	return mvndVersions, nil
 }
 
+// GetEmbeddedMavenVersion returns the Maven version line embedded in the given mvnd version
+// (e.g. "3.9" for mvnd 1.x, "4.0" for mvnd 2.x)
+func (m *MvndTool) GetEmbeddedMavenVersion(mvndVersion string) (string, error) {
+	v, err := version.ParseVersion(mvndVersion)
+	if err != nil {
+		return "", fmt.Errorf("invalid Maven Daemon version %s: %w", mvndVersion, err)
+	}
+
+	switch v.Major {
+	case 0:
+		return "3", nil
+	case 1:
+		return "3.9", nil
+	case 2:
+		return "4.0", nil
+	default:
+		return "", fmt.Errorf("unknown embedded Maven version for Maven Daemon %s", mvndVersion)
+	}
+}
+
+// CheckMavenCompatibility returns a warning message when the configured Maven version does not
+// match the Maven version embedded in the configured mvnd version, or an empty string otherwise.
+// Mismatches are legitimate in some setups, so callers should only warn.
+func (m *MvndTool) CheckMavenCompatibility(mavenVersion, mvndVersion string) string {
+	embedded, err := m.GetEmbeddedMavenVersion(mvndVersion)
+	if err != nil {
+		return ""
+	}
+
+	mavenParsed, err := version.ParseVersion(mavenVersion)
+	if err != nil {
+		// Version specs like "latest" cannot be checked without resolution
+		return ""
+	}
+	embeddedParsed, err := version.ParseVersion(embedded)
+	if err != nil {
+		return ""
+	}
+
+	if mavenParsed.Major != embeddedParsed.Major {
+		return fmt.Sprintf("mvnd %s embeds Maven %s.x, but maven %s is configured; builds run with mvnd and mvn may behave differently",
+			mvndVersion, embedded, mavenVersion)
+	}
+	return ""
+}
+
 // getFallbackMvndVersions returns known mvnd versions as fallback
 func (m *MvndTool) getFallbackMvndVersions() []string {
 	return []string{
@@ -146,6 +252,12 @@ func (m *MvndTool) getDownloadURL(version string) string {
 	return fmt.Sprintf("https://dist.apache.org/repos/dist/release/maven/mvnd/%s/maven-mvnd-%s-%s.zip", version, version, platform)
 }
 
+// GetFallbackURLs returns the archive URL of the specified version, which is no longer on the
+// dist site once a newer version is released
+func (m *MvndTool) GetFallbackURLs(version string) []string {
+	return []string{m.getArchiveDownloadURL(version)}
+}
+
 // getArchiveDownloadURL returns the fallback archive URL for the specified version
 func (m *MvndTool) getArchiveDownloadURL(version string) string {
 	// Determine platform-specific archive name
@@ -155,26 +267,14 @@ func (m *MvndTool) getArchiveDownloadURL(version string) string {
 	return fmt.Sprintf("https://archive.apache.org/dist/maven/mvnd/%s/maven-mvnd-%s-%s.zip", version, version, platform)
 }
 
-// getPlatformString returns the platform string for mvnd downloads
+// getPlatformString returns the platform string for mvnd downloads, e.g. "darwin-aarch64"
 func (m *MvndTool) getPlatformString() string {
-	platformMapper := NewPlatformMapper()
-
-	switch platformMapper.GetOS() {
-	case "linux":
-		if platformMapper.GetArch() == "arm64" {
-			return "linux-aarch64"
-		}
-		return "linux-amd64"
-	case "darwin":
-		if platformMapper.GetArch() == "arm64" {
-			return "darwin-aarch64"
-		}
-		return "darwin-amd64"
-	case "windows":
-		return "windows-amd64"
-	default:
-		return "linux-amd64" // fallback
+	platform := m.manager.GetPlatform()
+	if suffix, published := mvndPlatforms[platform]; published {
+		return suffix
 	}
+	// Not published: CheckPlatformSupport reports it before any download
+	return platform.OS + "-" + platform.Arch
 }
 
 // ResolveVersion resolves a Mvnd version specification to a concrete version
@@ -210,7 +310,7 @@ func (m *MvndTool) getChecksumURL(version, filename string) string {
 
 // GetChecksum implements Tool interface for Maven Daemon
 func (m *MvndTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
-	fmt.Printf("  🔍 Fetching Maven Daemon checksum from Apache archive...\n")
+	m.printf("  🔍 Fetching Maven Daemon checksum from Apache archive...\n")
 
 	checksumURL := m.getChecksumURL(version, filename)
 	if checksumURL == "" {
@@ -220,11 +320,11 @@ func (m *MvndTool) GetChecksum(version string, cfg config.ToolConfig, filename s
 	// Fetch checksum from Apache archive (reuse Maven's implementation)
 	checksum, err := m.fetchChecksumFromURL(checksumURL)
 	if err != nil {
-		fmt.Printf("  ⚠️  Failed to get Maven Daemon checksum: %v\n", err)
+		m.printf("  ⚠️  Failed to get Maven Daemon checksum: %v\n", err)
 		return ChecksumInfo{}, err
 	}
 
-	fmt.Printf("  ✅ Found Maven Daemon checksum from Apache archive\n")
+	m.printf("  ✅ Found Maven Daemon checksum from Apache archive\n")
 	return ChecksumInfo{
 		Type:  SHA512,
 		Value: checksum,
@@ -233,7 +333,10 @@ func (m *MvndTool) GetChecksum(version string, cfg config.ToolConfig, filename s
 
 // fetchChecksumFromURL fetches checksum from a URL (same as Maven)
 func (m *MvndTool) fetchChecksumFromURL(url string) (string, error) {
-	resp, err := http.Get(url)
+	if IsOffline() {
+		return "", fmt.Errorf("%w: refusing GET %s", ErrOffline, url)
+	}
+	resp, err := m.manager.Get(url)
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch checksum: %w", err)
 	}
@@ -257,8 +360,8 @@ func (m *MvndTool) fetchChecksumFromURL(url string) (string, error) {
 	return checksum, nil
 }
 
-// installWithFallback tries primary URL first, then fallback archive URL with improved retry strategy
-func (m *MvndTool) installWithFallback(version string, cfg config.ToolConfig) error {
+// install downloads and extracts the specified version, unless the system mvnd is used
+func (m *MvndTool) install(version string, cfg config.ToolConfig) error {
 	// Check if we should use system tool instead of downloading
 	if UseSystemTool("mvnd") {
 		return m.StandardInstall(version, cfg, m.getDownloadURL)
@@ -270,86 +373,26 @@ func (m *MvndTool) installWithFallback(version string, cfg config.ToolConfig) er
 		return InstallError("mvnd", version, fmt.Errorf("failed to create install directory: %w", err))
 	}
 
-	// Try both URLs with reduced retries instead of exhausting retries on first URL
-	primaryURL := m.getDownloadURL(version)
-	archiveURL := m.getArchiveDownloadURL(version)
+	// Download from the dist site, falling back to the archive for older versions
 	m.PrintDownloadMessage(version)
-
-	// Try to download with alternating URLs and reduced retries per URL
-	archivePath, err := m.downloadWithAlternatingURLs(primaryURL, archiveURL, version, cfg)
+	archivePath, err := m.Download(m.getDownloadURL(version), version, cfg)
 	if err != nil {
-		return InstallError("mvnd", version, fmt.Errorf("download failed from both primary and archive URLs: %w", err))
+		return InstallError("mvnd", version, err)
 	}
 
-	// Extract archive
+	// Extract archive, whose content is in a maven-mvnd-<version>-<platform> directory
 	if err := m.Extract(archivePath, installDir); err != nil {
 		return InstallError("mvnd", version, fmt.Errorf("failed to extract archive: %w", err))
 	}
+	if _, err := m.getInstalledPath(version, cfg); err != nil {
+		return InstallError("mvnd", version, fmt.Errorf("%s not found in the archive: %w", m.GetBinaryName(), err))
+	}
 
 	// Clean up downloaded archive
 	if err := os.Remove(archivePath); err != nil {
-		fmt.Printf("  ⚠️  Warning: failed to remove archive file: %v\n", err)
+		m.printf("  ⚠️  Warning: failed to remove archive file: %v\n", err)
 	}
 
-	fmt.Printf("  ✅ Maven Daemon %s installed successfully\n", version)
+	m.printf("  ✅ Maven Daemon %s installed successfully\n", version)
 	return nil
 }
-
-// downloadWithAlternatingURLs tries downloading from both URLs with reduced retries per URL
-// instead of exhausting all retries on the first URL before trying the second
-func (m *MvndTool) downloadWithAlternatingURLs(primaryURL, archiveURL, version string, cfg config.ToolConfig) (string, error) {
-	urls := []struct {
-		url  string
-		name string
-	}{
-		{primaryURL, "primary"},
-		{archiveURL, "archive"},
-	}
-
-	maxRetries := 3    // Total retries across both URLs
-	retriesPerURL := 1 // Reduced retries per URL to allow trying both
-
-	var lastErr error
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		urlIndex := attempt % len(urls)
-		currentURL := urls[urlIndex]
-
-		if attempt > 0 && urlIndex == 0 {
-			fmt.Printf("  🔄 Trying %s URL again (attempt %d)...\n", currentURL.name, (attempt/len(urls))+1)
-		} else if urlIndex == 1 {
-			fmt.Printf("  🔄 Switching to %s URL...\n", currentURL.name)
-		}
-
-		// Create download config with reduced retries per URL
-		downloadConfig := DefaultDownloadConfig(currentURL.url, "")
-		downloadConfig.MaxRetries = retriesPerURL
-		// Note: MinSize/MaxSize/ExpectedType removed - we rely on checksums for validation
-		downloadConfig.ToolName = "mvnd"
-		downloadConfig.Version = version
-		downloadConfig.Config = cfg
-		downloadConfig.Tool = m
-
-		// Create temporary file for download (Mvnd always uses ZIP)
-		tmpFile, err := os.CreateTemp("", "mvnd-*.zip")
-		if err != nil {
-			lastErr = fmt.Errorf("failed to create temporary file: %w", err)
-			continue
-		}
-		downloadConfig.DestPath = tmpFile.Name()
-		tmpFile.Close()
-
-		_, err = RobustDownload(downloadConfig)
-		if err == nil {
-			fmt.Printf("  ✅ Successfully downloaded from %s URL\n", currentURL.name)
-			return downloadConfig.DestPath, nil
-		}
-
-		lastErr = err
-		fmt.Printf("  ⚠️  Download from %s URL failed: %v\n", currentURL.name, err)
-		// Clean up failed download
-		os.Remove(downloadConfig.DestPath)
-	}
-
-	return "", fmt.Errorf("all download attempts failed, last error: %w", lastErr)
-}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestMvndEmbeddedMavenVersion(t *testing.T) {
+	tool := NewMvndTool(nil)
+
+	tests := []struct {
+		mvndVersion string
+		expected    string
+		wantErr     bool
+	}{
+		{"0.9.0", "3", false},
+		{"1.0.2", "3.9", false},
+		{"2.0.0-rc-3", "4.0", false},
+		{"9.0.0", "", true},
+		{"invalid", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mvndVersion, func(t *testing.T) {
+			embedded, err := tool.GetEmbeddedMavenVersion(tt.mvndVersion)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetEmbeddedMavenVersion(%s) error = %v, wantErr %v", tt.mvndVersion, err, tt.wantErr)
+			}
+			if embedded != tt.expected {
+				t.Errorf("GetEmbeddedMavenVersion(%s) = %s, want %s", tt.mvndVersion, embedded, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckToolCompatibility(t *testing.T) {
+	manager := newTestManager(t)
+	manager.RegisterTool(NewMvndTool(manager))
+
+	tests := []struct {
+		name         string
+		maven        string
+		mvnd         string
+		expectWarned bool
+	}{
+		{"matching Maven 3", "3.9.6", "1.0.2", false},
+		{"matching Maven 4", "4.0.0-rc-4", "2.0.0-rc-3", false},
+		{"Maven 4 with mvnd 1", "4.0.0-rc-4", "1.0.2", true},
+		{"Maven 3 with mvnd 2", "3.9.6", "2.0.0-rc-3", true},
+		{"unresolved Maven spec", "latest", "1.0.2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Tools: map[string]config.ToolConfig{
+					ToolMaven: {Version: tt.maven},
+					ToolMvnd:  {Version: tt.mvnd},
+				},
+			}
+			warnings := manager.CheckToolCompatibility(cfg)
+			if (len(warnings) > 0) != tt.expectWarned {
+				t.Errorf("CheckToolCompatibility() = %v, expectWarned %v", warnings, tt.expectWarned)
+			}
+		})
+	}
+}
+
+func TestMvndDownloadURLs(t *testing.T) {
+	tests := []struct {
+		os       string
+		arch     string
+		expected string
+	}{
+		{"linux", "amd64", "maven-mvnd-1.0.2-linux-amd64.zip"},
+		{"linux", "arm64", "maven-mvnd-1.0.2-linux-aarch64.zip"},
+		{"darwin", "amd64", "maven-mvnd-1.0.2-darwin-amd64.zip"},
+		{"darwin", "arm64", "maven-mvnd-1.0.2-darwin-aarch64.zip"},
+		{"windows", "amd64", "maven-mvnd-1.0.2-windows-amd64.zip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.os+"-"+tt.arch, func(t *testing.T) {
+			manager := newTestManager(t)
+			manager.platform = &PlatformInfo{OS: tt.os, Arch: tt.arch}
+			mvndTool := NewMvndTool(manager)
+			if got := mvndTool.GetDownloadURL("1.0.2"); got != ApacheDistBase+"/mvnd/1.0.2/"+tt.expected {
+				t.Errorf("GetDownloadURL() = %s, want %s", got, tt.expected)
+			}
+			if err := manager.CheckPlatformSupport(mvndTool, "1.0.2", *manager.platform); err != nil {
+				t.Errorf("CheckPlatformSupport() error = %v", err)
+			}
+		})
+	}
+
+	manager := newTestManager(t)
+	if err := manager.CheckPlatformSupport(NewMvndTool(manager), "1.0.2", PlatformInfo{OS: "windows", Arch: "arm64"}); err == nil {
+		t.Error("CheckPlatformSupport() accepted windows/arm64, for which mvnd isn't published")
+	}
+}
+
+func TestMvndReleaseVersion(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected string
+		ok       bool
+	}{
+		{"1.0.2", "1.0.2", true},
+		{"2.0.0-rc-3", "2.0.0-rc-3", true},
+		{"0.9.0", "0.9.0", true},
+		{"latest", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := mvndReleaseVersion(tt.tag)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("mvndReleaseVersion(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestMvndInstalledPath(t *testing.T) {
+	manager := newTestManager(t)
+	manager.platform = &PlatformInfo{OS: "linux", Arch: "amd64"}
+	mvndTool := NewMvndTool(manager)
+
+	// Archives contain a maven-mvnd-<version>-<platform> directory
+	binDir := filepath.Join(manager.GetToolVersionDir(ToolMvnd, "1.0.2", ""), "maven-mvnd-1.0.2-linux-amd64", "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(binDir, "mvnd"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := mvndTool.getInstalledPath("1.0.2", config.ToolConfig{Version: "1.0.2"})
+	if err != nil {
+		t.Fatalf("getInstalledPath() error = %v", err)
+	}
+	if path != binDir {
+		t.Errorf("getInstalledPath() = %s, want %s", path, binDir)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"runtime"
+	"path"
 	"strings"
 
 	"github.com/gnodet/mvx/pkg/config"
@@ -15,6 +15,8 @@ import (
 // Compile-time interface validation
 var _ Tool = (*NodeTool)(nil)
 var _ EnvironmentProvider = (*NodeTool)(nil)
+var _ PlatformSupportProvider = (*NodeTool)(nil)
+var _ VersionCanonicalizer = (*NodeTool)(nil)
 
 // NodeTool manages Node.js
 // Downloads from https://nodejs.org/dist/
@@ -23,10 +25,7 @@ type NodeTool struct {
 }
 
 func getNodeBinaryName() string {
-	if NewPlatformMapper().IsWindows() {
-		return BinaryNode + ExtExe
-	}
-	return BinaryNode
+	return NewPlatformMapper().BinaryName(BinaryNode, ExtExe)
 }
 
 // NewNodeTool creates a new Node tool instance
@@ -55,7 +54,7 @@ func (n *NodeTool) GetBinaryName() string {
 // getInstalledPath returns the path for an installed Node version
 func (n *NodeTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
 	installDir := n.manager.GetToolVersionDir(n.GetToolName(), version, "")
-	pathResolver := NewPathResolver(n.manager.GetToolsDir())
+	pathResolver := n.manager.GetPathResolver()
 	binDir, err := pathResolver.FindBinaryParentDir(installDir, n.GetBinaryName())
 	if err != nil {
 		return "", err
@@ -81,11 +80,28 @@ func (n *NodeTool) ListVersions() ([]string, error) {
 	return version.SortVersions(versions), nil
 }
 
+// nodeDarwinArm64Since is the first Node.js release published for Apple Silicon
+var nodeDarwinArm64Since = version.Version{Major: 16}
+
+// SupportedPlatforms returns the platforms a Node.js version is published for (implements PlatformSupportProvider)
+func (n *NodeTool) SupportedPlatforms(v string) []PlatformInfo {
+	parsed, err := version.ParseVersion(v)
+	if err != nil || parsed.Compare(&nodeDarwinArm64Since) >= 0 {
+		return AllPlatforms()
+	}
+	return platformsExcept(PlatformInfo{OS: "darwin", Arch: "arm64"})
+}
+
 // GetDisplayName returns the human-readable name for Node.js (implements ToolMetadataProvider)
 func (n *NodeTool) GetDisplayName() string {
 	return "Node.js"
 }
 
+// CanonicalVersion strips the "v" prefix of Node.js versions (implements VersionCanonicalizer)
+func (n *NodeTool) CanonicalVersion(raw string) string {
+	return strings.TrimPrefix(raw, "v")
+}
+
 // SetupEnvironment sets up Node.js-specific environment variables (implements EnvironmentProvider)
 func (n *NodeTool) SetupEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error {
 	// Convert EnvironmentManager to map for the existing helper
@@ -157,7 +173,7 @@ func (n *NodeTool) fetchNodeLTSVersions() ([]string, error) {
 }
 
 func (n *NodeTool) getDownloadURL(version string) string {
-	platformMapper := NewPlatformMapper()
+	platformMapper := n.manager.GetPlatformMapper()
 
 	// Generate Node.js platform string
 	var platform string
@@ -222,24 +238,30 @@ func (n *NodeTool) ResolveVersion(versionSpec, distribution string) (string, err
 	return resolved, nil
 }
 
-// GetChecksum implements ChecksumProvider interface for Node.js
+// GetChecksum implements ChecksumProvider interface for Node.js, looking the downloaded
+// archive up in the SHASUMS256.txt file published with each release
 func (n *NodeTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
-	fmt.Printf("  🔍 Fetching Node.js checksum from SHASUMS256.txt...\n")
+	if filename == "" {
+		filename = path.Base(n.getDownloadURL(version))
+	}
+
+	n.printf("  🔍 Fetching Node.js checksum from SHASUMS256.txt...\n")
 
 	checksum, err := n.fetchNodeChecksum(version, filename)
 	if err != nil {
-		fmt.Printf("  ⚠️  Failed to get Node.js checksum from SHASUMS256.txt: %v\n", err)
+		n.printf("  ⚠️  Failed to get Node.js checksum from SHASUMS256.txt: %v\n", err)
 		return ChecksumInfo{}, err
 	}
 
-	fmt.Printf("  ✅ Found Node.js checksum from SHASUMS256.txt\n")
+	n.printf("  ✅ Found Node.js checksum from SHASUMS256.txt\n")
 	return ChecksumInfo{
-		Type:  SHA256,
-		Value: checksum,
+		Type:     SHA256,
+		Value:    checksum,
+		Filename: filename,
 	}, nil
 }
 
-// fetchNodeChecksum fetches Node.js checksum from SHASUMS256.txt
+// fetchNodeChecksum fetches the checksum of a release file from SHASUMS256.txt
 func (n *NodeTool) fetchNodeChecksum(version, filename string) (string, error) {
 	url := fmt.Sprintf("%s/v%s/SHASUMS256.txt", NodeJSDistBase, version)
 
@@ -253,90 +275,30 @@ func (n *NodeTool) fetchNodeChecksum(version, filename string) (string, error) {
 		return "", fmt.Errorf("Node.js checksums returned status %d", resp.StatusCode)
 	}
 
-	// Read and parse the checksum file content
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("failed to read Node.js checksums: %w", err)
 	}
 
-	content := string(body)
-
-	// Parse the checksum file to find the checksum for our filename
-	nodeFilename := n.getNodeFilename(version)
-	return n.parseNodeChecksumFile(content, nodeFilename)
+	return parseNodeChecksumFile(string(body), filename)
 }
 
-// parseNodeChecksumFile parses Node.js SHASUMS256.txt content to find checksum for specific filename
-func (n *NodeTool) parseNodeChecksumFile(content, filename string) (string, error) {
-	lines := strings.Split(content, "\n")
-	var candidateFiles []string
-
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
+// parseNodeChecksumFile returns the checksum SHASUMS256.txt lists for a file,
+// each line being formatted as "<sha256>  <filename>"
+func parseNodeChecksumFile(content, filename string) (string, error) {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || path.Base(fields[1]) != filename {
 			continue
 		}
-
-		// Split on whitespace - Node.js format is: checksum  filename
-		parts := strings.Fields(line)
-		if len(parts) < 2 {
-			continue
-		}
-
-		checksum := parts[0]
-		fileInLine := parts[1]
-
-		// Store candidate files for debugging
-		candidateFiles = append(candidateFiles, fileInLine)
-
-		// Check if this line matches our filename (exact match)
-		if fileInLine == filename {
-			return checksum, nil
-		}
-
-		// Also try matching just the basename
-		if strings.Contains(fileInLine, "/") {
-			parts := strings.Split(fileInLine, "/")
-			basename := parts[len(parts)-1]
-			if basename == filename {
-				return checksum, nil
-			}
+		if !sha256Pattern.MatchString(fields[0]) {
+			return "", fmt.Errorf("invalid SHA-256 %q listed for Node.js file %s", fields[0], filename)
 		}
+		return strings.ToLower(fields[0]), nil
 	}
-
-	// If we get here, no match was found - provide helpful debug info
-	fmt.Printf("⚠️  Debug: Available Node.js files in SHASUMS256.txt: %v\n", candidateFiles)
-	fmt.Printf("   Looking for: %s\n", filename)
 	return "", fmt.Errorf("checksum not found for Node.js file %s", filename)
 }
 
-// getNodeFilename determines the correct Node.js filename based on version and platform
-func (n *NodeTool) getNodeFilename(version string) string {
-	platform := ""
-	switch runtime.GOOS {
-	case "linux":
-		if runtime.GOARCH == "arm64" {
-			platform = "linux-arm64"
-		} else {
-			platform = "linux-x64"
-		}
-	case "darwin":
-		if runtime.GOARCH == "arm64" {
-			platform = "darwin-arm64"
-		} else {
-			platform = "darwin-x64"
-		}
-	case "windows":
-		platform = "win-x64"
-	}
-
-	// Windows uses zip, others tar.gz
-	if runtime.GOOS == "windows" {
-		return fmt.Sprintf("node-v%s-%s.zip", version, platform)
-	}
-	return fmt.Sprintf("node-v%s-%s.tar.gz", version, platform)
-}
-
 // GetDownloadURL implements URLProvider interface for Node.js
 func (n *NodeTool) GetDownloadURL(version string) string {
 	return n.getDownloadURL(version)
@@ -1,9 +1,13 @@
 package tools
 
 import (
+	"net/http"
+	"path"
 	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
 )
 
 func TestNodeToolBasicFunctionality(t *testing.T) {
@@ -65,6 +69,56 @@ func TestNodeToolAutomaticArchiveDetection(t *testing.T) {
 	}
 }
 
+func TestNodeGetChecksum(t *testing.T) {
+	hash := "2a0c9b2d5c6e8f9a1b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f708192"
+	shasums := strings.Repeat("1", 64) + "  node-v20.19.5-darwin-arm64.tar.gz\n" +
+		strings.ToUpper(hash) + "  node-v20.19.5-linux-x64.tar.gz\n" +
+		strings.Repeat("3", 64) + "  node-v20.19.5-linux-x64.tar.xz\n" +
+		strings.Repeat("4", 64) + "  win-x64/node.exe\n"
+
+	tests := []struct {
+		os       string
+		arch     string
+		filename string
+		expected string
+	}{
+		{os: "linux", arch: "amd64", expected: hash},
+		{os: "darwin", arch: "arm64", expected: strings.Repeat("1", 64)},
+		{os: "linux", arch: "amd64", filename: "node-v20.19.5-linux-x64.tar.xz", expected: strings.Repeat("3", 64)},
+		{os: "linux", arch: "amd64", filename: "node.exe", expected: strings.Repeat("4", 64)},
+		{os: "windows", arch: "amd64"}, // The zip is not listed
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.os+"-"+tt.arch+"-"+tt.filename, func(t *testing.T) {
+			manager := newTestManager(t)
+			manager.platform = &PlatformInfo{OS: tt.os, Arch: tt.arch}
+			manager.httpClient = &http.Client{Transport: staticTransport{
+				NodeJSDistBase + "/v20.19.5/SHASUMS256.txt": shasums,
+			}}
+			nodeTool := NewNodeTool(manager)
+			if !nodeTool.SupportsChecksumVerification() {
+				t.Fatal("SupportsChecksumVerification() = false, want true")
+			}
+
+			filename := tt.filename
+			if filename == "" {
+				filename = path.Base(nodeTool.GetDownloadURL("20.19.5"))
+			}
+			checksum, err := nodeTool.GetChecksum("20.19.5", config.ToolConfig{}, filename)
+			if tt.expected == "" {
+				if err == nil {
+					t.Errorf("GetChecksum(%s) = %+v, want an error", filename, checksum)
+				}
+				return
+			}
+			if err != nil || checksum.Type != SHA256 || checksum.Value != tt.expected {
+				t.Errorf("GetChecksum(%s) = %+v, %v, want SHA-256 %s", filename, checksum, err, tt.expected)
+			}
+		})
+	}
+}
+
 // Helper function to check if a string ends with a suffix
 func endsWith(s, suffix string) bool {
 	return len(s) >= len(suffix) && s[len(s)-len(suffix):] == suffix
@@ -98,3 +152,17 @@ func TestNodeToolTempFileExtension(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeCanonicalVersion(t *testing.T) {
+	nodeTool := NewNodeTool(newTestManager(t))
+
+	tests := map[string]string{
+		"v18.17.0": "18.17.0",
+		"18.17.0":  "18.17.0",
+	}
+	for raw, expected := range tests {
+		if got := nodeTool.CanonicalVersion(raw); got != expected {
+			t.Errorf("CanonicalVersion(%q) = %q, expected %q", raw, got, expected)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+// ErrOffline is returned instead of performing network requests in offline mode
+var ErrOffline = errors.New("offline mode (" + EnvOffline + "=true)")
+
+// IsOffline reports whether offline mode is enabled, in which mvx never touches the network
+func IsOffline() bool {
+	return os.Getenv(EnvOffline) == "true"
+}
+
+// OfflineInstallError reports a tool version that would have to be downloaded in offline mode
+func OfflineInstallError(tool, version string) *ToolError {
+	return InstallError(tool, version, fmt.Errorf("%w: not installed, install it while online or unset %s", ErrOffline, EnvOffline))
+}
+
+// AllowStaleMetadata lets cached metadata of any age replace requests that are refused offline,
+// fail or are rate-limited, for commands that prefer stale data to none, such as tools search
+func (m *Manager) AllowStaleMetadata() {
+	m.staleMetadata = true
+}
+
+// UsedStaleMetadata reports whether some metadata was not fetched live: it came from an
+// expired cache entry, or was unavailable and tools used their fallback data
+func (m *Manager) UsedStaleMetadata() bool {
+	return m.metadataUnavailable.Load()
+}
+
+// getStaleMetadata returns the disk cached response of a URL whatever its age, when stale
+// metadata is allowed
+func (m *Manager) getStaleMetadata(url string) ([]byte, bool) {
+	if !m.staleMetadata {
+		return nil, false
+	}
+	entry, found := m.readDiskCacheEntry(url)
+	if !found {
+		return nil, false
+	}
+	util.LogVerbose("Using cached metadata from %s: %s", entry.Timestamp.Format(time.RFC3339), url)
+	m.metadataUnavailable.Store(true)
+	return []byte(entry.Body), true
+}
+
+// cachedResponse returns a successful response with a cached body
+func cachedResponse(body []byte) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Header:     make(http.Header),
+	}
+}
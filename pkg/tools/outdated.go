@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// ToolUpdate reports the newest version of the release line of a configured tool
+type ToolUpdate struct {
+	Tool     string
+	Current  string // Version the configuration resolves to
+	Latest   string // Newest version of the same release line
+	Outdated bool
+	Err      error // Set when the tool could not be checked
+}
+
+// CheckUpdates resolves, for each configured tool, the newest version of its release line and
+// reports whether it is newer than the version the configuration uses. Nothing is changed or
+// installed. Results are sorted by tool name.
+func (m *Manager) CheckUpdates(cfg *config.Config) []ToolUpdate {
+	toolNames := make([]string, 0, len(cfg.Tools))
+	for toolName := range cfg.Tools {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	updates := make([]ToolUpdate, 0, len(toolNames))
+	for _, toolName := range toolNames {
+		updates = append(updates, m.checkUpdate(toolName, cfg.Tools[toolName]))
+	}
+	return updates
+}
+
+// checkUpdate compares the version a tool configuration resolves to with the newest of its line
+func (m *Manager) checkUpdate(toolName string, toolConfig config.ToolConfig) ToolUpdate {
+	update := ToolUpdate{Tool: toolName}
+	current, err := m.resolveVersion(toolName, toolConfig)
+	if err != nil {
+		update.Err = err
+		return update
+	}
+	update.Current = m.CanonicalVersion(toolName, current)
+
+	// The newest version of the line, whatever the configured resolution preference
+	lineConfig := toolConfig
+	lineConfig.Version = releaseLine(toolConfig.Version)
+	lineConfig.Options = make(map[string]string, len(toolConfig.Options))
+	for key, value := range toolConfig.Options {
+		if key != OptionResolve {
+			lineConfig.Options[key] = value
+		}
+	}
+	latest, err := m.resolveVersionInternal(toolName, lineConfig)
+	if err != nil {
+		update.Err = err
+		return update
+	}
+	update.Latest = m.CanonicalVersion(toolName, latest)
+
+	currentVersion, errCurrent := version.ParseVersion(update.Current)
+	latestVersion, errLatest := version.ParseVersion(update.Latest)
+	if errCurrent == nil && errLatest == nil {
+		update.Outdated = latestVersion.Compare(currentVersion) > 0
+	}
+	return update
+}
+
+// releaseLine returns the version specification of the release line a configured version
+// belongs to. Exact versions follow their major version, like "^" ranges, while specifications
+// such as "21", "3.9" or "lts" are kept, as they already select a line.
+func releaseLine(versionSpec string) string {
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil || spec.Constraint != "exact" || spec.Pre != "" {
+		return versionSpec
+	}
+	return strconv.Itoa(spec.Major)
+}
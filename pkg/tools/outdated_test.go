@@ -0,0 +1,70 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestCheckUpdates(t *testing.T) {
+	manager := newTestManager(t)
+	alpha := newFakeTool(manager, "alpha", nil)
+	alpha.versions = []string{"1.0.0", "1.2.0", "2.0.0"}
+	manager.RegisterTool(&resolvingFakeTool{alpha})
+	beta := newFakeTool(manager, "beta", nil)
+	beta.versions = []string{"3.0.0", "3.1.0"}
+	manager.RegisterTool(&resolvingFakeTool{beta})
+	gamma := newFakeTool(manager, "gamma", nil)
+	gamma.versions = []string{"1.0.0", "1.1.0"}
+	manager.RegisterTool(&resolvingFakeTool{gamma})
+
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{
+		"alpha":   {Version: "1.0.0"},
+		"beta":    {Version: "3"},
+		"gamma":   {Version: "1", Options: map[string]string{OptionResolve: "lowest"}},
+		"unknown": {Version: "1.0.0"},
+	}}
+
+	expected := []ToolUpdate{
+		{Tool: "alpha", Current: "1.0.0", Latest: "1.2.0", Outdated: true},
+		{Tool: "beta", Current: "3.1.0", Latest: "3.1.0"},
+		{Tool: "gamma", Current: "1.0.0", Latest: "1.1.0", Outdated: true},
+		{Tool: "unknown", Current: "1.0.0"},
+	}
+
+	updates := manager.CheckUpdates(cfg)
+	if len(updates) != len(expected) {
+		t.Fatalf("CheckUpdates() = %+v, want %d results", updates, len(expected))
+	}
+	for i, want := range expected {
+		got := updates[i]
+		if got.Tool != want.Tool || got.Current != want.Current || got.Latest != want.Latest || got.Outdated != want.Outdated {
+			t.Errorf("CheckUpdates()[%d] = %+v, want %+v", i, got, want)
+		}
+		if (got.Err != nil) != (want.Tool == "unknown") {
+			t.Errorf("CheckUpdates()[%d] error = %v", i, got.Err)
+		}
+	}
+}
+
+func TestReleaseLine(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{"3.9.6", "3"},
+		{"21.0.1+12", "21"},
+		{"3.9", "3.9"},
+		{"21", "21"},
+		{"lts", "lts"},
+		{"1.0.0-rc1", "1.0.0-rc1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			if got := releaseLine(tt.spec); got != tt.expected {
+				t.Errorf("releaseLine(%q) = %q, want %q", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
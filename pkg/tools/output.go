@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+// toolOutput collects the messages about a tool installed in parallel with other tools, so
+// that they are printed together once its install completes instead of interleaving with the
+// messages of the other installs. Only complete lines are printed.
+type toolOutput struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+// Write appends messages to the group (implements io.Writer)
+func (o *toolOutput) Write(p []byte) (int, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.buffer.Write(p)
+}
+
+// lines returns the complete lines written so far, ending an incomplete last line
+func (o *toolOutput) lines() string {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	text := o.buffer.String()
+	if text != "" && text[len(text)-1] != '\n' {
+		text += "\n"
+	}
+	return text
+}
+
+// output returns where the messages about a tool go: its group while it is installed in
+// parallel with other tools (see groupOutput), or else stdout, or a JSON log in JSON log format
+func (m *Manager) output(toolName string) io.Writer {
+	if util.IsJSONLog() {
+		return util.NewLogWriter(toolName)
+	}
+	m.outputsMutex.Lock()
+	defer m.outputsMutex.Unlock()
+	if out, found := m.outputs[toolName]; found {
+		return out
+	}
+	return os.Stdout
+}
+
+// groupOutput starts collecting the messages about a tool, until releaseOutput
+func (m *Manager) groupOutput(toolName string) {
+	m.outputsMutex.Lock()
+	defer m.outputsMutex.Unlock()
+	if m.outputs == nil {
+		m.outputs = make(map[string]*toolOutput)
+	}
+	m.outputs[toolName] = &toolOutput{}
+}
+
+// releaseOutput stops collecting the messages about a tool, and returns the lines collected
+func (m *Manager) releaseOutput(toolName string) string {
+	m.outputsMutex.Lock()
+	out, found := m.outputs[toolName]
+	delete(m.outputs, toolName)
+	m.outputsMutex.Unlock()
+	if !found {
+		return ""
+	}
+	return out.lines()
+}
+
+// statusf prints a status message about a tool, to stdout or as a JSON log entry
+func statusf(toolName, format string, args ...interface{}) {
+	if util.IsJSONLog() {
+		fmt.Fprintf(util.NewLogWriter(toolName), format, args...)
+		return
+	}
+	fmt.Printf(format, args...)
+}
+
+// printf prints a message about the tool to its output
+func (b *BaseTool) printf(format string, args ...interface{}) {
+	fmt.Fprintf(b.manager.output(b.toolName), format, args...)
+}
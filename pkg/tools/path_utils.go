@@ -12,12 +12,19 @@ import (
 // PathResolver provides common path resolution utilities for tools
 type PathResolver struct {
 	toolsDir string
+	platform *PlatformMapper
 }
 
-// NewPathResolver creates a new path resolver
+// NewPathResolver creates a new path resolver for the host platform
 func NewPathResolver(toolsDir string) *PathResolver {
+	return NewPathResolverFor(toolsDir, NewPlatformMapper())
+}
+
+// NewPathResolverFor creates a new path resolver probing the binary names of the given platform
+func NewPathResolverFor(toolsDir string, platform *PlatformMapper) *PathResolver {
 	return &PathResolver{
 		toolsDir: toolsDir,
+		platform: platform,
 	}
 }
 
@@ -135,7 +142,8 @@ func (ipm *InstallationPathManager) CleanupFailedInstallation(installDir string)
 	return os.RemoveAll(installDir)
 }
 
-// FindBinaryParentDir recursively searches for any binary in binaryNames under rootDir.
+// FindBinaryParentDir recursively searches for the binary under rootDir, accepting any of its
+// platform names (e.g. mvn.cmd or mvn.bat on Windows).
 // Returns the parent directory of the first found binary.
 func (r *PathResolver) FindBinaryParentDir(rootDir string, binaryName string) (string, error) {
 	var foundPath string
@@ -144,7 +152,7 @@ func (r *PathResolver) FindBinaryParentDir(rootDir string, binaryName string) (s
 			return err
 		}
 		if !info.IsDir() {
-			if info.Name() == binaryName {
+			if r.platform.IsBinaryName(info.Name(), binaryName) {
 				foundPath = filepath.Dir(path)
 				return filepath.SkipDir // Stop walking once found
 			}
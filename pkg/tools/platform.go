@@ -2,7 +2,11 @@ package tools
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 )
 
 // PlatformInfo contains platform detection information
@@ -19,6 +23,54 @@ func GetPlatformInfo() PlatformInfo {
 	}
 }
 
+// String returns the platform as an os-arch string (e.g., linux-arm64)
+func (p PlatformInfo) String() string {
+	return fmt.Sprintf("%s-%s", p.OS, p.Arch)
+}
+
+// supportedPlatforms lists the architectures tools can be installed for, per operating system
+var supportedPlatforms = map[string][]string{
+	"linux":   {"amd64", "arm64"},
+	"darwin":  {"amd64", "arm64"},
+	"windows": {"amd64"},
+}
+
+// ValidatePlatform checks that tools can be installed for the given os/arch combination
+func ValidatePlatform(platform PlatformInfo) error {
+	archs, ok := supportedPlatforms[platform.OS]
+	if !ok {
+		return fmt.Errorf("unsupported operating system %q (supported: linux, darwin, windows)", platform.OS)
+	}
+	for _, arch := range archs {
+		if arch == platform.Arch {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported architecture %q for %s (supported: %s)", platform.Arch, platform.OS, strings.Join(archs, ", "))
+}
+
+// AllPlatforms returns every supported os/arch combination, in a stable order
+func AllPlatforms() []PlatformInfo {
+	var platforms []PlatformInfo
+	for _, osName := range []string{"linux", "darwin", "windows"} {
+		for _, arch := range supportedPlatforms[osName] {
+			platforms = append(platforms, PlatformInfo{OS: osName, Arch: arch})
+		}
+	}
+	return platforms
+}
+
+// platformsExcept returns all supported platforms but the excluded ones
+func platformsExcept(excluded ...PlatformInfo) []PlatformInfo {
+	var platforms []PlatformInfo
+	for _, platform := range AllPlatforms() {
+		if !slices.Contains(excluded, platform) {
+			platforms = append(platforms, platform)
+		}
+	}
+	return platforms
+}
+
 // PlatformMapper provides platform-specific string generation for different tools
 type PlatformMapper struct {
 	platform PlatformInfo
@@ -26,8 +78,13 @@ type PlatformMapper struct {
 
 // NewPlatformMapper creates a new platform mapper
 func NewPlatformMapper() *PlatformMapper {
+	return NewPlatformMapperFor(GetPlatformInfo())
+}
+
+// NewPlatformMapperFor creates a new platform mapper for the given platform
+func NewPlatformMapperFor(platform PlatformInfo) *PlatformMapper {
 	return &PlatformMapper{
-		platform: GetPlatformInfo(),
+		platform: platform,
 	}
 }
 
@@ -67,6 +124,60 @@ func (pm *PlatformMapper) IsWindows() bool {
 	return pm.platform.OS == "windows"
 }
 
+// windowsBinaryExtensions lists the executable extensions probed on Windows, in order of preference
+var windowsBinaryExtensions = []string{ExtExe, ExtCmd, ExtBat}
+
+// BinaryName returns the name of a binary on the platform: base on Unix, base with the
+// given extension (ExtExe, ExtCmd or ExtBat) on Windows
+func (pm *PlatformMapper) BinaryName(base, windowsExt string) string {
+	if pm.IsWindows() {
+		return base + windowsExt
+	}
+	return base
+}
+
+// BinaryNames returns the file names a binary may have on the platform, the given name first.
+// On Windows launchers ship as .exe, .cmd or .bat depending on the tool and its version, so
+// all of them are candidates.
+func (pm *PlatformMapper) BinaryNames(name string) []string {
+	if !pm.IsWindows() {
+		return []string{name}
+	}
+	base := name
+	if ext := filepath.Ext(name); slices.Contains(windowsBinaryExtensions, strings.ToLower(ext)) {
+		base = strings.TrimSuffix(name, ext)
+	}
+	names := []string{name}
+	for _, ext := range windowsBinaryExtensions {
+		if candidate := base + ext; !strings.EqualFold(candidate, name) {
+			names = append(names, candidate)
+		}
+	}
+	return names
+}
+
+// IsBinaryName reports whether fileName is one of the names of a binary on the platform.
+// File names are compared case-insensitively on Windows.
+func (pm *PlatformMapper) IsBinaryName(fileName, name string) bool {
+	for _, candidate := range pm.BinaryNames(name) {
+		if fileName == candidate || (pm.IsWindows() && strings.EqualFold(fileName, candidate)) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindBinary returns the path of a binary in dir, probing all its names on the platform
+func (pm *PlatformMapper) FindBinary(dir, name string) (string, bool) {
+	for _, candidate := range pm.BinaryNames(name) {
+		path := filepath.Join(dir, candidate)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
 // IsUnix returns true if the current platform is Unix-like (Linux, macOS, etc.)
 func (pm *PlatformMapper) IsUnix() bool {
 	return pm.platform.OS != "windows"
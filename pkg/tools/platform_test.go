@@ -0,0 +1,148 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidatePlatform(t *testing.T) {
+	tests := []struct {
+		platform PlatformInfo
+		wantErr  bool
+	}{
+		{platform: PlatformInfo{OS: "linux", Arch: "arm64"}, wantErr: false},
+		{platform: PlatformInfo{OS: "darwin", Arch: "amd64"}, wantErr: false},
+		{platform: PlatformInfo{OS: "windows", Arch: "amd64"}, wantErr: false},
+		{platform: PlatformInfo{OS: "windows", Arch: "arm64"}, wantErr: true},
+		{platform: PlatformInfo{OS: "linux", Arch: "386"}, wantErr: true},
+		{platform: PlatformInfo{OS: "plan9", Arch: "amd64"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform.String(), func(t *testing.T) {
+			err := ValidatePlatform(tt.platform)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePlatform(%s) error = %v, wantErr %v", tt.platform, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckPlatformSupport(t *testing.T) {
+	manager := newTestManager(t)
+	goTool := NewGoTool(manager)
+	darwinArm64 := PlatformInfo{OS: "darwin", Arch: "arm64"}
+	linuxAmd64 := PlatformInfo{OS: "linux", Arch: "amd64"}
+
+	tests := []struct {
+		name     string
+		tool     Tool
+		version  string
+		platform PlatformInfo
+		wantErr  string
+	}{
+		{name: "go before apple silicon", tool: goTool, version: "1.4", platform: darwinArm64, wantErr: "go 1.4 isn't available for darwin/arm64"},
+		{name: "go on apple silicon", tool: goTool, version: "1.16", platform: darwinArm64},
+		{name: "old go on linux", tool: goTool, version: "1.4", platform: linuxAmd64},
+		{name: "unparsable version", tool: goTool, version: "1.21rc2", platform: darwinArm64},
+		{name: "clojure on windows", tool: NewClojureTool(manager), version: "1.12.0.1488", platform: PlatformInfo{OS: "windows", Arch: "amd64"}, wantErr: "isn't available for windows/amd64"},
+		{name: "tool without matrix", tool: NewMavenTool(manager), version: "3.9.6", platform: darwinArm64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := manager.CheckPlatformSupport(tt.tool, tt.version, tt.platform)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckPlatformSupport() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckPlatformSupport() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBinaryNames(t *testing.T) {
+	windows := NewPlatformMapperFor(PlatformInfo{OS: "windows", Arch: "amd64"})
+	linux := NewPlatformMapperFor(PlatformInfo{OS: "linux", Arch: "amd64"})
+
+	tests := []struct {
+		name     string
+		mapper   *PlatformMapper
+		binary   string
+		expected []string
+	}{
+		{"unix name", linux, "mvn", []string{"mvn"}},
+		{"windows cmd launcher", windows, "mvn.cmd", []string{"mvn.cmd", "mvn.exe", "mvn.bat"}},
+		{"windows exe", windows, "java.exe", []string{"java.exe", "java.cmd", "java.bat"}},
+		{"windows upper-case extension", windows, "gradle.BAT", []string{"gradle.BAT", "gradle.exe", "gradle.cmd"}},
+		{"windows bare name", windows, "node", []string{"node", "node.exe", "node.cmd", "node.bat"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.mapper.BinaryNames(tt.binary)
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("BinaryNames(%q) = %v, expected %v", tt.binary, got, tt.expected)
+			}
+		})
+	}
+
+	if got := windows.BinaryName(BinaryMaven, ExtCmd); got != "mvn.cmd" {
+		t.Errorf("BinaryName() on windows = %q, expected mvn.cmd", got)
+	}
+	if got := linux.BinaryName(BinaryMaven, ExtCmd); got != "mvn" {
+		t.Errorf("BinaryName() on linux = %q, expected mvn", got)
+	}
+}
+
+func TestFindBinaryParentDirWindowsLaunchers(t *testing.T) {
+	windows := NewPlatformMapperFor(PlatformInfo{OS: "windows", Arch: "amd64"})
+
+	tests := []struct {
+		name   string
+		file   string
+		binary string
+		found  bool
+	}{
+		{"exact name", "mvn.cmd", "mvn.cmd", true},
+		{"bat instead of cmd", "mvn.bat", "mvn.cmd", true},
+		{"different case", "MVN.CMD", "mvn.cmd", true},
+		{"exe instead of bat", "gradle.exe", "gradle.bat", true},
+		{"unix script only", "mvn", "mvn.cmd", false},
+		{"other binary", "mvnDebug.cmd", "mvn.cmd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			binDir := filepath.Join(root, "apache-maven", "bin")
+			if err := os.MkdirAll(binDir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(binDir, tt.file), []byte("@echo off"), 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			dir, err := NewPathResolverFor(root, windows).FindBinaryParentDir(root, tt.binary)
+			if tt.found {
+				if err != nil || dir != binDir {
+					t.Errorf("FindBinaryParentDir() = %q, %v, expected %q", dir, err, binDir)
+				}
+			} else if err == nil {
+				t.Errorf("FindBinaryParentDir() = %q, expected not found", dir)
+			}
+
+			_, found := windows.FindBinary(binDir, tt.binary)
+			// FindBinary relies on the file system for case-insensitivity, which only Windows provides
+			if tt.name != "different case" && found != tt.found {
+				t.Errorf("FindBinary() found = %v, expected %v", found, tt.found)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+const (
+	// progressRefreshInterval is the delay between download progress events, and so between
+	// in-place progress updates on a terminal
+	progressRefreshInterval = 200 * time.Millisecond
+	// progressLogInterval is the delay between progress lines in verbose mode, when the
+	// progress can't be updated in place
+	progressLogInterval = 5 * time.Second
+)
+
+// activeDownloads counts the downloads in progress. A download only updates its progress in
+// place while it runs alone, so that parallel installs don't overwrite each other's line.
+var activeDownloads atomic.Int32
+
+// consoleOutput renders the download progress on the console. It is the default observer of
+// managers, and renders the downloads not made for a managed tool.
+var consoleOutput = newConsoleObserver(os.Stdout)
+
+// defaultObserver returns the observer of the install events of managers and of the downloads
+// not made for a managed tool: the console, or the JSON log in JSON log format
+func defaultObserver() InstallObserver {
+	if util.IsJSONLog() {
+		return jsonLogObserver{}
+	}
+	return consoleOutput
+}
+
+// progressReader reports the progress of a download as it is read, as download progress
+// events sent at most every progressRefreshInterval
+type progressReader struct {
+	reader     io.Reader
+	notify     func(InstallEvent)
+	offset     int64     // Bytes resumed from a previous attempt
+	total      int64     // Expected size of the file, 0 if unknown
+	read       int64     // Bytes of the file read so far, including offset
+	start      time.Time // Start of the transfer
+	lastReport time.Time
+}
+
+// newProgressReader creates a progress reader for the rest of a download, after offset bytes
+func newProgressReader(reader io.Reader, notify func(InstallEvent), offset, total int64) *progressReader {
+	now := time.Now()
+	return &progressReader{
+		reader:     reader,
+		notify:     notify,
+		offset:     offset,
+		total:      total,
+		read:       offset,
+		start:      now,
+		lastReport: now,
+	}
+}
+
+// Read reads from the download, reporting its progress when due
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	if now := time.Now(); now.Sub(r.lastReport) >= progressRefreshInterval {
+		r.notify(r.event(EventDownloadProgress, now))
+		r.lastReport = now
+	}
+	return n, err
+}
+
+// Finish reports the end of the transfer
+func (r *progressReader) Finish() {
+	r.notify(r.event(EventDownloadDone, time.Now()))
+}
+
+// event returns a progress event, with the transfer rate since the start of the transfer
+func (r *progressReader) event(eventType InstallEventType, now time.Time) InstallEvent {
+	var rate float64
+	if elapsed := now.Sub(r.start).Seconds(); elapsed > 0 {
+		rate = float64(r.read-r.offset) / elapsed
+	}
+	return InstallEvent{Type: eventType, Time: now, Downloaded: r.read, Total: r.total, Rate: rate}
+}
+
+// consoleObserver renders the download progress: in place on a terminal while no other
+// download runs, or else as periodic lines in verbose mode
+type consoleObserver struct {
+	out      io.Writer
+	terminal bool // Whether out is a terminal, where the progress is updated in place
+	mutex    sync.Mutex
+	inPlace  bool                 // Whether a line updated in place needs to be ended
+	lastLine map[string]time.Time // Last progress line of each download, in verbose mode
+}
+
+// newConsoleObserver creates an observer rendering the download progress to a file
+func newConsoleObserver(out *os.File) *consoleObserver {
+	return &consoleObserver{
+		out:      out,
+		terminal: isTerminal(out),
+		lastLine: make(map[string]time.Time),
+	}
+}
+
+// OnInstallEvent renders the download events (implements InstallObserver)
+func (c *consoleObserver) OnInstallEvent(event InstallEvent) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	prefix := ""
+	if event.Tool != "" {
+		prefix = fmt.Sprintf("[%s] ", event.Tool)
+	}
+	switch event.Type {
+	case EventDownloadStart:
+		c.lastLine[event.Tool] = event.Time
+	case EventDownloadProgress:
+		inPlace := c.terminal && activeDownloads.Load() <= 1
+		switch {
+		case inPlace:
+			fmt.Fprintf(c.out, "\r  ⬇️  %s%s\033[K", prefix, describeProgress(event))
+			c.inPlace = true
+		case util.IsVerbose() && event.Time.Sub(c.lastLine[event.Tool]) >= progressLogInterval:
+			c.endLine()
+			fmt.Fprintf(c.out, "  ⬇️  %s%s\n", prefix, describeProgress(event))
+			c.lastLine[event.Tool] = event.Time
+		}
+	case EventDownloadDone:
+		// Complete the progress line updated in place, if any
+		if c.inPlace {
+			fmt.Fprintf(c.out, "\r  ⬇️  %s%s\033[K", prefix, describeProgress(event))
+			c.endLine()
+		}
+		delete(c.lastLine, event.Tool)
+	}
+}
+
+// printLines prints complete lines at once, after ending the line updated in place if any
+func (c *consoleObserver) printLines(text string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.endLine()
+	io.WriteString(c.out, text)
+}
+
+// endLine ends the line updated in place, before printing other lines
+func (c *consoleObserver) endLine() {
+	if c.inPlace {
+		fmt.Fprintln(c.out)
+		c.inPlace = false
+	}
+}
+
+// jsonLogObserver logs the install events as JSON entries, except the download progress
+type jsonLogObserver struct{}
+
+// OnInstallEvent logs an event (implements InstallObserver)
+func (jsonLogObserver) OnInstallEvent(event InstallEvent) {
+	if event.Type == EventDownloadProgress {
+		return
+	}
+	entry := util.LogEntry{
+		Time:    event.Time,
+		Level:   util.LevelInfo,
+		Tool:    event.Tool,
+		Version: event.Version,
+		Event:   string(event.Type),
+	}
+	switch event.Type {
+	case EventResolveStart:
+		entry.Level = util.LevelDebug
+		entry.Msg = "resolving version " + event.Version
+	case EventResolveDone:
+		entry.Msg = "resolved version " + event.Version
+	case EventInstallStart:
+		entry.Msg = "installing"
+	case EventDownloadStart:
+		entry.Msg = "downloading " + event.URL
+	case EventDownloadDone:
+		entry.Msg = fmt.Sprintf("downloaded %s from %s", formatByteSize(event.Downloaded), event.URL)
+	case EventExtractStart:
+		entry.Msg = "extracting " + event.Path
+	case EventVerifyStart:
+		entry.Msg = "verifying the installation"
+	case EventVerifyDone:
+		entry.Msg = "installation verified"
+	case EventInstallDone:
+		entry.Msg = "installed"
+	case EventError:
+		entry.Level = util.LevelError
+		if event.Err != nil {
+			entry.Msg = event.Err.Error()
+		}
+	}
+	util.Log(entry)
+}
+
+// describeProgress formats the progress of a download, e.g.
+// "42.0% 84.0 MB / 200.0 MB, 10.5 MB/s, ETA 11s"
+func describeProgress(event InstallEvent) string {
+	speed := formatByteSize(int64(event.Rate)) + "/s"
+	if event.Total <= 0 {
+		return fmt.Sprintf("%s, %s", formatByteSize(event.Downloaded), speed)
+	}
+	percent := float64(event.Downloaded) * 100 / float64(event.Total)
+	eta := "?"
+	if event.Rate > 0 {
+		remaining := time.Duration(float64(event.Total-event.Downloaded)/event.Rate) * time.Second
+		eta = remaining.Round(time.Second).String()
+	}
+	return fmt.Sprintf("%.1f%% %s / %s, %s, ETA %s", percent, formatByteSize(event.Downloaded), formatByteSize(event.Total), speed, eta)
+}
+
+// formatByteSize formats a number of bytes with a binary unit, e.g. "1.5 MB"
+func formatByteSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+// isTerminal reports whether a file is a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
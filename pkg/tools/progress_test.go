@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressReaderDescribe(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		name     string
+		offset   int64
+		total    int64
+		read     int64
+		expected string
+	}{
+		{"known size", 0, 100 << 20, 20 << 20, "20.0% 20.0 MB / 100.0 MB, 2.0 MB/s, ETA 40s"},
+		{"resumed download", 50 << 20, 100 << 20, 60 << 20, "60.0% 60.0 MB / 100.0 MB, 1.0 MB/s, ETA 40s"},
+		{"unknown size", 0, 0, 3 << 10, "3.0 KB, 307 B/s"},
+		{"nothing read yet", 0, 1 << 30, 0, "0.0% 0 B / 1.0 GB, 0 B/s, ETA ?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &progressReader{offset: tt.offset, total: tt.total, read: tt.read, start: start}
+			event := r.event(EventDownloadProgress, start.Add(10*time.Second))
+			if got := describeProgress(event); got != tt.expected {
+				t.Errorf("describeProgress() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProgressReaderEvents(t *testing.T) {
+	var events []InstallEvent
+	r := newProgressReader(strings.NewReader("mvx"), func(event InstallEvent) {
+		events = append(events, event)
+	}, 10, 13)
+	r.lastReport = r.start.Add(-progressRefreshInterval)
+
+	if _, err := r.Read(make([]byte, 8)); err != nil {
+		t.Fatal(err)
+	}
+	r.Finish()
+
+	if len(events) != 2 || events[0].Type != EventDownloadProgress || events[1].Type != EventDownloadDone {
+		t.Fatalf("events = %+v, want a progress event then a done event", events)
+	}
+	if events[1].Downloaded != 13 || events[1].Total != 13 {
+		t.Errorf("done event = %d / %d bytes, want 13 / 13", events[1].Downloaded, events[1].Total)
+	}
+}
+
+func TestConsoleObserverProgress(t *testing.T) {
+	tests := []struct {
+		name      string
+		terminal  bool
+		verbose   bool
+		parallel  bool
+		expected  string
+		wantLines int
+	}{
+		{name: "terminal", terminal: true, expected: "\r  ⬇️  [java] 50.0%"},
+		{name: "parallel downloads on a terminal", terminal: true, parallel: true},
+		{name: "parallel downloads in verbose mode", terminal: true, parallel: true, verbose: true, expected: "  ⬇️  [java] 50.0%", wantLines: 1},
+		{name: "not a terminal", terminal: false},
+		{name: "not a terminal in verbose mode", terminal: false, verbose: true, expected: "  ⬇️  [java] 50.0%", wantLines: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.verbose {
+				t.Setenv("MVX_VERBOSE", "true")
+			} else {
+				t.Setenv("MVX_VERBOSE", "")
+			}
+			running := int32(1)
+			if tt.parallel {
+				running = 2
+			}
+			activeDownloads.Add(running)
+			defer activeDownloads.Add(-running)
+
+			var out bytes.Buffer
+			start := time.Now()
+			c := &consoleObserver{out: &out, terminal: tt.terminal, lastLine: make(map[string]time.Time)}
+			c.OnInstallEvent(InstallEvent{Type: EventDownloadStart, Time: start, Tool: "java", Total: 100})
+			c.OnInstallEvent(InstallEvent{Type: EventDownloadProgress, Time: start.Add(10 * time.Second), Tool: "java", Downloaded: 50, Total: 100})
+
+			if !strings.HasPrefix(out.String(), tt.expected) || (tt.expected == "" && out.Len() > 0) {
+				t.Errorf("OnInstallEvent() printed %q, want a line starting with %q", out.String(), tt.expected)
+			}
+			if lines := strings.Count(out.String(), "\n"); lines != tt.wantLines {
+				t.Errorf("OnInstallEvent() printed %d lines, want %d", lines, tt.wantLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// KeepAllVersions in the versions kept for a tool protects all of its installations, e.g. when
+// the configured version could not be resolved
+const KeepAllVersions = "*"
+
+// UnusedVersion is an installed tool version that is not kept by a prune
+type UnusedVersion struct {
+	Tool    string
+	Version string // Installation directory name: version, or version@distribution
+	Path    string
+	Size    int64
+}
+
+// ConfiguredVersions returns the installation directory names of the versions a configuration
+// uses, keyed by tool. Specifications such as "lts" or "21" are resolved, so the version they
+// currently select is kept; tools that cannot be resolved keep all their versions.
+func (m *Manager) ConfiguredVersions(cfg *config.Config) map[string][]string {
+	keep := make(map[string][]string)
+	for toolName, toolConfig := range cfg.Tools {
+		tool, err := m.GetTool(toolName)
+		if err != nil {
+			continue
+		}
+		resolvedVersion, err := m.resolveVersion(toolName, toolConfig)
+		if err != nil {
+			util.LogVerbose("Keeping all versions of %s, failed to resolve %s: %v", toolName, toolConfig.Version, err)
+			keep[toolName] = []string{KeepAllVersions}
+			continue
+		}
+		resolvedConfig := toolConfig
+		resolvedConfig.Version = resolvedVersion
+		keep[toolName] = append(keep[toolName], filepath.Base(m.getInstallDir(tool, resolvedVersion, resolvedConfig)))
+	}
+	return keep
+}
+
+// KeepLatestVersions adds the n most recent installed versions of every tool to keep
+func (m *Manager) KeepLatestVersions(keep map[string][]string, n int) error {
+	if n <= 0 {
+		return nil
+	}
+	pathManager := NewInstallationPathManager(m.GetToolsDir())
+	for _, toolName := range m.GetToolNames() {
+		installed, err := pathManager.ListInstalledVersions(toolName)
+		if err != nil {
+			return err
+		}
+		sort.SliceStable(installed, func(i, j int) bool {
+			return CompareInstalledVersions(installed[i], installed[j]) > 0
+		})
+		if len(installed) > n {
+			installed = installed[:n]
+		}
+		keep[toolName] = append(keep[toolName], installed...)
+	}
+	return nil
+}
+
+// CompareInstalledVersions compares the versions of two installation directory names
+// (version, or version@distribution), ignoring their distribution. Unparsable versions sort first.
+func CompareInstalledVersions(a, b string) int {
+	versionA, _, _ := strings.Cut(a, "@")
+	versionB, _, _ := strings.Cut(b, "@")
+	parsedA, errA := version.ParseVersion(versionA)
+	parsedB, errB := version.ParseVersion(versionB)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	return parsedA.Compare(parsedB)
+}
+
+// CanonicalInstalledVersion returns an installation directory name (version, or
+// version@distribution) with the canonical form of its version
+func (m *Manager) CanonicalInstalledVersion(toolName, dirName string) string {
+	versionPart, distribution, found := strings.Cut(dirName, "@")
+	canonical := m.CanonicalVersion(toolName, versionPart)
+	if found {
+		return canonical + "@" + distribution
+	}
+	return canonical
+}
+
+// UnusedVersions lists the installed versions of all tools that are not kept.
+// keep maps tool names to installation directory names (version, or version@distribution).
+func (m *Manager) UnusedVersions(keep map[string][]string) ([]UnusedVersion, error) {
+	pathManager := NewInstallationPathManager(m.GetToolsDir())
+	toolNames := m.GetToolNames()
+	sort.Strings(toolNames)
+	var unused []UnusedVersion
+	for _, toolName := range toolNames {
+		kept := keep[toolName]
+		if slices.Contains(kept, KeepAllVersions) {
+			continue
+		}
+		installed, err := pathManager.ListInstalledVersions(toolName)
+		if err != nil {
+			return nil, err
+		}
+		for _, dirName := range installed {
+			if slices.Contains(kept, dirName) {
+				continue
+			}
+			path := filepath.Join(m.GetToolDir(toolName), dirName)
+			size, err := util.DirSize(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute disk usage of %s: %w", path, err)
+			}
+			unused = append(unused, UnusedVersion{Tool: toolName, Version: dirName, Path: path, Size: size})
+		}
+	}
+	return unused, nil
+}
+
+// GetToolDiskUsage returns the disk space used by each installed version of a tool, keyed by
+// installation directory name, and their total. Versions whose size cannot be computed are skipped.
+func (m *Manager) GetToolDiskUsage(toolName string) (map[string]int64, int64) {
+	perVersion := make(map[string]int64)
+	var total int64
+	installed, err := NewInstallationPathManager(m.GetToolsDir()).ListInstalledVersions(toolName)
+	if err != nil {
+		util.LogVerbose("Failed to list installed versions of %s: %v", toolName, err)
+		return perVersion, 0
+	}
+	for _, dirName := range installed {
+		size, err := util.DirSize(filepath.Join(m.GetToolDir(toolName), dirName))
+		if err != nil {
+			util.LogVerbose("Failed to compute disk usage of %s %s: %v", toolName, dirName, err)
+			continue
+		}
+		perVersion[dirName] = size
+		total += size
+	}
+	return perVersion, total
+}
+
+// PruneUnusedVersions removes the installed versions of all tools that are not kept and
+// returns what was removed. See UnusedVersions for the format of keep.
+func (m *Manager) PruneUnusedVersions(keep map[string][]string) ([]UnusedVersion, error) {
+	unused, err := m.UnusedVersions(keep)
+	if err != nil {
+		return nil, err
+	}
+	var removed []UnusedVersion
+	for _, v := range unused {
+		versionPart, distribution, _ := strings.Cut(v.Version, "@")
+		if err := m.UninstallTool(v.Tool, versionPart, distribution); err != nil {
+			return removed, err
+		}
+		// Tools with a custom installation layout may not map back to the directory name
+		if err := os.RemoveAll(v.Path); err != nil {
+			return removed, fmt.Errorf("failed to remove %s: %w", v.Path, err)
+		}
+		removed = append(removed, v)
+	}
+	return removed, nil
+}
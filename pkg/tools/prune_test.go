@@ -0,0 +1,136 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// resolvingFakeTool resolves version specifications against its available versions
+type resolvingFakeTool struct {
+	*fakeTool
+}
+
+func (r *resolvingFakeTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil {
+		return "", err
+	}
+	versions, _ := r.ListVersions()
+	return spec.Resolve(versions)
+}
+
+func TestPruneUnusedVersions(t *testing.T) {
+	manager := newTestManager(t)
+	alpha := newFakeTool(manager, "alpha", nil)
+	alpha.versions = []string{"1.0.0", "1.1.0", "2.0.0"}
+	manager.RegisterTool(&resolvingFakeTool{alpha})
+	manager.RegisterTool(newFakeTool(manager, "beta", nil))
+
+	for _, dir := range []string{"alpha/1.0.0", "alpha/1.1.0", "alpha/2.0.0", "beta/0.9.0", "beta/1.0.0"} {
+		path := filepath.Join(manager.GetToolsDir(), dir)
+		if err := os.MkdirAll(path, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(path, "payload"), []byte("12345"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// "1" resolves to 1.1.0, which must survive the prune
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{"alpha": {Version: "1"}}}
+
+	tests := []struct {
+		name       string
+		keepLatest int
+		expected   []string
+	}{
+		{"configured only", 0, []string{"alpha/1.0.0", "alpha/2.0.0", "beta/0.9.0", "beta/1.0.0"}},
+		{"keep latest", 1, []string{"alpha/1.0.0", "beta/0.9.0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keep := manager.ConfiguredVersions(cfg)
+			if err := manager.KeepLatestVersions(keep, tt.keepLatest); err != nil {
+				t.Fatalf("KeepLatestVersions() error = %v", err)
+			}
+			unused, err := manager.UnusedVersions(keep)
+			if err != nil {
+				t.Fatalf("UnusedVersions() error = %v", err)
+			}
+			var got []string
+			for _, v := range unused {
+				got = append(got, v.Tool+"/"+v.Version)
+				if v.Size != 5 {
+					t.Errorf("Expected size 5 for %s %s, got %d", v.Tool, v.Version, v.Size)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("UnusedVersions() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	keep := manager.ConfiguredVersions(cfg)
+	removed, err := manager.PruneUnusedVersions(keep)
+	if err != nil {
+		t.Fatalf("PruneUnusedVersions() error = %v", err)
+	}
+	if len(removed) != 4 {
+		t.Errorf("Expected 4 versions removed, got %d", len(removed))
+	}
+	if _, err := os.Stat(filepath.Join(manager.GetToolsDir(), "alpha", "1.1.0")); err != nil {
+		t.Errorf("Expected configured version to be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(manager.GetToolsDir(), "alpha", "2.0.0")); !os.IsNotExist(err) {
+		t.Errorf("Expected unused version to be removed, got %v", err)
+	}
+}
+
+func TestConfiguredVersionsKeepsUnresolvableTools(t *testing.T) {
+	manager := newTestManager(t)
+	manager.RegisterTool(&resolvingFakeTool{newFakeTool(manager, "alpha", nil)})
+
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{"alpha": {Version: "9"}}}
+	keep := manager.ConfiguredVersions(cfg)
+	if got := keep["alpha"]; len(got) != 1 || got[0] != KeepAllVersions {
+		t.Errorf("ConfiguredVersions() = %v, expected all versions kept", got)
+	}
+}
+
+func TestGetToolDiskUsage(t *testing.T) {
+	manager := newTestManager(t)
+	files := map[string]string{
+		"21.0.1@temurin/bin/java":  "1234567890",
+		"21.0.1@temurin/release":   "12345",
+		"17.0.9@zulu/lib/rt.jar":   "123",
+		"17.0.9@zulu/lib/empty":    "",
+		"17.0.9@zulu/conf/jvm.cfg": "12",
+	}
+	for name, content := range files {
+		path := filepath.Join(manager.GetToolDir(ToolJava), name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	perVersion, total := manager.GetToolDiskUsage(ToolJava)
+	if perVersion["21.0.1@temurin"] != 15 || perVersion["17.0.9@zulu"] != 5 || len(perVersion) != 2 {
+		t.Errorf("GetToolDiskUsage() per version = %v", perVersion)
+	}
+	if total != 20 {
+		t.Errorf("GetToolDiskUsage() total = %d, expected 20", total)
+	}
+
+	if perVersion, total := manager.GetToolDiskUsage(ToolMaven); len(perVersion) != 0 || total != 0 {
+		t.Errorf("GetToolDiskUsage() for a tool without installations = %v, %d", perVersion, total)
+	}
+}
@@ -0,0 +1,81 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+// resolveRequest identifies a version resolution, shared by the tools configured alike
+type resolveRequest struct {
+	tool         string
+	version      string
+	distribution string
+	preference   string
+}
+
+// ResolveVersions resolves the versions of all tools of a configuration in one pass, including
+// the tools overridden by commands, and returns the versions of the project tools. Resolutions
+// run concurrently, each distinct specification once, and the metadata they share, such as the
+// Java major versions, is fetched a single time. Resolved versions are cached, so later
+// ResolveVersion calls need no network access.
+func (m *Manager) ResolveVersions(cfg *config.Config) (map[string]string, error) {
+	requests := make(map[resolveRequest]config.ToolConfig)
+	addRequest := func(toolName string, toolConfig config.ToolConfig) resolveRequest {
+		request := resolveRequest{
+			tool:         toolName,
+			version:      toolConfig.Version,
+			distribution: toolConfig.Distribution,
+			preference:   toolConfig.Options[OptionResolve],
+		}
+		requests[request] = toolConfig
+		return request
+	}
+
+	projectRequests := make(map[string]resolveRequest, len(cfg.Tools))
+	for toolName, toolConfig := range cfg.Tools {
+		projectRequests[toolName] = addRequest(toolName, toolConfig)
+	}
+	for _, cmdConfig := range cfg.Commands {
+		for toolName, toolConfig := range cmdConfig.Tools {
+			addRequest(toolName, toolConfig)
+		}
+	}
+
+	type resolution struct {
+		version string
+		err     error
+	}
+	resolutions := make(map[resolveRequest]resolution, len(requests))
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for request, toolConfig := range requests {
+		wg.Add(1)
+		go func(request resolveRequest, toolConfig config.ToolConfig) {
+			defer wg.Done()
+			resolved, err := m.resolveVersion(request.tool, toolConfig)
+			mutex.Lock()
+			resolutions[request] = resolution{version: resolved, err: err}
+			mutex.Unlock()
+		}(request, toolConfig)
+	}
+	wg.Wait()
+
+	toolNames := make([]string, 0, len(projectRequests))
+	for toolName := range projectRequests {
+		toolNames = append(toolNames, toolName)
+	}
+	sort.Strings(toolNames)
+
+	versions := make(map[string]string, len(projectRequests))
+	for _, toolName := range toolNames {
+		result := resolutions[projectRequests[toolName]]
+		if result.err != nil {
+			return nil, fmt.Errorf("failed to resolve version for %s: %w", toolName, result.err)
+		}
+		versions[toolName] = result.version
+	}
+	return versions, nil
+}
@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// discoTransport stubs the Disco API, counting the requests made for each path
+type discoTransport struct {
+	mutex    sync.Mutex
+	requests map[string]int
+}
+
+func (d *discoTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	d.mutex.Lock()
+	d.requests[req.URL.Path]++
+	d.mutex.Unlock()
+	// Leave concurrent resolutions time to issue the same request
+	time.Sleep(10 * time.Millisecond)
+
+	var body string
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/major_versions"):
+		body = `[{"major_version":21},{"major_version":17}]`
+	case strings.HasSuffix(req.URL.Path, "/packages"):
+		major := req.URL.Query().Get("version")
+		body = fmt.Sprintf(`{"result":[{"java_version":"%s.0.5+11"}]}`, major)
+	default:
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func TestResolveVersions(t *testing.T) {
+	manager := newTestManager(t)
+	transport := &discoTransport{requests: make(map[string]int)}
+	manager.httpClient = &http.Client{Transport: transport}
+	manager.RegisterTool(NewJavaTool(manager))
+	manager.RegisterTool(newFakeTool(manager, "alpha", nil))
+
+	cfg := &config.Config{
+		Tools: map[string]config.ToolConfig{
+			"java":  {Version: "21"},
+			"alpha": {Version: "1.0.0"},
+		},
+		Commands: map[string]config.CommandConfig{
+			"legacy": {Tools: map[string]config.ToolConfig{"java": {Version: "17"}}},
+			"compat": {Tools: map[string]config.ToolConfig{"java": {Version: "17"}}},
+		},
+	}
+
+	versions, err := manager.ResolveVersions(cfg)
+	if err != nil {
+		t.Fatalf("ResolveVersions() error = %v", err)
+	}
+	expected := map[string]string{"java": "21.0.5", "alpha": "1.0.0"}
+	for toolName, version := range expected {
+		if versions[toolName] != version {
+			t.Errorf("ResolveVersions()[%s] = %q, want %q", toolName, versions[toolName], version)
+		}
+	}
+	if len(versions) != len(expected) {
+		t.Errorf("ResolveVersions() = %v, want only the project tools", versions)
+	}
+
+	if count := transport.requests["/disco/v3.0/major_versions"]; count != 1 {
+		t.Errorf("major versions fetched %d times, want 1 (requests: %v)", count, transport.requests)
+	}
+	if count := transport.requests["/disco/v3.0/packages"]; count != 2 {
+		t.Errorf("packages fetched %d times, want once per major version (requests: %v)", count, transport.requests)
+	}
+
+	// Command overrides are cached too, and resolve without further requests
+	resolved, err := manager.ResolveVersion("java", config.ToolConfig{Version: "17"})
+	if err != nil || resolved != "17.0.5" {
+		t.Errorf("ResolveVersion(java 17) = %q, %v, want 17.0.5", resolved, err)
+	}
+	if count := transport.requests["/disco/v3.0/packages"]; count != 2 {
+		t.Errorf("packages fetched %d times after ResolveVersions, want 2", count)
+	}
+}
+
+func TestJavaResolveVersionRange(t *testing.T) {
+	tests := []struct {
+		spec       string
+		preference version.Preference
+		expected   string
+		wantErr    bool
+	}{
+		{spec: ">=17 <21", preference: version.PreferHighest, expected: "17.0.5"},
+		{spec: ">=17", preference: version.PreferHighest, expected: "21.0.5"},
+		{spec: ">=17", preference: version.PreferLowest, expected: "17.0.5"},
+		{spec: "^21.0.2", preference: version.PreferHighest, expected: "21.0.5"},
+		{spec: ">=17.0.6 <21", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+string(tt.preference), func(t *testing.T) {
+			manager := newTestManager(t)
+			transport := &discoTransport{requests: make(map[string]int)}
+			manager.httpClient = &http.Client{Transport: transport}
+			javaTool := NewJavaTool(manager)
+
+			resolved, err := javaTool.ResolveVersionWithPreference(tt.spec, "zulu", tt.preference)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveVersionWithPreference(%s) = %q, %v, wantErr %v", tt.spec, resolved, err, tt.wantErr)
+			}
+			if resolved != tt.expected {
+				t.Errorf("ResolveVersionWithPreference(%s) = %s, want %s", tt.spec, resolved, tt.expected)
+			}
+		})
+	}
+}
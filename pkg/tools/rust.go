@@ -0,0 +1,309 @@
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// Compile-time interface validation
+var _ Tool = (*RustTool)(nil)
+var _ EnvironmentProvider = (*RustTool)(nil)
+var _ VersionResolver = (*RustTool)(nil)
+
+// RustTool implements Tool interface for Rust toolchain management, using the standalone
+// installers published on static.rust-lang.org rather than rustup
+type RustTool struct {
+	*BaseTool
+}
+
+// getRustBinaryName returns the rustc binary name for the target platform
+func getRustBinaryName(manager *Manager) string {
+	return manager.GetPlatformMapper().BinaryName(BinaryRustc, ExtExe)
+}
+
+// NewRustTool creates a new Rust tool instance
+func NewRustTool(manager *Manager) *RustTool {
+	return &RustTool{
+		BaseTool: NewBaseTool(manager, ToolRust, getRustBinaryName(manager)),
+	}
+}
+
+// Install downloads and installs the specified Rust version
+func (r *RustTool) Install(version string, cfg config.ToolConfig) error {
+	return r.StandardInstallWithExtractor(version, cfg, r.getDownloadURL, r.extractToolchain)
+}
+
+// IsInstalled checks if the specified version is installed
+func (r *RustTool) IsInstalled(version string, cfg config.ToolConfig) bool {
+	return r.StandardIsInstalled(version, cfg, r.GetPath)
+}
+
+// GetPath returns the binary path for the specified version (for PATH management)
+func (r *RustTool) GetPath(version string, cfg config.ToolConfig) (string, error) {
+	return r.StandardGetPath(version, cfg, r.getInstalledPath)
+}
+
+// getInstalledPath returns the path for an installed Rust version
+func (r *RustTool) getInstalledPath(version string, cfg config.ToolConfig) (string, error) {
+	installDir := r.manager.GetToolVersionDir(r.GetToolName(), version, "")
+	pathResolver := r.manager.GetPathResolver()
+	return pathResolver.FindBinaryParentDir(installDir, r.GetBinaryName())
+}
+
+// Verify checks if the installation is working correctly
+func (r *RustTool) Verify(version string, cfg config.ToolConfig) error {
+	verifyConfig := VerificationConfig{
+		BinaryName:      r.GetBinaryName(),
+		VersionArgs:     []string{"--version"},
+		ExpectedVersion: "rustc " + version,
+	}
+	return r.StandardVerifyWithConfig(version, cfg, verifyConfig)
+}
+
+// ListVersions returns available Rust versions, newest first
+func (r *RustTool) ListVersions() ([]string, error) {
+	versions, err := r.fetchRustVersions()
+	if err != nil || len(versions) == 0 {
+		// Fallback to known versions if the release manifests are unavailable
+		return r.getFallbackRustVersions(), nil
+	}
+	return version.SortVersions(versions), nil
+}
+
+// GetDisplayName returns the human-readable name for Rust (implements ToolMetadataProvider)
+func (r *RustTool) GetDisplayName() string {
+	return "Rust"
+}
+
+// SetupEnvironment sets up Cargo and toolchain selection (implements EnvironmentProvider).
+// CARGO_HOME keeps its value or defaults to ~/.cargo, so crates and 'cargo install'ed binaries
+// are shared across toolchains. RUSTUP_TOOLCHAIN points to the installed toolchain, so rustup
+// proxies that come first in PATH still run this version.
+func (r *RustTool) SetupEnvironment(version string, cfg config.ToolConfig, envManager *EnvironmentManager) error {
+	binDir, err := r.GetPath(version, cfg)
+	if err != nil {
+		return err
+	}
+	toolchainDir := filepath.Dir(binDir)
+	envManager.SetEnv(EnvRustupToolchain, toolchainDir)
+	util.LogVerbose("Set %s=%s for Rust %s", EnvRustupToolchain, toolchainDir, version)
+
+	cargoHome, exists := envManager.GetEnv(EnvCargoHome)
+	if !exists {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			util.LogVerbose("Failed to get user home directory: %v", err)
+			return nil
+		}
+		cargoHome = filepath.Join(homeDir, ".cargo")
+		envManager.SetEnv(EnvCargoHome, cargoHome)
+		util.LogVerbose("Set %s=%s for Rust %s", EnvCargoHome, cargoHome, version)
+	}
+
+	// Add CARGO_HOME/bin to PATH for binaries installed with 'cargo install'
+	envManager.AddToPath(filepath.Join(cargoHome, "bin"))
+	return nil
+}
+
+// rustArchitectures maps Go architectures to Rust target architectures
+var rustArchitectures = map[string]string{
+	"amd64": "x86_64",
+	"arm64": "aarch64",
+	"386":   "i686",
+}
+
+// rustTarget returns the Rust target triple of the manager's platform
+func (r *RustTool) rustTarget() string {
+	platformMapper := r.manager.GetPlatformMapper()
+	arch := platformMapper.MapArchitecture(rustArchitectures)
+	switch {
+	case platformMapper.IsWindows():
+		return arch + "-pc-windows-msvc"
+	case platformMapper.IsMacOS():
+		return arch + "-apple-darwin"
+	default:
+		return arch + "-unknown-linux-gnu"
+	}
+}
+
+// getDownloadURL returns the download URL of the standalone installer
+func (r *RustTool) getDownloadURL(version string) string {
+	return fmt.Sprintf("%s/rust-%s-%s%s", RustDistBase, version, r.rustTarget(), ExtTarGz)
+}
+
+// GetDownloadURL implements URLProvider interface for Rust
+func (r *RustTool) GetDownloadURL(version string) string {
+	return r.getDownloadURL(version)
+}
+
+// getChecksumURL returns the checksum URL for Rust, published next to the installer
+func (r *RustTool) getChecksumURL(version string) string {
+	return r.getDownloadURL(version) + ".sha256"
+}
+
+// extractToolchain extracts a standalone installer and installs its components, flattened
+// into installDir. Each component (rustc, cargo, rust-std-<target>, ...) is a directory
+// mirroring the installation prefix, merged so that installDir/bin contains rustc and cargo.
+func (r *RustTool) extractToolchain(archivePath, installDir string) error {
+	stagingDir, err := os.MkdirTemp(filepath.Dir(installDir), ".rust-staging-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if err := ExtractArchive(archivePath, stagingDir); err != nil {
+		return err
+	}
+	return installRustComponents(stagingDir, installDir)
+}
+
+// installRustComponents moves the components listed in the installer's 'components' file
+// into installDir. Documentation is skipped, as it is large and available online.
+func installRustComponents(stagingDir, installDir string) error {
+	componentsFile := filepath.Join(stagingDir, "components")
+	file, err := os.Open(componentsFile)
+	if err != nil {
+		return fmt.Errorf("invalid Rust installer, missing components file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		component := strings.TrimSpace(scanner.Text())
+		if component == "" || strings.HasPrefix(component, "rust-docs") {
+			continue
+		}
+		if err := installRustComponent(filepath.Join(stagingDir, component), installDir); err != nil {
+			return fmt.Errorf("failed to install Rust component %s: %w", component, err)
+		}
+		util.LogVerbose("Installed Rust component %s", component)
+	}
+	return scanner.Err()
+}
+
+// installRustComponent moves the files of a component into installDir, keeping their paths
+func installRustComponent(componentDir, installDir string) error {
+	return filepath.Walk(componentDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(componentDir, path)
+		if err != nil {
+			return err
+		}
+		// manifest.in lists the component files for the installer script
+		if info.IsDir() || relPath == "manifest.in" {
+			return nil
+		}
+		target := filepath.Join(installDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.Rename(path, target)
+	})
+}
+
+// rustManifestPattern matches the stable release manifests listed in manifests.txt
+var rustManifestPattern = regexp.MustCompile(`/channel-rust-(\d+\.\d+\.\d+)\.toml$`)
+
+// fetchRustVersions fetches stable release versions from the list of release manifests
+func (r *RustTool) fetchRustVersions() ([]string, error) {
+	resp, err := r.manager.Get(RustManifestsURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch Rust versions: status %d", resp.StatusCode)
+	}
+	return parseRustManifests(resp.Body)
+}
+
+// parseRustManifests extracts the stable versions from manifests.txt, which lists the manifest
+// of every release of every channel, e.g. static.rust-lang.org/dist/2024-09-05/channel-rust-1.81.0.toml
+func parseRustManifests(r io.Reader) ([]string, error) {
+	seen := make(map[string]bool)
+	var versions []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		match := rustManifestPattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if match == nil || seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		versions = append(versions, match[1])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Rust versions: %w", err)
+	}
+	return versions, nil
+}
+
+// getFallbackRustVersions returns known Rust versions as fallback
+func (r *RustTool) getFallbackRustVersions() []string {
+	return []string{
+		"1.82.0", "1.81.0", "1.80.1", "1.80.0", "1.79.0", "1.78.0", "1.77.2", "1.76.0", "1.75.0",
+		"1.74.1", "1.73.0", "1.72.1", "1.71.1", "1.70.0",
+	}
+}
+
+// ResolveVersion resolves a Rust version specification to a concrete version
+func (r *RustTool) ResolveVersion(versionSpec, distribution string) (string, error) {
+	availableVersions, err := r.ListVersions()
+	if err != nil {
+		return "", err
+	}
+
+	spec, err := version.ParseSpec(versionSpec)
+	if err != nil {
+		return "", fmt.Errorf("invalid version specification %s: %w", versionSpec, err)
+	}
+
+	resolved, err := spec.Resolve(availableVersions)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Rust version %s: %w", versionSpec, err)
+	}
+
+	return resolved, nil
+}
+
+// GetChecksum implements Tool interface for Rust
+func (r *RustTool) GetChecksum(version string, cfg config.ToolConfig, filename string) (ChecksumInfo, error) {
+	url := r.getChecksumURL(version)
+	resp, err := r.manager.Get(url)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to fetch Rust checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return ChecksumInfo{}, fmt.Errorf("Rust checksum request returned status %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ChecksumInfo{}, fmt.Errorf("failed to read Rust checksum: %w", err)
+	}
+
+	// The checksum file has the format "<hash>  <filename>"
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return ChecksumInfo{}, fmt.Errorf("empty Rust checksum file at %s", url)
+	}
+
+	return ChecksumInfo{
+		Type:  SHA256,
+		Value: fields[0],
+	}, nil
+}
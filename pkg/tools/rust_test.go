@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRustDownloadURL(t *testing.T) {
+	tests := []struct {
+		platform PlatformInfo
+		expected string
+	}{
+		{PlatformInfo{OS: "linux", Arch: "amd64"}, "https://static.rust-lang.org/dist/rust-1.81.0-x86_64-unknown-linux-gnu.tar.gz"},
+		{PlatformInfo{OS: "linux", Arch: "arm64"}, "https://static.rust-lang.org/dist/rust-1.81.0-aarch64-unknown-linux-gnu.tar.gz"},
+		{PlatformInfo{OS: "darwin", Arch: "arm64"}, "https://static.rust-lang.org/dist/rust-1.81.0-aarch64-apple-darwin.tar.gz"},
+		{PlatformInfo{OS: "windows", Arch: "amd64"}, "https://static.rust-lang.org/dist/rust-1.81.0-x86_64-pc-windows-msvc.tar.gz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.platform.String(), func(t *testing.T) {
+			manager := newTestManager(t)
+			manager.platform = &tt.platform
+			rustTool := NewRustTool(manager)
+			if got := rustTool.getDownloadURL("1.81.0"); got != tt.expected {
+				t.Errorf("getDownloadURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestInstallRustComponents(t *testing.T) {
+	stagingDir := t.TempDir()
+	installDir := filepath.Join(t.TempDir(), "1.81.0")
+	files := map[string]string{
+		"components":                          "rustc\ncargo\nrust-docs\nrust-std-x86_64-unknown-linux-gnu\n",
+		"rustc/bin/rustc":                     "rustc",
+		"rustc/manifest.in":                   "file:bin/rustc",
+		"cargo/bin/cargo":                     "cargo",
+		"rust-docs/share/doc/rust/index.html": "docs",
+		"rust-std-x86_64-unknown-linux-gnu/lib/rustlib/x86_64-unknown-linux-gnu/lib/libstd.rlib": "std",
+	}
+	for name, content := range files {
+		path := filepath.Join(stagingDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := installRustComponents(stagingDir, installDir); err != nil {
+		t.Fatalf("installRustComponents() error = %v", err)
+	}
+
+	for _, name := range []string{"bin/rustc", "bin/cargo", "lib/rustlib/x86_64-unknown-linux-gnu/lib/libstd.rlib"} {
+		if _, err := os.Stat(filepath.Join(installDir, filepath.FromSlash(name))); err != nil {
+			t.Errorf("expected %s to be installed: %v", name, err)
+		}
+	}
+	for _, name := range []string{"manifest.in", "share/doc/rust/index.html"} {
+		if _, err := os.Stat(filepath.Join(installDir, filepath.FromSlash(name))); err == nil {
+			t.Errorf("expected %s not to be installed", name)
+		}
+	}
+}
+
+func TestParseRustManifests(t *testing.T) {
+	manifests := strings.Join([]string{
+		"static.rust-lang.org/dist/2024-09-05/channel-rust-1.81.0.toml",
+		"static.rust-lang.org/dist/2024-09-05/channel-rust-stable.toml",
+		"static.rust-lang.org/dist/2024-09-06/channel-rust-1.81.0.toml",
+		"static.rust-lang.org/dist/2024-09-06/channel-rust-nightly.toml",
+		"static.rust-lang.org/dist/2024-09-04/channel-rust-1.82.0-beta.1.toml",
+		"static.rust-lang.org/dist/2024-07-25/channel-rust-1.80.0.toml",
+	}, "\n")
+
+	versions, err := parseRustManifests(strings.NewReader(manifests))
+	if err != nil {
+		t.Fatalf("parseRustManifests() error = %v", err)
+	}
+	expected := []string{"1.81.0", "1.80.0"}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("parseRustManifests() = %v, want %v", versions, expected)
+	}
+}
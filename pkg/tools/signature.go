@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"golang.org/x/crypto/openpgp"
+)
+
+// verifySignature verifies the detached PGP signature of a downloaded file, when signature
+// verification is configured for the tool. Required verification failures remove the file.
+func verifySignature(filePath string, config *DownloadConfig) error {
+	sigConfig := config.Config.Signature
+	if sigConfig == nil || config.Tool == nil {
+		return nil
+	}
+
+	signer, err := checkSignature(filePath, config)
+	if err != nil {
+		if sigConfig.Required {
+			os.Remove(filePath)
+			return fmt.Errorf("signature verification failed (required): %w", err)
+		}
+		fmt.Fprintf(config.output(), "  ⚠️  Signature verification failed: %v\n", err)
+		return nil
+	}
+
+	fmt.Fprintf(config.output(), "  ✅ Signature verified (signed by %s)\n", signer)
+	return nil
+}
+
+// checkSignature checks the signature of a file against the configured keys and returns the signer
+func checkSignature(filePath string, config *DownloadConfig) (string, error) {
+	sigConfig := config.Config.Signature
+	manager := config.Tool.GetManager()
+	provider, _ := config.Tool.(SignatureProvider)
+
+	keyring, err := loadSignatureKeyring(manager, sigConfig, provider)
+	if err != nil {
+		return "", err
+	}
+
+	signatureURL := sigConfig.URL
+	if signatureURL == "" && provider != nil {
+		signatureURL = provider.GetSignatureURL(config.URL)
+	}
+	if signatureURL == "" {
+		signatureURL = config.URL + ".asc"
+	}
+	signature, err := fetchSignatureResource(manager, signatureURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch signature: %w", err)
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	var signer *openpgp.Entity
+	if isArmored(signature) {
+		signer, err = openpgp.CheckArmoredDetachedSignature(keyring, file, bytes.NewReader(signature))
+	} else {
+		signer, err = openpgp.CheckDetachedSignature(keyring, file, bytes.NewReader(signature))
+	}
+	if err != nil {
+		return "", fmt.Errorf("bad signature from %s: %w", signatureURL, err)
+	}
+
+	for name := range signer.Identities {
+		return name, nil
+	}
+	return fmt.Sprintf("key %X", signer.PrimaryKey.KeyId), nil
+}
+
+// loadSignatureKeyring reads the trusted public keys from the configured keyring file or URL,
+// or from the tool's published keys
+func loadSignatureKeyring(manager *Manager, sigConfig *config.SignatureConfig, provider SignatureProvider) (openpgp.EntityList, error) {
+	var data []byte
+	var source string
+	switch {
+	case sigConfig.Keyring != "":
+		source = sigConfig.Keyring
+		content, err := os.ReadFile(sigConfig.Keyring)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keyring: %w", err)
+		}
+		data = content
+	case sigConfig.KeyURL != "" || provider != nil:
+		source = sigConfig.KeyURL
+		if source == "" {
+			source = provider.GetSignatureKeyURL()
+		}
+		content, err := fetchSignatureResource(manager, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch keys: %w", err)
+		}
+		data = content
+	default:
+		return nil, fmt.Errorf("no keyring or key_url configured")
+	}
+
+	keyring, err := readKeyRing(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse keys from %s: %w", source, err)
+	}
+	return keyring, nil
+}
+
+// publicKeyBlockHeader starts each ASCII-armored public key block
+const publicKeyBlockHeader = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// readKeyRing parses binary or ASCII-armored public keys. Files such as Apache KEYS contain
+// several armored blocks separated by text, and all of them are read.
+func readKeyRing(data []byte) (openpgp.EntityList, error) {
+	if !isArmored(data) {
+		return openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+
+	var keyring openpgp.EntityList
+	blocks := strings.Split(string(data), publicKeyBlockHeader)
+	for _, block := range blocks[1:] {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(publicKeyBlockHeader + block))
+		if err != nil {
+			return nil, err
+		}
+		keyring = append(keyring, entities...)
+	}
+	if len(keyring) == 0 {
+		return nil, fmt.Errorf("no public key found")
+	}
+	return keyring, nil
+}
+
+// fetchSignatureResource downloads a signature or key file
+func fetchSignatureResource(manager *Manager, url string) ([]byte, error) {
+	resp, err := manager.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status %d", url, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// isArmored reports whether PGP data is ASCII-armored. Apache KEYS files start with
+// comments, so the armor header is searched rather than expected at the start.
+func isArmored(data []byte) bool {
+	return strings.Contains(string(data), "-----BEGIN PGP ")
+}
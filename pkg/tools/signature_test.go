@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+func TestVerifySignature(t *testing.T) {
+	entity, err := openpgp.NewEntity("Release Manager", "", "rm@example.org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte("archive content")
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, entity, bytes.NewReader(content), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Write the key twice with text in between, like Apache KEYS files
+	var keys bytes.Buffer
+	for i := 0; i < 2; i++ {
+		keys.WriteString("pub   rsa2048 Release Manager\n\n")
+		w, err := armor.Encode(&keys, openpgp.PublicKeyType, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := entity.Serialize(w); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+		keys.WriteString("\n")
+	}
+	keyring := filepath.Join(t.TempDir(), "KEYS")
+	if err := os.WriteFile(keyring, keys.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(signature.Bytes())
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		content  []byte
+		required bool
+		wantErr  bool
+		wantFile bool
+	}{
+		{"valid signature", content, true, false, true},
+		{"tampered file required", []byte("tampered content"), true, true, false},
+		{"tampered file not required", []byte("tampered content"), false, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newTestManager(t)
+			filePath := filepath.Join(t.TempDir(), "archive.zip")
+			if err := os.WriteFile(filePath, tt.content, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := verifySignature(filePath, &DownloadConfig{
+				URL:  server.URL + "/archive.zip",
+				Tool: newFakeTool(manager, "fake", nil),
+				Config: config.ToolConfig{Signature: &config.SignatureConfig{
+					Keyring:  keyring,
+					URL:      server.URL + "/archive.zip.asc",
+					Required: tt.required,
+				}},
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("verifySignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "signature verification failed") {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if _, statErr := os.Stat(filePath); (statErr == nil) != tt.wantFile {
+				t.Errorf("file exists = %v, want %v", statErr == nil, tt.wantFile)
+			}
+		})
+	}
+}
+
+func TestVerifySignatureNotConfigured(t *testing.T) {
+	manager := newTestManager(t)
+	err := verifySignature("missing", &DownloadConfig{Tool: newFakeTool(manager, "fake", nil)})
+	if err != nil {
+		t.Errorf("verifySignature() without signature config = %v, want nil", err)
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
 )
 
 // UseSystemTool checks if a system tool should be used instead of downloading
@@ -80,3 +84,69 @@ func getToolVersionOverride(toolName string) string {
 func getToolVersionOverrideEnvVar(toolName string) string {
 	return fmt.Sprintf("MVX_%s_VERSION", strings.ToUpper(toolName))
 }
+
+// PathShadowing describes a configured tool whose binary, on the current PATH, resolves to
+// another installation than the one managed by mvx
+type PathShadowing struct {
+	Tool       string // Tool name
+	Binary     string // Binary name looked up on PATH
+	SystemPath string // Binary found first on the current PATH
+	ManagedDir string // Directory containing the mvx-managed binary
+}
+
+// CheckPathShadowing looks up the binaries of the installed, configured tools on the current
+// PATH. Outside of mvx's environment (IDEs, scripts, other shells), a binary found earlier on
+// PATH wins over the mvx-managed one, which explains many "wrong version" reports.
+func (m *Manager) CheckPathShadowing(cfg *config.Config) []PathShadowing {
+	var shadowed []PathShadowing
+	for _, toolName := range sortedKeys(cfg.Tools) {
+		if UseSystemTool(toolName) {
+			continue // The system tool is what the user asked for
+		}
+
+		tool, err := m.GetTool(toolName)
+		if err != nil {
+			continue
+		}
+		resolvedVersion, err := m.resolveVersion(toolName, cfg.Tools[toolName])
+		if err != nil {
+			util.LogVerbose("Skipping PATH check for %s: %v", toolName, err)
+			continue
+		}
+		resolvedConfig := cfg.Tools[toolName]
+		resolvedConfig.Version = resolvedVersion
+		if !m.isToolInstalled(toolName, resolvedVersion, resolvedConfig) {
+			continue
+		}
+		managedDir, err := tool.GetPath(resolvedVersion, resolvedConfig)
+		if err != nil || managedDir == "" {
+			continue
+		}
+
+		binary := tool.GetBinaryName()
+		systemPath, err := exec.LookPath(binary)
+		if err != nil {
+			continue // Not on PATH at all: nothing shadows the managed binary
+		}
+		if !sameDirectory(filepath.Dir(systemPath), managedDir) {
+			shadowed = append(shadowed, PathShadowing{
+				Tool:       toolName,
+				Binary:     binary,
+				SystemPath: systemPath,
+				ManagedDir: managedDir,
+			})
+		}
+	}
+	return shadowed
+}
+
+// sameDirectory reports whether two paths refer to the same directory, following symlinks
+func sameDirectory(a, b string) bool {
+	if resolved, err := filepath.EvalSymlinks(a); err == nil {
+		a = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(b); err == nil {
+		b = resolved
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
@@ -0,0 +1,175 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/util"
+)
+
+// mavenToolchainJDK is a JDK declared in the Maven toolchains file
+type mavenToolchainJDK struct {
+	Version string
+	Vendor  string
+	Home    string
+}
+
+// jdkHomePattern matches the JDK homes declared in a toolchains file
+var jdkHomePattern = regexp.MustCompile(`<jdkHome>\s*([^<]*?)\s*</jdkHome>`)
+
+// emptyToolchainsPattern matches an empty toolchains element written as <toolchains/>
+var emptyToolchainsPattern = regexp.MustCompile(`<toolchains(\s[^>]*?)?\s*/>`)
+
+// GetMavenToolchainsPath returns the path of the user's Maven toolchains file
+func GetMavenToolchainsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".m2", "toolchains.xml"), nil
+}
+
+// updateMavenToolchains declares the installed JDKs configured with the mavenToolchains option in
+// the user's Maven toolchains file. Failures are reported without failing the installation.
+func (m *Manager) updateMavenToolchains(cfg *config.Config) {
+	var jdks []mavenToolchainJDK
+	for key, toolConfig := range cfg.Tools {
+		if m.toolNameForKey(key) != ToolJava || !strings.EqualFold(toolConfig.Options[OptionMavenToolchains], "true") {
+			continue
+		}
+		tool, err := m.GetTool(key)
+		if err != nil {
+			continue
+		}
+		resolvedVersion, err := m.resolveVersion(key, toolConfig)
+		if err != nil {
+			continue
+		}
+		resolvedConfig := toolConfig
+		resolvedConfig.Version = resolvedVersion
+		javaHome, err := tool.(*JavaTool).GetJavaHome(resolvedVersion, resolvedConfig)
+		if err != nil {
+			util.LogVerbose("Not adding %s %s to the Maven toolchains: %v", key, resolvedVersion, err)
+			continue
+		}
+		vendor := toolConfig.Distribution
+		if vendor == "" {
+			vendor = "temurin"
+		}
+		jdks = append(jdks, mavenToolchainJDK{Version: resolvedVersion, Vendor: vendor, Home: javaHome})
+	}
+	if len(jdks) == 0 {
+		return
+	}
+	sort.Slice(jdks, func(i, k int) bool { return jdks[i].Home < jdks[k].Home })
+
+	path, err := GetMavenToolchainsPath()
+	if err == nil {
+		var added []mavenToolchainJDK
+		if added, err = addMavenToolchains(path, jdks); err == nil {
+			for _, jdk := range added {
+				statusf(ToolJava, "  ☕ Added JDK %s (%s) to %s\n", jdk.Version, jdk.Vendor, path)
+			}
+			return
+		}
+	}
+	statusf(ToolJava, "  ⚠️  Failed to update the Maven toolchains: %v\n", err)
+}
+
+// addMavenToolchains adds to a toolchains file the JDKs it does not declare yet, creating the file
+// if needed, and returns the added ones. The existing content is preserved as is.
+func addMavenToolchains(path string, jdks []mavenToolchainJDK) ([]mavenToolchainJDK, error) {
+	content := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<toolchains>\n</toolchains>\n"
+	if data, err := os.ReadFile(path); err == nil {
+		content = string(data)
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	declared := make(map[string]bool)
+	for _, match := range jdkHomePattern.FindAllStringSubmatch(content, -1) {
+		declared[filepath.Clean(match[1])] = true
+	}
+
+	var added []mavenToolchainJDK
+	var entries strings.Builder
+	for _, jdk := range jdks {
+		if declared[filepath.Clean(jdk.Home)] {
+			continue
+		}
+		declared[filepath.Clean(jdk.Home)] = true
+		added = append(added, jdk)
+		fmt.Fprintf(&entries, `  <toolchain>
+    <type>jdk</type>
+    <provides>
+      <version>%s</version>
+      <vendor>%s</vendor>
+    </provides>
+    <configuration>
+      <jdkHome>%s</jdkHome>
+    </configuration>
+  </toolchain>
+`, xmlEscape(jdk.Version), xmlEscape(jdk.Vendor), xmlEscape(jdk.Home))
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	if end := strings.LastIndex(content, "</toolchains>"); end >= 0 {
+		content = content[:end] + entries.String() + content[end:]
+	} else if match := emptyToolchainsPattern.FindStringSubmatch(content); match != nil {
+		// Open the empty element to add the entries to it
+		replacement := "<toolchains" + match[1] + ">\n" + entries.String() + "</toolchains>"
+		content = strings.Replace(content, match[0], replacement, 1)
+	} else {
+		return nil, fmt.Errorf("%s has no </toolchains> element", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := writeToolchainsFile(path, content); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// writeToolchainsFile atomically replaces a toolchains file, so that Maven never reads it
+// half written, keeping the permissions of the file it replaces
+func writeToolchainsFile(path, content string) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	temp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(temp.Name()) // No-op once renamed
+
+	if _, err := temp.WriteString(content); err != nil {
+		temp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := temp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Chmod(temp.Name(), mode); err != nil {
+		return fmt.Errorf("failed to set permissions on %s: %w", path, err)
+	}
+	if err := os.Rename(temp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move file to %s: %w", path, err)
+	}
+	return nil
+}
+
+// xmlEscape escapes the characters with a special meaning in XML text
+func xmlEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
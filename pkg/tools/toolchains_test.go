@@ -0,0 +1,140 @@
+package tools
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestAddMavenToolchains(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".m2", "toolchains.xml")
+	jdk21 := mavenToolchainJDK{Version: "21.0.5", Vendor: "zulu", Home: "/tools/java/21.0.5-zulu"}
+	jdk8 := mavenToolchainJDK{Version: "8.0.392", Vendor: "temurin", Home: "/tools/java/8.0.392-temurin"}
+
+	added, err := addMavenToolchains(path, []mavenToolchainJDK{jdk21})
+	if err != nil || len(added) != 1 {
+		t.Fatalf("addMavenToolchains() = %v, %v, want the JDK added", added, err)
+	}
+
+	// Entries written by hand are kept, and JDKs already declared are not added twice
+	data, _ := os.ReadFile(path)
+	custom := "  <toolchain><type>netbeans</type></toolchain>\n</toolchains>"
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "</toolchains>", custom, 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	added, err = addMavenToolchains(path, []mavenToolchainJDK{jdk21, jdk8})
+	if err != nil || len(added) != 1 || added[0] != jdk8 {
+		t.Fatalf("addMavenToolchains() = %v, %v, want only %v added", added, err, jdk8)
+	}
+
+	data, _ = os.ReadFile(path)
+	content := string(data)
+	for _, expected := range []string{"<type>netbeans</type>", "<jdkHome>/tools/java/21.0.5-zulu</jdkHome>", "<version>8.0.392</version>", "<vendor>temurin</vendor>"} {
+		if strings.Count(content, expected) != 1 {
+			t.Errorf("toolchains.xml should contain %q once:\n%s", expected, content)
+		}
+	}
+	if !strings.HasSuffix(strings.TrimSpace(content), "</toolchains>") {
+		t.Errorf("toolchains.xml should end with </toolchains>:\n%s", content)
+	}
+}
+
+func TestAddMavenToolchainsEmptyElement(t *testing.T) {
+	jdk := mavenToolchainJDK{Version: "21.0.5", Vendor: "zulu", Home: "/tools/java/21.0.5-zulu"}
+
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "self-closing", content: "<toolchains/>\n", expected: "<toolchains>\n"},
+		{name: "with attributes", content: `<toolchains xmlns="http://maven.apache.org/TOOLCHAINS/1.1.0" />` + "\n",
+			expected: `<toolchains xmlns="http://maven.apache.org/TOOLCHAINS/1.1.0">` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "toolchains.xml")
+			if err := os.WriteFile(path, []byte(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			if added, err := addMavenToolchains(path, []mavenToolchainJDK{jdk}); err != nil || len(added) != 1 {
+				t.Fatalf("addMavenToolchains() = %v, %v, want the JDK added", added, err)
+			}
+
+			data, _ := os.ReadFile(path)
+			content := string(data)
+			if !strings.Contains(content, tt.expected+"  <toolchain>") || !strings.HasSuffix(content, "</toolchains>\n") {
+				t.Errorf("toolchains.xml should open with %q and close the element:\n%s", tt.expected, content)
+			}
+			if info, err := os.Stat(path); err != nil {
+				t.Fatal(err)
+			} else if info.Mode().Perm() != 0600 {
+				t.Errorf("toolchains.xml permissions = %v, want the original 0600 kept", info.Mode().Perm())
+			}
+			if entries, _ := os.ReadDir(filepath.Dir(path)); len(entries) != 1 {
+				t.Errorf("temporary files left next to toolchains.xml: %v", entries)
+			}
+		})
+	}
+}
+
+func TestMultipleJavaEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses shell scripts as java launchers")
+	}
+	manager := newTestManager(t)
+	manager.httpClient = &http.Client{Transport: &failingTransport{}}
+	manager.RegisterTool(NewJavaTool(manager))
+	t.Setenv("HOME", t.TempDir())
+
+	homes := make(map[string]string)
+	for version, distribution := range map[string]string{"21.0.5": "zulu", "8.0.392": "temurin"} {
+		home := filepath.Join(manager.GetToolVersionDir(ToolJava, version, distribution), "jdk-"+version)
+		if err := os.MkdirAll(filepath.Join(home, "bin"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		script := "#!/bin/sh\necho 'openjdk version \"" + version + "\"' >&2\n"
+		if err := os.WriteFile(filepath.Join(home, "bin", "java"), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+		homes[version] = home
+	}
+
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{
+		"java":  {Version: "21.0.5", Distribution: "zulu"},
+		"java8": {Version: "8.0.392", Distribution: "temurin", Options: map[string]string{OptionMavenToolchains: "true"}},
+	}}
+	env, err := manager.computeEnvironment(cfg)
+	if err != nil {
+		t.Fatalf("computeEnvironment() error = %v", err)
+	}
+	expected := map[string]string{
+		EnvJavaHome:   homes["21.0.5"],
+		"JAVA21_HOME": homes["21.0.5"],
+		"JAVA8_HOME":  homes["8.0.392"],
+	}
+	for key, value := range expected {
+		if env[key] != value {
+			t.Errorf("%s = %q, want %q", key, env[key], value)
+		}
+	}
+	if strings.Contains(env["PATH"], homes["8.0.392"]) {
+		t.Errorf("PATH should only contain the primary JDK: %s", env["PATH"])
+	}
+
+	manager.updateMavenToolchains(cfg)
+	toolchainsPath, _ := GetMavenToolchainsPath()
+	data, err := os.ReadFile(toolchainsPath)
+	if err != nil {
+		t.Fatalf("toolchains.xml was not written: %v", err)
+	}
+	if !strings.Contains(string(data), homes["8.0.392"]) || strings.Contains(string(data), homes["21.0.5"]) {
+		t.Errorf("toolchains.xml should only declare the JDK with the %s option:\n%s", OptionMavenToolchains, data)
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gnodet/mvx/pkg/config"
+	"github.com/gnodet/mvx/pkg/version"
+)
+
+// VersionUpdate reports the version a tool specification resolved to before and after an update
+type VersionUpdate struct {
+	Tool     string
+	Spec     string
+	Previous string // Version from the lockfile or the version cache, empty if unknown
+	Resolved string // Version resolved against the latest releases
+	Err      error  // Set when the tool could not be resolved
+}
+
+// Changed reports whether the update resolved the specification to another version
+func (u VersionUpdate) Changed() bool {
+	return u.Err == nil && u.Previous != u.Resolved
+}
+
+// ForceRefresh makes metadata requests bypass the disk cache, as MVX_FORCE_REFRESH does, and
+// drops the responses already cached in memory, so that resolutions see the latest releases
+func (m *Manager) ForceRefresh() {
+	m.forceRefresh.Store(true)
+	m.cacheMutex.Lock()
+	m.httpCache = make(map[string]HTTPCacheEntry)
+	m.cacheMutex.Unlock()
+}
+
+// UpdateResolutions re-resolves the version specifications of the given tools, or of all the
+// configured tools if none are given, against the latest releases, ignoring the version cache
+// and lockfile. Unless dryRun is set, the new resolutions replace the cached ones and, if the
+// project has a lockfile (see UseLockfile), are written to it. Results are sorted by tool name.
+func (m *Manager) UpdateResolutions(cfg *config.Config, toolNames []string, projectRoot string, dryRun bool) ([]VersionUpdate, error) {
+	if len(toolNames) == 0 {
+		toolNames = sortedKeys(cfg.Tools)
+	}
+	for _, toolName := range toolNames {
+		if _, exists := cfg.Tools[toolName]; !exists {
+			return nil, fmt.Errorf("tool %s not configured", toolName)
+		}
+	}
+	sort.Strings(toolNames)
+
+	updates := make([]VersionUpdate, 0, len(toolNames))
+	for _, toolName := range toolNames {
+		toolConfig := cfg.Tools[toolName]
+		update := VersionUpdate{Tool: toolName, Spec: toolConfig.Version}
+		if locked, ok := m.lockedVersion(toolName, toolConfig); ok {
+			update.Previous = locked
+		} else if previous, err := m.resolveConfiguredVersion(toolName, toolConfig); err == nil {
+			update.Previous = previous
+		}
+		updates = append(updates, update)
+	}
+
+	m.ForceRefresh()
+	for i := range updates {
+		update := &updates[i]
+		toolConfig := cfg.Tools[update.Tool]
+		if toolConfig.LocalArchive() != "" || m.isConcreteVersion(update.Tool, toolConfig.Version) {
+			update.Resolved = toolConfig.Version
+			continue
+		}
+
+		preference, err := version.ParsePreference(toolConfig.Options[OptionResolve])
+		if err != nil {
+			update.Err = fmt.Errorf("invalid %s option for %s: %w", OptionResolve, update.Tool, err)
+			continue
+		}
+		tool, err := m.GetTool(update.Tool)
+		if err != nil {
+			update.Err = err
+			continue
+		}
+		if update.Resolved, err = m.resolveOnline(tool, toolConfig.Version, toolConfig.Distribution, preference); err != nil {
+			update.Err = err
+			continue
+		}
+		if !dryRun {
+			m.setCachedVersion(update.Tool, versionCacheSpec(toolConfig.Version, preference), toolConfig.Distribution, update.Resolved)
+		}
+	}
+
+	if dryRun || m.lockfile == nil {
+		return updates, nil
+	}
+	if m.lockfile.Tools == nil {
+		m.lockfile.Tools = make(map[string]LockedTool)
+	}
+	for _, update := range updates {
+		if update.Err == nil {
+			m.lockfile.Tools[update.Tool] = m.lockTool(update.Tool, cfg.Tools[update.Tool], update.Resolved)
+		}
+	}
+	return updates, m.saveLockfile(m.lockfile, projectRoot)
+}
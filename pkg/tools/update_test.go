@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnodet/mvx/pkg/config"
+)
+
+func TestUpdateResolutions(t *testing.T) {
+	manager := newTestManager(t)
+	alpha := newFakeTool(manager, "alpha", nil)
+	alpha.versions = []string{"1.0.0", "1.1.0", "2.0.0"}
+	manager.RegisterTool(&resolvingFakeTool{alpha})
+	beta := newFakeTool(manager, "beta", nil)
+	beta.versions = []string{"3.0.0", "3.1.0"}
+	manager.RegisterTool(&resolvingFakeTool{beta})
+
+	projectRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectRoot, ".mvx"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Tools: map[string]config.ToolConfig{
+		"alpha": {Version: "1"},
+		"beta":  {Version: "3.0.0"},
+	}}
+	if _, err := manager.WriteLockfile(cfg, projectRoot); err != nil {
+		t.Fatalf("WriteLockfile() error = %v", err)
+	}
+	if err := manager.UseLockfile(projectRoot); err != nil {
+		t.Fatalf("UseLockfile() error = %v", err)
+	}
+
+	// A newer release of the line, hidden by the lockfile and the version cache
+	alpha.versions = []string{"1.0.0", "1.1.0", "1.2.0", "2.0.0"}
+
+	if _, err := manager.UpdateResolutions(cfg, []string{"gamma"}, projectRoot, false); err == nil {
+		t.Error("UpdateResolutions() accepted a tool that is not configured")
+	}
+
+	updates, err := manager.UpdateResolutions(cfg, nil, projectRoot, true)
+	if err != nil {
+		t.Fatalf("UpdateResolutions(dry run) error = %v", err)
+	}
+	expected := []VersionUpdate{
+		{Tool: "alpha", Spec: "1", Previous: "1.1.0", Resolved: "1.2.0"},
+		{Tool: "beta", Spec: "3.0.0", Previous: "3.0.0", Resolved: "3.0.0"},
+	}
+	if len(updates) != len(expected) {
+		t.Fatalf("UpdateResolutions() = %+v, want %+v", updates, expected)
+	}
+	for i, update := range updates {
+		if update != expected[i] {
+			t.Errorf("UpdateResolutions()[%d] = %+v, want %+v", i, update, expected[i])
+		}
+	}
+	if !updates[0].Changed() || updates[1].Changed() {
+		t.Errorf("Changed() = %v, %v, want true, false", updates[0].Changed(), updates[1].Changed())
+	}
+	if resolved, _ := manager.ResolveVersion("alpha", cfg.Tools["alpha"]); resolved != "1.1.0" {
+		t.Errorf("ResolveVersion(alpha) after a dry run = %s, want the locked 1.1.0", resolved)
+	}
+
+	if _, err := manager.UpdateResolutions(cfg, []string{"alpha"}, projectRoot, false); err != nil {
+		t.Fatalf("UpdateResolutions() error = %v", err)
+	}
+	lockfile, err := ReadLockfile(projectRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if locked := lockfile.Tools["alpha"]; locked.Version != "1.2.0" {
+		t.Errorf("locked alpha = %+v, want 1.2.0", locked)
+	}
+	if locked := lockfile.Tools["beta"]; locked.Version != "3.0.0" {
+		t.Errorf("locked beta = %+v, want it unchanged", locked)
+	}
+
+	// Without the lockfile, the version cache holds the new resolution
+	manager.IgnoreLockfile()
+	if resolved, _ := manager.ResolveVersion("alpha", cfg.Tools["alpha"]); resolved != "1.2.0" {
+		t.Errorf("ResolveVersion(alpha) from the cache = %s, want 1.2.0", resolved)
+	}
+}
@@ -0,0 +1,28 @@
+package util
+
+import (
+	"io/fs"
+	"path/filepath"
+)
+
+// DirSize returns the total size in bytes of the regular files under path.
+// Symbolic links are not followed, so linked files are not counted twice.
+func DirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		// WalkDir reads directory entries in batches, only regular files need a stat
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
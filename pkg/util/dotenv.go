@@ -0,0 +1,126 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadDotenvFile reads the variables of a dotenv file
+func ReadDotenvFile(path string) (map[string]string, error) {
+	return readDotenvFile(path, failOnLine)
+}
+
+// ReadDotenvFileLenient reads the variables of a dotenv file, skipping malformed lines after
+// reporting them to warn
+func ReadDotenvFileLenient(path string, warn func(err error)) (map[string]string, error) {
+	return readDotenvFile(path, func(err error) error {
+		warn(fmt.Errorf("%s: %w", path, err))
+		return nil
+	})
+}
+
+func readDotenvFile(path string, onLineError func(err error) error) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	env, err := parseDotenv(file, onLineError)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return env, nil
+}
+
+// ParseDotenv parses KEY=VALUE lines. Blank lines, # comments and an "export " prefix are
+// ignored. Single-quoted values are literal, double-quoted values support backslash escapes
+// (\n, \", \\, \$ and \`), and unquoted values end at an inline " #" comment.
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	return parseDotenv(r, failOnLine)
+}
+
+// failOnLine makes a malformed line fail the parsing of a dotenv file
+func failOnLine(err error) error {
+	return err
+}
+
+// parseDotenv parses dotenv lines, passing the errors of malformed lines to onLineError:
+// the parsing fails if it returns an error, and skips the line otherwise
+func parseDotenv(r io.Reader, onLineError func(err error) error) (map[string]string, error) {
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" || strings.ContainsAny(key, " \t") {
+			if err := onLineError(fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		parsed, err := parseDotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			if err := onLineError(fmt.Errorf("line %d: %w", lineNumber, err)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		env[key] = parsed
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// parseDotenvValue unquotes a dotenv value
+func parseDotenvValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single quote")
+		}
+		return value[1 : end+1], nil
+
+	case strings.HasPrefix(value, `"`):
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			switch char := value[i]; char {
+			case '"':
+				return b.String(), nil
+			case '\\':
+				if i+1 < len(value) {
+					i++
+					if value[i] == 'n' {
+						b.WriteByte('\n')
+					} else {
+						b.WriteByte(value[i])
+					}
+				}
+			default:
+				b.WriteByte(char)
+			}
+		}
+		return "", fmt.Errorf("unterminated double quote")
+
+	default:
+		if comment := strings.Index(value, " #"); comment >= 0 {
+			value = strings.TrimSpace(value[:comment])
+		}
+		return value, nil
+	}
+}
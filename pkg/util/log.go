@@ -1,8 +1,27 @@
 package util
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// EnvLogFormat selects the format of the messages: "json" logs one JSON object per line on
+// stderr, for log processing in CI, instead of the default output for humans
+const EnvLogFormat = "MVX_LOG_FORMAT"
+
+// Log levels of the JSON log entries
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
 )
 
 // IsVerbose returns true if verbose logging is enabled
@@ -10,9 +29,117 @@ func IsVerbose() bool {
 	return os.Getenv("MVX_VERBOSE") == "true"
 }
 
+// IsJSONLog returns true if messages are logged as JSON objects
+func IsJSONLog() bool {
+	return os.Getenv(EnvLogFormat) == "json"
+}
+
 // LogVerbose prints verbose log messages
 func LogVerbose(format string, args ...interface{}) {
 	if IsVerbose() {
+		if IsJSONLog() {
+			Log(LogEntry{Level: LevelDebug, Msg: fmt.Sprintf(format, args...)})
+			return
+		}
 		fmt.Printf("[VERBOSE] "+format+"\n", args...)
 	}
 }
+
+// LogEntry is a message logged as a JSON object
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Tool    string    `json:"tool,omitempty"`
+	Version string    `json:"version,omitempty"`
+	Event   string    `json:"event,omitempty"`
+	Msg     string    `json:"msg"`
+}
+
+var (
+	logOutput io.Writer = os.Stderr
+	logMutex  sync.Mutex
+)
+
+// Log writes an entry as a JSON object on its own line, debug entries in verbose mode only
+func Log(entry LogEntry) {
+	if entry.Level == LevelDebug && !IsVerbose() {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	logMutex.Lock()
+	defer logMutex.Unlock()
+	logOutput.Write(append(data, '\n'))
+}
+
+// StatusOutput returns where status messages are printed: stdout, or in JSON log format a
+// writer logging each line
+func StatusOutput() io.Writer {
+	if IsJSONLog() {
+		return NewLogWriter("")
+	}
+	return os.Stdout
+}
+
+// logWriter logs each line written to it as the message of a tool
+type logWriter struct {
+	tool    string
+	mutex   sync.Mutex
+	pending []byte
+}
+
+// NewLogWriter returns a writer logging each line written to it as a JSON entry, with a level
+// inferred from its leading symbol (e.g. ⚠️ for warnings), for messages printed with fmt
+func NewLogWriter(tool string) io.Writer {
+	return &logWriter{tool: tool}
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+	}
+	return len(p), nil
+}
+
+// logLine logs a line written to the writer
+func (w *logWriter) logLine(line string) {
+	LogMessage(LevelInfo, w.tool, line)
+}
+
+// LogMessage logs each line of a message printed for humans as a JSON entry, without its
+// decorations (indentation, leading symbols and tool prefix), at the level of its leading symbol
+// if it is a warning or an error symbol
+func LogMessage(level, tool, message string) {
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(line)
+		lineLevel := level
+		switch {
+		case strings.HasPrefix(line, "⚠"):
+			lineLevel = LevelWarn
+		case strings.HasPrefix(line, "❌"):
+			lineLevel = LevelError
+		}
+		msg := strings.TrimLeftFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.Is(unicode.So, r) || unicode.Is(unicode.Mn, r) || r == '\u200d'
+		})
+		if tool != "" {
+			msg = strings.TrimPrefix(msg, "["+tool+"] ")
+		}
+		if msg != "" {
+			Log(LogEntry{Level: lineLevel, Tool: tool, Msg: msg})
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLogWriter(t *testing.T) {
+	var out bytes.Buffer
+	previous := logOutput
+	logOutput = &out
+	defer func() { logOutput = previous }()
+
+	w := NewLogWriter("maven")
+	fmt.Fprintf(w, "  ⏳ Downloading Maven 3.9.9...\n  🌐 [maven] Connecting")
+	fmt.Fprintf(w, " to server...\n  ⚠️  [maven] Download attempt 1 failed: HTTP 503\n\n")
+	LogMessage(LevelInfo, "", "  ❌ No server reachable")
+
+	expected := []LogEntry{
+		{Level: LevelInfo, Tool: "maven", Msg: "Downloading Maven 3.9.9..."},
+		{Level: LevelInfo, Tool: "maven", Msg: "Connecting to server..."},
+		{Level: LevelWarn, Tool: "maven", Msg: "Download attempt 1 failed: HTTP 503"},
+		{Level: LevelError, Msg: "No server reachable"},
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("logged %d entries, want %d:\n%s", len(lines), len(expected), out.String())
+	}
+	for i, line := range lines {
+		var entry LogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("entry %d is not JSON: %v\n%s", i, err, line)
+		}
+		if entry.Time.IsZero() {
+			t.Errorf("entry %d has no time", i)
+		}
+		entry.Time = expected[i].Time
+		if entry != expected[i] {
+			t.Errorf("entry %d = %+v, want %+v", i, entry, expected[i])
+		}
+	}
+}
@@ -25,6 +25,14 @@ type Spec struct {
 	Minor      int
 	Patch      int
 	Pre        string
+	// Comparators of a "range" constraint, which a version must all satisfy
+	Comparators []Comparator
+}
+
+// Comparator is a comparison of a version range, such as ">=17" or "<21"
+type Comparator struct {
+	Op      string // "=", ">", ">=", "<" or "<="
+	Version Version
 }
 
 // ParseVersion parses a version string into a Version struct
@@ -72,9 +80,16 @@ func ParseSpec(spec string) (*Spec, error) {
 		}, nil
 	}
 
-	// Handle range specifications (future enhancement)
-	if strings.Contains(spec, ">=") || strings.Contains(spec, "<=") || strings.Contains(spec, "~") || strings.Contains(spec, "^") {
-		return nil, fmt.Errorf("range specifications not yet implemented: %s", spec)
+	if IsRange(spec) {
+		comparators, err := parseRange(spec)
+		if err != nil {
+			return nil, err
+		}
+		return &Spec{
+			Raw:         spec,
+			Constraint:  "range",
+			Comparators: comparators,
+		}, nil
 	}
 
 	// Parse as version
@@ -103,6 +118,122 @@ func ParseSpec(spec string) (*Spec, error) {
 	}, nil
 }
 
+// IsRange reports whether a version specification is a range, made of comparators such as
+// ">=17 <21", or of caret and tilde operators such as "^18" or "~3.9.6"
+func IsRange(spec string) bool {
+	return strings.ContainsAny(strings.TrimSpace(spec), "<>=^~ \t,")
+}
+
+// parseRange parses the comparators of a range, separated by whitespace or commas. Partial
+// versions match any version they are a prefix of: "<=17" allows 17.0.5 and ">17" does not.
+func parseRange(spec string) ([]Comparator, error) {
+	tokens := strings.FieldsFunc(spec, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == ','
+	})
+
+	var comparators []Comparator
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		op := strings.TrimRight(token, "0123456789.-+abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+		if op == token && i+1 < len(tokens) {
+			// An operator separated from its version, as in ">= 17"
+			i++
+			token += tokens[i]
+		}
+		operand := strings.TrimPrefix(token, op)
+
+		v, err := ParseVersion(operand)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version range %q: %w", spec, err)
+		}
+		parts := strings.Count(strings.SplitN(strings.SplitN(strings.TrimPrefix(operand, "v"), "-", 2)[0], "+", 2)[0], ".") + 1
+
+		switch op {
+		case "^":
+			comparators = append(comparators, Comparator{">=", *v}, Comparator{"<", caretUpperBound(v, parts)})
+		case "~":
+			comparators = append(comparators, Comparator{">=", *v}, Comparator{"<", nextPrefix(v, min(parts, 2))})
+		case ">=", "<":
+			comparators = append(comparators, Comparator{op, *v})
+		case ">":
+			if parts < 3 {
+				comparators = append(comparators, Comparator{">=", nextPrefix(v, parts)})
+			} else {
+				comparators = append(comparators, Comparator{op, *v})
+			}
+		case "<=":
+			if parts < 3 {
+				comparators = append(comparators, Comparator{"<", nextPrefix(v, parts)})
+			} else {
+				comparators = append(comparators, Comparator{op, *v})
+			}
+		case "", "=":
+			if parts < 3 {
+				comparators = append(comparators, Comparator{">=", *v}, Comparator{"<", nextPrefix(v, parts)})
+			} else {
+				comparators = append(comparators, Comparator{"=", *v})
+			}
+		default:
+			return nil, fmt.Errorf("invalid version range %q: unknown operator %q", spec, op)
+		}
+	}
+
+	if len(comparators) == 0 {
+		return nil, fmt.Errorf("invalid version range %q", spec)
+	}
+	return comparators, nil
+}
+
+// nextPrefix returns the first version after all the versions starting with the given
+// number of parts of v, e.g. 18.0.0 for the one part of 17 and 3.10.0 for the two of 3.9
+func nextPrefix(v *Version, parts int) Version {
+	switch parts {
+	case 1:
+		return Version{Major: v.Major + 1}
+	case 2:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	default:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	}
+}
+
+// caretUpperBound returns the exclusive upper bound of "^v", which allows changes that do not
+// modify its first non-zero part: ^1.2.3 allows up to 2.0.0 but ^0.2.3 only up to 0.3.0
+func caretUpperBound(v *Version, parts int) Version {
+	switch {
+	case v.Major > 0 || parts == 1:
+		return nextPrefix(v, 1)
+	case v.Minor > 0 || parts == 2:
+		return nextPrefix(v, 2)
+	default:
+		return nextPrefix(v, 3)
+	}
+}
+
+// satisfies reports whether a version satisfies a comparator
+func (c Comparator) satisfies(v *Version) bool {
+	cmp := v.Compare(&c.Version)
+	switch c.Op {
+	case "=":
+		return cmp == 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	default:
+		return false
+	}
+}
+
+// String returns the comparator as written in a range, e.g. ">=17.0.0"
+func (c Comparator) String() string {
+	return c.Op + c.Version.String()
+}
+
 // String returns the string representation of a version
 func (v *Version) String() string {
 	result := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
@@ -146,12 +277,46 @@ func (v *Version) Compare(other *Version) int {
 		return -1 // Pre-release < release version
 	}
 	if v.Pre != other.Pre {
-		return strings.Compare(v.Pre, other.Pre)
+		return comparePre(v.Pre, other.Pre)
 	}
 
 	return 0
 }
 
+// comparePre compares pre-release identifiers as semantic versioning does: dot-separated
+// parts are compared in turn, numerically when both are numbers, so that ea.10 > ea.9
+func comparePre(a, b string) int {
+	aParts, bParts := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		aNum, aErr := strconv.Atoi(aParts[i])
+		bNum, bErr := strconv.Atoi(bParts[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNum != bNum {
+				if aNum < bNum {
+					return -1
+				}
+				return 1
+			}
+		case aErr == nil:
+			return -1 // Numeric identifiers have lower precedence
+		case bErr == nil:
+			return 1
+		default:
+			if cmp := strings.Compare(aParts[i], bParts[i]); cmp != 0 {
+				return cmp
+			}
+		}
+	}
+	if len(aParts) != len(bParts) {
+		if len(aParts) < len(bParts) {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
 // Matches checks if a version matches a specification
 func (s *Spec) Matches(v *Version) bool {
 	switch s.Constraint {
@@ -163,13 +328,87 @@ func (s *Spec) Matches(v *Version) bool {
 		return s.Major == v.Major
 	case "minor":
 		return s.Major == v.Major && s.Minor == v.Minor
+	case "range":
+		return s.matchesRange(v)
 	default:
 		return false
 	}
 }
 
+// matchesRange checks if a version satisfies all the comparators of a range. As with npm
+// ranges, a pre-release only matches if a comparator names a pre-release of the same
+// major.minor.patch: ">=21.0.0-ea <22" allows 21.0.0-ea.5 but not 21.0.1-ea.
+func (s *Spec) matchesRange(v *Version) bool {
+	allowsPre := v.Pre == ""
+	for _, c := range s.Comparators {
+		if !c.satisfies(v) {
+			return false
+		}
+		if c.Version.Pre != "" && c.Version.Major == v.Major && c.Version.Minor == v.Minor && c.Version.Patch == v.Patch {
+			allowsPre = true
+		}
+	}
+	return allowsPre
+}
+
+// AllowsMajor reports whether some version of a major version may match the specification,
+// which lets resolvers listing versions per major version skip the ones that cannot match
+func (s *Spec) AllowsMajor(major int) bool {
+	switch s.Constraint {
+	case "latest":
+		return true
+	case "range":
+		// Intersect [major.0.0, major+1.0.0) with the bounds of the comparators
+		low, lowInclusive := Version{Major: major}, true
+		high, highInclusive := Version{Major: major + 1}, false
+		for _, c := range s.Comparators {
+			if c.Op == "=" || c.Op == ">" || c.Op == ">=" {
+				if cmp := c.Version.Compare(&low); cmp > 0 || (cmp == 0 && c.Op == ">") {
+					low, lowInclusive = c.Version, c.Op != ">"
+				}
+			}
+			if c.Op == "=" || c.Op == "<" || c.Op == "<=" {
+				if cmp := c.Version.Compare(&high); cmp < 0 || (cmp == 0 && c.Op == "<") {
+					high, highInclusive = c.Version, c.Op != "<"
+				}
+			}
+		}
+		cmp := low.Compare(&high)
+		return cmp < 0 || (cmp == 0 && lowInclusive && highInclusive)
+	default:
+		return s.Major == major
+	}
+}
+
+// Preference selects which of the versions matching a specification is resolved
+type Preference string
+
+const (
+	// PreferHighest resolves to the newest matching version (the default)
+	PreferHighest Preference = "highest"
+	// PreferLowest resolves to the oldest matching version, e.g. for minimum supported version testing
+	PreferLowest Preference = "lowest"
+)
+
+// ParsePreference parses a resolution preference, defaulting to PreferHighest when empty
+func ParsePreference(value string) (Preference, error) {
+	switch Preference(strings.ToLower(strings.TrimSpace(value))) {
+	case "", PreferHighest:
+		return PreferHighest, nil
+	case PreferLowest:
+		return PreferLowest, nil
+	default:
+		return "", fmt.Errorf("invalid version resolution preference %q: must be %s or %s", value, PreferHighest, PreferLowest)
+	}
+}
+
 // Resolve finds the best matching version from a list of available versions
 func (s *Spec) Resolve(availableVersions []string) (string, error) {
+	return s.ResolveWithPreference(availableVersions, PreferHighest)
+}
+
+// ResolveWithPreference finds the highest or lowest matching version from a list of available versions
+func (s *Spec) ResolveWithPreference(availableVersions []string, preference Preference) (string, error) {
 	if len(availableVersions) == 0 {
 		return "", fmt.Errorf("no versions available")
 	}
@@ -208,8 +447,11 @@ func (s *Spec) Resolve(availableVersions []string) (string, error) {
 		return matching[i].Compare(matching[j]) > 0
 	})
 
-	// Return the highest matching version
+	// Return the highest or lowest matching version
 	best := matching[0]
+	if preference == PreferLowest {
+		best = matching[len(matching)-1]
+	}
 	if original, exists := versionMap[best.String()]; exists {
 		return original, nil
 	}
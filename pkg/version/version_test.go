@@ -0,0 +1,240 @@
+package version
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestResolveWithPreference(t *testing.T) {
+	available := []string{"17.0.9", "21.0.2", "17.0.1", "17.0.16", "11.0.24"}
+
+	tests := []struct {
+		spec       string
+		preference Preference
+		expected   string
+	}{
+		{spec: "17", preference: PreferHighest, expected: "17.0.16"},
+		{spec: "17", preference: PreferLowest, expected: "17.0.1"},
+		{spec: "latest", preference: PreferHighest, expected: "21.0.2"},
+		{spec: "latest", preference: PreferLowest, expected: "11.0.24"},
+		{spec: "17.0.9", preference: PreferLowest, expected: "17.0.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+string(tt.preference), func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%q) error = %v", tt.spec, err)
+			}
+			resolved, err := spec.ResolveWithPreference(available, tt.preference)
+			if err != nil {
+				t.Fatalf("ResolveWithPreference() error = %v", err)
+			}
+			if resolved != tt.expected {
+				t.Errorf("ResolveWithPreference(%s, %s) = %s, want %s", tt.spec, tt.preference, resolved, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePreference(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected Preference
+		wantErr  bool
+	}{
+		{value: "", expected: PreferHighest},
+		{value: "highest", expected: PreferHighest},
+		{value: "Lowest", expected: PreferLowest},
+		{value: "oldest", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			preference, err := ParsePreference(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePreference(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if preference != tt.expected {
+				t.Errorf("ParsePreference(%q) = %q, want %q", tt.value, preference, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected string
+		wantErr  bool
+	}{
+		{spec: ">=17 <21", expected: ">=17.0.0 <21.0.0"},
+		{spec: ">= 17, < 21", expected: ">=17.0.0 <21.0.0"},
+		{spec: "^18", expected: ">=18.0.0 <19.0.0"},
+		{spec: "^18.2.1", expected: ">=18.2.1 <19.0.0"},
+		{spec: "^0.2.3", expected: ">=0.2.3 <0.3.0"},
+		{spec: "^0.0.3", expected: ">=0.0.3 <0.0.4"},
+		{spec: "^0", expected: ">=0.0.0 <1.0.0"},
+		{spec: "~3.9.6", expected: ">=3.9.6 <3.10.0"},
+		{spec: "~3.9", expected: ">=3.9.0 <3.10.0"},
+		{spec: "~3", expected: ">=3.0.0 <4.0.0"},
+		{spec: ">17", expected: ">=18.0.0"},
+		{spec: ">17.0.1", expected: ">17.0.1"},
+		{spec: "<=17", expected: "<18.0.0"},
+		{spec: "<=3.9", expected: "<3.10.0"},
+		{spec: "=21.0.2", expected: "=21.0.2"},
+		{spec: ">=21.0.0-ea <22", expected: ">=21.0.0-ea <22.0.0"},
+		{spec: ">=abc", wantErr: true},
+		{spec: "=>17", wantErr: true},
+		{spec: ">=", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSpec(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if spec.Constraint != "range" {
+				t.Errorf("ParseSpec(%q).Constraint = %q, want range", tt.spec, spec.Constraint)
+			}
+			var comparators []string
+			for _, c := range spec.Comparators {
+				comparators = append(comparators, c.String())
+			}
+			if got := strings.Join(comparators, " "); got != tt.expected {
+				t.Errorf("ParseSpec(%q) comparators = %s, want %s", tt.spec, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRangeMatches(t *testing.T) {
+	tests := []struct {
+		spec    string
+		version string
+		matches bool
+	}{
+		// Intersection of all the comparators
+		{spec: ">=17 <21", version: "17.0.0", matches: true},
+		{spec: ">=17 <21", version: "20.0.2", matches: true},
+		{spec: ">=17 <21", version: "21.0.0", matches: false},
+		{spec: ">=17 <21", version: "11.0.24", matches: false},
+		{spec: ">=17 <21 >=19", version: "18.0.2", matches: false},
+		{spec: ">=17 <21 >=19", version: "19.0.1", matches: true},
+		{spec: ">=21 <17", version: "19.0.0", matches: false},
+		{spec: "^18 ~18.2", version: "18.2.9", matches: true},
+		{spec: "^18 ~18.2", version: "18.3.0", matches: false},
+		{spec: "<=17", version: "17.0.16", matches: true},
+		{spec: ">17", version: "17.0.16", matches: false},
+		{spec: "17 <17.0.10", version: "17.0.9", matches: true},
+		{spec: "17 <17.0.10", version: "17.0.10", matches: false},
+		// Pre-releases only match comparators naming a pre-release of the same version
+		{spec: ">=21 <23", version: "22.0.0-ea", matches: false},
+		{spec: "<21", version: "21.0.0-ea", matches: false},
+		{spec: ">=21.0.0-ea <22", version: "21.0.0-ea.5", matches: true},
+		{spec: ">=21.0.0-ea <22", version: "21.0.0", matches: true},
+		{spec: ">=21.0.0-ea <22", version: "21.0.1-ea", matches: false},
+		{spec: ">=21.0.0-ea.10", version: "21.0.0-ea.9", matches: false},
+		{spec: "^1.0.0-rc.1", version: "1.0.0-rc.2", matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+tt.version, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%q) error = %v", tt.spec, err)
+			}
+			v, err := ParseVersion(tt.version)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error = %v", tt.version, err)
+			}
+			if got := spec.Matches(v); got != tt.matches {
+				t.Errorf("ParseSpec(%q).Matches(%s) = %v, want %v", tt.spec, tt.version, got, tt.matches)
+			}
+		})
+	}
+}
+
+func TestResolveRange(t *testing.T) {
+	available := []string{"11.0.24", "17.0.1", "17.0.16", "20.0.2", "21.0.5", "22.0.1", "23-ea", "18.20.4", "18.3.0"}
+
+	tests := []struct {
+		spec       string
+		preference Preference
+		expected   string
+		wantErr    bool
+	}{
+		{spec: ">=17 <21", preference: PreferHighest, expected: "20.0.2"},
+		{spec: ">=17 <21", preference: PreferLowest, expected: "17.0.1"},
+		{spec: "^18", preference: PreferHighest, expected: "18.20.4"},
+		{spec: "~17.0.2", preference: PreferHighest, expected: "17.0.16"},
+		{spec: ">=22", preference: PreferHighest, expected: "22.0.1"},
+		{spec: ">=23.0.0-ea", preference: PreferHighest, expected: "23-ea"},
+		{spec: ">=12 <17", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+"/"+string(tt.preference), func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%q) error = %v", tt.spec, err)
+			}
+			resolved, err := spec.ResolveWithPreference(available, tt.preference)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveWithPreference(%s) = %q, %v, wantErr %v", tt.spec, resolved, err, tt.wantErr)
+			}
+			if resolved != tt.expected {
+				t.Errorf("ResolveWithPreference(%s, %s) = %s, want %s", tt.spec, tt.preference, resolved, tt.expected)
+			}
+		})
+	}
+}
+
+func TestAllowsMajor(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected []int
+	}{
+		{spec: ">=17.0.5 <21", expected: []int{17, 18, 19, 20}},
+		{spec: ">17 <=21", expected: []int{18, 19, 20, 21}},
+		{spec: ">=20.0.0 <=20.0.0", expected: []int{20}},
+		{spec: ">20.0.0 <20.0.1", expected: []int{20}}, // Pre-releases and builds are not excluded
+		{spec: "<17.0.0", expected: []int{15, 16}},
+		{spec: "21", expected: []int{21}},
+		{spec: "latest", expected: []int{15, 16, 17, 18, 19, 20, 21, 22}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			spec, err := ParseSpec(tt.spec)
+			if err != nil {
+				t.Fatalf("ParseSpec(%q) error = %v", tt.spec, err)
+			}
+			var allowed []int
+			for major := 15; major <= 22; major++ {
+				if spec.AllowsMajor(major) {
+					allowed = append(allowed, major)
+				}
+			}
+			if !slices.Equal(allowed, tt.expected) {
+				t.Errorf("ParseSpec(%q) allows majors %v, want %v", tt.spec, allowed, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComparePre(t *testing.T) {
+	ordered := []string{"21.0.0-alpha", "21.0.0-alpha.1", "21.0.0-alpha.beta", "21.0.0-ea.2", "21.0.0-ea.10", "21.0.0-rc.1", "21.0.0"}
+	for i := 0; i+1 < len(ordered); i++ {
+		lower, _ := ParseVersion(ordered[i])
+		higher, _ := ParseVersion(ordered[i+1])
+		if lower.Compare(higher) >= 0 || higher.Compare(lower) <= 0 {
+			t.Errorf("expected %s < %s", ordered[i], ordered[i+1])
+		}
+	}
+}
@@ -91,6 +91,10 @@ func TestMvxBinary(t *testing.T) {
 	t.Run("AutoSetup", func(t *testing.T) {
 		testAutoSetup(t, mvxBinary)
 	})
+
+	t.Run("Lockfile", func(t *testing.T) {
+		testLockfile(t, mvxBinary)
+	})
 }
 
 func findMvxBinary(t *testing.T) string {
@@ -1843,3 +1847,45 @@ func testNetworkConnectivity(t *testing.T) error {
 	t.Logf("DEBUG: HTTP connectivity successful (status: %s)", resp.Status)
 	return nil
 }
+
+// testLockfile tests that a setup relying on .mvx/mvx.lock resolves versions without the Disco API
+func testLockfile(t *testing.T, mvxBinary string) {
+	projectDir := t.TempDir()
+	homeDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(projectDir, ".mvx"), 0755); err != nil {
+		t.Fatalf("Failed to create .mvx directory: %v", err)
+	}
+	configContent := `{ project: { name: "lockfile-test" }, tools: { java: { version: "21" } } }`
+	if err := os.WriteFile(filepath.Join(projectDir, ".mvx", "config.json5"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	env := append(os.Environ(), "HOME="+homeDir, "USERPROFILE="+homeDir, "MVX_VERBOSE=true")
+	run := func(args ...string) string {
+		cmd := exec.Command(mvxBinary, args...)
+		cmd.Dir = projectDir
+		cmd.Env = env
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("mvx %s failed: %v\nOutput: %s", strings.Join(args, " "), err, output)
+		}
+		return string(output)
+	}
+
+	run("tools", "lock")
+	if _, err := os.Stat(filepath.Join(projectDir, ".mvx", "mvx.lock")); err != nil {
+		t.Fatalf("mvx tools lock did not write the lockfile: %v", err)
+	}
+	run("setup", "--tools-only")
+
+	// Forget every cached resolution and API response: only the lockfile is left
+	os.Remove(filepath.Join(homeDir, ".mvx", "version_cache.json"))
+	os.RemoveAll(filepath.Join(homeDir, ".mvx", "http_cache"))
+
+	output := run("setup", "--tools-only")
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "HTTP GET") && strings.Contains(line, "api.foojay.io") {
+			t.Errorf("Setup with a lockfile called the Disco API: %s", line)
+		}
+	}
+}